@@ -20,13 +20,19 @@ import (
 	awsSSMChaosByID "github.com/litmuschaos/litmus-go/experiments/aws-ssm/aws-ssm-chaos-by-id/experiment"
 	awsSSMChaosByTag "github.com/litmuschaos/litmus-go/experiments/aws-ssm/aws-ssm-chaos-by-tag/experiment"
 	azureDiskLoss "github.com/litmuschaos/litmus-go/experiments/azure/azure-disk-loss/experiment"
+	azureNSGBlock "github.com/litmuschaos/litmus-go/experiments/azure/azure-nsg-block/experiment"
+	azureVMSSInstanceDelete "github.com/litmuschaos/litmus-go/experiments/azure/azure-vmss-instance-delete/experiment"
+	azureZoneOutage "github.com/litmuschaos/litmus-go/experiments/azure/azure-zone-outage/experiment"
 	azureInstanceStop "github.com/litmuschaos/litmus-go/experiments/azure/instance-stop/experiment"
 	redfishNodeRestart "github.com/litmuschaos/litmus-go/experiments/baremetal/redfish-node-restart/experiment"
 	cassandraPodDelete "github.com/litmuschaos/litmus-go/experiments/cassandra/pod-delete/experiment"
+	gcpMIGInstanceDelete "github.com/litmuschaos/litmus-go/experiments/gcp/gcp-mig-instance-delete/experiment"
 	gcpVMDiskLossByLabel "github.com/litmuschaos/litmus-go/experiments/gcp/gcp-vm-disk-loss-by-label/experiment"
 	gcpVMDiskLoss "github.com/litmuschaos/litmus-go/experiments/gcp/gcp-vm-disk-loss/experiment"
 	gcpVMInstanceStopByLabel "github.com/litmuschaos/litmus-go/experiments/gcp/gcp-vm-instance-stop-by-label/experiment"
 	gcpVMInstanceStop "github.com/litmuschaos/litmus-go/experiments/gcp/gcp-vm-instance-stop/experiment"
+	gcpZoneOutage "github.com/litmuschaos/litmus-go/experiments/gcp/gcp-zone-outage/experiment"
+	compositeChaos "github.com/litmuschaos/litmus-go/experiments/generic/composite-chaos/experiment"
 	containerKill "github.com/litmuschaos/litmus-go/experiments/generic/container-kill/experiment"
 	diskFill "github.com/litmuschaos/litmus-go/experiments/generic/disk-fill/experiment"
 	dockerServiceKill "github.com/litmuschaos/litmus-go/experiments/generic/docker-service-kill/experiment"
@@ -40,15 +46,19 @@ import (
 	podAutoscaler "github.com/litmuschaos/litmus-go/experiments/generic/pod-autoscaler/experiment"
 	podCPUHogExec "github.com/litmuschaos/litmus-go/experiments/generic/pod-cpu-hog-exec/experiment"
 	podCPUHog "github.com/litmuschaos/litmus-go/experiments/generic/pod-cpu-hog/experiment"
+	podCPUThrottle "github.com/litmuschaos/litmus-go/experiments/generic/pod-cpu-throttle/experiment"
 	podDelete "github.com/litmuschaos/litmus-go/experiments/generic/pod-delete/experiment"
 	podDNSError "github.com/litmuschaos/litmus-go/experiments/generic/pod-dns-error/experiment"
 	podDNSSpoof "github.com/litmuschaos/litmus-go/experiments/generic/pod-dns-spoof/experiment"
+	podFDExhaustion "github.com/litmuschaos/litmus-go/experiments/generic/pod-fd-exhaustion/experiment"
 	podFioStress "github.com/litmuschaos/litmus-go/experiments/generic/pod-fio-stress/experiment"
 	podHttpLatency "github.com/litmuschaos/litmus-go/experiments/generic/pod-http-latency/experiment"
 	podHttpModifyBody "github.com/litmuschaos/litmus-go/experiments/generic/pod-http-modify-body/experiment"
 	podHttpModifyHeader "github.com/litmuschaos/litmus-go/experiments/generic/pod-http-modify-header/experiment"
 	podHttpResetPeer "github.com/litmuschaos/litmus-go/experiments/generic/pod-http-reset-peer/experiment"
 	podHttpStatusCode "github.com/litmuschaos/litmus-go/experiments/generic/pod-http-status-code/experiment"
+	podInodeExhaustion "github.com/litmuschaos/litmus-go/experiments/generic/pod-inode-exhaustion/experiment"
+	podIOFault "github.com/litmuschaos/litmus-go/experiments/generic/pod-io-fault/experiment"
 	podIOStress "github.com/litmuschaos/litmus-go/experiments/generic/pod-io-stress/experiment"
 	podMemoryHogExec "github.com/litmuschaos/litmus-go/experiments/generic/pod-memory-hog-exec/experiment"
 	podMemoryHog "github.com/litmuschaos/litmus-go/experiments/generic/pod-memory-hog/experiment"
@@ -58,17 +68,25 @@ import (
 	podNetworkLoss "github.com/litmuschaos/litmus-go/experiments/generic/pod-network-loss/experiment"
 	podNetworkPartition "github.com/litmuschaos/litmus-go/experiments/generic/pod-network-partition/experiment"
 	podNetworkRateLimit "github.com/litmuschaos/litmus-go/experiments/generic/pod-network-rate-limit/experiment"
+	podPIDExhaustion "github.com/litmuschaos/litmus-go/experiments/generic/pod-pid-exhaustion/experiment"
 	kafkaBrokerPodFailure "github.com/litmuschaos/litmus-go/experiments/kafka/kafka-broker-pod-failure/experiment"
+	awsAZOutage "github.com/litmuschaos/litmus-go/experiments/kube-aws/aws-az-outage/experiment"
+	awsECSTaskStop "github.com/litmuschaos/litmus-go/experiments/kube-aws/aws-ecs-task-stop/experiment"
+	awsEKSNodegroupScaleDown "github.com/litmuschaos/litmus-go/experiments/kube-aws/aws-eks-nodegroup-scale-down/experiment"
+	awsELBTargetDeregister "github.com/litmuschaos/litmus-go/experiments/kube-aws/aws-elb-target-deregister/experiment"
 	ebsLossByID "github.com/litmuschaos/litmus-go/experiments/kube-aws/ebs-loss-by-id/experiment"
 	ebsLossByTag "github.com/litmuschaos/litmus-go/experiments/kube-aws/ebs-loss-by-tag/experiment"
 	ec2TerminateByID "github.com/litmuschaos/litmus-go/experiments/kube-aws/ec2-terminate-by-id/experiment"
 	ec2TerminateByTag "github.com/litmuschaos/litmus-go/experiments/kube-aws/ec2-terminate-by-tag/experiment"
+	rdsClusterFailover "github.com/litmuschaos/litmus-go/experiments/kube-aws/rds-cluster-failover/experiment"
+	rdsInstanceReboot "github.com/litmuschaos/litmus-go/experiments/kube-aws/rds-instance-reboot/experiment"
 	rdsInstanceStop "github.com/litmuschaos/litmus-go/experiments/kube-aws/rds-instance-stop/experiment"
 	k6Loadgen "github.com/litmuschaos/litmus-go/experiments/load/k6-loadgen/experiment"
 	springBootFaults "github.com/litmuschaos/litmus-go/experiments/spring-boot/spring-boot-faults/experiment"
 	vmpoweroff "github.com/litmuschaos/litmus-go/experiments/vmware/vm-poweroff/experiment"
 	cli "github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/policy"
 	"github.com/litmuschaos/litmus-go/pkg/telemetry"
 	"github.com/sirupsen/logrus"
 )
@@ -114,8 +132,16 @@ func main() {
 
 	log.Infof("Experiment Name: %v", *experimentName)
 
+	// enforce the chaos policy of the cluster, before the experiment is started
+	if err := policy.Enforce(clients, *experimentName); err != nil {
+		log.Errorf("Chaos policy check failed, err: %v", err)
+		return
+	}
+
 	// invoke the corresponding experiment based on the (-name) flag
 	switch *experimentName {
+	case "composite-chaos":
+		compositeChaos.CompositeChaos(ctx, clients)
 	case "container-kill":
 		containerKill.ContainerKill(ctx, clients)
 	case "disk-fill":
@@ -144,6 +170,10 @@ func main() {
 		podDelete.PodDelete(ctx, clients)
 	case "pod-io-stress":
 		podIOStress.PodIOStress(ctx, clients)
+	case "pod-io-fault":
+		podIOFault.PodIOFault(ctx, clients)
+	case "pod-inode-exhaustion":
+		podInodeExhaustion.PodInodeExhaustion(ctx, clients)
 	case "pod-memory-hog-exec":
 		podMemoryHogExec.PodMemoryHogExec(ctx, clients)
 	case "pod-network-corruption":
@@ -162,6 +192,12 @@ func main() {
 		podMemoryHog.PodMemoryHog(ctx, clients)
 	case "pod-cpu-hog":
 		podCPUHog.PodCPUHog(ctx, clients)
+	case "pod-cpu-throttle":
+		podCPUThrottle.PodCPUThrottle(ctx, clients)
+	case "pod-fd-exhaustion":
+		podFDExhaustion.PodFDExhaustion(ctx, clients)
+	case "pod-pid-exhaustion":
+		podPIDExhaustion.PodPIDExhaustion(ctx, clients)
 	case "cassandra-pod-delete":
 		cassandraPodDelete.CasssandraPodDelete(ctx, clients)
 	case "aws-ssm-chaos-by-id":
@@ -172,12 +208,24 @@ func main() {
 		ec2TerminateByID.EC2TerminateByID(ctx, clients)
 	case "ec2-terminate-by-tag":
 		ec2TerminateByTag.EC2TerminateByTag(ctx, clients)
+	case "aws-az-outage":
+		awsAZOutage.AWSAZOutage(ctx, clients)
+	case "aws-elb-target-deregister":
+		awsELBTargetDeregister.AWSELBTargetDeregister(ctx, clients)
+	case "aws-ecs-task-stop":
+		awsECSTaskStop.AWSECSTaskStop(ctx, clients)
+	case "aws-eks-nodegroup-scale-down":
+		awsEKSNodegroupScaleDown.AWSEKSNodegroupScaleDown(ctx, clients)
 	case "ebs-loss-by-id":
 		ebsLossByID.EBSLossByID(ctx, clients)
 	case "ebs-loss-by-tag":
 		ebsLossByTag.EBSLossByTag(ctx, clients)
 	case "rds-instance-stop":
 		rdsInstanceStop.RDSInstanceStop(ctx, clients)
+	case "rds-instance-reboot":
+		rdsInstanceReboot.RDSInstanceReboot(ctx, clients)
+	case "rds-cluster-failover":
+		rdsClusterFailover.RDSClusterFailover(ctx, clients)
 	case "node-restart":
 		nodeRestart.NodeRestart(ctx, clients)
 	case "pod-dns-error":
@@ -200,6 +248,12 @@ func main() {
 		azureInstanceStop.AzureInstanceStop(ctx, clients)
 	case "azure-disk-loss":
 		azureDiskLoss.AzureDiskLoss(ctx, clients)
+	case "azure-vmss-instance-delete":
+		azureVMSSInstanceDelete.AzureVMSSInstanceDelete(ctx, clients)
+	case "azure-zone-outage":
+		azureZoneOutage.AzureZoneOutage(ctx, clients)
+	case "azure-nsg-block":
+		azureNSGBlock.AzureNSGBlock(ctx, clients)
 	case "gcp-vm-disk-loss":
 		gcpVMDiskLoss.VMDiskLoss(ctx, clients)
 	case "pod-fio-stress":
@@ -212,6 +266,10 @@ func main() {
 		gcpVMInstanceStopByLabel.GCPVMInstanceStopByLabel(ctx, clients)
 	case "gcp-vm-disk-loss-by-label":
 		gcpVMDiskLossByLabel.GCPVMDiskLossByLabel(ctx, clients)
+	case "gcp-mig-instance-delete":
+		gcpMIGInstanceDelete.GCPMIGInstanceDelete(ctx, clients)
+	case "gcp-zone-outage":
+		gcpZoneOutage.GCPZoneOutage(ctx, clients)
 	case "spring-boot-cpu-stress", "spring-boot-memory-stress", "spring-boot-exceptions", "spring-boot-app-kill", "spring-boot-faults", "spring-boot-latency":
 		springBootFaults.Experiment(ctx, clients, *experimentName)
 	case "k6-loadgen":
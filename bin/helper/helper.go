@@ -20,6 +20,8 @@ import (
 	httpChaos "github.com/litmuschaos/litmus-go/chaoslib/litmus/http-chaos/helper"
 	networkChaos "github.com/litmuschaos/litmus-go/chaoslib/litmus/network-chaos/helper"
 	dnsChaos "github.com/litmuschaos/litmus-go/chaoslib/litmus/pod-dns-chaos/helper"
+	inodeExhaustion "github.com/litmuschaos/litmus-go/chaoslib/litmus/pod-inode-exhaustion/helper"
+	ioFault "github.com/litmuschaos/litmus-go/chaoslib/litmus/pod-io-fault/helper"
 	stressChaos "github.com/litmuschaos/litmus-go/chaoslib/litmus/stress-chaos/helper"
 	cli "github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/log"
@@ -82,6 +84,10 @@ func main() {
 		networkChaos.Helper(ctx, clients)
 	case "http-chaos":
 		httpChaos.Helper(ctx, clients)
+	case "io-fault":
+		ioFault.Helper(ctx, clients)
+	case "inode-exhaustion":
+		inodeExhaustion.Helper(ctx, clients)
 
 	default:
 		log.Errorf("Unsupported -name %v, please provide the correct value of -name args", *helperName)
@@ -0,0 +1,344 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	awslib "github.com/litmuschaos/litmus-go/pkg/cloud/aws/ec2"
+	"github.com/litmuschaos/litmus-go/pkg/cloud/provider"
+	"github.com/litmuschaos/litmus-go/pkg/events"
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/kube-aws/aws-az-outage/types"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/probe"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+)
+
+// outage modes
+const (
+	outageModeStop    = "stop"
+	outageModeIsolate = "isolate"
+)
+
+// isolation modes
+const (
+	isolationModeSecurityGroup = "security-group"
+	isolationModeNetworkACL    = "nacl"
+)
+
+var (
+	abort chan os.Signal
+	// the chaos state, which is reverted after the chaos duration or when the abort signal is received
+	revertLock       sync.Mutex
+	outage           *awslib.AZOutage
+	stoppedInstances []string
+	detachedVolumes  []provider.Volume
+)
+
+// PrepareAZOutage contains the preparation and injection steps for the experiment
+func PrepareAZOutage(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, cloudProvider provider.Provider, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "PrepareAWSAZOutageFault")
+	defer span.End()
+
+	// abort channel is used to transmit signal notifications.
+	abort = make(chan os.Signal, 1)
+	// Catch and relay certain signal(s) to abort channel.
+	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+
+	//Waiting for the ramp time before chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
+		common.WaitForDuration(experimentsDetails.RampTime)
+	}
+
+	instanceIDList := GetTargetInstanceIDs(experimentsDetails)
+	log.Infof("[Chaos]: Number of instances targeted in the %v zone: %v", experimentsDetails.Zone, len(instanceIDList))
+
+	if chaosDetails.DryRun {
+		common.PlanTargets("EC2", experimentsDetails.Zone, instanceIDList, chaosDetails)
+		return common.StopForDryRun(experimentsDetails, chaosDetails)
+	}
+
+	// watching for the abort signal and revert the chaos
+	go abortWatcher(experimentsDetails, cloudProvider, chaosDetails)
+
+	// the outage is reverted after the chaos duration, the partially injected outage is reverted as well before the failure is reported
+	err := injectChaos(ctx, experimentsDetails, cloudProvider, clients, resultDetails, eventsDetails, chaosDetails)
+	revertErr := revertChaos(experimentsDetails, cloudProvider, chaosDetails)
+	if err != nil {
+		if revertErr != nil {
+			return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), stacktrace.RootCause(revertErr).Error())}
+		}
+		return stacktrace.Propagate(err, "could not inject the az outage")
+	}
+	if revertErr != nil {
+		return stacktrace.Propagate(revertErr, "could not revert the az outage")
+	}
+
+	//Waiting for the ramp time after chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
+		common.WaitForDuration(experimentsDetails.RampTime)
+	}
+	return nil
+}
+
+// injectChaos stops or isolates the target instances of the zone and detaches their volumes for the chaos duration
+func injectChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, cloudProvider provider.Provider, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectAWSAZOutageFault")
+	defer span.End()
+
+	if experimentsDetails.EngineName != "" {
+		msg := "Injecting " + experimentsDetails.ExperimentName + " chaos in " + experimentsDetails.Zone + " zone"
+		types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
+		events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
+	}
+
+	switch experimentsDetails.OutageMode {
+	case outageModeStop:
+		if err := stopInstances(experimentsDetails, cloudProvider, chaosDetails); err != nil {
+			return stacktrace.Propagate(err, "failed to stop the instances")
+		}
+	default:
+		if err := isolateInstances(experimentsDetails, chaosDetails); err != nil {
+			return stacktrace.Propagate(err, "failed to isolate the instances")
+		}
+	}
+
+	if experimentsDetails.DetachVolumes {
+		if err := detachVolumes(experimentsDetails, cloudProvider); err != nil {
+			return stacktrace.Propagate(err, "failed to detach the volumes")
+		}
+	}
+
+	// run the probes during chaos
+	if len(resultDetails.ProbeDetails) != 0 {
+		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+			return stacktrace.Propagate(err, "failed to run probes")
+		}
+	}
+
+	//Wait for chaos duration
+	log.Infof("[Wait]: Waiting for the chaos duration of %vs", experimentsDetails.ChaosDuration)
+	common.WaitForDuration(experimentsDetails.ChaosDuration)
+	return nil
+}
+
+// stopInstances stops the target instances and waits for them to get in stopped state
+func stopInstances(experimentsDetails *experimentTypes.ExperimentDetails, cloudProvider provider.Provider, chaosDetails *types.ChaosDetails) error {
+	for _, id := range GetTargetInstanceIDs(experimentsDetails) {
+		log.Infof("[Chaos]: Stopping the %v EC2 instance", id)
+		if err := cloudProvider.StopInstance(provider.Instance{ID: id}); err != nil {
+			return stacktrace.Propagate(err, "ec2 instance failed to stop")
+		}
+		revertLock.Lock()
+		stoppedInstances = append(stoppedInstances, id)
+		revertLock.Unlock()
+		common.SetTargets(id, "injected", "EC2", chaosDetails)
+	}
+
+	// the stopped instances are copied under the lock, as they are cleared by the revert of the abort watcher
+	revertLock.Lock()
+	instances := append([]string{}, stoppedInstances...)
+	revertLock.Unlock()
+
+	for _, id := range instances {
+		log.Infof("[Wait]: Wait for EC2 instance '%v' to get in stopped state", id)
+		if err := provider.WaitForInstanceState(cloudProvider, provider.Instance{ID: id}, provider.StateStopped, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
+			return stacktrace.Propagate(err, "ec2 instance failed to stop")
+		}
+	}
+	return nil
+}
+
+// isolateInstances isolates the network of the target instances with the deny-all security group or the deny network acl
+func isolateInstances(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails) error {
+	revertLock.Lock()
+	defer revertLock.Unlock()
+
+	outage = awslib.NewAZOutage(experimentsDetails.Zone, string(experimentsDetails.ChaosUID))
+	ec2Svc := awslib.GetEC2Client(experimentsDetails.Region)
+
+	var err error
+	switch experimentsDetails.IsolationMode {
+	case isolationModeNetworkACL:
+		err = awslib.IsolateByNetworkACL(ec2Svc, experimentsDetails.TargetInstances, outage)
+	default:
+		err = awslib.IsolateBySecurityGroup(ec2Svc, experimentsDetails.TargetInstances, experimentsDetails.DenyAllSecurityGroupID, outage)
+	}
+	if err != nil {
+		return err
+	}
+	for _, id := range GetTargetInstanceIDs(experimentsDetails) {
+		common.SetTargets(id, "injected", "EC2", chaosDetails)
+	}
+	return nil
+}
+
+// detachVolumes detaches the ebs volumes of the target instances, except their root volumes
+func detachVolumes(experimentsDetails *experimentTypes.ExperimentDetails, cloudProvider provider.Provider) error {
+	var attachments []provider.Volume
+	for _, instance := range experimentsDetails.TargetInstances {
+		for _, device := range instance.BlockDeviceMappings {
+			if device.Ebs == nil || awssdk.StringValue(device.DeviceName) == awssdk.StringValue(instance.RootDeviceName) {
+				continue
+			}
+			attachments = append(attachments, provider.Volume{
+				ID:         awssdk.StringValue(device.Ebs.VolumeId),
+				InstanceID: awssdk.StringValue(instance.InstanceId),
+				Device:     awssdk.StringValue(device.DeviceName),
+			})
+		}
+	}
+	if len(attachments) == 0 {
+		log.Info("[Info]: The target instances don't have any non-root ebs volume to detach")
+		return nil
+	}
+
+	for _, attachment := range attachments {
+		log.Infof("[Chaos]: Detaching the %v volume from the %v instance", attachment.ID, attachment.InstanceID)
+		if err := cloudProvider.DetachVolume(attachment); err != nil {
+			return stacktrace.Propagate(err, "ebs volume failed to detach")
+		}
+		revertLock.Lock()
+		detachedVolumes = append(detachedVolumes, attachment)
+		revertLock.Unlock()
+	}
+
+	for _, attachment := range attachments {
+		log.Infof("[Wait]: Wait for the %v volume to get detached", attachment.ID)
+		if err := provider.WaitForVolumeState(cloudProvider, attachment, provider.StateDetached, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
+			return stacktrace.Propagate(err, "ebs volume failed to detach")
+		}
+	}
+	return nil
+}
+
+// revertChaos attaches back the detached volumes, restores the network of the isolated instances and starts the stopped instances
+// the reverted state is cleared, so that the chaos is reverted only once if the abort signal is received during the revert
+func revertChaos(experimentsDetails *experimentTypes.ExperimentDetails, cloudProvider provider.Provider, chaosDetails *types.ChaosDetails) error {
+	revertLock.Lock()
+	defer revertLock.Unlock()
+
+	var errs []string
+
+	// the stopped instances are started before attaching back their volumes, the volumes can be attached to the running instances
+	for len(stoppedInstances) != 0 {
+		id := stoppedInstances[0]
+		log.Infof("[Revert]: Starting back the %v EC2 instance", id)
+		instance := provider.Instance{ID: id}
+		if err := provider.WaitForInstanceState(cloudProvider, instance, provider.StateStopped, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
+			log.Errorf("Unable to wait till stop of the instance: %v", err)
+		}
+		if err := cloudProvider.StartInstance(instance); err != nil {
+			errs = append(errs, stacktrace.RootCause(err).Error())
+		} else if err := provider.WaitForInstanceState(cloudProvider, instance, provider.StateRunning, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
+			errs = append(errs, stacktrace.RootCause(err).Error())
+		}
+		stoppedInstances = stoppedInstances[1:]
+	}
+
+	for len(detachedVolumes) != 0 {
+		attachment := detachedVolumes[0]
+		log.Infof("[Revert]: Attaching back the %v volume to the %v instance", attachment.ID, attachment.InstanceID)
+		if err := cloudProvider.AttachVolume(attachment); err != nil {
+			errs = append(errs, stacktrace.RootCause(err).Error())
+		} else if err := provider.WaitForVolumeState(cloudProvider, attachment, provider.StateAttached, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
+			errs = append(errs, stacktrace.RootCause(err).Error())
+		}
+		detachedVolumes = detachedVolumes[1:]
+	}
+
+	if outage != nil {
+		log.Infof("[Revert]: Restoring the network of the %v zone", outage.Zone)
+		if err := awslib.RestoreAZ(awslib.GetEC2Client(experimentsDetails.Region), outage); err != nil {
+			errs = append(errs, stacktrace.RootCause(err).Error())
+		} else {
+			outage = nil
+		}
+	}
+
+	if len(errs) != 0 {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{Zone: %v, Region: %v}", experimentsDetails.Zone, experimentsDetails.Region), Reason: strings.Join(errs, ", ")}
+	}
+	for _, id := range GetTargetInstanceIDs(experimentsDetails) {
+		common.SetTargets(id, "reverted", "EC2", chaosDetails)
+	}
+	return nil
+}
+
+// SetTargetInstances selects the running instances of the zone, which are filtered by the instance tag
+func SetTargetInstances(experimentsDetails *experimentTypes.ExperimentDetails) error {
+	if err := validateInputs(experimentsDetails); err != nil {
+		return err
+	}
+
+	instances, err := awslib.GetZoneInstances(awslib.GetEC2Client(experimentsDetails.Region), experimentsDetails.Ec2InstanceTag, experimentsDetails.Zone)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to get the instances of the zone")
+	}
+	if len(instances) == 0 {
+		return cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeTargetSelection,
+			Reason:    "no running instance found in the zone",
+			Target:    fmt.Sprintf("{EC2 Instance Tag: %v, Zone: %v, Region: %v}", experimentsDetails.Ec2InstanceTag, experimentsDetails.Zone, experimentsDetails.Region),
+		}
+	}
+	experimentsDetails.TargetInstances = instances
+
+	log.InfoWithValues("[Info]: Targeting the running instances of the zone", logrus.Fields{
+		"Zone":             experimentsDetails.Zone,
+		"Instance Tag":     experimentsDetails.Ec2InstanceTag,
+		"Target Instances": GetTargetInstanceIDs(experimentsDetails),
+	})
+	return nil
+}
+
+// validateInputs validates the zone and the outage modes of the experiment
+func validateInputs(experimentsDetails *experimentTypes.ExperimentDetails) error {
+	switch {
+	case experimentsDetails.Zone == "":
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "no zone provided, please provide the target availability zone in ZONE env"}
+	case experimentsDetails.Ec2InstanceTag == "":
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "no instance tag provided, please provide the tag of the target instances in EC2_INSTANCE_TAG env"}
+	case experimentsDetails.OutageMode != outageModeStop && experimentsDetails.OutageMode != outageModeIsolate:
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("'%s' outage mode is not supported, supported modes are: %s, %s", experimentsDetails.OutageMode, outageModeStop, outageModeIsolate)}
+	case experimentsDetails.OutageMode == outageModeIsolate && experimentsDetails.IsolationMode != isolationModeSecurityGroup && experimentsDetails.IsolationMode != isolationModeNetworkACL:
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("'%s' isolation mode is not supported, supported modes are: %s, %s", experimentsDetails.IsolationMode, isolationModeSecurityGroup, isolationModeNetworkACL)}
+	}
+	return nil
+}
+
+// GetTargetInstanceIDs returns the ids of the target instances
+func GetTargetInstanceIDs(experimentsDetails *experimentTypes.ExperimentDetails) []string {
+	var ids []string
+	for _, instance := range experimentsDetails.TargetInstances {
+		ids = append(ids, awssdk.StringValue(instance.InstanceId))
+	}
+	return ids
+}
+
+// watching for the abort signal and revert the chaos
+func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, cloudProvider provider.Provider, chaosDetails *types.ChaosDetails) {
+
+	<-abort
+
+	log.Info("[Abort]: Chaos Revert Started")
+	if err := revertChaos(experimentsDetails, cloudProvider, chaosDetails); err != nil {
+		log.Errorf("Failed to revert the az outage when an abort signal is received: %v", err)
+	}
+	log.Info("[Abort]: Chaos Revert Completed")
+	os.Exit(1)
+}
@@ -0,0 +1,124 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	awslib "github.com/litmuschaos/litmus-go/pkg/cloud/aws/ecs"
+	"github.com/litmuschaos/litmus-go/pkg/events"
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/kube-aws/aws-ecs-task-stop/types"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/probe"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+)
+
+// PrepareECSTaskStop contains the preparation and injection steps for the experiment
+func PrepareECSTaskStop(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "PrepareAWSECSTaskStopFault")
+	defer span.End()
+
+	//Waiting for the ramp time before chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
+		common.WaitForDuration(experimentsDetails.RampTime)
+	}
+
+	log.Infof("[Chaos]: Number of tasks targeted: %v", len(experimentsDetails.TargetTasks))
+
+	if chaosDetails.DryRun {
+		common.PlanTargets("ECS-Task", experimentsDetails.ServiceName, experimentsDetails.TargetTasks, chaosDetails)
+		return common.StopForDryRun(experimentsDetails, chaosDetails)
+	}
+
+	if err := injectChaos(ctx, experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+		return stacktrace.Propagate(err, "could not stop the tasks")
+	}
+
+	//Waiting for the ramp time after chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
+		common.WaitForDuration(experimentsDetails.RampTime)
+	}
+	return nil
+}
+
+// injectChaos stops the target tasks and waits for the service to replace them after the chaos duration
+func injectChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectAWSECSTaskStopFault")
+	defer span.End()
+
+	if experimentsDetails.EngineName != "" {
+		msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on " + experimentsDetails.ServiceName + " service"
+		types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
+		events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
+	}
+
+	reason := fmt.Sprintf("stopped by the %v chaos experiment", experimentsDetails.ExperimentName)
+	for _, task := range experimentsDetails.TargetTasks {
+		log.Infof("[Chaos]: Stopping the %v ECS task", task)
+		if err := awslib.StopTask(experimentsDetails.ClusterName, task, reason, experimentsDetails.Region); err != nil {
+			return stacktrace.Propagate(err, "ecs task failed to stop")
+		}
+		common.SetTargets(task, "injected", "ECS-Task", chaosDetails)
+	}
+
+	//Wait for the tasks to get in stopped state
+	log.Info("[Wait]: Wait for the ECS tasks to get in stopped state")
+	if err := awslib.WaitForTasksStopped(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.ClusterName, experimentsDetails.TargetTasks, experimentsDetails.Region); err != nil {
+		return stacktrace.Propagate(err, "ecs tasks failed to stop")
+	}
+
+	// run the probes during chaos
+	if len(resultDetails.ProbeDetails) != 0 {
+		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+			return stacktrace.Propagate(err, "failed to run probes")
+		}
+	}
+
+	//Wait for chaos duration
+	log.Infof("[Wait]: Waiting for the chaos duration of %vs", experimentsDetails.ChaosDuration)
+	common.WaitForDuration(experimentsDetails.ChaosDuration)
+
+	//Wait for the service to replace the stopped tasks
+	log.Infof("[Wait]: Wait for the %v ECS service to run the desired count of tasks", experimentsDetails.ServiceName)
+	if err := awslib.WaitForServiceSteady(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.ClusterName, experimentsDetails.ServiceName, experimentsDetails.Region); err != nil {
+		return stacktrace.Propagate(err, "ecs service failed to replace the stopped tasks")
+	}
+	for _, task := range experimentsDetails.TargetTasks {
+		common.SetTargets(task, "reverted", "ECS-Task", chaosDetails)
+	}
+	return nil
+}
+
+// SetTargetTasks selects the running tasks of the service, which are filtered by the affected percentage
+func SetTargetTasks(experimentsDetails *experimentTypes.ExperimentDetails) error {
+	if experimentsDetails.ClusterName == "" || experimentsDetails.ServiceName == "" {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "no cluster or service provided, please provide the target service in CLUSTER_NAME and SERVICE_NAME envs"}
+	}
+
+	tasks, err := awslib.GetServiceTasks(experimentsDetails.ClusterName, experimentsDetails.ServiceName, experimentsDetails.Region)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to get the tasks of the service")
+	}
+	if len(tasks) == 0 {
+		return cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeTargetSelection,
+			Reason:    "no running task found in the service",
+			Target:    fmt.Sprintf("{ECS Cluster: %v, Service: %v, Region: %v}", experimentsDetails.ClusterName, experimentsDetails.ServiceName, experimentsDetails.Region),
+		}
+	}
+	experimentsDetails.TargetTasks = common.FilterBasedOnPercentage(experimentsDetails.TasksAffectedPerc, tasks)
+
+	log.InfoWithValues("[Info]: Targeting the running tasks of the service", logrus.Fields{
+		"Service":      experimentsDetails.ServiceName,
+		"Target Tasks": experimentsDetails.TargetTasks,
+	})
+	return nil
+}
@@ -0,0 +1,191 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	awslib "github.com/litmuschaos/litmus-go/pkg/cloud/aws/eks"
+	"github.com/litmuschaos/litmus-go/pkg/events"
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/kube-aws/aws-eks-nodegroup-scale-down/types"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/probe"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+)
+
+var (
+	abort chan os.Signal
+	// scaledDown is set after the scaling config of the nodegroup is updated, the original scaling config is restored only once
+	revertLock sync.Mutex
+	scaledDown bool
+)
+
+// PrepareNodegroupScaleDown contains the preparation and injection steps for the experiment
+func PrepareNodegroupScaleDown(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "PrepareAWSEKSNodegroupScaleDownFault")
+	defer span.End()
+
+	// abort channel is used to transmit signal notifications.
+	abort = make(chan os.Signal, 1)
+	// Catch and relay certain signal(s) to abort channel.
+	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+
+	//Waiting for the ramp time before chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
+		common.WaitForDuration(experimentsDetails.RampTime)
+	}
+
+	scalingConfig, err := awslib.ScaleDownConfig(experimentsDetails.OriginalScaling, int64(experimentsDetails.DesiredSize))
+	if err != nil {
+		return stacktrace.Propagate(err, "invalid desired size")
+	}
+
+	if chaosDetails.DryRun {
+		common.PlanTargets("EKS-Nodegroup", experimentsDetails.ClusterName, []string{experimentsDetails.NodegroupName}, chaosDetails)
+		return common.StopForDryRun(experimentsDetails, chaosDetails)
+	}
+
+	// watching for the abort signal and revert the chaos
+	go abortWatcher(experimentsDetails, chaosDetails)
+
+	// the original scaling config is restored after the chaos duration, or before the failure is reported if the scale down is partially injected
+	err = injectChaos(ctx, experimentsDetails, scalingConfig, clients, resultDetails, eventsDetails, chaosDetails)
+	revertErr := revertChaos(experimentsDetails, chaosDetails)
+	if err != nil {
+		if revertErr != nil {
+			return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), stacktrace.RootCause(revertErr).Error())}
+		}
+		return stacktrace.Propagate(err, "could not scale down the nodegroup")
+	}
+	if revertErr != nil {
+		return stacktrace.Propagate(revertErr, "could not restore the scaling config of the nodegroup")
+	}
+
+	//Waiting for the ramp time after chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
+		common.WaitForDuration(experimentsDetails.RampTime)
+	}
+	return nil
+}
+
+// injectChaos lowers the desired size of the nodegroup for the chaos duration
+func injectChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, scalingConfig *eks.NodegroupScalingConfig, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectAWSEKSNodegroupScaleDownFault")
+	defer span.End()
+
+	if experimentsDetails.EngineName != "" {
+		msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on " + experimentsDetails.NodegroupName + " nodegroup"
+		types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
+		events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
+	}
+
+	log.Infof("[Chaos]: Scaling down the %v nodegroup to %v nodes", experimentsDetails.NodegroupName, experimentsDetails.DesiredSize)
+	revertLock.Lock()
+	updateID, err := awslib.UpdateNodegroupScaling(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.ClusterName, experimentsDetails.NodegroupName, scalingConfig, experimentsDetails.Region)
+	if err != nil {
+		revertLock.Unlock()
+		return stacktrace.Propagate(err, "nodegroup failed to scale down")
+	}
+	scaledDown = true
+	revertLock.Unlock()
+	common.SetTargets(experimentsDetails.NodegroupName, "injected", "EKS-Nodegroup", chaosDetails)
+
+	//Wait for the update of the nodegroup to get successful
+	log.Info("[Wait]: Wait for the nodegroup update to get successful")
+	if err := awslib.WaitForNodegroupUpdate(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.ClusterName, experimentsDetails.NodegroupName, updateID, experimentsDetails.Region); err != nil {
+		return stacktrace.Propagate(err, "nodegroup failed to scale down")
+	}
+
+	// run the probes during chaos
+	if len(resultDetails.ProbeDetails) != 0 {
+		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+			return stacktrace.Propagate(err, "failed to run probes")
+		}
+	}
+
+	//Wait for chaos duration
+	log.Infof("[Wait]: Waiting for the chaos duration of %vs", experimentsDetails.ChaosDuration)
+	common.WaitForDuration(experimentsDetails.ChaosDuration)
+	return nil
+}
+
+// revertChaos restores the original scaling config of the nodegroup
+// the restore is retried till the scale down update is completed, if the abort signal is received while it is in progress
+func revertChaos(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails) error {
+	revertLock.Lock()
+	defer revertLock.Unlock()
+
+	if !scaledDown {
+		return nil
+	}
+
+	log.Infof("[Revert]: Restoring the original scaling config of the %v nodegroup", experimentsDetails.NodegroupName)
+	updateID, err := awslib.UpdateNodegroupScaling(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.ClusterName, experimentsDetails.NodegroupName, experimentsDetails.OriginalScaling, experimentsDetails.Region)
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{EKS Cluster: %v, Nodegroup: %v}", experimentsDetails.ClusterName, experimentsDetails.NodegroupName), Reason: stacktrace.RootCause(err).Error()}
+	}
+	scaledDown = false
+
+	//Wait for the update of the nodegroup to get successful
+	log.Info("[Wait]: Wait for the nodegroup update to get successful")
+	if err := awslib.WaitForNodegroupUpdate(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.ClusterName, experimentsDetails.NodegroupName, updateID, experimentsDetails.Region); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{EKS Cluster: %v, Nodegroup: %v}", experimentsDetails.ClusterName, experimentsDetails.NodegroupName), Reason: stacktrace.RootCause(err).Error()}
+	}
+	common.SetTargets(experimentsDetails.NodegroupName, "reverted", "EKS-Nodegroup", chaosDetails)
+	return nil
+}
+
+// SetOriginalScaling records the scaling config of the active nodegroup, which is restored after the chaos
+func SetOriginalScaling(experimentsDetails *experimentTypes.ExperimentDetails) error {
+	if experimentsDetails.ClusterName == "" || experimentsDetails.NodegroupName == "" {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "no cluster or nodegroup provided, please provide the target nodegroup in CLUSTER_NAME and NODEGROUP_NAME envs"}
+	}
+
+	nodegroup, err := awslib.GetNodegroup(experimentsDetails.ClusterName, experimentsDetails.NodegroupName, experimentsDetails.Region)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to get the nodegroup")
+	}
+	if status := aws.StringValue(nodegroup.Status); status != eks.NodegroupStatusActive {
+		return cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeTargetSelection,
+			Reason:    fmt.Sprintf("nodegroup is not in ACTIVE state, current state: %v", status),
+			Target:    fmt.Sprintf("{EKS Cluster: %v, Nodegroup: %v, Region: %v}", experimentsDetails.ClusterName, experimentsDetails.NodegroupName, experimentsDetails.Region),
+		}
+	}
+	experimentsDetails.OriginalScaling = nodegroup.ScalingConfig
+
+	log.InfoWithValues("[Info]: The original scaling config of the nodegroup", logrus.Fields{
+		"Nodegroup":   experimentsDetails.NodegroupName,
+		"MinSize":     aws.Int64Value(nodegroup.ScalingConfig.MinSize),
+		"MaxSize":     aws.Int64Value(nodegroup.ScalingConfig.MaxSize),
+		"DesiredSize": aws.Int64Value(nodegroup.ScalingConfig.DesiredSize),
+	})
+	return nil
+}
+
+// watching for the abort signal and revert the chaos
+func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails) {
+
+	<-abort
+
+	log.Info("[Abort]: Chaos Revert Started")
+	if err := revertChaos(experimentsDetails, chaosDetails); err != nil {
+		log.Errorf("Failed to restore the scaling config of the nodegroup when an abort signal is received: %v", err)
+	}
+	log.Info("[Abort]: Chaos Revert Completed")
+	os.Exit(1)
+}
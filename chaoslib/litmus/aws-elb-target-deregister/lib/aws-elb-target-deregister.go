@@ -0,0 +1,200 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	elb "github.com/litmuschaos/litmus-go/pkg/cloud/aws/elb"
+	"github.com/litmuschaos/litmus-go/pkg/events"
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/kube-aws/aws-elb-target-deregister/types"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/probe"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+)
+
+var (
+	abort chan os.Signal
+	// the deregistered targets, which are registered back after the chaos duration or when the abort signal is received
+	revertLock          sync.Mutex
+	deregisteredTargets []*elbv2.TargetDescription
+)
+
+// PrepareELBTargetDeregister contains the preparation and injection steps for the experiment
+func PrepareELBTargetDeregister(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "PrepareAWSELBTargetDeregisterFault")
+	defer span.End()
+
+	// abort channel is used to transmit signal notifications.
+	abort = make(chan os.Signal, 1)
+	// Catch and relay certain signal(s) to abort channel.
+	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+
+	//Waiting for the ramp time before chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
+		common.WaitForDuration(experimentsDetails.RampTime)
+	}
+
+	targetKeys := elb.GetTargetKeys(experimentsDetails.TargetDescriptions)
+	log.Infof("[Chaos]: Number of targets targeted: %v", len(targetKeys))
+
+	if chaosDetails.DryRun {
+		common.PlanTargets("ELB-Target", experimentsDetails.TargetGroupARN, targetKeys, chaosDetails)
+		return common.StopForDryRun(experimentsDetails, chaosDetails)
+	}
+
+	// watching for the abort signal and revert the chaos
+	go abortWatcher(experimentsDetails, chaosDetails)
+
+	// the targets are registered back after the chaos duration, the partially deregistered targets are registered back as well before the failure is reported
+	err := injectChaos(ctx, experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails)
+	revertErr := revertChaos(experimentsDetails, chaosDetails)
+	if err != nil {
+		if revertErr != nil {
+			return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), stacktrace.RootCause(revertErr).Error())}
+		}
+		return stacktrace.Propagate(err, "could not deregister the targets")
+	}
+	if revertErr != nil {
+		return stacktrace.Propagate(revertErr, "could not register back the targets")
+	}
+
+	//Waiting for the ramp time after chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
+		common.WaitForDuration(experimentsDetails.RampTime)
+	}
+	return nil
+}
+
+// injectChaos deregisters the targets from the target group for the chaos duration
+func injectChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectAWSELBTargetDeregisterFault")
+	defer span.End()
+
+	if experimentsDetails.EngineName != "" {
+		msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on the target group"
+		types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
+		events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
+	}
+
+	log.Infof("[Chaos]: Deregistering the %v targets from the target group", elb.GetTargetKeys(experimentsDetails.TargetDescriptions))
+	revertLock.Lock()
+	if err := elb.DeregisterTargets(experimentsDetails.TargetGroupARN, experimentsDetails.TargetDescriptions, experimentsDetails.Region); err != nil {
+		revertLock.Unlock()
+		return stacktrace.Propagate(err, "targets failed to deregister")
+	}
+	deregisteredTargets = experimentsDetails.TargetDescriptions
+	revertLock.Unlock()
+	for _, key := range elb.GetTargetKeys(experimentsDetails.TargetDescriptions) {
+		common.SetTargets(key, "injected", "ELB-Target", chaosDetails)
+	}
+
+	//Wait for the targets to get in draining state
+	log.Info("[Wait]: Wait for the targets to get in draining state")
+	if err := elb.WaitForTargetsDeregistration(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.TargetGroupARN, experimentsDetails.TargetDescriptions, experimentsDetails.Region); err != nil {
+		return stacktrace.Propagate(err, "targets failed to deregister")
+	}
+
+	// run the probes during chaos
+	if len(resultDetails.ProbeDetails) != 0 {
+		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+			return stacktrace.Propagate(err, "failed to run probes")
+		}
+	}
+
+	//Wait for chaos duration
+	log.Infof("[Wait]: Waiting for the chaos duration of %vs", experimentsDetails.ChaosDuration)
+	common.WaitForDuration(experimentsDetails.ChaosDuration)
+	return nil
+}
+
+// revertChaos registers back the deregistered targets and waits for them to get in healthy state
+// the deregistered targets are cleared, so that the targets are registered back only once if the abort signal is received during the revert
+func revertChaos(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails) error {
+	revertLock.Lock()
+	defer revertLock.Unlock()
+
+	if len(deregisteredTargets) == 0 {
+		return nil
+	}
+
+	log.Infof("[Revert]: Registering back the %v targets with the target group", elb.GetTargetKeys(deregisteredTargets))
+	if err := elb.RegisterTargets(experimentsDetails.TargetGroupARN, deregisteredTargets, experimentsDetails.Region); err != nil {
+		return stacktrace.Propagate(err, "targets failed to register")
+	}
+	targets := deregisteredTargets
+	deregisteredTargets = nil
+
+	//Wait for the targets to get in healthy state
+	log.Info("[Wait]: Wait for the targets to get in healthy state")
+	if err := elb.WaitForTargetsHealthy(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.TargetGroupARN, targets, experimentsDetails.Region); err != nil {
+		return stacktrace.Propagate(err, "targets failed to get healthy")
+	}
+	for _, key := range elb.GetTargetKeys(targets) {
+		common.SetTargets(key, "reverted", "ELB-Target", chaosDetails)
+	}
+	return nil
+}
+
+// SetTargetDescriptions selects the healthy targets of the target group, which are filtered by the target ids or the affected percentage
+func SetTargetDescriptions(experimentsDetails *experimentTypes.ExperimentDetails) error {
+	if experimentsDetails.TargetGroupARN == "" {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "no target group provided, please provide the target group arn in TARGET_GROUP_ARN env"}
+	}
+
+	targetHealth, err := elb.GetTargetHealth(experimentsDetails.TargetGroupARN, nil, experimentsDetails.Region)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to get the targets of the target group")
+	}
+	targets, err := elb.SelectTargets(targetHealth, experimentsDetails.TargetIDs)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to select the targets")
+	}
+
+	if experimentsDetails.TargetIDs == "" {
+		selected := map[string]bool{}
+		for _, key := range common.FilterBasedOnPercentage(experimentsDetails.TargetsAffectedPerc, elb.GetTargetKeys(targets)) {
+			selected[key] = true
+		}
+		var filteredTargets []*elbv2.TargetDescription
+		for i, key := range elb.GetTargetKeys(targets) {
+			if selected[key] {
+				filteredTargets = append(filteredTargets, targets[i])
+			}
+		}
+		targets = filteredTargets
+	}
+	experimentsDetails.TargetDescriptions = targets
+
+	log.InfoWithValues("[Info]: Targeting the healthy targets of the target group", logrus.Fields{
+		"Target Group": experimentsDetails.TargetGroupARN,
+		"Targets":      elb.GetTargetKeys(targets),
+	})
+	return nil
+}
+
+// watching for the abort signal and revert the chaos
+func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails) {
+
+	<-abort
+
+	log.Info("[Abort]: Chaos Revert Started")
+	if err := revertChaos(experimentsDetails, chaosDetails); err != nil {
+		log.Errorf("Failed to register back the targets when an abort signal is received: %v", err)
+	}
+	log.Info("[Abort]: Chaos Revert Completed")
+	os.Exit(1)
+}
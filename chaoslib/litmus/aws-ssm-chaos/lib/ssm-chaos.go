@@ -72,7 +72,9 @@ func InjectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 				//wait for the ssm command to get succeeded in the given chaos duration
 				log.Info("[Wait]: Waiting for the ssm command to get completed")
-				if err := ssm.WaitForCommandStatus("Success", commandId, ec2ID, experimentsDetails.Region, experimentsDetails.ChaosDuration+experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
+				err = ssm.WaitForCommandStatus("Success", commandId, ec2ID, experimentsDetails.Region, experimentsDetails.ChaosDuration+experimentsDetails.Timeout, experimentsDetails.Delay)
+				recordCommandOutput(commandId, ec2ID, experimentsDetails.Region, chaosDetails)
+				if err != nil {
 					return stacktrace.Propagate(err, "failed to send ssm command")
 				}
 				common.SetTargets(ec2ID, "reverted", "EC2", chaosDetails)
@@ -140,7 +142,9 @@ func InjectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 			for _, ec2ID := range instanceIDList {
 				//wait for the ssm command to get succeeded in the given chaos duration
 				log.Info("[Wait]: Waiting for the ssm command to get completed")
-				if err := ssm.WaitForCommandStatus("Success", commandId, ec2ID, experimentsDetails.Region, experimentsDetails.ChaosDuration+experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
+				err = ssm.WaitForCommandStatus("Success", commandId, ec2ID, experimentsDetails.Region, experimentsDetails.ChaosDuration+experimentsDetails.Timeout, experimentsDetails.Delay)
+				recordCommandOutput(commandId, ec2ID, experimentsDetails.Region, chaosDetails)
+				if err != nil {
 					return stacktrace.Propagate(err, "failed to send ssm command")
 				}
 			}
@@ -156,8 +160,95 @@ func InjectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 	return nil
 }
 
+// recordCommandOutput records the output of the ssm command on the instance in the chaosresult
+func recordCommandOutput(commandID, ec2ID, region string, chaosDetails *types.ChaosDetails) {
+	status, output, errOutput, err := ssm.GetCommandOutput(commandID, ec2ID, region)
+	if err != nil {
+		log.Errorf("Failed to get the output of the ssm command: %v", err)
+		return
+	}
+	common.RecordCommandOutput(ec2ID, commandID, status, output, errOutput, chaosDetails)
+}
+
+// ValidateFault validates the parameters of the bundled fault before the documents are uploaded
+func ValidateFault(experimentsDetails *experimentTypes.ExperimentDetails) error {
+	if experimentsDetails.FaultName == "" {
+		return nil
+	}
+	parameters, revertParameters, err := ssm.GetFaultParameters(experimentsDetails)
+	if err != nil {
+		return stacktrace.Propagate(err, "invalid fault parameters")
+	}
+	experimentsDetails.FaultParameters = parameters
+	experimentsDetails.RevertFaultParameters = revertParameters
+	return nil
+}
+
+// UploadDocuments uploads the document of the bundled fault along with its revert document in the YAML format,
+// or the document from the document path in the DOCUMENT_FORMAT format if no fault is provided
+func UploadDocuments(experimentsDetails *experimentTypes.ExperimentDetails) error {
+	if experimentsDetails.FaultName == "" {
+		if err := ssm.CreateAndUploadDocument(experimentsDetails.DocumentName, experimentsDetails.DocumentType, experimentsDetails.DocumentFormat, experimentsDetails.DocumentPath, experimentsDetails.Region); err != nil {
+			return stacktrace.Propagate(err, "could not create and upload the ssm document")
+		}
+		experimentsDetails.IsDocsUploaded = true
+		return nil
+	}
+
+	document, revertDocument, err := ssm.GetFaultDocuments(experimentsDetails.FaultName)
+	if err != nil {
+		return stacktrace.Propagate(err, "could not get the fault documents")
+	}
+	if err := ssm.UploadDocument(experimentsDetails.DocumentName, experimentsDetails.DocumentType, ssm.FaultDocumentFormat, document, experimentsDetails.Region); err != nil {
+		return stacktrace.Propagate(err, "could not upload the ssm document")
+	}
+	experimentsDetails.IsDocsUploaded = true
+	if revertDocument == "" {
+		return nil
+	}
+	if err := ssm.UploadDocument(experimentsDetails.RevertDocumentName, experimentsDetails.DocumentType, ssm.FaultDocumentFormat, revertDocument, experimentsDetails.Region); err != nil {
+		return stacktrace.Propagate(err, "could not upload the revert ssm document")
+	}
+	experimentsDetails.IsRevertDocsUploaded = true
+	return nil
+}
+
+// DeleteDocuments deletes the uploaded document and the revert document
+func DeleteDocuments(experimentsDetails *experimentTypes.ExperimentDetails) error {
+	if experimentsDetails.IsRevertDocsUploaded {
+		if err := ssm.SSMDeleteDocument(experimentsDetails.RevertDocumentName, experimentsDetails.Region); err != nil {
+			return stacktrace.Propagate(err, "failed to delete the revert ssm doc")
+		}
+		experimentsDetails.IsRevertDocsUploaded = false
+	}
+	if experimentsDetails.IsDocsUploaded {
+		if err := ssm.SSMDeleteDocument(experimentsDetails.DocumentName, experimentsDetails.Region); err != nil {
+			return stacktrace.Propagate(err, "failed to delete ssm doc")
+		}
+		experimentsDetails.IsDocsUploaded = false
+	}
+	return nil
+}
+
+// RevertFault runs the revert document of the bundled fault on the instances and waits for it to complete
+func RevertFault(experimentsDetails *experimentTypes.ExperimentDetails, instanceIDList []string) error {
+	if !experimentsDetails.IsRevertDocsUploaded {
+		return nil
+	}
+	commandID, err := ssm.SendRevertCommand(experimentsDetails, instanceIDList)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to send the revert ssm command")
+	}
+	for _, ec2ID := range instanceIDList {
+		if err := ssm.WaitForCommandStatus("Success", commandID, ec2ID, experimentsDetails.Region, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
+			return stacktrace.Propagate(err, "failed to revert the fault")
+		}
+	}
+	return nil
+}
+
 // AbortWatcher will be watching for the abort signal and revert the chaos
-func AbortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, abort chan os.Signal) {
+func AbortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, instanceIDList []string, abort chan os.Signal) {
 
 	<-abort
 
@@ -172,7 +263,11 @@ func AbortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, abort c
 	default:
 		log.Info("[Abort]: No SSM Command found to cancel")
 	}
-	if err := ssm.SSMDeleteDocument(experimentsDetails.DocumentName, experimentsDetails.Region); err != nil {
+	// the cancelled command doesn't run the cleanup of the document, the revert document is run on all the targets
+	if err := RevertFault(experimentsDetails, instanceIDList); err != nil {
+		log.Errorf("[Abort]: Failed to revert the fault, manual recovery required: %v", err)
+	}
+	if err := DeleteDocuments(experimentsDetails); err != nil {
 		log.Errorf("Failed to delete ssm document: %v", err)
 	}
 	log.Info("[Abort]: Chaos Revert Completed")
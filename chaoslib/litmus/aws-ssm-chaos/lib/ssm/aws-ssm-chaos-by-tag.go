@@ -12,7 +12,6 @@ import (
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/aws-ssm/aws-ssm-chaos/types"
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
-	"github.com/litmuschaos/litmus-go/pkg/cloud/aws/ssm"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/telemetry"
 	"github.com/litmuschaos/litmus-go/pkg/types"
@@ -42,15 +41,6 @@ func PrepareAWSSSMChaosByTag(ctx context.Context, experimentsDetails *experiment
 		common.WaitForDuration(experimentsDetails.RampTime)
 	}
 
-	//create and upload the ssm document on the given aws service monitoring docs
-	if err = ssm.CreateAndUploadDocument(experimentsDetails.DocumentName, experimentsDetails.DocumentType, experimentsDetails.DocumentFormat, experimentsDetails.DocumentPath, experimentsDetails.Region); err != nil {
-		return stacktrace.Propagate(err, "could not create and upload the ssm document")
-	}
-	experimentsDetails.IsDocsUploaded = true
-	log.Info("[Info]: SSM docs uploaded successfully")
-
-	// watching for the abort signal and revert the chaos
-	go lib.AbortWatcher(experimentsDetails, abort)
 	instanceIDList := common.FilterBasedOnPercentage(experimentsDetails.InstanceAffectedPerc, experimentsDetails.TargetInstanceIDList)
 	log.Infof("[Chaos]:Number of Instance targeted: %v", len(instanceIDList))
 
@@ -58,6 +48,24 @@ func PrepareAWSSSMChaosByTag(ctx context.Context, experimentsDetails *experiment
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "no instance id found for chaos injection"}
 	}
 
+	if err = lib.ValidateFault(experimentsDetails); err != nil {
+		return stacktrace.Propagate(err, "could not validate the fault")
+	}
+
+	if chaosDetails.DryRun {
+		common.PlanTargets("EC2", "", instanceIDList, chaosDetails)
+		return common.StopForDryRun(experimentsDetails, chaosDetails)
+	}
+
+	//create and upload the ssm document on the given aws service monitoring docs
+	if err = lib.UploadDocuments(experimentsDetails); err != nil {
+		return stacktrace.Propagate(err, "could not upload the ssm documents")
+	}
+	log.Info("[Info]: SSM docs uploaded successfully")
+
+	// watching for the abort signal and revert the chaos
+	go lib.AbortWatcher(experimentsDetails, instanceIDList, abort)
+
 	switch strings.ToLower(experimentsDetails.Sequence) {
 	case "serial":
 		if err = lib.InjectChaosInSerialMode(ctx, experimentsDetails, instanceIDList, clients, resultDetails, eventsDetails, chaosDetails, inject); err != nil {
@@ -71,9 +79,8 @@ func PrepareAWSSSMChaosByTag(ctx context.Context, experimentsDetails *experiment
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: fmt.Sprintf("'%s' sequence is not supported", experimentsDetails.Sequence)}
 	}
 
-	//Delete the ssm document on the given aws service monitoring docs
-	err = ssm.SSMDeleteDocument(experimentsDetails.DocumentName, experimentsDetails.Region)
-	if err != nil {
+	//Delete the ssm documents on the given aws service monitoring docs
+	if err = lib.DeleteDocuments(experimentsDetails); err != nil {
 		return stacktrace.Propagate(err, "failed to delete ssm doc")
 	}
 
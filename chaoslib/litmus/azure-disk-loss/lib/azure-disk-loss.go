@@ -9,12 +9,13 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/profiles/latest/compute/mgmt/compute"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/azure/disk-loss/types"
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
+	azureCommon "github.com/litmuschaos/litmus-go/pkg/cloud/azure/common"
 	diskStatus "github.com/litmuschaos/litmus-go/pkg/cloud/azure/disk"
 	instanceStatus "github.com/litmuschaos/litmus-go/pkg/cloud/azure/instance"
+	"github.com/litmuschaos/litmus-go/pkg/cloud/provider"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/probe"
@@ -32,7 +33,7 @@ var (
 )
 
 // PrepareChaos contains the prepration and injection steps for the experiment
-func PrepareChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func PrepareChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, cloudProvider provider.Provider, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "PrepareAzureDiskLossFault")
 	defer span.End()
 
@@ -63,14 +64,9 @@ func PrepareChaos(ctx context.Context, experimentsDetails *experimentTypes.Exper
 		return stacktrace.Propagate(err, "error fetching attached instances for disks")
 	}
 
-	// Get the instance name with attached disks
-	attachedDisksWithInstance := make(map[string]*[]compute.DataDisk)
-
-	for instanceName := range instanceNamesWithDiskNames {
-		attachedDisksWithInstance[instanceName], err = diskStatus.GetInstanceDiskList(experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, experimentsDetails.ScaleSet, instanceName)
-		if err != nil {
-			return stacktrace.Propagate(err, "error fetching virtual disks")
-		}
+	if chaosDetails.DryRun {
+		common.PlanTargets("VirtualDisk", "", diskNameList, chaosDetails)
+		return common.StopForDryRun(experimentsDetails, chaosDetails)
 	}
 
 	select {
@@ -80,15 +76,15 @@ func PrepareChaos(ctx context.Context, experimentsDetails *experimentTypes.Exper
 	default:
 
 		// watching for the abort signal and revert the chaos
-		go abortWatcher(experimentsDetails, attachedDisksWithInstance, instanceNamesWithDiskNames, chaosDetails)
+		go abortWatcher(experimentsDetails, cloudProvider, instanceNamesWithDiskNames, chaosDetails)
 
 		switch strings.ToLower(experimentsDetails.Sequence) {
 		case "serial":
-			if err = injectChaosInSerialMode(ctx, experimentsDetails, instanceNamesWithDiskNames, attachedDisksWithInstance, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+			if err = injectChaosInSerialMode(ctx, experimentsDetails, cloudProvider, instanceNamesWithDiskNames, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
 				return stacktrace.Propagate(err, "could not run chaos in serial mode")
 			}
 		case "parallel":
-			if err = injectChaosInParallelMode(ctx, experimentsDetails, instanceNamesWithDiskNames, attachedDisksWithInstance, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+			if err = injectChaosInParallelMode(ctx, experimentsDetails, cloudProvider, instanceNamesWithDiskNames, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
 				return stacktrace.Propagate(err, "could not run chaos in parallel mode")
 			}
 		default:
@@ -105,7 +101,7 @@ func PrepareChaos(ctx context.Context, experimentsDetails *experimentTypes.Exper
 }
 
 // injectChaosInParallelMode will inject the Azure disk loss chaos in parallel mode that is all at once
-func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, instanceNamesWithDiskNames map[string][]string, attachedDisksWithInstance map[string]*[]compute.DataDisk, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, cloudProvider provider.Provider, instanceNamesWithDiskNames map[string][]string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectAzureDiskLossFaultInParallelMode")
 	defer span.End()
 
@@ -122,28 +118,24 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 		}
 
 		// Detaching the virtual disks
+		// the disks of an instance are detached one after the other, as the instance can't be updated while its previous update is in progress
 		log.Info("[Chaos]: Detaching the virtual disks from the instances")
 		for instanceName, diskNameList := range instanceNamesWithDiskNames {
-			if err = diskStatus.DetachDisks(experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, instanceName, experimentsDetails.ScaleSet, diskNameList); err != nil {
-				return stacktrace.Propagate(err, "failed to detach disks")
-			}
-		}
-		// Waiting for disk to be detached
-		for _, diskNameList := range instanceNamesWithDiskNames {
 			for _, diskName := range diskNameList {
+				volume := getVolume(experimentsDetails, instanceName, diskName)
+				if err = cloudProvider.DetachVolume(volume); err != nil {
+					return stacktrace.Propagate(err, "failed to detach disks")
+				}
+
+				// Waiting for disk to be detached
 				log.Infof("[Wait]: Waiting for Disk '%v' to detach", diskName)
-				if err := diskStatus.WaitForDiskToDetach(experimentsDetails, diskName); err != nil {
+				if err := provider.WaitForVolumeState(cloudProvider, volume, provider.StateDetached, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 					return stacktrace.Propagate(err, "disk detachment check failed")
 				}
-			}
-		}
-
-		// Updating the result details
-		for _, diskNameList := range instanceNamesWithDiskNames {
-			for _, diskName := range diskNameList {
 				common.SetTargets(diskName, "detached", "VirtualDisk", chaosDetails)
 			}
 		}
+
 		// run the probes during chaos
 		if len(resultDetails.ProbeDetails) != 0 {
 			if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
@@ -157,26 +149,19 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 
 		//Attaching the virtual disks to the instance
 		log.Info("[Chaos]: Attaching the Virtual disks back to the instances")
-		for instanceName, diskNameList := range attachedDisksWithInstance {
-			if err = diskStatus.AttachDisk(experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, instanceName, experimentsDetails.ScaleSet, diskNameList); err != nil {
-				return stacktrace.Propagate(err, "virtual disk attachment failed")
-			}
-
-			// Wait for disk to be attached
-			for _, diskNameList := range instanceNamesWithDiskNames {
-				for _, diskName := range diskNameList {
-					log.Infof("[Wait]: Waiting for Disk '%v' to attach", diskName)
-					if err := diskStatus.WaitForDiskToAttach(experimentsDetails, diskName); err != nil {
-						return stacktrace.Propagate(err, "disk attachment check failed")
-					}
+		for instanceName, diskNameList := range instanceNamesWithDiskNames {
+			for _, diskName := range diskNameList {
+				volume := getVolume(experimentsDetails, instanceName, diskName)
+				if err = cloudProvider.AttachVolume(volume); err != nil {
+					return stacktrace.Propagate(err, "virtual disk attachment failed")
 				}
-			}
 
-			// Updating the result details
-			for _, diskNameList := range instanceNamesWithDiskNames {
-				for _, diskName := range diskNameList {
-					common.SetTargets(diskName, "re-attached", "VirtualDisk", chaosDetails)
+				// Wait for disk to be attached
+				log.Infof("[Wait]: Waiting for Disk '%v' to attach", diskName)
+				if err := provider.WaitForVolumeState(cloudProvider, volume, provider.StateAttached, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
+					return stacktrace.Propagate(err, "disk attachment check failed")
 				}
+				common.SetTargets(diskName, "re-attached", "VirtualDisk", chaosDetails)
 			}
 		}
 		duration = int(time.Since(ChaosStartTimeStamp).Seconds())
@@ -185,7 +170,7 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 }
 
 // injectChaosInSerialMode will inject the Azure disk loss chaos in serial mode that is one after other
-func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, instanceNamesWithDiskNames map[string][]string, attachedDisksWithInstance map[string]*[]compute.DataDisk, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, cloudProvider provider.Provider, instanceNamesWithDiskNames map[string][]string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectAzureDiskLossFaultInSerialMode")
 	defer span.End()
 
@@ -203,18 +188,17 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 		for instanceName, diskNameList := range instanceNamesWithDiskNames {
 			for i, diskName := range diskNameList {
-				// Converting diskName to list type because DetachDisks() accepts a list type
-				diskNameToList := []string{diskName}
+				volume := getVolume(experimentsDetails, instanceName, diskName)
 
 				// Detaching the virtual disks
 				log.Infof("[Chaos]: Detaching %v from the instance", diskName)
-				if err = diskStatus.DetachDisks(experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, instanceName, experimentsDetails.ScaleSet, diskNameToList); err != nil {
+				if err = cloudProvider.DetachVolume(volume); err != nil {
 					return stacktrace.Propagate(err, "failed to detach disks")
 				}
 
 				// Waiting for disk to be detached
 				log.Infof("[Wait]: Waiting for Disk '%v' to detach", diskName)
-				if err := diskStatus.WaitForDiskToDetach(experimentsDetails, diskName); err != nil {
+				if err := provider.WaitForVolumeState(cloudProvider, volume, provider.StateDetached, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 					return stacktrace.Propagate(err, "disk detachment check failed")
 				}
 
@@ -234,13 +218,13 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 				//Attaching the virtual disks to the instance
 				log.Infof("[Chaos]: Attaching %v back to the instance", diskName)
-				if err = diskStatus.AttachDisk(experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, instanceName, experimentsDetails.ScaleSet, attachedDisksWithInstance[instanceName]); err != nil {
+				if err = cloudProvider.AttachVolume(volume); err != nil {
 					return stacktrace.Propagate(err, "disk attachment failed")
 				}
 
 				// Waiting for disk to be attached
 				log.Infof("[Wait]: Waiting for Disk '%v' to attach", diskName)
-				if err := diskStatus.WaitForDiskToAttach(experimentsDetails, diskName); err != nil {
+				if err := provider.WaitForVolumeState(cloudProvider, volume, provider.StateAttached, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 					return stacktrace.Propagate(err, "disk attachment check failed")
 				}
 
@@ -252,15 +236,25 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 	return nil
 }
 
+// getVolume returns the provider volume of the disk, which is attached to the given vm
+// the scale set vms are named as <scale set>_<instance id>
+func getVolume(experimentsDetails *experimentTypes.ExperimentDetails, instanceName, diskName string) provider.Volume {
+	volume := provider.Volume{ID: diskName, InstanceID: instanceName, ResourceGroup: experimentsDetails.ResourceGroup}
+	if experimentsDetails.ScaleSet == "enable" {
+		volume.ScaleSet, volume.InstanceID = azureCommon.GetScaleSetNameAndInstanceId(instanceName)
+	}
+	return volume
+}
+
 // abortWatcher will be watching for the abort signal and revert the chaos
-func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, attachedDisksWithInstance map[string]*[]compute.DataDisk, instanceNamesWithDiskNames map[string][]string, chaosDetails *types.ChaosDetails) {
+func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, cloudProvider provider.Provider, instanceNamesWithDiskNames map[string][]string, chaosDetails *types.ChaosDetails) {
 	<-abort
 
 	log.Info("[Abort]: Chaos Revert Started")
 
 	log.Info("[Abort]: Attaching disk(s) as abort signal received")
 
-	for instanceName, diskList := range attachedDisksWithInstance {
+	for instanceName, diskNameList := range instanceNamesWithDiskNames {
 		// Checking for provisioning state of the vm instances
 		err = retry.
 			Times(uint(experimentsDetails.Timeout / experimentsDetails.Delay)).
@@ -279,17 +273,22 @@ func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, attache
 			log.Errorf("[Error]: Instance is still in 'updating' state after timeout, re-attach might fail")
 		}
 		log.Infof("[Abort]: Attaching disk(s) to instance: %v", instanceName)
-		for _, disk := range *diskList {
-			diskStatusString, err := diskStatus.GetDiskStatus(experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, *disk.Name)
+		for _, diskName := range diskNameList {
+			volume := getVolume(experimentsDetails, instanceName, diskName)
+			diskState, err := cloudProvider.GetVolumeState(volume)
 			if err != nil {
 				log.Errorf("Failed to get disk status: %v", err)
 			}
-			if diskStatusString != "Attached" {
-				if err := diskStatus.AttachDisk(experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, instanceName, experimentsDetails.ScaleSet, diskList); err != nil {
+			if diskState != provider.StateAttached {
+				if err := cloudProvider.AttachVolume(volume); err != nil {
 					log.Errorf("Failed to attach disk, manual revert required: %v", err)
-				} else {
-					common.SetTargets(*disk.Name, "re-attached", "VirtualDisk", chaosDetails)
+					continue
 				}
+				if err := provider.WaitForVolumeState(cloudProvider, volume, provider.StateAttached, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
+					log.Errorf("Failed to attach disk, manual revert required: %v", err)
+					continue
+				}
+				common.SetTargets(diskName, "re-attached", "VirtualDisk", chaosDetails)
 			}
 		}
 	}
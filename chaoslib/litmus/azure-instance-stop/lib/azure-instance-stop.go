@@ -13,7 +13,7 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	azureCommon "github.com/litmuschaos/litmus-go/pkg/cloud/azure/common"
-	azureStatus "github.com/litmuschaos/litmus-go/pkg/cloud/azure/instance"
+	"github.com/litmuschaos/litmus-go/pkg/cloud/provider"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/probe"
@@ -30,7 +30,7 @@ var (
 )
 
 // PrepareAzureStop will initialize instanceNameList and start chaos injection based on sequence method selected
-func PrepareAzureStop(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func PrepareAzureStop(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, cloudProvider provider.Provider, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "PrepareAzureInstanceStopFault")
 	defer span.End()
 
@@ -55,16 +55,21 @@ func PrepareAzureStop(ctx context.Context, experimentsDetails *experimentTypes.E
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "no instance name found to stop"}
 	}
 
+	if chaosDetails.DryRun {
+		common.PlanTargets("VM", "", instanceNameList, chaosDetails)
+		return common.StopForDryRun(experimentsDetails, chaosDetails)
+	}
+
 	// watching for the abort signal and revert the chaos
-	go abortWatcher(experimentsDetails, instanceNameList)
+	go abortWatcher(experimentsDetails, cloudProvider, instanceNameList)
 
 	switch strings.ToLower(experimentsDetails.Sequence) {
 	case "serial":
-		if err = injectChaosInSerialMode(ctx, experimentsDetails, instanceNameList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+		if err = injectChaosInSerialMode(ctx, experimentsDetails, cloudProvider, instanceNameList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
 			return stacktrace.Propagate(err, "could not run chaos in serial mode")
 		}
 	case "parallel":
-		if err = injectChaosInParallelMode(ctx, experimentsDetails, instanceNameList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+		if err = injectChaosInParallelMode(ctx, experimentsDetails, cloudProvider, instanceNameList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
 			return stacktrace.Propagate(err, "could not run chaos in parallel mode")
 		}
 	default:
@@ -80,7 +85,7 @@ func PrepareAzureStop(ctx context.Context, experimentsDetails *experimentTypes.E
 }
 
 // injectChaosInSerialMode will inject the Azure instance termination in serial mode that is one after the other
-func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, instanceNameList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, cloudProvider provider.Provider, instanceNameList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectAzureInstanceStopFaultInSerialMode")
 	defer span.End()
 
@@ -108,19 +113,13 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 				// Stopping the Azure instance
 				log.Infof("[Chaos]: Stopping the Azure instance: %v", vmName)
-				if experimentsDetails.ScaleSet == "enable" {
-					if err := azureStatus.AzureScaleSetInstanceStop(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
-						return stacktrace.Propagate(err, "unable to stop the Azure instance")
-					}
-				} else {
-					if err := azureStatus.AzureInstanceStop(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
-						return stacktrace.Propagate(err, "unable to stop the Azure instance")
-					}
+				if err := cloudProvider.StopInstance(getInstance(experimentsDetails, vmName)); err != nil {
+					return stacktrace.Propagate(err, "unable to stop the Azure instance")
 				}
 
 				// Wait for Azure instance to completely stop
 				log.Infof("[Wait]: Waiting for Azure instance '%v' to get in the stopped state", vmName)
-				if err := azureStatus.WaitForAzureComputeDown(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.ScaleSet, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
+				if err := provider.WaitForInstanceState(cloudProvider, getInstance(experimentsDetails, vmName), provider.StateStopped, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 					return stacktrace.Propagate(err, "instance poweroff status check failed")
 				}
 
@@ -138,19 +137,13 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 				// Starting the Azure instance
 				log.Info("[Chaos]: Starting back the Azure instance")
-				if experimentsDetails.ScaleSet == "enable" {
-					if err := azureStatus.AzureScaleSetInstanceStart(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
-						return stacktrace.Propagate(err, "unable to start the Azure instance")
-					}
-				} else {
-					if err := azureStatus.AzureInstanceStart(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
-						return stacktrace.Propagate(err, "unable to start the Azure instance")
-					}
+				if err := cloudProvider.StartInstance(getInstance(experimentsDetails, vmName)); err != nil {
+					return stacktrace.Propagate(err, "unable to start the Azure instance")
 				}
 
 				// Wait for Azure instance to get in running state
 				log.Infof("[Wait]: Waiting for Azure instance '%v' to get in the running state", vmName)
-				if err := azureStatus.WaitForAzureComputeUp(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.ScaleSet, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
+				if err := provider.WaitForInstanceState(cloudProvider, getInstance(experimentsDetails, vmName), provider.StateRunning, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 					return stacktrace.Propagate(err, "instance power on status check failed")
 				}
 			}
@@ -161,7 +154,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 }
 
 // injectChaosInParallelMode will inject the Azure instance termination in parallel mode that is all at once
-func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, instanceNameList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, cloudProvider provider.Provider, instanceNameList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectAzureInstanceStopFaultInParallelMode")
 	defer span.End()
 
@@ -188,21 +181,15 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 			for _, vmName := range instanceNameList {
 				// Stopping the Azure instance
 				log.Infof("[Chaos]: Stopping the Azure instance: %v", vmName)
-				if experimentsDetails.ScaleSet == "enable" {
-					if err := azureStatus.AzureScaleSetInstanceStop(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
-						return stacktrace.Propagate(err, "unable to stop Azure instance")
-					}
-				} else {
-					if err := azureStatus.AzureInstanceStop(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
-						return stacktrace.Propagate(err, "unable to stop Azure instance")
-					}
+				if err := cloudProvider.StopInstance(getInstance(experimentsDetails, vmName)); err != nil {
+					return stacktrace.Propagate(err, "unable to stop Azure instance")
 				}
 			}
 
 			// Wait for all Azure instances to completely stop
 			for _, vmName := range instanceNameList {
 				log.Infof("[Wait]: Waiting for Azure instance '%v' to get in the stopped state", vmName)
-				if err := azureStatus.WaitForAzureComputeDown(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.ScaleSet, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
+				if err := provider.WaitForInstanceState(cloudProvider, getInstance(experimentsDetails, vmName), provider.StateStopped, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 					return stacktrace.Propagate(err, "instance poweroff status check failed")
 				}
 			}
@@ -221,21 +208,15 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 			// Starting the Azure instance
 			for _, vmName := range instanceNameList {
 				log.Infof("[Chaos]: Starting back the Azure instance: %v", vmName)
-				if experimentsDetails.ScaleSet == "enable" {
-					if err := azureStatus.AzureScaleSetInstanceStart(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
-						return stacktrace.Propagate(err, "unable to start the Azure instance")
-					}
-				} else {
-					if err := azureStatus.AzureInstanceStart(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
-						return stacktrace.Propagate(err, "unable to start the Azure instance")
-					}
+				if err := cloudProvider.StartInstance(getInstance(experimentsDetails, vmName)); err != nil {
+					return stacktrace.Propagate(err, "unable to start the Azure instance")
 				}
 			}
 
 			// Wait for Azure instance to get in running state
 			for _, vmName := range instanceNameList {
 				log.Infof("[Wait]: Waiting for Azure instance '%v' to get in the running state", vmName)
-				if err := azureStatus.WaitForAzureComputeUp(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.ScaleSet, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
+				if err := provider.WaitForInstanceState(cloudProvider, getInstance(experimentsDetails, vmName), provider.StateRunning, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 					return stacktrace.Propagate(err, "instance power on status check failed")
 				}
 			}
@@ -246,43 +227,39 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 	return nil
 }
 
+// getInstance returns the provider instance of the given vm, the scale set vms are named as <scale set>_<instance id>
+func getInstance(experimentsDetails *experimentTypes.ExperimentDetails, vmName string) provider.Instance {
+	instance := provider.Instance{ID: vmName, ResourceGroup: experimentsDetails.ResourceGroup}
+	if experimentsDetails.ScaleSet == "enable" {
+		instance.ScaleSet, instance.ID = azureCommon.GetScaleSetNameAndInstanceId(vmName)
+	}
+	return instance
+}
+
 // watching for the abort signal and revert the chaos
-func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, instanceNameList []string) {
+func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, cloudProvider provider.Provider, instanceNameList []string) {
 	<-abort
 
-	var instanceState string
-
 	log.Info("[Abort]: Chaos Revert Started")
 	for _, vmName := range instanceNameList {
-		if experimentsDetails.ScaleSet == "enable" {
-			scaleSetName, vmId := azureCommon.GetScaleSetNameAndInstanceId(vmName)
-			instanceState, err = azureStatus.GetAzureScaleSetInstanceStatus(experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, scaleSetName, vmId)
-		} else {
-			instanceState, err = azureStatus.GetAzureInstanceStatus(experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName)
-		}
+		instanceState, err := cloudProvider.GetInstanceState(getInstance(experimentsDetails, vmName))
 		if err != nil {
 			log.Errorf("[Abort]: Failed to get instance status when an abort signal is received: %v", err)
 		}
-		if instanceState != "VM running" && instanceState != "VM starting" {
+		if instanceState != provider.StateRunning {
 			log.Info("[Abort]: Waiting for the Azure instance to get down")
-			if err := azureStatus.WaitForAzureComputeDown(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.ScaleSet, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
+			if err := provider.WaitForInstanceState(cloudProvider, getInstance(experimentsDetails, vmName), provider.StateStopped, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 				log.Errorf("[Abort]: Instance power off status check failed: %v", err)
 			}
 
 			log.Info("[Abort]: Starting Azure instance as abort signal received")
-			if experimentsDetails.ScaleSet == "enable" {
-				if err := azureStatus.AzureScaleSetInstanceStart(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
-					log.Errorf("[Abort]: Unable to start the Azure instance: %v", err)
-				}
-			} else {
-				if err := azureStatus.AzureInstanceStart(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
-					log.Errorf("[Abort]: Unable to start the Azure instance: %v", err)
-				}
+			if err := cloudProvider.StartInstance(getInstance(experimentsDetails, vmName)); err != nil {
+				log.Errorf("[Abort]: Unable to start the Azure instance: %v", err)
 			}
 		}
 
 		log.Info("[Abort]: Waiting for the Azure instance to start")
-		err := azureStatus.WaitForAzureComputeUp(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.ScaleSet, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName)
+		err = provider.WaitForInstanceState(cloudProvider, getInstance(experimentsDetails, vmName), provider.StateRunning, experimentsDetails.Timeout, experimentsDetails.Delay)
 		if err != nil {
 			log.Errorf("[Abort]: Instance power on status check failed: %v", err)
 			log.Errorf("[Abort]: Azure instance %v failed to start after an abort signal is received", vmName)
@@ -0,0 +1,176 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/azure/nsg-block/types"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	azureNetwork "github.com/litmuschaos/litmus-go/pkg/cloud/azure/network"
+	"github.com/litmuschaos/litmus-go/pkg/events"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/probe"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/litmuschaos/litmus-go/pkg/utils/stringutils"
+	"github.com/palantir/stacktrace"
+	"go.opentelemetry.io/otel"
+)
+
+var (
+	abort chan os.Signal
+	// ruleInserted is set once the deny rule is inserted, the rule is removed after the chaos duration or when the abort signal is received
+	revertLock   sync.Mutex
+	ruleInserted bool
+)
+
+// PrepareNSGBlock contains the preparation and injection steps for the experiment
+func PrepareNSGBlock(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "PrepareAzureNSGBlockFault")
+	defer span.End()
+
+	// abort channel is used to transmit signal notifications.
+	abort = make(chan os.Signal, 1)
+	// Catch and relay certain signal(s) to abort channel.
+	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+
+	// Waiting for the ramp time before chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
+		common.WaitForDuration(experimentsDetails.RampTime)
+	}
+
+	if experimentsDetails.NetworkSecurityGroup == "" {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "no network security group provided, please provide the target network security group in NETWORK_SECURITY_GROUP env"}
+	}
+
+	// the deny rule is named uniquely, so that it doesn't replace any existing rule of the network security group
+	experimentsDetails.RuleName = "litmus-nsg-block-" + stringutils.GetRunID()
+	rule := getDenyRule(experimentsDetails)
+
+	securityRules, err := azureNetwork.GetSecurityRules(experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, experimentsDetails.NetworkSecurityGroup)
+	if err != nil {
+		return stacktrace.Propagate(err, "could not get the security rules")
+	}
+	if err := azureNetwork.ValidateDenyRule(rule, securityRules); err != nil {
+		return cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeTargetSelection,
+			Reason:    fmt.Sprintf("invalid deny rule: %v", err),
+			Target:    fmt.Sprintf("{Network Security Group: %v, Resource Group: %v}", experimentsDetails.NetworkSecurityGroup, experimentsDetails.ResourceGroup),
+		}
+	}
+
+	if chaosDetails.DryRun {
+		common.PlanTargets("NSG", experimentsDetails.ResourceGroup, []string{experimentsDetails.NetworkSecurityGroup}, chaosDetails)
+		return common.StopForDryRun(experimentsDetails, chaosDetails)
+	}
+
+	// watching for the abort signal and revert the chaos
+	go abortWatcher(experimentsDetails, chaosDetails)
+
+	// revertChaos runs even if the injection fails, since the rule may exist in the network security group after a timed out insert
+	err = injectChaos(ctx, experimentsDetails, rule, clients, resultDetails, eventsDetails, chaosDetails)
+	revertErr := revertChaos(experimentsDetails, chaosDetails)
+	if err != nil {
+		if revertErr != nil {
+			return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), stacktrace.RootCause(revertErr).Error())}
+		}
+		return stacktrace.Propagate(err, "could not block the network security group")
+	}
+	if revertErr != nil {
+		return stacktrace.Propagate(revertErr, "could not remove the deny rule")
+	}
+
+	// Waiting for the ramp time after chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
+		common.WaitForDuration(experimentsDetails.RampTime)
+	}
+	return nil
+}
+
+// injectChaos inserts the deny rule into the network security group for the chaos duration
+func injectChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, rule azureNetwork.DenyRule, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectAzureNSGBlockFault")
+	defer span.End()
+
+	if experimentsDetails.EngineName != "" {
+		msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on " + experimentsDetails.NetworkSecurityGroup + " network security group"
+		types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
+		events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
+	}
+
+	// the rule is marked as inserted before the request, so that a rule created by a timed out request is removed as well
+	revertLock.Lock()
+	ruleInserted = true
+	revertLock.Unlock()
+
+	log.Infof("[Chaos]: Blocking the %v traffic of the %v network security group", experimentsDetails.Direction, experimentsDetails.NetworkSecurityGroup)
+	if err := azureNetwork.CreateDenyRule(experimentsDetails.Timeout, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, experimentsDetails.NetworkSecurityGroup, rule); err != nil {
+		return stacktrace.Propagate(err, "unable to insert the deny rule")
+	}
+	common.SetTargets(experimentsDetails.NetworkSecurityGroup, "injected", "NSG", chaosDetails)
+
+	// Run probes during chaos
+	if len(resultDetails.ProbeDetails) != 0 {
+		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+			return stacktrace.Propagate(err, "failed to run probes")
+		}
+	}
+
+	// Wait for chaos duration
+	log.Infof("[Wait]: Waiting for the chaos duration of %vs", experimentsDetails.ChaosDuration)
+	common.WaitForDuration(experimentsDetails.ChaosDuration)
+	return nil
+}
+
+// revertChaos removes the deny rule from the network security group
+// ruleInserted is reset only after a successful delete, so the rule is removed once and an abort after a failed delete retries it
+func revertChaos(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails) error {
+	revertLock.Lock()
+	defer revertLock.Unlock()
+
+	if !ruleInserted {
+		return nil
+	}
+
+	log.Infof("[Revert]: Removing the %v deny rule from the %v network security group", experimentsDetails.RuleName, experimentsDetails.NetworkSecurityGroup)
+	if err := azureNetwork.DeleteSecurityRule(experimentsDetails.Timeout, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, experimentsDetails.NetworkSecurityGroup, experimentsDetails.RuleName); err != nil {
+		return err
+	}
+	ruleInserted = false
+	common.SetTargets(experimentsDetails.NetworkSecurityGroup, "reverted", "NSG", chaosDetails)
+	return nil
+}
+
+// getDenyRule returns the deny rule from the experiment details
+func getDenyRule(experimentsDetails *experimentTypes.ExperimentDetails) azureNetwork.DenyRule {
+	return azureNetwork.DenyRule{
+		Name:                     experimentsDetails.RuleName,
+		Priority:                 experimentsDetails.RulePriority,
+		Direction:                experimentsDetails.Direction,
+		Protocol:                 experimentsDetails.Protocol,
+		SourceAddressPrefix:      experimentsDetails.SourceAddressPrefix,
+		DestinationAddressPrefix: experimentsDetails.DestinationAddressPrefix,
+		DestinationPorts:         experimentsDetails.DestinationPorts,
+	}
+}
+
+// watching for the abort signal and revert the chaos
+func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails) {
+
+	<-abort
+
+	log.Info("[Abort]: Chaos Revert Started")
+	if err := revertChaos(experimentsDetails, chaosDetails); err != nil {
+		log.Errorf("Failed to remove the deny rule when an abort signal is received: %v", err)
+	}
+	log.Info("[Abort]: Chaos Revert Completed")
+	os.Exit(1)
+}
@@ -0,0 +1,173 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/azure/vmss-instance-delete/types"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	azureStatus "github.com/litmuschaos/litmus-go/pkg/cloud/azure/instance"
+	"github.com/litmuschaos/litmus-go/pkg/events"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/probe"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/litmuschaos/litmus-go/pkg/utils/random"
+	"github.com/palantir/stacktrace"
+	"go.opentelemetry.io/otel"
+)
+
+var inject chan os.Signal
+
+// PrepareVMSSInstanceDelete contains the preparation and injection steps for the experiment
+func PrepareVMSSInstanceDelete(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "PrepareAzureVMSSInstanceDeleteFault")
+	defer span.End()
+
+	// inject channel is used to transmit signal notifications
+	inject = make(chan os.Signal, 1)
+	// Catch and relay certain signal(s) to inject channel
+	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+
+	// Waiting for the ramp time before chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
+		common.WaitForDuration(experimentsDetails.RampTime)
+	}
+
+	if experimentsDetails.ScaleSetName == "" {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "no scale set name found to delete the instances"}
+	}
+
+	// the deletion lowers the capacity of the scale set, the deleted instances are replaced only by the autoscale up to its minimum instance count
+	// so the experiment fails before the injection, if the scale set has no autoscale setting or the deletion doesn't take it below the minimum
+	minimum, err := azureStatus.GetScaleSetAutoscaleMinimum(experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, experimentsDetails.ScaleSetName)
+	if err != nil {
+		return stacktrace.Propagate(err, "could not get the autoscale minimum instance count of the scale set")
+	}
+
+	if chaosDetails.DryRun {
+		instanceNameList, err := selectInstances(experimentsDetails, int(minimum))
+		if err != nil {
+			return stacktrace.Propagate(err, "could not select the target instances")
+		}
+		common.PlanTargets("VM", "", instanceNameList, chaosDetails)
+		return common.StopForDryRun(experimentsDetails, chaosDetails)
+	}
+
+	if err := injectChaos(ctx, experimentsDetails, int(minimum), clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+		return stacktrace.Propagate(err, "could not delete the scale set instances")
+	}
+
+	// Waiting for the ramp time after chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
+		common.WaitForDuration(experimentsDetails.RampTime)
+	}
+	return nil
+}
+
+// injectChaos deletes the instances of the scale set and waits for the autoscale to replace them, up to its minimum instance count
+func injectChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, minimum int, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectAzureVMSSInstanceDeleteFault")
+	defer span.End()
+
+	select {
+	case <-inject:
+		// stopping the chaos execution, if abort signal received
+		os.Exit(0)
+	default:
+		// ChaosStartTimeStamp contains the start timestamp, when the chaos injection begin
+		ChaosStartTimeStamp := time.Now()
+		duration := int(time.Since(ChaosStartTimeStamp).Seconds())
+
+		for iteration := 0; duration < experimentsDetails.ChaosDuration; iteration++ {
+
+			// the instances are selected in every iteration, as the deleted instances are replaced
+			instanceNameList, err := selectInstances(experimentsDetails, minimum)
+			if err != nil {
+				return stacktrace.Propagate(err, "could not select the target instances")
+			}
+			log.Infof("[Info]: Target instanceName list, %v", instanceNameList)
+
+			if experimentsDetails.EngineName != "" {
+				msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on Azure scale set"
+				types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
+				events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
+			}
+
+			deletedAt := time.Now()
+			for _, vmName := range instanceNameList {
+				log.Infof("[Chaos]: Deleting the Azure scale set instance: %v", vmName)
+				if err := azureStatus.AzureScaleSetInstanceDelete(experimentsDetails.Timeout, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
+					return stacktrace.Propagate(err, "unable to delete the Azure scale set instance")
+				}
+				common.SetTargets(vmName, "injected", "VM", chaosDetails)
+			}
+
+			// Run the probes during chaos
+			// the OnChaos probes execution will start in the first iteration and keep running for the entire chaos duration
+			if len(resultDetails.ProbeDetails) != 0 && iteration == 0 {
+				if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+					return stacktrace.Propagate(err, "failed to run probes")
+				}
+			}
+
+			// Wait for the scale set to replace the deleted instances
+			log.Infof("[Wait]: Waiting for the %v scale set to get back to the autoscale minimum of %v running instances", experimentsDetails.ScaleSetName, minimum)
+			if err := azureStatus.WaitForScaleSetHealed(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, experimentsDetails.ScaleSetName, minimum, instanceNameList); err != nil {
+				return stacktrace.Propagate(err, "scale set failed to replace the deleted instances")
+			}
+			common.RecordRecovery("VMSS", experimentsDetails.ScaleSetName, deletedAt, chaosDetails)
+
+			for _, vmName := range instanceNameList {
+				common.SetTargets(vmName, "reverted", "VM", chaosDetails)
+			}
+
+			// Wait for Chaos interval
+			log.Infof("[Wait]: Waiting for chaos interval of %vs", experimentsDetails.ChaosInterval)
+			common.WaitForDuration(experimentsDetails.ChaosInterval)
+
+			duration = int(time.Since(ChaosStartTimeStamp).Seconds())
+		}
+	}
+	return nil
+}
+
+// selectInstances selects the given number of random instances from the running instances of the scale set
+// the deletion of the selected instances should take the scale set below the autoscale minimum, so that the autoscale replaces them
+func selectInstances(experimentsDetails *experimentTypes.ExperimentDetails, minimum int) ([]string, error) {
+
+	instanceNameList, err := azureStatus.GetRunningScaleSetInstances(experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, experimentsDetails.ScaleSetName)
+	if err != nil {
+		return nil, err
+	}
+
+	if experimentsDetails.InstanceCount < 1 || experimentsDetails.InstanceCount > len(instanceNameList) {
+		return nil, cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeTargetSelection,
+			Reason:    fmt.Sprintf("invalid instance count %v, the scale set has %v running instances", experimentsDetails.InstanceCount, len(instanceNameList)),
+			Target:    fmt.Sprintf("{Azure Scale Set Name: %v, Resource Group: %v}", experimentsDetails.ScaleSetName, experimentsDetails.ResourceGroup),
+		}
+	}
+
+	if err := azureStatus.ValidateScaleSetDeletion(len(instanceNameList), experimentsDetails.InstanceCount, minimum); err != nil {
+		return nil, cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeTargetSelection,
+			Reason:    err.Error(),
+			Target:    fmt.Sprintf("{Azure Scale Set Name: %v, Resource Group: %v}", experimentsDetails.ScaleSetName, experimentsDetails.ResourceGroup),
+		}
+	}
+
+	var selected []string
+	for _, index := range random.Rand().Perm(len(instanceNameList))[:experimentsDetails.InstanceCount] {
+		selected = append(selected, instanceNameList[index])
+	}
+	return selected, nil
+}
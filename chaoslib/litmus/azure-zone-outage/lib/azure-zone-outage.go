@@ -0,0 +1,237 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/azure/zone-outage/types"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	azureStatus "github.com/litmuschaos/litmus-go/pkg/cloud/azure/instance"
+	"github.com/litmuschaos/litmus-go/pkg/events"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/probe"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+)
+
+// instance is the stopped instance, the scale set is enabled for the scale set instances
+type instance struct {
+	name     string
+	scaleSet string
+}
+
+var (
+	abort chan os.Signal
+	// stoppedInstances keeps the VMs and scale set instances powered off so far, with their scale set flag to pick the start API
+	revertLock       sync.Mutex
+	stoppedInstances []instance
+)
+
+// PrepareZoneOutage contains the preparation and injection steps for the experiment
+func PrepareZoneOutage(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "PrepareAzureZoneOutageFault")
+	defer span.End()
+
+	// abort channel is used to transmit signal notifications.
+	abort = make(chan os.Signal, 1)
+	// Catch and relay certain signal(s) to abort channel.
+	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+
+	// Waiting for the ramp time before chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
+		common.WaitForDuration(experimentsDetails.RampTime)
+	}
+
+	targets := getTargetInstances(experimentsDetails)
+	log.Infof("[Chaos]: Number of instances targeted in the %v zone: %v", experimentsDetails.Zone, len(targets))
+
+	if chaosDetails.DryRun {
+		var instanceNameList []string
+		for _, target := range targets {
+			instanceNameList = append(instanceNameList, target.name)
+		}
+		common.PlanTargets("VM", experimentsDetails.Zone, instanceNameList, chaosDetails)
+		return common.StopForDryRun(experimentsDetails, chaosDetails)
+	}
+
+	// watching for the abort signal and revert the chaos
+	go abortWatcher(experimentsDetails, chaosDetails)
+
+	// revertChaos runs even if the injection fails midway, as the VMs powered off before the failure still have to be started
+	err := injectChaos(ctx, experimentsDetails, targets, clients, resultDetails, eventsDetails, chaosDetails)
+	revertErr := revertChaos(experimentsDetails, chaosDetails)
+	if err != nil {
+		if revertErr != nil {
+			return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), stacktrace.RootCause(revertErr).Error())}
+		}
+		return stacktrace.Propagate(err, "could not inject the zone outage")
+	}
+	if revertErr != nil {
+		return stacktrace.Propagate(revertErr, "could not revert the zone outage")
+	}
+
+	// Waiting for the ramp time after chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
+		common.WaitForDuration(experimentsDetails.RampTime)
+	}
+	return nil
+}
+
+// injectChaos stops all the target instances of the zone for the chaos duration
+func injectChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, targets []instance, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectAzureZoneOutageFault")
+	defer span.End()
+
+	if experimentsDetails.EngineName != "" {
+		msg := "Injecting " + experimentsDetails.ExperimentName + " chaos in " + experimentsDetails.Zone + " zone"
+		types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
+		events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
+	}
+
+	for _, target := range targets {
+		log.Infof("[Chaos]: Stopping the Azure instance: %v", target.name)
+		if err := stopInstance(experimentsDetails, target); err != nil {
+			return stacktrace.Propagate(err, "unable to stop the Azure instance")
+		}
+		revertLock.Lock()
+		stoppedInstances = append(stoppedInstances, target)
+		revertLock.Unlock()
+		common.SetTargets(target.name, "injected", "VM", chaosDetails)
+	}
+
+	for _, target := range targets {
+		log.Infof("[Wait]: Waiting for Azure instance '%v' to get in the stopped state", target.name)
+		if err := azureStatus.WaitForAzureComputeDown(experimentsDetails.Timeout, experimentsDetails.Delay, target.scaleSet, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, target.name); err != nil {
+			return stacktrace.Propagate(err, "instance poweroff status check failed")
+		}
+	}
+
+	// Run probes during chaos
+	if len(resultDetails.ProbeDetails) != 0 {
+		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+			return stacktrace.Propagate(err, "failed to run probes")
+		}
+	}
+
+	// Wait for chaos duration
+	log.Infof("[Wait]: Waiting for the chaos duration of %vs", experimentsDetails.ChaosDuration)
+	common.WaitForDuration(experimentsDetails.ChaosDuration)
+	return nil
+}
+
+// revertChaos starts the stopped instances of the zone
+// each instance is dropped from stoppedInstances once its start is attempted, so an abort during the revert doesn't start it again
+func revertChaos(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails) error {
+	revertLock.Lock()
+	defer revertLock.Unlock()
+
+	var errs []string
+
+	for len(stoppedInstances) != 0 {
+		target := stoppedInstances[0]
+		if err := startInstance(experimentsDetails, target); err != nil {
+			errs = append(errs, stacktrace.RootCause(err).Error())
+		} else {
+			common.SetTargets(target.name, "reverted", "VM", chaosDetails)
+		}
+		stoppedInstances = stoppedInstances[1:]
+	}
+
+	if len(errs) != 0 {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{Zone: %v, Resource Group: %v}", experimentsDetails.Zone, experimentsDetails.ResourceGroup), Reason: strings.Join(errs, ", ")}
+	}
+	return nil
+}
+
+// stopInstance stops the virtual machine or the scale set instance
+func stopInstance(experimentsDetails *experimentTypes.ExperimentDetails, target instance) error {
+	if target.scaleSet == "enable" {
+		return azureStatus.AzureScaleSetInstanceStop(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, target.name)
+	}
+	return azureStatus.AzureInstanceStop(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, target.name)
+}
+
+// startInstance starts the stopped instance and waits for it to get in running state
+func startInstance(experimentsDetails *experimentTypes.ExperimentDetails, target instance) error {
+
+	log.Infof("[Revert]: Waiting for Azure instance '%v' to get in the stopped state", target.name)
+	if err := azureStatus.WaitForAzureComputeDown(experimentsDetails.Timeout, experimentsDetails.Delay, target.scaleSet, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, target.name); err != nil {
+		log.Errorf("Unable to wait till stop of %v instance: %v", target.name, err)
+	}
+
+	log.Infof("[Revert]: Starting back the Azure instance: %v", target.name)
+	if target.scaleSet == "enable" {
+		if err := azureStatus.AzureScaleSetInstanceStart(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, target.name); err != nil {
+			return err
+		}
+	} else {
+		if err := azureStatus.AzureInstanceStart(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, target.name); err != nil {
+			return err
+		}
+	}
+
+	log.Infof("[Wait]: Waiting for Azure instance '%v' to get in the running state", target.name)
+	return azureStatus.WaitForAzureComputeUp(experimentsDetails.Timeout, experimentsDetails.Delay, target.scaleSet, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, target.name)
+}
+
+// getTargetInstances returns the target virtual machines and scale set instances
+func getTargetInstances(experimentsDetails *experimentTypes.ExperimentDetails) []instance {
+	var targets []instance
+	for _, name := range experimentsDetails.TargetInstanceNameList {
+		targets = append(targets, instance{name: name, scaleSet: "disable"})
+	}
+	for _, name := range experimentsDetails.TargetScaleSetInstanceNameList {
+		targets = append(targets, instance{name: name, scaleSet: "enable"})
+	}
+	return targets
+}
+
+// SetTargetInstances selects the running virtual machines and scale set instances of the zone
+func SetTargetInstances(experimentsDetails *experimentTypes.ExperimentDetails) error {
+	if experimentsDetails.Zone == "" {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "no zone provided, please provide the target zone in ZONE env"}
+	}
+
+	instanceNames, scaleSetInstanceNames, err := azureStatus.GetRunningInstancesByZone(experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, experimentsDetails.Zone)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to get the instances of the zone")
+	}
+	if len(instanceNames) == 0 && len(scaleSetInstanceNames) == 0 {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "no running instance found in the zone", Target: fmt.Sprintf("{Zone: %v, Resource Group: %v}", experimentsDetails.Zone, experimentsDetails.ResourceGroup)}
+	}
+	experimentsDetails.TargetInstanceNameList = instanceNames
+	experimentsDetails.TargetScaleSetInstanceNameList = scaleSetInstanceNames
+
+	log.InfoWithValues("[Info]: Targeting the running instances of the zone", logrus.Fields{
+		"Zone":                       experimentsDetails.Zone,
+		"Resource Group":             experimentsDetails.ResourceGroup,
+		"Target Instances":           experimentsDetails.TargetInstanceNameList,
+		"Target Scale Set Instances": experimentsDetails.TargetScaleSetInstanceNameList,
+	})
+	return nil
+}
+
+// watching for the abort signal and revert the chaos
+func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails) {
+
+	<-abort
+
+	log.Info("[Abort]: Chaos Revert Started")
+	if err := revertChaos(experimentsDetails, chaosDetails); err != nil {
+		log.Errorf("Failed to revert the zone outage when an abort signal is received: %v", err)
+	}
+	log.Info("[Abort]: Chaos Revert Completed")
+	os.Exit(1)
+}
@@ -0,0 +1,290 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/composite-chaos/types"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/probe"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"gopkg.in/yaml.v2"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// sharedENVs are the envs of the experiment, which identify the run and can't be overridden by the faults
+var sharedENVs = []string{"EXPERIMENT_NAME", "CHAOSENGINE", "CHAOS_NAMESPACE", "CHAOS_UID", "POD_NAME", "CHAOS_SEED", "DRY_RUN"}
+
+// fault is the fault of the composite chaos along with its parsed details
+type fault struct {
+	id            string
+	spec          experimentTypes.FaultSpec
+	inject        faultInjector
+	chaosDetails  types.ChaosDetails
+	resultDetails types.ResultDetails
+	eventsDetails types.EventDetails
+}
+
+// ParseFaults parses and validates the faults of the composite chaos
+func ParseFaults(experimentsDetails *experimentTypes.ExperimentDetails) error {
+	if strings.TrimSpace(experimentsDetails.FaultsSpec) == "" {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: "no faults provided, provide the faults inside FAULTS env"}
+	}
+	if err := yaml.UnmarshalStrict([]byte(experimentsDetails.FaultsSpec), &experimentsDetails.Faults); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("invalid FAULTS env: %s", err.Error())}
+	}
+	if len(experimentsDetails.Faults) == 0 {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: "no faults provided, provide the faults inside FAULTS env"}
+	}
+
+	networkFaults := 0
+	for i, spec := range experimentsDetails.Faults {
+		id := getFaultID(i, spec)
+		if _, ok := supportedFaults[spec.Name]; !ok {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{fault: %s}", id), Reason: fmt.Sprintf("'%s' fault is not supported, supported faults are: %s", spec.Name, strings.Join(getSupportedFaults(), ","))}
+		}
+		if spec.Offset < 0 {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{fault: %s}", id), Reason: "offset should not be negative"}
+		}
+		for _, env := range sharedENVs {
+			if _, ok := spec.Env[env]; ok {
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{fault: %s}", id), Reason: fmt.Sprintf("%s env is shared by all the faults, it can't be overridden", env)}
+			}
+		}
+		// the network faults share the destination ips of the network chaoslib, these can't be injected concurrently
+		if strings.HasPrefix(spec.Name, "pod-network-") {
+			if networkFaults++; networkFaults > 1 {
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{fault: %s}", id), Reason: "only one pod-network fault is supported inside the composite chaos"}
+			}
+		}
+	}
+	return nil
+}
+
+// PrepareCompositeChaos contains the preparation & injection steps of all the faults
+// the faults are injected in parallel, each of them after its offset, while the probes are evaluated once for all the faults
+// the failure of any fault reverts all the faults, as the helper pods revert the chaos once these are deleted
+func PrepareCompositeChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectCompositeFault")
+	defer span.End()
+
+	faults, err := getFaults(experimentsDetails, chaosDetails)
+	if err != nil {
+		return stacktrace.Propagate(err, "could not parse the faults")
+	}
+
+	// the chaos lasts till the last fault is completed
+	experimentsDetails.ChaosDuration = 0
+	for _, f := range faults {
+		experimentsDetails.ChaosDuration = max(experimentsDetails.ChaosDuration, f.spec.Offset+f.chaosDetails.ChaosDuration)
+	}
+	chaosDetails.ChaosDuration = experimentsDetails.ChaosDuration
+
+	if chaosDetails.DryRun {
+		return planFaults(ctx, faults, clients, chaosDetails)
+	}
+
+	// run the probes during chaos
+	if len(resultDetails.ProbeDetails) != 0 {
+		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+			return err
+		}
+	}
+
+	failedFault, err := injectFaults(ctx, experimentsDetails, faults, clients, chaosDetails)
+	for _, f := range faults {
+		chaosDetails.Targets = append(chaosDetails.Targets, f.chaosDetails.Targets...)
+		chaosDetails.SkippedTargets = append(chaosDetails.SkippedTargets, f.chaosDetails.SkippedTargets...)
+	}
+	if err != nil {
+		return stacktrace.Propagate(err, "could not inject %s fault", failedFault)
+	}
+	return nil
+}
+
+// injectFaults injects all the faults and waits till these are completed
+// it returns the fault which failed first, along with its error
+func injectFaults(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, faults []*fault, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (string, error) {
+	var (
+		wg          sync.WaitGroup
+		once        sync.Once
+		failedFault string
+		failure     error
+	)
+	stop, done := make(chan struct{}), make(chan struct{})
+
+	// fail stops the pending faults and reverts the injected faults
+	fail := func(f *fault, err error) {
+		once.Do(func() {
+			log.Errorf("[Revert]: %s fault failed, reverting all the faults, err: %v", f.id, err)
+			failedFault, failure = f.id, err
+			close(stop)
+		})
+	}
+
+	go func() {
+		select {
+		case <-stop:
+		case <-done:
+			return
+		}
+		// the helper pods, which are created by the faults being injected, are deleted till all the faults are returned
+		for {
+			RevertFaults(experimentsDetails, clients, chaosDetails)
+			select {
+			case <-done:
+				return
+			case <-time.After(time.Duration(chaosDetails.Delay) * time.Second):
+			}
+		}
+	}()
+
+	for _, f := range faults {
+		wg.Add(1)
+		go func(f *fault) {
+			defer wg.Done()
+			if f.spec.Offset != 0 {
+				log.Infof("[Wait]: Waiting for the %vs offset before injecting %s fault", f.spec.Offset, f.id)
+				select {
+				case <-stop:
+					log.Infof("[Skip]: Skipping %s fault, as the composite chaos is reverted", f.id)
+					return
+				case <-time.After(time.Duration(f.spec.Offset) * time.Second):
+				}
+			}
+			log.Infof("[Chaos]: Injecting %s fault", f.id)
+			f.chaosDetails.Phase = types.ChaosInjectPhase
+			if err := f.inject(ctx, clients, &f.resultDetails, &f.eventsDetails, &f.chaosDetails); err != nil {
+				fail(f, err)
+				return
+			}
+			log.Infof("[Chaos]: %s fault is completed", f.id)
+		}(f)
+	}
+	wg.Wait()
+	close(done)
+
+	return failedFault, failure
+}
+
+// planFaults runs the dry run of all the faults and records their plans inside the dry run plan of the composite chaos
+func planFaults(ctx context.Context, faults []*fault, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	plan := &chaosDetails.DryRunPlan
+	plan.FaultParameters = map[string]string{}
+	for _, f := range faults {
+		err := f.inject(ctx, clients, &f.resultDetails, &f.eventsDetails, &f.chaosDetails)
+		if cerrors.GetErrorType(err) != cerrors.ErrorTypeDryRun {
+			if err == nil {
+				err = cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: "fault doesn't support the dry run"}
+			}
+			return stacktrace.Propagate(err, "could not plan %s fault", f.id)
+		}
+		plan.Targets = append(plan.Targets, f.chaosDetails.DryRunPlan.Targets...)
+		plan.HelperPods = append(plan.HelperPods, f.chaosDetails.DryRunPlan.HelperPods...)
+		plan.FaultParameters[f.id+".Offset"] = strconv.Itoa(f.spec.Offset)
+		for key, value := range f.chaosDetails.DryRunPlan.FaultParameters {
+			plan.FaultParameters[f.id+"."+key] = value
+		}
+	}
+	return common.StopForDryRun(nil, chaosDetails)
+}
+
+// RevertFaults reverts all the faults of the composite chaos by deleting their helper pods, the helper pods revert the chaos once these are terminated
+// the helper pods are derived from the chaosUID and the app label of the helper pods, as the helper pods share the rest of the labels with the experiment pod
+func RevertFaults(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) {
+	listOptions := v1.ListOptions{}
+	if chaosDetails.ChaosUID != "" {
+		listOptions.LabelSelector = "chaosUID=" + string(chaosDetails.ChaosUID)
+	}
+	podList, err := clients.KubeClient.CoreV1().Pods(chaosDetails.ChaosNamespace).List(context.Background(), listOptions)
+	if err != nil {
+		log.Errorf("[Revert]: Unable to list the helper pods, err: %v", err)
+		return
+	}
+	for _, pod := range podList.Items {
+		if !strings.HasPrefix(pod.Labels["app"], experimentsDetails.ExperimentName+"-helper-") || pod.DeletionTimestamp != nil {
+			continue
+		}
+		log.Infof("[Revert]: Deleting %s helper pod to revert the chaos", pod.Name)
+		if err := clients.KubeClient.CoreV1().Pods(pod.Namespace).Delete(context.Background(), pod.Name, v1.DeleteOptions{}); err != nil {
+			log.Errorf("[Revert]: Unable to delete %s helper pod, err: %v", pod.Name, err)
+		}
+	}
+}
+
+// getFaults parses the details of all the faults from their envs
+// the envs of each fault are set only while its details are parsed, the rest of the envs are shared with the experiment
+func getFaults(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails) ([]*fault, error) {
+	var faults []*fault
+	for i, spec := range experimentsDetails.Faults {
+		f := &fault{id: getFaultID(i, spec), spec: spec}
+
+		env := map[string]string{
+			// all the faults reuse the seed of the experiment, so that the run can be replayed
+			"CHAOS_SEED": strconv.FormatInt(chaosDetails.Seed, 10),
+		}
+		for key, value := range spec.Env {
+			env[key] = value
+		}
+
+		var err error
+		withENV(env, func() {
+			if f.inject, err = supportedFaults[spec.Name](); err != nil {
+				return
+			}
+			types.InitialiseChaosVariables(&f.chaosDetails)
+		})
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "could not parse %s fault", f.id)
+		}
+		// the probes are evaluated by the composite chaos, these are not evaluated by the faults
+		types.SetResultAttributes(&f.resultDetails, f.chaosDetails)
+
+		log.InfoWithValues(fmt.Sprintf("[Info]: The details of %s fault are as follows", f.id), logrus.Fields{
+			"Offset":         f.spec.Offset,
+			"Chaos Duration": f.chaosDetails.ChaosDuration,
+			"Targets":        common.GetAppDetailsForLogging(f.chaosDetails.AppDetail),
+		})
+		faults = append(faults, f)
+	}
+	return faults, nil
+}
+
+// withENV sets the given envs while running the given function, the previous envs are restored afterwards
+func withENV(env map[string]string, fn func()) {
+	previous := map[string]*string{}
+	for key, value := range env {
+		if old, ok := os.LookupEnv(key); ok {
+			previous[key] = &old
+		} else {
+			previous[key] = nil
+		}
+		os.Setenv(key, value)
+	}
+	defer func() {
+		for key, value := range previous {
+			if value == nil {
+				os.Unsetenv(key)
+				continue
+			}
+			os.Setenv(key, *value)
+		}
+	}()
+	fn()
+}
+
+func getFaultID(index int, spec experimentTypes.FaultSpec) string {
+	return fmt.Sprintf("%s[%d]", spec.Name, index)
+}
@@ -0,0 +1,125 @@
+package lib
+
+import (
+	"os"
+	"testing"
+
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/composite-chaos/types"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFaults(t *testing.T) {
+	tests := []struct {
+		name    string
+		faults  string
+		wantErr string
+	}{
+		{
+			name:   "valid faults",
+			faults: "[{name: pod-network-latency, env: {NETWORK_LATENCY: '2000'}}, {name: pod-cpu-hog, offset: 30}]",
+		},
+		{
+			name:    "no faults",
+			faults:  "  ",
+			wantErr: "no faults provided",
+		},
+		{
+			name:    "empty list of faults",
+			faults:  "[]",
+			wantErr: "no faults provided",
+		},
+		{
+			name:    "invalid yaml",
+			faults:  "[{name: pod-cpu-hog",
+			wantErr: "invalid FAULTS env",
+		},
+		{
+			name:    "unknown field",
+			faults:  "[{name: pod-cpu-hog, envs: {CPU_CORES: '1'}}]",
+			wantErr: "invalid FAULTS env",
+		},
+		{
+			name:    "invalid offset",
+			faults:  "[{name: pod-cpu-hog, offset: ten}]",
+			wantErr: "invalid FAULTS env",
+		},
+		{
+			name:    "unsupported fault",
+			faults:  "[{name: pod-delete}]",
+			wantErr: "'pod-delete' fault is not supported",
+		},
+		{
+			name:    "missing fault name",
+			faults:  "[{offset: 10}]",
+			wantErr: "'' fault is not supported",
+		},
+		{
+			name:    "negative offset",
+			faults:  "[{name: pod-cpu-hog, offset: -5}]",
+			wantErr: "offset should not be negative",
+		},
+		{
+			name:    "shared env overridden",
+			faults:  "[{name: pod-cpu-hog, env: {CHAOS_UID: abc}}]",
+			wantErr: "CHAOS_UID env is shared by all the faults",
+		},
+		{
+			name:    "multiple network faults",
+			faults:  "[{name: pod-network-latency}, {name: pod-network-loss}]",
+			wantErr: "only one pod-network fault is supported",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ParseFaults(&experimentTypes.ExperimentDetails{FaultsSpec: tt.faults})
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestWithENV(t *testing.T) {
+	t.Setenv("COMPOSITE_TEST_SET", "experiment")
+	require.NoError(t, os.Unsetenv("COMPOSITE_TEST_UNSET"))
+
+	withENV(map[string]string{"COMPOSITE_TEST_SET": "fault", "COMPOSITE_TEST_UNSET": "fault"}, func() {
+		assert.Equal(t, "fault", os.Getenv("COMPOSITE_TEST_SET"))
+		assert.Equal(t, "fault", os.Getenv("COMPOSITE_TEST_UNSET"))
+	})
+
+	assert.Equal(t, "experiment", os.Getenv("COMPOSITE_TEST_SET"))
+	_, ok := os.LookupEnv("COMPOSITE_TEST_UNSET")
+	assert.False(t, ok, "the env which wasn't set before should be unset")
+}
+
+func TestGetFaultsENVPrecedence(t *testing.T) {
+	t.Setenv("TOTAL_CHAOS_DURATION", "60")
+	t.Setenv("CHAOS_NAMESPACE", "litmus")
+
+	experimentsDetails := &experimentTypes.ExperimentDetails{
+		FaultsSpec: "[{name: pod-cpu-hog, env: {TOTAL_CHAOS_DURATION: '20'}}, {name: pod-memory-hog, offset: 10}]",
+	}
+	require.NoError(t, ParseFaults(experimentsDetails))
+
+	faults, err := getFaults(experimentsDetails, &types.ChaosDetails{Seed: 42})
+	require.NoError(t, err)
+	require.Len(t, faults, 2)
+
+	assert.Equal(t, "pod-cpu-hog[0]", faults[0].id)
+	assert.Equal(t, 20, faults[0].chaosDetails.ChaosDuration, "the env of the fault should override the env of the experiment")
+	assert.Equal(t, "pod-memory-hog[1]", faults[1].id)
+	assert.Equal(t, 60, faults[1].chaosDetails.ChaosDuration, "the env of the experiment should be used if the fault doesn't provide it")
+	for _, f := range faults {
+		assert.Equal(t, "litmus", f.chaosDetails.ChaosNamespace)
+		assert.Equal(t, int64(42), f.chaosDetails.Seed, "all the faults should reuse the seed of the experiment")
+	}
+
+	assert.Equal(t, "60", os.Getenv("TOTAL_CHAOS_DURATION"), "the envs of the experiment should be restored")
+	_, ok := os.LookupEnv("CHAOS_SEED")
+	assert.False(t, ok, "the seed env should be unset after the faults are parsed")
+}
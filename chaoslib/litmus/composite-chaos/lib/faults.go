@@ -0,0 +1,146 @@
+package lib
+
+import (
+	"context"
+	"sort"
+
+	containerKillLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/container-kill/lib"
+	diskFillLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/disk-fill/lib"
+	httpModifyHeaderLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/http-chaos/lib/header"
+	httpLatencyLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/http-chaos/lib/latency"
+	httpModifyBodyLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/http-chaos/lib/modify-body"
+	httpResetPeerLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/http-chaos/lib/reset"
+	httpStatusCodeLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/http-chaos/lib/statuscode"
+	networkCorruptionLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/network-chaos/lib/corruption"
+	networkDuplicationLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/network-chaos/lib/duplication"
+	networkLatencyLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/network-chaos/lib/latency"
+	networkLossLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/network-chaos/lib/loss"
+	networkRateLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/network-chaos/lib/rate"
+	nodeCPUHogLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/node-cpu-hog/lib"
+	nodeIOStressLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/node-io-stress/lib"
+	nodeMemoryHogLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/node-memory-hog/lib"
+	podDNSLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/pod-dns-chaos/lib"
+	stressLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/stress-chaos/lib"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	containerKillEnv "github.com/litmuschaos/litmus-go/pkg/generic/container-kill/environment"
+	containerKillTypes "github.com/litmuschaos/litmus-go/pkg/generic/container-kill/types"
+	diskFillEnv "github.com/litmuschaos/litmus-go/pkg/generic/disk-fill/environment"
+	diskFillTypes "github.com/litmuschaos/litmus-go/pkg/generic/disk-fill/types"
+	httpEnv "github.com/litmuschaos/litmus-go/pkg/generic/http-chaos/environment"
+	httpTypes "github.com/litmuschaos/litmus-go/pkg/generic/http-chaos/types"
+	networkEnv "github.com/litmuschaos/litmus-go/pkg/generic/network-chaos/environment"
+	networkTypes "github.com/litmuschaos/litmus-go/pkg/generic/network-chaos/types"
+	nodeCPUHogEnv "github.com/litmuschaos/litmus-go/pkg/generic/node-cpu-hog/environment"
+	nodeCPUHogTypes "github.com/litmuschaos/litmus-go/pkg/generic/node-cpu-hog/types"
+	nodeIOStressEnv "github.com/litmuschaos/litmus-go/pkg/generic/node-io-stress/environment"
+	nodeIOStressTypes "github.com/litmuschaos/litmus-go/pkg/generic/node-io-stress/types"
+	nodeMemoryHogEnv "github.com/litmuschaos/litmus-go/pkg/generic/node-memory-hog/environment"
+	nodeMemoryHogTypes "github.com/litmuschaos/litmus-go/pkg/generic/node-memory-hog/types"
+	podDNSEnv "github.com/litmuschaos/litmus-go/pkg/generic/pod-dns-chaos/environment"
+	podDNSTypes "github.com/litmuschaos/litmus-go/pkg/generic/pod-dns-chaos/types"
+	stressEnv "github.com/litmuschaos/litmus-go/pkg/generic/stress-chaos/environment"
+	stressTypes "github.com/litmuschaos/litmus-go/pkg/generic/stress-chaos/types"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+)
+
+// faultInjector injects the fault with its parsed details
+type faultInjector func(ctx context.Context, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error
+
+// faultParser parses the details of the fault from the envs and returns its injector
+type faultParser func() (faultInjector, error)
+
+// supportedFaults contains the faults which can be composed, these are the faults which revert the chaos inside their helper pods
+// so that all the faults can be reverted together by deleting their helper pods
+var supportedFaults = map[string]faultParser{
+	"pod-network-latency":     networkFault("pod-network-latency", networkLatencyLIB.PodNetworkLatencyChaos),
+	"pod-network-loss":        networkFault("pod-network-loss", networkLossLIB.PodNetworkLossChaos),
+	"pod-network-corruption":  networkFault("pod-network-corruption", networkCorruptionLIB.PodNetworkCorruptionChaos),
+	"pod-network-duplication": networkFault("pod-network-duplication", networkDuplicationLIB.PodNetworkDuplicationChaos),
+	"pod-network-rate-limit":  networkFault("pod-network-rate-limit", networkRateLIB.PodNetworkRateChaos),
+	"pod-cpu-hog":             stressFault("pod-cpu-hog"),
+	"pod-memory-hog":          stressFault("pod-memory-hog"),
+	"pod-io-stress":           stressFault("pod-io-stress"),
+	"pod-http-latency":        httpFault("pod-http-latency", httpLatencyLIB.PodHttpLatencyChaos),
+	"pod-http-modify-body":    httpFault("pod-http-modify-body", httpModifyBodyLIB.PodHttpModifyBodyChaos),
+	"pod-http-modify-header":  httpFault("pod-http-modify-header", httpModifyHeaderLIB.PodHttpModifyHeaderChaos),
+	"pod-http-reset-peer":     httpFault("pod-http-reset-peer", httpResetPeerLIB.PodHttpResetPeerChaos),
+	"pod-http-status-code": newFault(func(experimentsDetails *httpTypes.ExperimentDetails) error {
+		var err error
+		httpEnv.GetENV(experimentsDetails, "pod-http-status-code")
+		experimentsDetails.StatusCode, err = httpStatusCodeLIB.GetStatusCode(experimentsDetails.StatusCode)
+		return err
+	}, httpStatusCodeLIB.PodHttpStatusCodeChaos),
+	"pod-dns-error": podDNSFault(podDNSEnv.Error),
+	"pod-dns-spoof": podDNSFault(podDNSEnv.Spoof),
+	"container-kill": newFault(func(experimentsDetails *containerKillTypes.ExperimentDetails) error {
+		containerKillEnv.GetENV(experimentsDetails)
+		return nil
+	}, containerKillLIB.PrepareContainerKill),
+	"disk-fill": newFault(func(experimentsDetails *diskFillTypes.ExperimentDetails) error {
+		diskFillEnv.GetENV(experimentsDetails)
+		return nil
+	}, diskFillLIB.PrepareDiskFill),
+	"node-cpu-hog": newFault(func(experimentsDetails *nodeCPUHogTypes.ExperimentDetails) error {
+		nodeCPUHogEnv.GetENV(experimentsDetails)
+		return nil
+	}, nodeCPUHogLIB.PrepareNodeCPUHog),
+	"node-memory-hog": newFault(func(experimentsDetails *nodeMemoryHogTypes.ExperimentDetails) error {
+		nodeMemoryHogEnv.GetENV(experimentsDetails)
+		return nil
+	}, nodeMemoryHogLIB.PrepareNodeMemoryHog),
+	"node-io-stress": newFault(func(experimentsDetails *nodeIOStressTypes.ExperimentDetails) error {
+		nodeIOStressEnv.GetENV(experimentsDetails)
+		return nil
+	}, nodeIOStressLIB.PrepareNodeIOStress),
+}
+
+// newFault returns the parser of the fault, which parses the experiment details with getENV and injects the fault with the chaoslib
+func newFault[T any](getENV func(*T) error, chaoslib func(context.Context, *T, clients.ClientSets, *types.ResultDetails, *types.EventDetails, *types.ChaosDetails) error) faultParser {
+	return func() (faultInjector, error) {
+		experimentsDetails := new(T)
+		if err := getENV(experimentsDetails); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+			return chaoslib(ctx, experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails)
+		}, nil
+	}
+}
+
+func networkFault(name string, chaoslib func(context.Context, *networkTypes.ExperimentDetails, clients.ClientSets, *types.ResultDetails, *types.EventDetails, *types.ChaosDetails) error) faultParser {
+	return newFault(func(experimentsDetails *networkTypes.ExperimentDetails) error {
+		networkEnv.GetENV(experimentsDetails, name)
+		return nil
+	}, chaoslib)
+}
+
+func stressFault(name string) faultParser {
+	return newFault(func(experimentsDetails *stressTypes.ExperimentDetails) error {
+		stressEnv.GetENV(experimentsDetails, name)
+		return nil
+	}, stressLIB.PrepareAndInjectStressChaos)
+}
+
+func httpFault(name string, chaoslib func(context.Context, *httpTypes.ExperimentDetails, clients.ClientSets, *types.ResultDetails, *types.EventDetails, *types.ChaosDetails) error) faultParser {
+	return newFault(func(experimentsDetails *httpTypes.ExperimentDetails) error {
+		httpEnv.GetENV(experimentsDetails, name)
+		return nil
+	}, chaoslib)
+}
+
+func podDNSFault(chaosType podDNSEnv.DNSChaosType) faultParser {
+	return newFault(func(experimentsDetails *podDNSTypes.ExperimentDetails) error {
+		podDNSEnv.GetENV(experimentsDetails, chaosType)
+		return nil
+	}, podDNSLIB.PrepareAndInjectChaos)
+}
+
+// getSupportedFaults returns the names of the supported faults
+func getSupportedFaults() []string {
+	var names []string
+	for name := range supportedFaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -2,6 +2,7 @@ package helper
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"os"
 	"os/exec"
@@ -15,6 +16,7 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/telemetry"
 	"github.com/palantir/stacktrace"
 	"go.opentelemetry.io/otel"
+	"golang.org/x/sys/unix"
 
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/events"
@@ -82,10 +84,10 @@ func diskFill(experimentsDetails *experimentTypes.ExperimentDetails, clients cli
 		return stacktrace.Propagate(err, "could not parse targets")
 	}
 
-	var targets []targetDetails
+	var targets []*targetDetails
 
 	for _, t := range targetList.Target {
-		td := targetDetails{
+		td := &targetDetails{
 			Name:            t.Name,
 			Namespace:       t.Namespace,
 			TargetContainer: t.TargetContainer,
@@ -104,14 +106,20 @@ func diskFill(experimentsDetails *experimentTypes.ExperimentDetails, clients cli
 			return err
 		}
 
+		if err = setFillPath(td, experimentsDetails, clients); err != nil {
+			return stacktrace.Propagate(err, "could not get fill path")
+		}
+
 		td.SizeToFill, err = getDiskSizeToFill(td, experimentsDetails, clients)
 		if err != nil {
+			td.close()
 			return stacktrace.Propagate(err, "could not get disk size to fill")
 		}
 
 		log.InfoWithValues("[Info]: Details of application under chaos injection", logrus.Fields{
 			"PodName":         td.Name,
 			"Namespace":       td.Namespace,
+			"FillPath":        td.FillPath,
 			"SizeToFill(KB)":  td.SizeToFill,
 			"TargetContainer": td.TargetContainer,
 		})
@@ -136,26 +144,43 @@ func diskFill(experimentsDetails *experimentTypes.ExperimentDetails, clients cli
 	default:
 	}
 
-	for _, t := range targets {
-		if t.SizeToFill > 0 {
-			if err := fillDisk(t, experimentsDetails.DataBlockSize); err != nil {
-				return stacktrace.Propagate(err, "could not fill ephemeral storage")
+	// in hold mode the disk is filled at once, in gradual mode the fill grows in steps over the chaos duration
+	steps, interval := getFillSteps(experimentsDetails)
+
+	log.Infof("[Chaos]: Filling the disk in %v step(s) over %vs", steps, experimentsDetails.ChaosDuration)
+
+	for step := 1; step <= steps; step++ {
+		for _, t := range targets {
+			if t.SizeToFill <= 0 {
+				if step == 1 {
+					log.Warnf("No required free space found for target: {name: %s, namespace: %v}", t.Name, t.Namespace)
+				}
+				continue
+			}
+			if err := fillDisk(t, t.SizeToFill*step/steps, experimentsDetails.DataBlockSize); err != nil {
+				if revertErr := revertTargets(targets, clients); revertErr != nil {
+					return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), revertErr.Error())}
+				}
+				return stacktrace.Propagate(err, "could not fill disk")
+			}
+			if step != 1 {
+				continue
 			}
 			log.Infof("successfully injected chaos on target: {name: %s, namespace: %v, container: %v}", t.Name, t.Namespace, t.TargetContainer)
 			if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "injected", "pod", t.Name); err != nil {
-				if revertErr := revertDiskFill(t, clients); revertErr != nil {
-					return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), stacktrace.RootCause(revertErr).Error())}
+				if revertErr := revertTargets(targets, clients); revertErr != nil {
+					return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), revertErr.Error())}
 				}
 				return stacktrace.Propagate(err, "could not annotate chaosresult")
 			}
-		} else {
-			log.Warn("No required free space found!")
 		}
+		log.Infof("[Chaos]: Waiting for %vs", interval)
+		common.WaitForDuration(interval)
 	}
 
-	log.Infof("[Chaos]: Waiting for %vs", experimentsDetails.ChaosDuration)
-
-	common.WaitForDuration(experimentsDetails.ChaosDuration)
+	if remaining := experimentsDetails.ChaosDuration - steps*interval; remaining > 0 {
+		common.WaitForDuration(remaining)
+	}
 
 	log.Info("[Chaos]: Stopping the experiment")
 
@@ -179,24 +204,161 @@ func diskFill(experimentsDetails *experimentTypes.ExperimentDetails, clients cli
 	return nil
 }
 
-// fillDisk fill the ephemeral disk by creating files
-func fillDisk(t targetDetails, bs int) error {
+// fillDisk grows the fill file up to the given size (in KB)
+// it allocates the blocks with fallocate and falls back to writing the data if fallocate is not supported by the filesystem
+func fillDisk(t *targetDetails, sizeToFill, bs int) error {
+	if t.File == nil {
+		fd, err := unix.Openat(int(t.Dir.Fd()), t.FileName, unix.O_CREAT|unix.O_WRONLY|unix.O_CLOEXEC, 0644)
+		if err != nil {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Source: t.Source, Target: fmt.Sprintf("{podName: %s, namespace: %s, container: %s}", t.Name, t.Namespace, t.TargetContainer), Reason: fmt.Sprintf("failed to create fill file: %s", err.Error())}
+		}
+		t.File = os.NewFile(uintptr(fd), t.FileName)
+	}
 
-	// Creating files to fill the required ephemeral storage size of block size of 4K
-	log.Infof("[Fill]: Filling ephemeral storage, size: %vKB", t.SizeToFill)
-	dd := fmt.Sprintf("sudo dd if=/dev/urandom of=/proc/%v/root/home/diskfill bs=%vK count=%v", t.TargetPID, bs, strconv.Itoa(t.SizeToFill/bs))
-	log.Infof("dd: {%v}", dd)
-	cmd := exec.Command("/bin/bash", "-c", dd)
-	out, err := cmd.CombinedOutput()
+	size := int64(sizeToFill) * 1024
+	if size <= 0 {
+		return nil
+	}
+	log.Infof("[Fill]: Filling %v/%v, size: %vKB", t.FillPath, t.FileName, sizeToFill)
+
+	err := unix.Fallocate(int(t.File.Fd()), 0, 0, size)
+	if err == unix.EOPNOTSUPP || err == unix.ENOSYS {
+		err = writeFillData(t.File, size, bs)
+	}
 	if err != nil {
-		log.Error(err.Error())
-		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Source: t.Source, Target: fmt.Sprintf("{podName: %s, namespace: %s, container: %s}", t.Name, t.Namespace, t.TargetContainer), Reason: string(out)}
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Source: t.Source, Target: fmt.Sprintf("{podName: %s, namespace: %s, container: %s}", t.Name, t.Namespace, t.TargetContainer), Reason: fmt.Sprintf("failed to fill disk: %s", err.Error())}
 	}
 	return nil
 }
 
+// writeFillData appends the data blocks (of size bs KB) to the file until it reaches the given size
+func writeFillData(file *os.File, size int64, bs int) error {
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if bs <= 0 {
+		bs = 256
+	}
+	block := make([]byte, bs*1024)
+	if _, err := rand.Read(block); err != nil {
+		return err
+	}
+
+	for offset := info.Size(); offset < size; offset += int64(len(block)) {
+		n := int64(len(block))
+		if size-offset < n {
+			n = size - offset
+		}
+		if _, err := file.WriteAt(block[:n], offset); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getFillSteps returns the number of fill steps and the interval between the steps
+func getFillSteps(experimentsDetails *experimentTypes.ExperimentDetails) (int, int) {
+	if strings.ToLower(experimentsDetails.FillMode) != "gradual" || experimentsDetails.FillStepInterval <= 0 {
+		return 1, experimentsDetails.ChaosDuration
+	}
+	steps := experimentsDetails.ChaosDuration / experimentsDetails.FillStepInterval
+	if steps < 1 {
+		return 1, experimentsDetails.ChaosDuration
+	}
+	return steps, experimentsDetails.FillStepInterval
+}
+
+// setFillPath derive the directory to be filled inside the target container
+// it can be the mount path of the given pvc, the given volume mount path or the ephemeral storage (/home) of the container
+func setFillPath(t *targetDetails, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets) error {
+	switch {
+	case experimentsDetails.PVCName != "":
+		mountPath, err := getPVCMountPath(t, experimentsDetails.PVCName, clients)
+		if err != nil {
+			return err
+		}
+		t.FillPath, t.FileName, t.IsVolume = mountPath, "litmus-disk-fill-"+t.Name, true
+	case experimentsDetails.VolumeMountPath != "":
+		t.FillPath, t.FileName, t.IsVolume = experimentsDetails.VolumeMountPath, "litmus-disk-fill-"+t.Name, true
+	default:
+		t.FillPath, t.FileName = "/home", "diskfill"
+	}
+
+	// keep a handle of the fill directory, so that the fill file can be removed even if the target container is restarted
+	dir, err := os.Open(fmt.Sprintf("/proc/%v/root%v", t.TargetPID, t.FillPath))
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeHelper, Source: t.Source, Target: fmt.Sprintf("{podName: %s, namespace: %s, container: %s}", t.Name, t.Namespace, t.TargetContainer), Reason: fmt.Sprintf("failed to open fill path: %s", err.Error())}
+	}
+	t.Dir = dir
+	return nil
+}
+
+// getPVCMountPath derive the mount path of the given pvc inside the target container
+func getPVCMountPath(t *targetDetails, pvcName string, clients clients.ClientSets) (string, error) {
+	pod, err := clients.GetPod(t.Namespace, t.Name, 180, 2)
+	if err != nil {
+		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeHelper, Source: t.Source, Target: fmt.Sprintf("{podName: %s, namespace: %s}", t.Name, t.Namespace), Reason: err.Error()}
+	}
+
+	var volumeName string
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == pvcName {
+			volumeName = volume.Name
+			break
+		}
+	}
+	if volumeName == "" {
+		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeHelper, Source: t.Source, Target: fmt.Sprintf("{podName: %s, namespace: %s}", t.Name, t.Namespace), Reason: fmt.Sprintf("pvc %s is not used by the pod", pvcName)}
+	}
+
+	for _, container := range pod.Spec.Containers {
+		if container.Name != t.TargetContainer {
+			continue
+		}
+		for _, mount := range container.VolumeMounts {
+			if mount.Name == volumeName {
+				return mount.MountPath, nil
+			}
+		}
+	}
+	return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeHelper, Source: t.Source, Target: fmt.Sprintf("{podName: %s, namespace: %s, container: %s}", t.Name, t.Namespace, t.TargetContainer), Reason: fmt.Sprintf("pvc %s is not mounted inside the target container", pvcName)}
+}
+
+// getVolumeSizeToFill derive the size (in KB) need to be filled from the capacity of the filesystem
+func getVolumeSizeToFill(t *targetDetails, experimentsDetails *experimentTypes.ExperimentDetails) (int, error) {
+	var stat unix.Statfs_t
+	if err := unix.Fstatfs(int(t.Dir.Fd()), &stat); err != nil {
+		return 0, cerrors.Error{ErrorCode: cerrors.ErrorTypeHelper, Source: t.Source, Target: fmt.Sprintf("{podName: %s, namespace: %s, container: %s}", t.Name, t.Namespace, t.TargetContainer), Reason: fmt.Sprintf("failed to get filesystem stats: %s", err.Error())}
+	}
+
+	capacity := int(stat.Blocks * uint64(stat.Bsize) / 1024)
+	used := int((stat.Blocks - stat.Bfree) * uint64(stat.Bsize) / 1024)
+	available := int(stat.Bavail * uint64(stat.Bsize) / 1024)
+
+	log.InfoWithValues("[Info]: Filesystem details of the fill path", logrus.Fields{
+		"Capacity(KB)":  capacity,
+		"Used(KB)":      used,
+		"Available(KB)": available,
+	})
+
+	var requirementToBeFill int
+	if experimentsDetails.FillPercentage != "" {
+		fillPercentage, _ := strconv.Atoi(experimentsDetails.FillPercentage)
+		requirementToBeFill = (capacity*fillPercentage)/100 - used
+	} else {
+		ephemeralStorageMebibytes, _ := strconv.Atoi(experimentsDetails.EphemeralStorageMebibytes)
+		requirementToBeFill = ephemeralStorageMebibytes * 1024
+	}
+
+	if requirementToBeFill > available {
+		requirementToBeFill = available
+	}
+	return requirementToBeFill, nil
+}
+
 // getEphemeralStorageAttributes derive the ephemeral storage attributes from the target pod
-func getEphemeralStorageAttributes(t targetDetails, clients clients.ClientSets) (int64, error) {
+func getEphemeralStorageAttributes(t *targetDetails, clients clients.ClientSets) (int64, error) {
 
 	pod, err := clients.GetPod(t.Namespace, t.Name, 180, 2)
 	if err != nil {
@@ -249,29 +411,42 @@ func getSizeToBeFilled(experimentsDetails *experimentTypes.ExperimentDetails, us
 	return needToBeFilled
 }
 
-// revertDiskFill will delete the target pod if target pod is evicted
-// if target pod is still running then it will delete the files, which was created during chaos execution
-func revertDiskFill(t targetDetails, clients clients.ClientSets) error {
+// revertTargets removes the fill files of all the targets, when the injection fails
+// the earlier targets and the earlier steps of the failed target are already filled by then
+func revertTargets(targets []*targetDetails, clients clients.ClientSets) error {
+	var errList []string
+	for _, t := range targets {
+		if err := revertDiskFill(t, clients); err != nil {
+			errList = append(errList, stacktrace.RootCause(err).Error())
+		}
+	}
+	if len(errList) != 0 {
+		return cerrors.PreserveError{ErrString: strings.Join(errList, ",")}
+	}
+	return nil
+}
+
+// revertDiskFill will delete the files, which was created during chaos execution
+// and it will delete the target pod if target pod is evicted
+func revertDiskFill(t *targetDetails, clients clients.ClientSets) error {
+	// deleting the files after chaos execution, it is done even for evicted pods as the persistent volumes outlive the pod
+	if t.Dir != nil {
+		if err := unix.Unlinkat(int(t.Dir.Fd()), t.FileName, 0); err != nil && err != unix.ENOENT {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Source: t.Source, Target: fmt.Sprintf("{podName: %s,namespace: %s}", t.Name, t.Namespace), Reason: fmt.Sprintf("failed to cleanup the fill file: %s", err.Error())}
+		}
+		t.close()
+	}
+
 	pod, err := clients.GetPod(t.Namespace, t.Name, 180, 2)
 	if err != nil {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Source: t.Source, Target: fmt.Sprintf("{podName: %s,namespace: %s}", t.Name, t.Namespace), Reason: err.Error()}
 	}
-	podReason := pod.Status.Reason
-	if podReason == "Evicted" {
+	if pod.Status.Reason == "Evicted" {
 		// Deleting the pod as pod is already evicted
 		log.Warn("Target pod is evicted, deleting the pod")
 		if err := clients.KubeClient.CoreV1().Pods(t.Namespace).Delete(context.Background(), t.Name, v1.DeleteOptions{}); err != nil {
 			return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Source: t.Source, Target: fmt.Sprintf("{podName: %s,namespace: %s}", t.Name, t.Namespace), Reason: fmt.Sprintf("failed to delete target pod after eviction :%s", err.Error())}
 		}
-	} else {
-		// deleting the files after chaos execution
-		rm := fmt.Sprintf("sudo rm -rf /proc/%v/root/home/diskfill", t.TargetPID)
-		cmd := exec.Command("/bin/bash", "-c", rm)
-		out, err := cmd.CombinedOutput()
-		if err != nil {
-			log.Error(err.Error())
-			return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Source: t.Source, Target: fmt.Sprintf("{podName: %s,namespace: %s}", t.Name, t.Namespace), Reason: fmt.Sprintf("failed to cleanup ephemeral storage: %s", string(out))}
-		}
 	}
 	log.Infof("successfully reverted chaos on target: {name: %s, namespace: %v, container: %v}", t.Name, t.Namespace, t.TargetContainer)
 	return nil
@@ -291,10 +466,14 @@ func getENV(experimentDetails *experimentTypes.ExperimentDetails) {
 	experimentDetails.DataBlockSize, _ = strconv.Atoi(types.Getenv("DATA_BLOCK_SIZE", "256"))
 	experimentDetails.ContainerRuntime = types.Getenv("CONTAINER_RUNTIME", "")
 	experimentDetails.SocketPath = types.Getenv("SOCKET_PATH", "")
+	experimentDetails.VolumeMountPath = types.Getenv("VOLUME_MOUNT_PATH", "")
+	experimentDetails.PVCName = types.Getenv("PVC_NAME", "")
+	experimentDetails.FillMode = types.Getenv("FILL_MODE", "hold")
+	experimentDetails.FillStepInterval, _ = strconv.Atoi(types.Getenv("FILL_STEP_INTERVAL", "10"))
 }
 
 // abortWatcher continuously watch for the abort signals
-func abortWatcher(targets []targetDetails, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultName string) {
+func abortWatcher(targets []*targetDetails, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultName string) {
 	// waiting till the abort signal received
 	<-abort
 
@@ -304,6 +483,9 @@ func abortWatcher(targets []targetDetails, experimentsDetails *experimentTypes.E
 	retry := 3
 	for retry > 0 {
 		for _, t := range targets {
+			if t.Dir == nil {
+				continue
+			}
 			err := revertDiskFill(t, clients)
 			if err != nil {
 				log.Errorf("unable to kill disk-fill process, err :%v", err)
@@ -320,7 +502,11 @@ func abortWatcher(targets []targetDetails, experimentsDetails *experimentTypes.E
 	os.Exit(1)
 }
 
-func getDiskSizeToFill(t targetDetails, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets) (int, error) {
+func getDiskSizeToFill(t *targetDetails, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets) (int, error) {
+
+	if t.IsVolume {
+		return getVolumeSizeToFill(t, experimentsDetails)
+	}
 
 	usedEphemeralStorageSize, err := getUsedEphemeralStorage(t)
 	if err != nil {
@@ -343,7 +529,7 @@ func getDiskSizeToFill(t targetDetails, experimentsDetails *experimentTypes.Expe
 	return sizeTobeFilled, nil
 }
 
-func getUsedEphemeralStorage(t targetDetails) (int, error) {
+func getUsedEphemeralStorage(t *targetDetails) (int, error) {
 	// derive the used ephemeral storage size from the target container
 	du := fmt.Sprintf("sudo du /proc/%v/root", t.TargetPID)
 	cmd := exec.Command("/bin/bash", "-c", du)
@@ -371,4 +557,21 @@ type targetDetails struct {
 	SizeToFill      int
 	TargetPID       int
 	Source          string
+	FillPath        string
+	FileName        string
+	IsVolume        bool
+	Dir             *os.File
+	File            *os.File
+}
+
+// close releases the handles of the fill directory and the fill file
+func (t *targetDetails) close() {
+	if t.File != nil {
+		t.File.Close()
+		t.File = nil
+	}
+	if t.Dir != nil {
+		t.Dir.Close()
+		t.Dir = nil
+	}
 }
@@ -38,12 +38,20 @@ func PrepareDiskFill(ctx context.Context, experimentsDetails *experimentTypes.Ex
 	if experimentsDetails.TargetPods == "" && chaosDetails.AppDetail == nil {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "provide one of the appLabel or TARGET_PODS"}
 	}
+	switch strings.ToLower(experimentsDetails.FillMode) {
+	case "hold", "gradual":
+	default:
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("'%s' fill mode is not supported, supported modes are: hold, gradual", experimentsDetails.FillMode)}
+	}
 	//set up the tunables if provided in range
 	setChaosTunables(experimentsDetails)
 
 	log.InfoWithValues("[Info]: The chaos tunables are:", logrus.Fields{
 		"FillPercentage":            experimentsDetails.FillPercentage,
 		"EphemeralStorageMebibytes": experimentsDetails.EphemeralStorageMebibytes,
+		"VolumeMountPath":           experimentsDetails.VolumeMountPath,
+		"PVCName":                   experimentsDetails.PVCName,
+		"FillMode":                  experimentsDetails.FillMode,
 		"PodsAffectedPerc":          experimentsDetails.PodsAffectedPerc,
 		"Sequence":                  experimentsDetails.Sequence,
 	})
@@ -231,7 +239,7 @@ func createHelperPod(ctx context.Context, experimentsDetails *experimentTypes.Ex
 		helperPod.Spec.Volumes = append(helperPod.Spec.Volumes, common.GetSidecarVolumes(chaosDetails)...)
 	}
 
-	if err := clients.CreatePod(experimentsDetails.ChaosNamespace, helperPod); err != nil {
+	if err := common.CreateHelperPod(experimentsDetails.ChaosNamespace, helperPod, clients, chaosDetails); err != nil {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("unable to create helper pod: %s", err.Error())}
 	}
 
@@ -252,6 +260,10 @@ func getPodEnv(ctx context.Context, experimentsDetails *experimentTypes.Experime
 		SetEnv("FILL_PERCENTAGE", experimentsDetails.FillPercentage).
 		SetEnv("EPHEMERAL_STORAGE_MEBIBYTES", experimentsDetails.EphemeralStorageMebibytes).
 		SetEnv("DATA_BLOCK_SIZE", strconv.Itoa(experimentsDetails.DataBlockSize)).
+		SetEnv("VOLUME_MOUNT_PATH", experimentsDetails.VolumeMountPath).
+		SetEnv("PVC_NAME", experimentsDetails.PVCName).
+		SetEnv("FILL_MODE", experimentsDetails.FillMode).
+		SetEnv("FILL_STEP_INTERVAL", strconv.Itoa(experimentsDetails.FillStepInterval)).
 		SetEnv("INSTANCE_ID", experimentsDetails.InstanceID).
 		SetEnv("SOCKET_PATH", experimentsDetails.SocketPath).
 		SetEnv("CONTAINER_RUNTIME", experimentsDetails.ContainerRuntime).
@@ -9,6 +9,7 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	ebs "github.com/litmuschaos/litmus-go/pkg/cloud/aws/ebs"
+	"github.com/litmuschaos/litmus-go/pkg/cloud/provider"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/kube-aws/ebs-loss/types"
 	"github.com/litmuschaos/litmus-go/pkg/log"
@@ -21,7 +22,7 @@ import (
 )
 
 // InjectChaosInSerialMode will inject the ebs loss chaos in serial mode which means one after other
-func InjectChaosInSerialMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, targetEBSVolumeIDList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func InjectChaosInSerialMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, cloudProvider provider.Provider, targetEBSVolumeIDList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectAWSEBSLossFaultInSerialMode")
 	defer span.End()
 
@@ -46,7 +47,7 @@ func InjectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 			//Detaching the ebs volume from the instance
 			log.Info("[Chaos]: Detaching the EBS volume from the instance")
-			if err = ebs.EBSVolumeDetach(volumeID, experimentsDetails.Region); err != nil {
+			if err = cloudProvider.DetachVolume(provider.Volume{ID: volumeID, InstanceID: ec2InstanceID}); err != nil {
 				return stacktrace.Propagate(err, "ebs detachment failed")
 			}
 
@@ -54,7 +55,7 @@ func InjectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 			//Wait for ebs volume detachment
 			log.Infof("[Wait]: Wait for EBS volume detachment for volume %v", volumeID)
-			if err = ebs.WaitForVolumeDetachment(volumeID, ec2InstanceID, experimentsDetails.Region, experimentsDetails.Delay, experimentsDetails.Timeout); err != nil {
+			if err = provider.WaitForVolumeState(cloudProvider, provider.Volume{ID: volumeID, InstanceID: ec2InstanceID}, provider.StateDetached, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 				return stacktrace.Propagate(err, "ebs detachment failed")
 			}
 
@@ -71,24 +72,24 @@ func InjectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 			common.WaitForDuration(experimentsDetails.ChaosInterval)
 
 			//Getting the EBS volume attachment status
-			ebsState, err := ebs.GetEBSStatus(volumeID, ec2InstanceID, experimentsDetails.Region)
+			ebsState, err := cloudProvider.GetVolumeState(provider.Volume{ID: volumeID, InstanceID: ec2InstanceID})
 			if err != nil {
 				return stacktrace.Propagate(err, "failed to get the ebs status")
 			}
 
 			switch ebsState {
-			case "attached":
+			case provider.StateAttached:
 				log.Info("[Skip]: The EBS volume is already attached")
 			default:
 				//Attaching the ebs volume from the instance
 				log.Info("[Chaos]: Attaching the EBS volume back to the instance")
-				if err = ebs.EBSVolumeAttach(volumeID, ec2InstanceID, device, experimentsDetails.Region); err != nil {
+				if err = cloudProvider.AttachVolume(provider.Volume{ID: volumeID, InstanceID: ec2InstanceID, Device: device}); err != nil {
 					return stacktrace.Propagate(err, "ebs attachment failed")
 				}
 
 				//Wait for ebs volume attachment
 				log.Infof("[Wait]: Wait for EBS volume attachment for %v volume", volumeID)
-				if err = ebs.WaitForVolumeAttachment(volumeID, ec2InstanceID, experimentsDetails.Region, experimentsDetails.Delay, experimentsDetails.Timeout); err != nil {
+				if err = provider.WaitForVolumeState(cloudProvider, provider.Volume{ID: volumeID, InstanceID: ec2InstanceID}, provider.StateAttached, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 					return stacktrace.Propagate(err, "ebs attachment failed")
 				}
 			}
@@ -100,7 +101,7 @@ func InjectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 }
 
 // InjectChaosInParallelMode will inject the chaos in parallel mode that means all at once
-func InjectChaosInParallelMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, targetEBSVolumeIDList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func InjectChaosInParallelMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, cloudProvider provider.Provider, targetEBSVolumeIDList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectAWSEBSLossFaultInParallelMode")
 	defer span.End()
 
@@ -135,10 +136,10 @@ func InjectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 			deviceList = append(deviceList, device)
 		}
 
-		for _, volumeID := range targetEBSVolumeIDList {
+		for i, volumeID := range targetEBSVolumeIDList {
 			//Detaching the ebs volume from the instance
 			log.Info("[Chaos]: Detaching the EBS volume from the instance")
-			if err := ebs.EBSVolumeDetach(volumeID, experimentsDetails.Region); err != nil {
+			if err := cloudProvider.DetachVolume(provider.Volume{ID: volumeID, InstanceID: ec2InstanceIDList[i]}); err != nil {
 				return stacktrace.Propagate(err, "ebs detachment failed")
 			}
 			common.SetTargets(volumeID, "injected", "EBS", chaosDetails)
@@ -152,7 +153,7 @@ func InjectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 		for i, volumeID := range targetEBSVolumeIDList {
 			//Wait for ebs volume detachment
 			log.Infof("[Wait]: Wait for EBS volume detachment for volume %v", volumeID)
-			if err := ebs.WaitForVolumeDetachment(volumeID, ec2InstanceIDList[i], experimentsDetails.Region, experimentsDetails.Delay, experimentsDetails.Timeout); err != nil {
+			if err := provider.WaitForVolumeState(cloudProvider, provider.Volume{ID: volumeID, InstanceID: ec2InstanceIDList[i]}, provider.StateDetached, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 				return stacktrace.Propagate(err, "ebs detachment failed")
 			}
 		}
@@ -171,24 +172,24 @@ func InjectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 		for i, volumeID := range targetEBSVolumeIDList {
 
 			//Getting the EBS volume attachment status
-			ebsState, err := ebs.GetEBSStatus(volumeID, ec2InstanceIDList[i], experimentsDetails.Region)
+			ebsState, err := cloudProvider.GetVolumeState(provider.Volume{ID: volumeID, InstanceID: ec2InstanceIDList[i]})
 			if err != nil {
 				return stacktrace.Propagate(err, "failed to get the ebs status")
 			}
 
 			switch ebsState {
-			case "attached":
+			case provider.StateAttached:
 				log.Info("[Skip]: The EBS volume is already attached")
 			default:
 				//Attaching the ebs volume from the instance
 				log.Info("[Chaos]: Attaching the EBS volume from the instance")
-				if err = ebs.EBSVolumeAttach(volumeID, ec2InstanceIDList[i], deviceList[i], experimentsDetails.Region); err != nil {
+				if err = cloudProvider.AttachVolume(provider.Volume{ID: volumeID, InstanceID: ec2InstanceIDList[i], Device: deviceList[i]}); err != nil {
 					return stacktrace.Propagate(err, "ebs attachment failed")
 				}
 
 				//Wait for ebs volume attachment
 				log.Infof("[Wait]: Wait for EBS volume attachment for volume %v", volumeID)
-				if err = ebs.WaitForVolumeAttachment(volumeID, ec2InstanceIDList[i], experimentsDetails.Region, experimentsDetails.Delay, experimentsDetails.Timeout); err != nil {
+				if err = provider.WaitForVolumeState(cloudProvider, provider.Volume{ID: volumeID, InstanceID: ec2InstanceIDList[i]}, provider.StateAttached, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 					return stacktrace.Propagate(err, "ebs attachment failed")
 				}
 			}
@@ -200,7 +201,7 @@ func InjectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 }
 
 // AbortWatcher will watching for the abort signal and revert the chaos
-func AbortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, volumeIDList []string, abort chan os.Signal, chaosDetails *types.ChaosDetails) {
+func AbortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, cloudProvider provider.Provider, volumeIDList []string, abort chan os.Signal, chaosDetails *types.ChaosDetails) {
 
 	<-abort
 
@@ -213,21 +214,21 @@ func AbortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, volumeI
 		}
 
 		//Getting the EBS volume attachment status
-		ebsState, err := ebs.GetEBSStatus(experimentsDetails.EBSVolumeID, instanceID, experimentsDetails.Region)
+		ebsState, err := cloudProvider.GetVolumeState(provider.Volume{ID: volumeID, InstanceID: instanceID})
 		if err != nil {
 			log.Errorf("Failed to get the ebs status when an abort signal is received: %v", err)
 		}
-		if ebsState != "attached" {
+		if ebsState != provider.StateAttached {
 
 			//Wait for ebs volume detachment
 			//We first wait for the volume to get in detached state then we are attaching it.
 			log.Info("[Abort]: Wait for EBS complete volume detachment")
-			if err = ebs.WaitForVolumeDetachment(experimentsDetails.EBSVolumeID, instanceID, experimentsDetails.Region, experimentsDetails.Delay, experimentsDetails.Timeout); err != nil {
+			if err = provider.WaitForVolumeState(cloudProvider, provider.Volume{ID: volumeID, InstanceID: instanceID}, provider.StateDetached, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 				log.Errorf("Unable to detach the ebs volume: %v", err)
 			}
 			//Attaching the ebs volume from the instance
 			log.Info("[Chaos]: Attaching the EBS volume from the instance")
-			err = ebs.EBSVolumeAttach(experimentsDetails.EBSVolumeID, instanceID, deviceName, experimentsDetails.Region)
+			err = cloudProvider.AttachVolume(provider.Volume{ID: volumeID, InstanceID: instanceID, Device: deviceName})
 			if err != nil {
 				log.Errorf("EBS attachment failed when an abort signal is received: %v", err)
 			}
@@ -11,7 +11,7 @@ import (
 
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
-	awslib "github.com/litmuschaos/litmus-go/pkg/cloud/aws/ec2"
+	"github.com/litmuschaos/litmus-go/pkg/cloud/provider"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/kube-aws/ec2-terminate-by-id/types"
 	"github.com/litmuschaos/litmus-go/pkg/log"
@@ -29,7 +29,7 @@ var (
 )
 
 // PrepareEC2TerminateByID contains the prepration and injection steps for the experiment
-func PrepareEC2TerminateByID(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func PrepareEC2TerminateByID(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, cloudProvider provider.Provider, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "PrepareAWSEC2TerminateFaultByID")
 	defer span.End()
 
@@ -55,16 +55,21 @@ func PrepareEC2TerminateByID(ctx context.Context, experimentsDetails *experiment
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "no EC2 instance ID found to terminate"}
 	}
 
+	if chaosDetails.DryRun {
+		common.PlanTargets("EC2", "", instanceIDList, chaosDetails)
+		return common.StopForDryRun(experimentsDetails, chaosDetails)
+	}
+
 	// watching for the abort signal and revert the chaos
-	go abortWatcher(experimentsDetails, instanceIDList, chaosDetails)
+	go abortWatcher(experimentsDetails, cloudProvider, instanceIDList, chaosDetails)
 
 	switch strings.ToLower(experimentsDetails.Sequence) {
 	case "serial":
-		if err = injectChaosInSerialMode(ctx, experimentsDetails, instanceIDList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+		if err = injectChaosInSerialMode(ctx, experimentsDetails, cloudProvider, instanceIDList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
 			return stacktrace.Propagate(err, "could not run chaos in serial mode")
 		}
 	case "parallel":
-		if err = injectChaosInParallelMode(ctx, experimentsDetails, instanceIDList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+		if err = injectChaosInParallelMode(ctx, experimentsDetails, cloudProvider, instanceIDList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
 			return stacktrace.Propagate(err, "could not run chaos in parallel mode")
 		}
 	default:
@@ -80,7 +85,7 @@ func PrepareEC2TerminateByID(ctx context.Context, experimentsDetails *experiment
 }
 
 // injectChaosInSerialMode will inject the ec2 instance termination in serial mode that is one after other
-func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, instanceIDList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, cloudProvider provider.Provider, instanceIDList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectAWSEC2TerminateFaultByIDInSerialMode")
 	defer span.End()
 
@@ -108,7 +113,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 				//Stopping the EC2 instance
 				log.Info("[Chaos]: Stopping the desired EC2 instance")
-				if err := awslib.EC2Stop(id, experimentsDetails.Region); err != nil {
+				if err := cloudProvider.StopInstance(provider.Instance{ID: id}); err != nil {
 					return stacktrace.Propagate(err, "ec2 instance failed to stop")
 				}
 
@@ -116,7 +121,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 				//Wait for ec2 instance to completely stop
 				log.Infof("[Wait]: Wait for EC2 instance '%v' to get in stopped state", id)
-				if err := awslib.WaitForEC2Down(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.ManagedNodegroup, experimentsDetails.Region, id); err != nil {
+				if err := provider.WaitForInstanceState(cloudProvider, provider.Instance{ID: id}, stoppedState(experimentsDetails), experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 					return stacktrace.Propagate(err, "ec2 instance failed to stop")
 				}
 
@@ -135,13 +140,13 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 				//Starting the EC2 instance
 				if experimentsDetails.ManagedNodegroup != "enable" {
 					log.Info("[Chaos]: Starting back the EC2 instance")
-					if err := awslib.EC2Start(id, experimentsDetails.Region); err != nil {
+					if err := cloudProvider.StartInstance(provider.Instance{ID: id}); err != nil {
 						return stacktrace.Propagate(err, "ec2 instance failed to start")
 					}
 
 					//Wait for ec2 instance to get in running state
 					log.Infof("[Wait]: Wait for EC2 instance '%v' to get in running state", id)
-					if err := awslib.WaitForEC2Up(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.ManagedNodegroup, experimentsDetails.Region, id); err != nil {
+					if err := provider.WaitForInstanceState(cloudProvider, provider.Instance{ID: id}, provider.StateRunning, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 						return stacktrace.Propagate(err, "ec2 instance failed to start")
 					}
 				}
@@ -154,7 +159,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 }
 
 // injectChaosInParallelMode will inject the ec2 instance termination in parallel mode that is all at once
-func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, instanceIDList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, cloudProvider provider.Provider, instanceIDList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectAWSEC2TerminateFaultByIDInParallelMode")
 	defer span.End()
 
@@ -181,7 +186,7 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 			for _, id := range instanceIDList {
 				//Stopping the EC2 instance
 				log.Info("[Chaos]: Stopping the desired EC2 instance")
-				if err := awslib.EC2Stop(id, experimentsDetails.Region); err != nil {
+				if err := cloudProvider.StopInstance(provider.Instance{ID: id}); err != nil {
 					return stacktrace.Propagate(err, "ec2 instance failed to stop")
 				}
 				common.SetTargets(id, "injected", "EC2", chaosDetails)
@@ -190,7 +195,7 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 			for _, id := range instanceIDList {
 				//Wait for ec2 instance to completely stop
 				log.Infof("[Wait]: Wait for EC2 instance '%v' to get in stopped state", id)
-				if err := awslib.WaitForEC2Down(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.ManagedNodegroup, experimentsDetails.Region, id); err != nil {
+				if err := provider.WaitForInstanceState(cloudProvider, provider.Instance{ID: id}, stoppedState(experimentsDetails), experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 					return stacktrace.Propagate(err, "ec2 instance failed to stop")
 				}
 				common.SetTargets(id, "reverted", "EC2 Instance ID", chaosDetails)
@@ -212,7 +217,7 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 
 				for _, id := range instanceIDList {
 					log.Info("[Chaos]: Starting back the EC2 instance")
-					if err := awslib.EC2Start(id, experimentsDetails.Region); err != nil {
+					if err := cloudProvider.StartInstance(provider.Instance{ID: id}); err != nil {
 						return stacktrace.Propagate(err, "ec2 instance failed to start")
 					}
 				}
@@ -220,7 +225,7 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 				for _, id := range instanceIDList {
 					//Wait for ec2 instance to get in running state
 					log.Infof("[Wait]: Wait for EC2 instance '%v' to get in running state", id)
-					if err := awslib.WaitForEC2Up(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.ManagedNodegroup, experimentsDetails.Region, id); err != nil {
+					if err := provider.WaitForInstanceState(cloudProvider, provider.Instance{ID: id}, provider.StateRunning, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 						return stacktrace.Propagate(err, "ec2 instance failed to start")
 					}
 				}
@@ -235,25 +240,25 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 }
 
 // watching for the abort signal and revert the chaos
-func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, instanceIDList []string, chaosDetails *types.ChaosDetails) {
+func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, cloudProvider provider.Provider, instanceIDList []string, chaosDetails *types.ChaosDetails) {
 
 	<-abort
 
 	log.Info("[Abort]: Chaos Revert Started")
 	for _, id := range instanceIDList {
-		instanceState, err := awslib.GetEC2InstanceStatus(id, experimentsDetails.Region)
+		instanceState, err := cloudProvider.GetInstanceState(provider.Instance{ID: id})
 		if err != nil {
 			log.Errorf("Failed to get instance status when an abort signal is received: %v", err)
 		}
-		if instanceState != "running" && experimentsDetails.ManagedNodegroup != "enable" {
+		if instanceState != provider.StateRunning && experimentsDetails.ManagedNodegroup != "enable" {
 
 			log.Info("[Abort]: Waiting for the EC2 instance to get down")
-			if err := awslib.WaitForEC2Down(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.ManagedNodegroup, experimentsDetails.Region, id); err != nil {
+			if err := provider.WaitForInstanceState(cloudProvider, provider.Instance{ID: id}, provider.StateStopped, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 				log.Errorf("Unable to wait till stop of the instance: %v", err)
 			}
 
 			log.Info("[Abort]: Starting EC2 instance as abort signal received")
-			err := awslib.EC2Start(id, experimentsDetails.Region)
+			err := cloudProvider.StartInstance(provider.Instance{ID: id})
 			if err != nil {
 				log.Errorf("EC2 instance failed to start when an abort signal is received: %v", err)
 			}
@@ -263,3 +268,11 @@ func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, instanc
 	log.Info("[Abort]: Chaos Revert Completed")
 	os.Exit(1)
 }
+
+// stoppedState returns the state of the stopped instance, the instances of the managed nodegroup are terminated instead of stopped
+func stoppedState(experimentsDetails *experimentTypes.ExperimentDetails) provider.State {
+	if experimentsDetails.ManagedNodegroup == "enable" {
+		return provider.StateTerminated
+	}
+	return provider.StateStopped
+}
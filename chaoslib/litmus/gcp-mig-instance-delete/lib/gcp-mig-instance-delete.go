@@ -0,0 +1,180 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	gcplib "github.com/litmuschaos/litmus-go/pkg/cloud/gcp"
+	"github.com/litmuschaos/litmus-go/pkg/events"
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/gcp/gcp-mig-instance-delete/types"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/probe"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/litmuschaos/litmus-go/pkg/utils/random"
+	"github.com/palantir/stacktrace"
+	"go.opentelemetry.io/otel"
+	"google.golang.org/api/compute/v1"
+)
+
+var inject chan os.Signal
+
+// PrepareMIGInstanceDelete contains the preparation and injection steps for the experiment
+func PrepareMIGInstanceDelete(ctx context.Context, computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "PrepareGCPMIGInstanceDeleteFault")
+	defer span.End()
+
+	// inject channel is used to transmit signal notifications.
+	inject = make(chan os.Signal, 1)
+	// Catch and relay certain signal(s) to inject channel.
+	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+
+	//Waiting for the ramp time before chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
+		common.WaitForDuration(experimentsDetails.RampTime)
+	}
+
+	mig := GetMIG(experimentsDetails)
+
+	if chaosDetails.DryRun {
+		instances, err := selectInstances(computeService, experimentsDetails, mig)
+		if err != nil {
+			return stacktrace.Propagate(err, "could not select the target instances")
+		}
+		common.PlanTargets("VM", "", instanceNames(instances), chaosDetails)
+		return common.StopForDryRun(experimentsDetails, chaosDetails)
+	}
+
+	if err := injectChaos(ctx, computeService, experimentsDetails, mig, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+		return stacktrace.Propagate(err, "could not delete the managed instances")
+	}
+
+	//Waiting for the ramp time after chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
+		common.WaitForDuration(experimentsDetails.RampTime)
+	}
+
+	return nil
+}
+
+// GetMIG returns the zonal managed instance group, or the regional one if the region is provided
+func GetMIG(experimentsDetails *experimentTypes.ExperimentDetails) gcplib.ManagedInstanceGroup {
+	if experimentsDetails.Region != "" {
+		return gcplib.ManagedInstanceGroup{Name: experimentsDetails.MIGName, Region: experimentsDetails.Region}
+	}
+	return gcplib.ManagedInstanceGroup{Name: experimentsDetails.MIGName, Zone: experimentsDetails.Zone}
+}
+
+// injectChaos deletes the instances of the managed instance group and waits for the group to recreate them
+func injectChaos(ctx context.Context, computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, mig gcplib.ManagedInstanceGroup, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectGCPMIGInstanceDeleteFault")
+	defer span.End()
+
+	select {
+	case <-inject:
+		// stopping the chaos execution, if abort signal received
+		os.Exit(0)
+	default:
+		//ChaosStartTimeStamp contains the start timestamp, when the chaos injection begin
+		ChaosStartTimeStamp := time.Now()
+		duration := int(time.Since(ChaosStartTimeStamp).Seconds())
+
+		for iteration := 0; duration < experimentsDetails.ChaosDuration; iteration++ {
+
+			// the instances are selected in every iteration, as the deleted instances are recreated
+			instances, err := selectInstances(computeService, experimentsDetails, mig)
+			if err != nil {
+				return stacktrace.Propagate(err, "could not select the target instances")
+			}
+			log.Infof("[Info]: Target VM instance list, %v", instanceNames(instances))
+
+			if experimentsDetails.EngineName != "" {
+				msg := "Injecting " + experimentsDetails.ExperimentName + " chaos in managed instance group"
+				types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
+				events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
+			}
+
+			deletedAt := time.Now()
+			operations := make([]string, len(instances))
+			for i, instance := range instances {
+				log.Infof("[Chaos]: Deleting %s VM instance", instance.Name)
+				if operations[i], err = gcplib.VMInstanceDelete(computeService, instance.Name, experimentsDetails.GCPProjectID, instance.Zone); err != nil {
+					return stacktrace.Propagate(err, "vm instance failed to delete")
+				}
+				common.SetTargets(instance.Name, "injected", "VM", chaosDetails)
+			}
+
+			for i, instance := range instances {
+				log.Infof("[Wait]: Wait for VM instance %s to get deleted", instance.Name)
+				if err := gcplib.WaitForZoneOperation(computeService, experimentsDetails.Timeout, experimentsDetails.Delay, operations[i], experimentsDetails.GCPProjectID, instance.Zone); err != nil {
+					return stacktrace.Propagate(err, "vm instance failed to delete")
+				}
+			}
+
+			// run the probes during chaos
+			// the OnChaos probes execution will start in the first iteration and keep running for the entire chaos duration
+			if len(resultDetails.ProbeDetails) != 0 && iteration == 0 {
+				if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+					return err
+				}
+			}
+
+			// wait for the managed instance group to recreate the instances
+			log.Infof("[Wait]: Wait for the %s managed instance group to get stable at its target size", mig.Name)
+			if err := gcplib.WaitForMIGStable(computeService, experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.GCPProjectID, mig); err != nil {
+				return stacktrace.Propagate(err, "managed instance group failed to recreate the instances")
+			}
+			common.RecordRecovery("MIG", mig.Name, deletedAt, chaosDetails)
+
+			for _, instance := range instances {
+				common.SetTargets(instance.Name, "reverted", "VM", chaosDetails)
+			}
+
+			// wait for the chaos interval
+			log.Infof("[Wait]: Waiting for chaos interval of %vs", experimentsDetails.ChaosInterval)
+			common.WaitForDuration(experimentsDetails.ChaosInterval)
+
+			duration = int(time.Since(ChaosStartTimeStamp).Seconds())
+		}
+	}
+
+	return nil
+}
+
+// selectInstances selects the given number of random instances from the running instances of the managed instance group
+func selectInstances(computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, mig gcplib.ManagedInstanceGroup) ([]gcplib.MIGInstance, error) {
+
+	instances, err := gcplib.GetRunningMIGInstances(computeService, experimentsDetails.GCPProjectID, mig)
+	if err != nil {
+		return nil, err
+	}
+
+	if experimentsDetails.InstanceCount < 1 || experimentsDetails.InstanceCount > len(instances) {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: mig.String(), Reason: fmt.Sprintf("invalid instance count %d, the managed instance group has %d running instances", experimentsDetails.InstanceCount, len(instances))}
+	}
+
+	var selected []gcplib.MIGInstance
+	for _, index := range random.Rand().Perm(len(instances))[:experimentsDetails.InstanceCount] {
+		selected = append(selected, instances[index])
+	}
+
+	return selected, nil
+}
+
+// instanceNames returns the names of the instances
+func instanceNames(instances []gcplib.MIGInstance) []string {
+	var names []string
+	for _, instance := range instances {
+		names = append(names, instance.Name)
+	}
+	return names
+}
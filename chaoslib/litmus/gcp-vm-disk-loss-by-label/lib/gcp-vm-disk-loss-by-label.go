@@ -12,6 +12,7 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/cloud/gcp"
+	"github.com/litmuschaos/litmus-go/pkg/cloud/provider"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/gcp/gcp-vm-disk-loss/types"
 	"github.com/litmuschaos/litmus-go/pkg/log"
@@ -30,7 +31,7 @@ var (
 )
 
 // PrepareDiskVolumeLossByLabel contains the prepration and injection steps for the experiment
-func PrepareDiskVolumeLossByLabel(ctx context.Context, computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func PrepareDiskVolumeLossByLabel(ctx context.Context, computeService *compute.Service, cloudProvider provider.Provider, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "PrepareGCPDiskVolumeLossFaultByLabel")
 	defer span.End()
 
@@ -56,6 +57,11 @@ func PrepareDiskVolumeLossByLabel(ctx context.Context, computeService *compute.S
 		return err
 	}
 
+	if chaosDetails.DryRun {
+		common.PlanTargets("DiskVolume", "", diskVolumeNamesList, chaosDetails)
+		return common.StopForDryRun(experimentsDetails, chaosDetails)
+	}
+
 	select {
 
 	case <-inject:
@@ -64,15 +70,15 @@ func PrepareDiskVolumeLossByLabel(ctx context.Context, computeService *compute.S
 
 	default:
 		// watching for the abort signal and revert the chaos
-		go abortWatcher(computeService, experimentsDetails, diskVolumeNamesList, experimentsDetails.TargetDiskInstanceNamesList, experimentsDetails.Zones, abort, chaosDetails)
+		go abortWatcher(cloudProvider, experimentsDetails, diskVolumeNamesList, experimentsDetails.TargetDiskInstanceNamesList, experimentsDetails.Zones, abort, chaosDetails)
 
 		switch strings.ToLower(experimentsDetails.Sequence) {
 		case "serial":
-			if err = injectChaosInSerialMode(ctx, computeService, experimentsDetails, diskVolumeNamesList, experimentsDetails.TargetDiskInstanceNamesList, experimentsDetails.Zones, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+			if err = injectChaosInSerialMode(ctx, cloudProvider, experimentsDetails, diskVolumeNamesList, experimentsDetails.TargetDiskInstanceNamesList, experimentsDetails.Zones, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
 				return stacktrace.Propagate(err, "could not run chaos in serial mode")
 			}
 		case "parallel":
-			if err = injectChaosInParallelMode(ctx, computeService, experimentsDetails, diskVolumeNamesList, experimentsDetails.TargetDiskInstanceNamesList, experimentsDetails.Zones, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+			if err = injectChaosInParallelMode(ctx, cloudProvider, experimentsDetails, diskVolumeNamesList, experimentsDetails.TargetDiskInstanceNamesList, experimentsDetails.Zones, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
 				return stacktrace.Propagate(err, "could not run chaos in parallel mode")
 			}
 		default:
@@ -90,7 +96,7 @@ func PrepareDiskVolumeLossByLabel(ctx context.Context, computeService *compute.S
 }
 
 // injectChaosInSerialMode will inject the disk loss chaos in serial mode which means one after the other
-func injectChaosInSerialMode(ctx context.Context, computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, targetDiskVolumeNamesList, instanceNamesList []string, zone string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func injectChaosInSerialMode(ctx context.Context, cloudProvider provider.Provider, experimentsDetails *experimentTypes.ExperimentDetails, targetDiskVolumeNamesList, instanceNamesList []string, zone string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectGCPDiskVolumeLossFaultByLabelInSerialMode")
 	defer span.End()
 
@@ -110,7 +116,7 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 
 			//Detaching the disk volume from the instance
 			log.Info("[Chaos]: Detaching the disk volume from the instance")
-			if err = gcp.DiskVolumeDetach(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, zone, experimentsDetails.DeviceNamesList[i]); err != nil {
+			if err = cloudProvider.DetachVolume(provider.Volume{ID: targetDiskVolumeNamesList[i], InstanceID: instanceNamesList[i], Device: experimentsDetails.DeviceNamesList[i], Zone: zone}); err != nil {
 				return stacktrace.Propagate(err, "disk detachment failed")
 			}
 
@@ -118,7 +124,7 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 
 			//Wait for disk volume detachment
 			log.Infof("[Wait]: Wait for disk volume detachment for volume %v", targetDiskVolumeNamesList[i])
-			if err = gcp.WaitForVolumeDetachment(computeService, targetDiskVolumeNamesList[i], experimentsDetails.GCPProjectID, instanceNamesList[i], zone, experimentsDetails.Delay, experimentsDetails.Timeout); err != nil {
+			if err = provider.WaitForVolumeState(cloudProvider, provider.Volume{ID: targetDiskVolumeNamesList[i], InstanceID: instanceNamesList[i], Zone: zone}, provider.StateDetached, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 				return stacktrace.Propagate(err, "unable to detach the disk volume from the vm instance")
 			}
 
@@ -135,24 +141,24 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 			common.WaitForDuration(experimentsDetails.ChaosInterval)
 
 			//Getting the disk volume attachment status
-			diskState, err := gcp.GetDiskVolumeState(computeService, targetDiskVolumeNamesList[i], experimentsDetails.GCPProjectID, instanceNamesList[i], zone)
+			diskState, err := cloudProvider.GetVolumeState(provider.Volume{ID: targetDiskVolumeNamesList[i], InstanceID: instanceNamesList[i], Zone: zone})
 			if err != nil {
 				return stacktrace.Propagate(err, "failed to get the disk volume status")
 			}
 
 			switch diskState {
-			case "attached":
+			case provider.StateAttached:
 				log.Info("[Skip]: The disk volume is already attached")
 			default:
 				//Attaching the disk volume to the instance
 				log.Info("[Chaos]: Attaching the disk volume back to the instance")
-				if err = gcp.DiskVolumeAttach(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, zone, experimentsDetails.DeviceNamesList[i], targetDiskVolumeNamesList[i]); err != nil {
+				if err = cloudProvider.AttachVolume(provider.Volume{ID: targetDiskVolumeNamesList[i], InstanceID: instanceNamesList[i], Device: experimentsDetails.DeviceNamesList[i], Zone: zone}); err != nil {
 					return stacktrace.Propagate(err, "disk attachment failed")
 				}
 
 				//Wait for disk volume attachment
 				log.Infof("[Wait]: Wait for disk volume attachment for %v volume", targetDiskVolumeNamesList[i])
-				if err = gcp.WaitForVolumeAttachment(computeService, targetDiskVolumeNamesList[i], experimentsDetails.GCPProjectID, instanceNamesList[i], zone, experimentsDetails.Delay, experimentsDetails.Timeout); err != nil {
+				if err = provider.WaitForVolumeState(cloudProvider, provider.Volume{ID: targetDiskVolumeNamesList[i], InstanceID: instanceNamesList[i], Zone: zone}, provider.StateAttached, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 					return stacktrace.Propagate(err, "unable to attach the disk volume to the vm instance")
 				}
 			}
@@ -167,7 +173,7 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 }
 
 // injectChaosInParallelMode will inject the disk loss chaos in parallel mode that means all at once
-func injectChaosInParallelMode(ctx context.Context, computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, targetDiskVolumeNamesList, instanceNamesList []string, zone string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func injectChaosInParallelMode(ctx context.Context, cloudProvider provider.Provider, experimentsDetails *experimentTypes.ExperimentDetails, targetDiskVolumeNamesList, instanceNamesList []string, zone string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectGCPDiskVolumeLossFaultByLabelInParallelMode")
 	defer span.End()
 
@@ -187,7 +193,7 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 
 			//Detaching the disk volume from the instance
 			log.Info("[Chaos]: Detaching the disk volume from the instance")
-			if err = gcp.DiskVolumeDetach(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, zone, experimentsDetails.DeviceNamesList[i]); err != nil {
+			if err = cloudProvider.DetachVolume(provider.Volume{ID: targetDiskVolumeNamesList[i], InstanceID: instanceNamesList[i], Device: experimentsDetails.DeviceNamesList[i], Zone: zone}); err != nil {
 				return stacktrace.Propagate(err, "disk detachment failed")
 			}
 
@@ -198,7 +204,7 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 
 			//Wait for disk volume detachment
 			log.Infof("[Wait]: Wait for disk volume detachment for volume %v", targetDiskVolumeNamesList[i])
-			if err = gcp.WaitForVolumeDetachment(computeService, targetDiskVolumeNamesList[i], experimentsDetails.GCPProjectID, instanceNamesList[i], zone, experimentsDetails.Delay, experimentsDetails.Timeout); err != nil {
+			if err = provider.WaitForVolumeState(cloudProvider, provider.Volume{ID: targetDiskVolumeNamesList[i], InstanceID: instanceNamesList[i], Zone: zone}, provider.StateDetached, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 				return stacktrace.Propagate(err, "unable to detach the disk volume from the vm instance")
 			}
 		}
@@ -217,24 +223,24 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 		for i := range targetDiskVolumeNamesList {
 
 			//Getting the disk volume attachment status
-			diskState, err := gcp.GetDiskVolumeState(computeService, targetDiskVolumeNamesList[i], experimentsDetails.GCPProjectID, instanceNamesList[i], zone)
+			diskState, err := cloudProvider.GetVolumeState(provider.Volume{ID: targetDiskVolumeNamesList[i], InstanceID: instanceNamesList[i], Zone: zone})
 			if err != nil {
 				return stacktrace.Propagate(err, "failed to get the disk status")
 			}
 
 			switch diskState {
-			case "attached":
+			case provider.StateAttached:
 				log.Info("[Skip]: The disk volume is already attached")
 			default:
 				//Attaching the disk volume to the instance
 				log.Info("[Chaos]: Attaching the disk volume to the instance")
-				if err = gcp.DiskVolumeAttach(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, zone, experimentsDetails.DeviceNamesList[i], targetDiskVolumeNamesList[i]); err != nil {
+				if err = cloudProvider.AttachVolume(provider.Volume{ID: targetDiskVolumeNamesList[i], InstanceID: instanceNamesList[i], Device: experimentsDetails.DeviceNamesList[i], Zone: zone}); err != nil {
 					return stacktrace.Propagate(err, "disk attachment failed")
 				}
 
 				//Wait for disk volume attachment
 				log.Infof("[Wait]: Wait for disk volume attachment for volume %v", targetDiskVolumeNamesList[i])
-				if err = gcp.WaitForVolumeAttachment(computeService, targetDiskVolumeNamesList[i], experimentsDetails.GCPProjectID, instanceNamesList[i], zone, experimentsDetails.Delay, experimentsDetails.Timeout); err != nil {
+				if err = provider.WaitForVolumeState(cloudProvider, provider.Volume{ID: targetDiskVolumeNamesList[i], InstanceID: instanceNamesList[i], Zone: zone}, provider.StateAttached, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 					return stacktrace.Propagate(err, "unable to attach the disk volume to the vm instance")
 				}
 			}
@@ -249,7 +255,7 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 }
 
 // AbortWatcher will watching for the abort signal and revert the chaos
-func abortWatcher(computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, targetDiskVolumeNamesList, instanceNamesList []string, zone string, abort chan os.Signal, chaosDetails *types.ChaosDetails) {
+func abortWatcher(cloudProvider provider.Provider, experimentsDetails *experimentTypes.ExperimentDetails, targetDiskVolumeNamesList, instanceNamesList []string, zone string, abort chan os.Signal, chaosDetails *types.ChaosDetails) {
 
 	<-abort
 
@@ -258,25 +264,25 @@ func abortWatcher(computeService *compute.Service, experimentsDetails *experimen
 	for i := range targetDiskVolumeNamesList {
 
 		//Getting the disk volume attachment status
-		diskState, err := gcp.GetDiskVolumeState(computeService, targetDiskVolumeNamesList[i], experimentsDetails.GCPProjectID, instanceNamesList[i], zone)
+		diskState, err := cloudProvider.GetVolumeState(provider.Volume{ID: targetDiskVolumeNamesList[i], InstanceID: instanceNamesList[i], Zone: zone})
 		if err != nil {
 			log.Errorf("Failed to get %s disk state when an abort signal is received, err: %v", targetDiskVolumeNamesList[i], err)
 		}
 
-		if diskState != "attached" {
+		if diskState != provider.StateAttached {
 
 			//Wait for disk volume detachment
 			//We first wait for the volume to get in detached state then we are attaching it.
 			log.Infof("[Abort]: Wait for %s complete disk volume detachment", targetDiskVolumeNamesList[i])
 
-			if err = gcp.WaitForVolumeDetachment(computeService, targetDiskVolumeNamesList[i], experimentsDetails.GCPProjectID, instanceNamesList[i], zone, experimentsDetails.Delay, experimentsDetails.Timeout); err != nil {
+			if err = provider.WaitForVolumeState(cloudProvider, provider.Volume{ID: targetDiskVolumeNamesList[i], InstanceID: instanceNamesList[i], Zone: zone}, provider.StateDetached, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 				log.Errorf("Unable to detach %s disk volume, err: %v", targetDiskVolumeNamesList[i], err)
 			}
 
 			//Attaching the disk volume from the instance
 			log.Infof("[Chaos]: Attaching %s disk volume to the instance", targetDiskVolumeNamesList[i])
 
-			err = gcp.DiskVolumeAttach(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, zone, experimentsDetails.DeviceNamesList[i], targetDiskVolumeNamesList[i])
+			err = cloudProvider.AttachVolume(provider.Volume{ID: targetDiskVolumeNamesList[i], InstanceID: instanceNamesList[i], Device: experimentsDetails.DeviceNamesList[i], Zone: zone})
 			if err != nil {
 				log.Errorf("%s disk attachment failed when an abort signal is received, err: %v", targetDiskVolumeNamesList[i], err)
 			}
@@ -12,6 +12,7 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/cloud/gcp"
+	"github.com/litmuschaos/litmus-go/pkg/cloud/provider"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/gcp/gcp-vm-disk-loss/types"
 	"github.com/litmuschaos/litmus-go/pkg/log"
@@ -31,7 +32,7 @@ var (
 )
 
 // PrepareDiskVolumeLoss contains the prepration and injection steps for the experiment
-func PrepareDiskVolumeLoss(ctx context.Context, computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func PrepareDiskVolumeLoss(ctx context.Context, computeService *compute.Service, cloudProvider provider.Provider, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "PrepareVMDiskLossFault")
 	defer span.End()
 
@@ -62,6 +63,11 @@ func PrepareDiskVolumeLoss(ctx context.Context, computeService *compute.Service,
 		return stacktrace.Propagate(err, "failed to fetch the disk device names")
 	}
 
+	if chaosDetails.DryRun {
+		common.PlanTargets("DiskVolume", "", diskNamesList, chaosDetails)
+		return common.StopForDryRun(experimentsDetails, chaosDetails)
+	}
+
 	select {
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
@@ -69,15 +75,15 @@ func PrepareDiskVolumeLoss(ctx context.Context, computeService *compute.Service,
 	default:
 
 		// watching for the abort signal and revert the chaos
-		go abortWatcher(computeService, experimentsDetails, diskNamesList, diskZonesList, abort, chaosDetails)
+		go abortWatcher(cloudProvider, experimentsDetails, diskNamesList, diskZonesList, abort, chaosDetails)
 
 		switch strings.ToLower(experimentsDetails.Sequence) {
 		case "serial":
-			if err = injectChaosInSerialMode(ctx, computeService, experimentsDetails, diskNamesList, diskZonesList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+			if err = injectChaosInSerialMode(ctx, cloudProvider, experimentsDetails, diskNamesList, diskZonesList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
 				return stacktrace.Propagate(err, "could not run chaos in serial mode")
 			}
 		case "parallel":
-			if err = injectChaosInParallelMode(ctx, computeService, experimentsDetails, diskNamesList, diskZonesList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+			if err = injectChaosInParallelMode(ctx, cloudProvider, experimentsDetails, diskNamesList, diskZonesList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
 				return stacktrace.Propagate(err, "could not run chaos in parallel mode")
 			}
 		default:
@@ -95,7 +101,7 @@ func PrepareDiskVolumeLoss(ctx context.Context, computeService *compute.Service,
 }
 
 // injectChaosInSerialMode will inject the disk loss chaos in serial mode which means one after the other
-func injectChaosInSerialMode(ctx context.Context, computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, targetDiskVolumeNamesList, diskZonesList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func injectChaosInSerialMode(ctx context.Context, cloudProvider provider.Provider, experimentsDetails *experimentTypes.ExperimentDetails, targetDiskVolumeNamesList, diskZonesList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectVMDiskLossFaultInSerialMode")
 	defer span.End()
 	//ChaosStartTimeStamp contains the start timestamp, when the chaos injection begin
@@ -113,7 +119,7 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 
 			//Detaching the disk volume from the instance
 			log.Infof("[Chaos]: Detaching %s disk volume from the instance", targetDiskVolumeNamesList[i])
-			if err = gcp.DiskVolumeDetach(computeService, experimentsDetails.TargetDiskInstanceNamesList[i], experimentsDetails.GCPProjectID, diskZonesList[i], experimentsDetails.DeviceNamesList[i]); err != nil {
+			if err = cloudProvider.DetachVolume(provider.Volume{ID: targetDiskVolumeNamesList[i], InstanceID: experimentsDetails.TargetDiskInstanceNamesList[i], Device: experimentsDetails.DeviceNamesList[i], Zone: diskZonesList[i]}); err != nil {
 				return stacktrace.Propagate(err, "disk detachment failed")
 			}
 
@@ -121,7 +127,7 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 
 			//Wait for disk volume detachment
 			log.Infof("[Wait]: Wait for %s disk volume detachment", targetDiskVolumeNamesList[i])
-			if err = gcp.WaitForVolumeDetachment(computeService, targetDiskVolumeNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.TargetDiskInstanceNamesList[i], diskZonesList[i], experimentsDetails.Delay, experimentsDetails.Timeout); err != nil {
+			if err = provider.WaitForVolumeState(cloudProvider, provider.Volume{ID: targetDiskVolumeNamesList[i], InstanceID: experimentsDetails.TargetDiskInstanceNamesList[i], Zone: diskZonesList[i]}, provider.StateDetached, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 				return stacktrace.Propagate(err, "unable to detach disk volume from the vm instance")
 			}
 
@@ -138,24 +144,24 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 			common.WaitForDuration(experimentsDetails.ChaosInterval)
 
 			//Getting the disk volume attachment status
-			diskState, err := gcp.GetDiskVolumeState(computeService, targetDiskVolumeNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.TargetDiskInstanceNamesList[i], diskZonesList[i])
+			diskState, err := cloudProvider.GetVolumeState(provider.Volume{ID: targetDiskVolumeNamesList[i], InstanceID: experimentsDetails.TargetDiskInstanceNamesList[i], Zone: diskZonesList[i]})
 			if err != nil {
 				return stacktrace.Propagate(err, fmt.Sprintf("failed to get %s disk volume status", targetDiskVolumeNamesList[i]))
 			}
 
 			switch diskState {
-			case "attached":
+			case provider.StateAttached:
 				log.Infof("[Skip]: %s disk volume is already attached", targetDiskVolumeNamesList[i])
 			default:
 				//Attaching the disk volume to the instance
 				log.Infof("[Chaos]: Attaching %s disk volume back to the instance", targetDiskVolumeNamesList[i])
-				if err = gcp.DiskVolumeAttach(computeService, experimentsDetails.TargetDiskInstanceNamesList[i], experimentsDetails.GCPProjectID, diskZonesList[i], experimentsDetails.DeviceNamesList[i], targetDiskVolumeNamesList[i]); err != nil {
+				if err = cloudProvider.AttachVolume(provider.Volume{ID: targetDiskVolumeNamesList[i], InstanceID: experimentsDetails.TargetDiskInstanceNamesList[i], Device: experimentsDetails.DeviceNamesList[i], Zone: diskZonesList[i]}); err != nil {
 					return stacktrace.Propagate(err, "disk attachment failed")
 				}
 
 				//Wait for disk volume attachment
 				log.Infof("[Wait]: Wait for %s disk volume attachment", targetDiskVolumeNamesList[i])
-				if err = gcp.WaitForVolumeAttachment(computeService, targetDiskVolumeNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.TargetDiskInstanceNamesList[i], diskZonesList[i], experimentsDetails.Delay, experimentsDetails.Timeout); err != nil {
+				if err = provider.WaitForVolumeState(cloudProvider, provider.Volume{ID: targetDiskVolumeNamesList[i], InstanceID: experimentsDetails.TargetDiskInstanceNamesList[i], Zone: diskZonesList[i]}, provider.StateAttached, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 					return stacktrace.Propagate(err, "unable to attach disk volume to the vm instance")
 				}
 			}
@@ -167,7 +173,7 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 }
 
 // injectChaosInParallelMode will inject the disk loss chaos in parallel mode that means all at once
-func injectChaosInParallelMode(ctx context.Context, computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, targetDiskVolumeNamesList, diskZonesList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func injectChaosInParallelMode(ctx context.Context, cloudProvider provider.Provider, experimentsDetails *experimentTypes.ExperimentDetails, targetDiskVolumeNamesList, diskZonesList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectVMDiskLossFaultInParallelMode")
 	defer span.End()
 
@@ -187,7 +193,7 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 
 			//Detaching the disk volume from the instance
 			log.Infof("[Chaos]: Detaching %s disk volume from the instance", targetDiskVolumeNamesList[i])
-			if err = gcp.DiskVolumeDetach(computeService, experimentsDetails.TargetDiskInstanceNamesList[i], experimentsDetails.GCPProjectID, diskZonesList[i], experimentsDetails.DeviceNamesList[i]); err != nil {
+			if err = cloudProvider.DetachVolume(provider.Volume{ID: targetDiskVolumeNamesList[i], InstanceID: experimentsDetails.TargetDiskInstanceNamesList[i], Device: experimentsDetails.DeviceNamesList[i], Zone: diskZonesList[i]}); err != nil {
 				return stacktrace.Propagate(err, "disk detachment failed")
 			}
 
@@ -198,7 +204,7 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 
 			//Wait for disk volume detachment
 			log.Infof("[Wait]: Wait for %s disk volume detachment", targetDiskVolumeNamesList[i])
-			if err = gcp.WaitForVolumeDetachment(computeService, targetDiskVolumeNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.TargetDiskInstanceNamesList[i], diskZonesList[i], experimentsDetails.Delay, experimentsDetails.Timeout); err != nil {
+			if err = provider.WaitForVolumeState(cloudProvider, provider.Volume{ID: targetDiskVolumeNamesList[i], InstanceID: experimentsDetails.TargetDiskInstanceNamesList[i], Zone: diskZonesList[i]}, provider.StateDetached, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 				return stacktrace.Propagate(err, "unable to detach disk volume from the vm instance")
 			}
 		}
@@ -217,24 +223,24 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 		for i := range targetDiskVolumeNamesList {
 
 			//Getting the disk volume attachment status
-			diskState, err := gcp.GetDiskVolumeState(computeService, targetDiskVolumeNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.TargetDiskInstanceNamesList[i], diskZonesList[i])
+			diskState, err := cloudProvider.GetVolumeState(provider.Volume{ID: targetDiskVolumeNamesList[i], InstanceID: experimentsDetails.TargetDiskInstanceNamesList[i], Zone: diskZonesList[i]})
 			if err != nil {
 				return errors.Errorf("failed to get the disk status, err: %v", err)
 			}
 
 			switch diskState {
-			case "attached":
+			case provider.StateAttached:
 				log.Infof("[Skip]: %s disk volume is already attached", targetDiskVolumeNamesList[i])
 			default:
 				//Attaching the disk volume to the instance
 				log.Infof("[Chaos]: Attaching %s disk volume to the instance", targetDiskVolumeNamesList[i])
-				if err = gcp.DiskVolumeAttach(computeService, experimentsDetails.TargetDiskInstanceNamesList[i], experimentsDetails.GCPProjectID, diskZonesList[i], experimentsDetails.DeviceNamesList[i], targetDiskVolumeNamesList[i]); err != nil {
+				if err = cloudProvider.AttachVolume(provider.Volume{ID: targetDiskVolumeNamesList[i], InstanceID: experimentsDetails.TargetDiskInstanceNamesList[i], Device: experimentsDetails.DeviceNamesList[i], Zone: diskZonesList[i]}); err != nil {
 					return stacktrace.Propagate(err, "disk attachment failed")
 				}
 
 				//Wait for disk volume attachment
 				log.Infof("[Wait]: Wait for %s disk volume attachment", targetDiskVolumeNamesList[i])
-				if err = gcp.WaitForVolumeAttachment(computeService, targetDiskVolumeNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.TargetDiskInstanceNamesList[i], diskZonesList[i], experimentsDetails.Delay, experimentsDetails.Timeout); err != nil {
+				if err = provider.WaitForVolumeState(cloudProvider, provider.Volume{ID: targetDiskVolumeNamesList[i], InstanceID: experimentsDetails.TargetDiskInstanceNamesList[i], Zone: diskZonesList[i]}, provider.StateAttached, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 					return stacktrace.Propagate(err, "unable to attach disk volume to the vm instance")
 				}
 			}
@@ -246,7 +252,7 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 }
 
 // AbortWatcher will watching for the abort signal and revert the chaos
-func abortWatcher(computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, targetDiskVolumeNamesList, diskZonesList []string, abort chan os.Signal, chaosDetails *types.ChaosDetails) {
+func abortWatcher(cloudProvider provider.Provider, experimentsDetails *experimentTypes.ExperimentDetails, targetDiskVolumeNamesList, diskZonesList []string, abort chan os.Signal, chaosDetails *types.ChaosDetails) {
 
 	<-abort
 
@@ -255,25 +261,25 @@ func abortWatcher(computeService *compute.Service, experimentsDetails *experimen
 	for i := range targetDiskVolumeNamesList {
 
 		//Getting the disk volume attachment status
-		diskState, err := gcp.GetDiskVolumeState(computeService, targetDiskVolumeNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.TargetDiskInstanceNamesList[i], diskZonesList[i])
+		diskState, err := cloudProvider.GetVolumeState(provider.Volume{ID: targetDiskVolumeNamesList[i], InstanceID: experimentsDetails.TargetDiskInstanceNamesList[i], Zone: diskZonesList[i]})
 		if err != nil {
 			log.Errorf("Failed to get %s disk state when an abort signal is received, err: %v", targetDiskVolumeNamesList[i], err)
 		}
 
-		if diskState != "attached" {
+		if diskState != provider.StateAttached {
 
 			//Wait for disk volume detachment
 			//We first wait for the volume to get in detached state then we are attaching it.
 			log.Infof("[Abort]: Wait for complete disk volume detachment for %s", targetDiskVolumeNamesList[i])
 
-			if err = gcp.WaitForVolumeDetachment(computeService, targetDiskVolumeNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.TargetDiskInstanceNamesList[i], diskZonesList[i], experimentsDetails.Delay, experimentsDetails.Timeout); err != nil {
+			if err = provider.WaitForVolumeState(cloudProvider, provider.Volume{ID: targetDiskVolumeNamesList[i], InstanceID: experimentsDetails.TargetDiskInstanceNamesList[i], Zone: diskZonesList[i]}, provider.StateDetached, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 				log.Errorf("Unable to detach %s disk volume, err: %v", targetDiskVolumeNamesList[i], err)
 			}
 
 			//Attaching the disk volume from the instance
 			log.Infof("[Chaos]: Attaching %s disk volume from the instance", targetDiskVolumeNamesList[i])
 
-			err = gcp.DiskVolumeAttach(computeService, experimentsDetails.TargetDiskInstanceNamesList[i], experimentsDetails.GCPProjectID, diskZonesList[i], experimentsDetails.DeviceNamesList[i], targetDiskVolumeNamesList[i])
+			err = cloudProvider.AttachVolume(provider.Volume{ID: targetDiskVolumeNamesList[i], InstanceID: experimentsDetails.TargetDiskInstanceNamesList[i], Device: experimentsDetails.DeviceNamesList[i], Zone: diskZonesList[i]})
 			if err != nil {
 				log.Errorf("%s disk attachment failed when an abort signal is received, err: %v", targetDiskVolumeNamesList[i], err)
 			}
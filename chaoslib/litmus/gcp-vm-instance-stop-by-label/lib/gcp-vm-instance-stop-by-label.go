@@ -11,7 +11,7 @@ import (
 
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
-	gcplib "github.com/litmuschaos/litmus-go/pkg/cloud/gcp"
+	"github.com/litmuschaos/litmus-go/pkg/cloud/provider"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/gcp/gcp-vm-instance-stop/types"
 	"github.com/litmuschaos/litmus-go/pkg/log"
@@ -21,13 +21,12 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/utils/common"
 	"github.com/palantir/stacktrace"
 	"go.opentelemetry.io/otel"
-	"google.golang.org/api/compute/v1"
 )
 
 var inject, abort chan os.Signal
 
 // PrepareVMStopByLabel executes the experiment steps by injecting chaos into target VM instances
-func PrepareVMStopByLabel(ctx context.Context, computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func PrepareVMStopByLabel(ctx context.Context, cloudProvider provider.Provider, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "PrepareGCPVMInstanceStopFaultByLabel")
 	defer span.End()
 
@@ -50,16 +49,21 @@ func PrepareVMStopByLabel(ctx context.Context, computeService *compute.Service,
 	instanceNamesList := common.FilterBasedOnPercentage(experimentsDetails.InstanceAffectedPerc, experimentsDetails.TargetVMInstanceNameList)
 	log.Infof("[Chaos]:Number of Instance targeted: %v", len(instanceNamesList))
 
+	if chaosDetails.DryRun {
+		common.PlanTargets("VM", "", instanceNamesList, chaosDetails)
+		return common.StopForDryRun(experimentsDetails, chaosDetails)
+	}
+
 	// watching for the abort signal and revert the chaos
-	go abortWatcher(computeService, experimentsDetails, instanceNamesList, chaosDetails)
+	go abortWatcher(cloudProvider, experimentsDetails, instanceNamesList, chaosDetails)
 
 	switch strings.ToLower(experimentsDetails.Sequence) {
 	case "serial":
-		if err := injectChaosInSerialMode(ctx, computeService, experimentsDetails, instanceNamesList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+		if err := injectChaosInSerialMode(ctx, cloudProvider, experimentsDetails, instanceNamesList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
 			return stacktrace.Propagate(err, "could not run chaos in serial mode")
 		}
 	case "parallel":
-		if err := injectChaosInParallelMode(ctx, computeService, experimentsDetails, instanceNamesList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+		if err := injectChaosInParallelMode(ctx, cloudProvider, experimentsDetails, instanceNamesList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
 			return stacktrace.Propagate(err, "could not run chaos in parallel mode")
 		}
 	default:
@@ -76,7 +80,7 @@ func PrepareVMStopByLabel(ctx context.Context, computeService *compute.Service,
 }
 
 // injectChaosInSerialMode stops VM instances in serial mode i.e. one after the other
-func injectChaosInSerialMode(ctx context.Context, computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, instanceNamesList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func injectChaosInSerialMode(ctx context.Context, cloudProvider provider.Provider, experimentsDetails *experimentTypes.ExperimentDetails, instanceNamesList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectGCPVMInstanceStopFaultByLabelInSerialMode")
 	defer span.End()
 
@@ -104,7 +108,7 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 
 				//Stopping the VM instance
 				log.Infof("[Chaos]: Stopping %s VM instance", instanceNamesList[i])
-				if err := gcplib.VMInstanceStop(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.Zones); err != nil {
+				if err := cloudProvider.StopInstance(provider.Instance{ID: instanceNamesList[i], Zone: experimentsDetails.Zones}); err != nil {
 					return stacktrace.Propagate(err, "VM instance failed to stop")
 				}
 
@@ -112,7 +116,7 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 
 				//Wait for VM instance to completely stop
 				log.Infof("[Wait]: Wait for VM instance %s to stop", instanceNamesList[i])
-				if err := gcplib.WaitForVMInstanceDown(computeService, experimentsDetails.Timeout, experimentsDetails.Delay, instanceNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.Zones); err != nil {
+				if err := provider.WaitForInstanceState(cloudProvider, provider.Instance{ID: instanceNamesList[i], Zone: experimentsDetails.Zones}, provider.StateStopped, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 					return stacktrace.Propagate(err, "vm instance failed to fully shutdown")
 				}
 
@@ -133,7 +137,7 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 
 					// wait for VM instance to get in running state
 					log.Infof("[Wait]: Wait for VM instance %s to get in RUNNING state", instanceNamesList[i])
-					if err := gcplib.WaitForVMInstanceUp(computeService, experimentsDetails.Timeout, experimentsDetails.Delay, instanceNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.Zones); err != nil {
+					if err := provider.WaitForInstanceState(cloudProvider, provider.Instance{ID: instanceNamesList[i], Zone: experimentsDetails.Zones}, provider.StateRunning, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 						return stacktrace.Propagate(err, "unable to start %s vm instance")
 					}
 
@@ -141,13 +145,13 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 
 					// starting the VM instance
 					log.Infof("[Chaos]: Starting back %s VM instance", instanceNamesList[i])
-					if err := gcplib.VMInstanceStart(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.Zones); err != nil {
+					if err := cloudProvider.StartInstance(provider.Instance{ID: instanceNamesList[i], Zone: experimentsDetails.Zones}); err != nil {
 						return stacktrace.Propagate(err, "vm instance failed to start")
 					}
 
 					// wait for VM instance to get in running state
 					log.Infof("[Wait]: Wait for VM instance %s to get in RUNNING state", instanceNamesList[i])
-					if err := gcplib.WaitForVMInstanceUp(computeService, experimentsDetails.Timeout, experimentsDetails.Delay, instanceNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.Zones); err != nil {
+					if err := provider.WaitForInstanceState(cloudProvider, provider.Instance{ID: instanceNamesList[i], Zone: experimentsDetails.Zones}, provider.StateRunning, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 						return stacktrace.Propagate(err, "unable to start %s vm instance")
 					}
 				}
@@ -163,7 +167,7 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 }
 
 // injectChaosInParallelMode will inject the VM instance termination in serial mode that is one after other
-func injectChaosInParallelMode(ctx context.Context, computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, instanceNamesList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func injectChaosInParallelMode(ctx context.Context, cloudProvider provider.Provider, experimentsDetails *experimentTypes.ExperimentDetails, instanceNamesList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectGCPVMInstanceStopFaultByLabelInParallelMode")
 	defer span.End()
 	select {
@@ -190,7 +194,7 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 
 				// stopping the VM instance
 				log.Infof("[Chaos]: Stopping %s VM instance", instanceNamesList[i])
-				if err := gcplib.VMInstanceStop(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.Zones); err != nil {
+				if err := cloudProvider.StopInstance(provider.Instance{ID: instanceNamesList[i], Zone: experimentsDetails.Zones}); err != nil {
 					return stacktrace.Propagate(err, "vm instance failed to stop")
 				}
 
@@ -201,7 +205,7 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 
 				// wait for VM instance to completely stop
 				log.Infof("[Wait]: Wait for VM instance %s to get in stopped state", instanceNamesList[i])
-				if err := gcplib.WaitForVMInstanceDown(computeService, experimentsDetails.Timeout, experimentsDetails.Delay, instanceNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.Zones); err != nil {
+				if err := provider.WaitForInstanceState(cloudProvider, provider.Instance{ID: instanceNamesList[i], Zone: experimentsDetails.Zones}, provider.StateStopped, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 					return stacktrace.Propagate(err, "vm instance failed to fully shutdown")
 				}
 			}
@@ -224,7 +228,7 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 				for i := range instanceNamesList {
 
 					log.Infof("[Wait]: Wait for VM instance '%v' to get in running state", instanceNamesList[i])
-					if err := gcplib.WaitForVMInstanceUp(computeService, experimentsDetails.Timeout, experimentsDetails.Delay, instanceNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.Zones); err != nil {
+					if err := provider.WaitForInstanceState(cloudProvider, provider.Instance{ID: instanceNamesList[i], Zone: experimentsDetails.Zones}, provider.StateRunning, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 						return stacktrace.Propagate(err, "unable to start the vm instance")
 					}
 
@@ -237,7 +241,7 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 				for i := range instanceNamesList {
 
 					log.Info("[Chaos]: Starting back the VM instance")
-					if err := gcplib.VMInstanceStart(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.Zones); err != nil {
+					if err := cloudProvider.StartInstance(provider.Instance{ID: instanceNamesList[i], Zone: experimentsDetails.Zones}); err != nil {
 						return stacktrace.Propagate(err, "vm instance failed to start")
 					}
 				}
@@ -246,7 +250,7 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 				for i := range instanceNamesList {
 
 					log.Infof("[Wait]: Wait for VM instance '%v' to get in running state", instanceNamesList[i])
-					if err := gcplib.WaitForVMInstanceUp(computeService, experimentsDetails.Timeout, experimentsDetails.Delay, instanceNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.Zones); err != nil {
+					if err := provider.WaitForInstanceState(cloudProvider, provider.Instance{ID: instanceNamesList[i], Zone: experimentsDetails.Zones}, provider.StateRunning, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 						return stacktrace.Propagate(err, "unable to start the vm instance")
 					}
 
@@ -262,25 +266,25 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 }
 
 // abortWatcher watches for the abort signal and reverts the chaos
-func abortWatcher(computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, instanceNamesList []string, chaosDetails *types.ChaosDetails) {
+func abortWatcher(cloudProvider provider.Provider, experimentsDetails *experimentTypes.ExperimentDetails, instanceNamesList []string, chaosDetails *types.ChaosDetails) {
 
 	<-abort
 
 	log.Info("[Abort]: Chaos Revert Started")
 	for i := range instanceNamesList {
-		instanceState, err := gcplib.GetVMInstanceStatus(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.Zones)
+		instanceState, err := cloudProvider.GetInstanceState(provider.Instance{ID: instanceNamesList[i], Zone: experimentsDetails.Zones})
 		if err != nil {
 			log.Errorf("Failed to get %s instance status when an abort signal is received, err: %v", instanceNamesList[i], err)
 		}
-		if instanceState != "RUNNING" && experimentsDetails.ManagedInstanceGroup != "enable" {
+		if instanceState != provider.StateRunning && experimentsDetails.ManagedInstanceGroup != "enable" {
 
 			log.Info("[Abort]: Waiting for the VM instance to shut down")
-			if err := gcplib.WaitForVMInstanceDown(computeService, experimentsDetails.Timeout, experimentsDetails.Delay, instanceNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.Zones); err != nil {
+			if err := provider.WaitForInstanceState(cloudProvider, provider.Instance{ID: instanceNamesList[i], Zone: experimentsDetails.Zones}, provider.StateStopped, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 				log.Errorf("Unable to wait till stop of %s instance, err: %v", instanceNamesList[i], err)
 			}
 
 			log.Info("[Abort]: Starting VM instance as abort signal received")
-			err := gcplib.VMInstanceStart(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.Zones)
+			err := cloudProvider.StartInstance(provider.Instance{ID: instanceNamesList[i], Zone: experimentsDetails.Zones})
 			if err != nil {
 				log.Errorf("%s instance failed to start when an abort signal is received, err: %v", instanceNamesList[i], err)
 			}
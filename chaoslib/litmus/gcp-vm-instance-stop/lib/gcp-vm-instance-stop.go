@@ -11,7 +11,7 @@ import (
 
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
-	gcplib "github.com/litmuschaos/litmus-go/pkg/cloud/gcp"
+	"github.com/litmuschaos/litmus-go/pkg/cloud/provider"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/gcp/gcp-vm-instance-stop/types"
 	"github.com/litmuschaos/litmus-go/pkg/log"
@@ -21,7 +21,6 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/utils/common"
 	"github.com/palantir/stacktrace"
 	"go.opentelemetry.io/otel"
-	"google.golang.org/api/compute/v1"
 )
 
 var (
@@ -30,7 +29,7 @@ var (
 )
 
 // PrepareVMStop contains the prepration and injection steps for the experiment
-func PrepareVMStop(ctx context.Context, computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func PrepareVMStop(ctx context.Context, cloudProvider provider.Provider, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "PrepareVMInstanceStopFault")
 	defer span.End()
 
@@ -56,15 +55,20 @@ func PrepareVMStop(ctx context.Context, computeService *compute.Service, experim
 	// get the zone name or list of corresponding zones for the instances
 	instanceZonesList := strings.Split(experimentsDetails.Zones, ",")
 
-	go abortWatcher(computeService, experimentsDetails, instanceNamesList, instanceZonesList, chaosDetails)
+	if chaosDetails.DryRun {
+		common.PlanTargets("VM", "", instanceNamesList, chaosDetails)
+		return common.StopForDryRun(experimentsDetails, chaosDetails)
+	}
+
+	go abortWatcher(cloudProvider, experimentsDetails, instanceNamesList, instanceZonesList, chaosDetails)
 
 	switch strings.ToLower(experimentsDetails.Sequence) {
 	case "serial":
-		if err = injectChaosInSerialMode(ctx, computeService, experimentsDetails, instanceNamesList, instanceZonesList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+		if err = injectChaosInSerialMode(ctx, cloudProvider, experimentsDetails, instanceNamesList, instanceZonesList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
 			return stacktrace.Propagate(err, "could not run chaos in serial mode")
 		}
 	case "parallel":
-		if err = injectChaosInParallelMode(ctx, computeService, experimentsDetails, instanceNamesList, instanceZonesList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+		if err = injectChaosInParallelMode(ctx, cloudProvider, experimentsDetails, instanceNamesList, instanceZonesList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
 			return stacktrace.Propagate(err, "could not run chaos in parallel mode")
 		}
 	default:
@@ -81,7 +85,7 @@ func PrepareVMStop(ctx context.Context, computeService *compute.Service, experim
 }
 
 // injectChaosInSerialMode stops VM instances in serial mode i.e. one after the other
-func injectChaosInSerialMode(ctx context.Context, computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, instanceNamesList []string, instanceZonesList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func injectChaosInSerialMode(ctx context.Context, cloudProvider provider.Provider, experimentsDetails *experimentTypes.ExperimentDetails, instanceNamesList []string, instanceZonesList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectVMInstanceStopFaultInSerialMode")
 	defer span.End()
 
@@ -109,7 +113,7 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 
 				//Stopping the VM instance
 				log.Infof("[Chaos]: Stopping %s VM instance", instanceNamesList[i])
-				if err := gcplib.VMInstanceStop(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, instanceZonesList[i]); err != nil {
+				if err := cloudProvider.StopInstance(provider.Instance{ID: instanceNamesList[i], Zone: instanceZonesList[i]}); err != nil {
 					return stacktrace.Propagate(err, "vm instance failed to stop")
 				}
 
@@ -117,7 +121,7 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 
 				//Wait for VM instance to completely stop
 				log.Infof("[Wait]: Wait for VM instance %s to get in stopped state", instanceNamesList[i])
-				if err := gcplib.WaitForVMInstanceDown(computeService, experimentsDetails.Timeout, experimentsDetails.Delay, instanceNamesList[i], experimentsDetails.GCPProjectID, instanceZonesList[i]); err != nil {
+				if err := provider.WaitForInstanceState(cloudProvider, provider.Instance{ID: instanceNamesList[i], Zone: instanceZonesList[i]}, provider.StateStopped, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 					return stacktrace.Propagate(err, "vm instance failed to fully shutdown")
 				}
 
@@ -138,13 +142,13 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 
 					// starting the VM instance
 					log.Infof("[Chaos]: Starting back %s VM instance", instanceNamesList[i])
-					if err := gcplib.VMInstanceStart(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, instanceZonesList[i]); err != nil {
+					if err := cloudProvider.StartInstance(provider.Instance{ID: instanceNamesList[i], Zone: instanceZonesList[i]}); err != nil {
 						return stacktrace.Propagate(err, "vm instance failed to start")
 					}
 
 					// wait for VM instance to get in running state
 					log.Infof("[Wait]: Wait for VM instance %s to get in running state", instanceNamesList[i])
-					if err := gcplib.WaitForVMInstanceUp(computeService, experimentsDetails.Timeout, experimentsDetails.Delay, instanceNamesList[i], experimentsDetails.GCPProjectID, instanceZonesList[i]); err != nil {
+					if err := provider.WaitForInstanceState(cloudProvider, provider.Instance{ID: instanceNamesList[i], Zone: instanceZonesList[i]}, provider.StateRunning, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 						return stacktrace.Propagate(err, "unable to start vm instance")
 					}
 
@@ -152,7 +156,7 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 
 					// wait for VM instance to get in running state
 					log.Infof("[Wait]: Wait for VM instance %s to get in running state", instanceNamesList[i])
-					if err := gcplib.WaitForVMInstanceUp(computeService, experimentsDetails.Timeout, experimentsDetails.Delay, instanceNamesList[i], experimentsDetails.GCPProjectID, instanceZonesList[i]); err != nil {
+					if err := provider.WaitForInstanceState(cloudProvider, provider.Instance{ID: instanceNamesList[i], Zone: instanceZonesList[i]}, provider.StateRunning, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 						return stacktrace.Propagate(err, "unable to start vm instance")
 					}
 				}
@@ -168,7 +172,7 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 }
 
 // injectChaosInParallelMode stops VM instances in parallel mode i.e. all at once
-func injectChaosInParallelMode(ctx context.Context, computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, instanceNamesList []string, instanceZonesList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func injectChaosInParallelMode(ctx context.Context, cloudProvider provider.Provider, experimentsDetails *experimentTypes.ExperimentDetails, instanceNamesList []string, instanceZonesList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectVMInstanceStopFaultInParallelMode")
 	defer span.End()
 
@@ -196,7 +200,7 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 
 				// stopping the VM instance
 				log.Infof("[Chaos]: Stopping %s VM instance", instanceNamesList[i])
-				if err := gcplib.VMInstanceStop(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, instanceZonesList[i]); err != nil {
+				if err := cloudProvider.StopInstance(provider.Instance{ID: instanceNamesList[i], Zone: instanceZonesList[i]}); err != nil {
 					return stacktrace.Propagate(err, "vm instance failed to stop")
 				}
 
@@ -207,7 +211,7 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 
 				// wait for VM instance to completely stop
 				log.Infof("[Wait]: Wait for VM instance %s to get in stopped state", instanceNamesList[i])
-				if err := gcplib.WaitForVMInstanceDown(computeService, experimentsDetails.Timeout, experimentsDetails.Delay, instanceNamesList[i], experimentsDetails.GCPProjectID, instanceZonesList[i]); err != nil {
+				if err := provider.WaitForInstanceState(cloudProvider, provider.Instance{ID: instanceNamesList[i], Zone: instanceZonesList[i]}, provider.StateStopped, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 					return stacktrace.Propagate(err, "vm instance failed to fully shutdown")
 				}
 			}
@@ -229,7 +233,7 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 				// starting the VM instance
 				for i := range instanceNamesList {
 					log.Infof("[Chaos]: Starting back %s VM instance", instanceNamesList[i])
-					if err := gcplib.VMInstanceStart(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, instanceZonesList[i]); err != nil {
+					if err := cloudProvider.StartInstance(provider.Instance{ID: instanceNamesList[i], Zone: instanceZonesList[i]}); err != nil {
 						return stacktrace.Propagate(err, "vm instance failed to start")
 					}
 				}
@@ -238,7 +242,7 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 				for i := range instanceNamesList {
 
 					log.Infof("[Wait]: Wait for VM instance %s to get in running state", instanceNamesList[i])
-					if err := gcplib.WaitForVMInstanceUp(computeService, experimentsDetails.Timeout, experimentsDetails.Delay, instanceNamesList[i], experimentsDetails.GCPProjectID, instanceZonesList[i]); err != nil {
+					if err := provider.WaitForInstanceState(cloudProvider, provider.Instance{ID: instanceNamesList[i], Zone: instanceZonesList[i]}, provider.StateRunning, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 						return stacktrace.Propagate(err, "unable to start vm instance")
 					}
 
@@ -251,7 +255,7 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 				for i := range instanceNamesList {
 
 					log.Infof("[Wait]: Wait for VM instance %s to get in running state", instanceNamesList[i])
-					if err := gcplib.WaitForVMInstanceUp(computeService, experimentsDetails.Timeout, experimentsDetails.Delay, instanceNamesList[i], experimentsDetails.GCPProjectID, instanceZonesList[i]); err != nil {
+					if err := provider.WaitForInstanceState(cloudProvider, provider.Instance{ID: instanceNamesList[i], Zone: instanceZonesList[i]}, provider.StateRunning, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 						return stacktrace.Propagate(err, "unable to start vm instance")
 					}
 
@@ -267,7 +271,7 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 }
 
 // abortWatcher watches for the abort signal and reverts the chaos
-func abortWatcher(computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, instanceNamesList []string, zonesList []string, chaosDetails *types.ChaosDetails) {
+func abortWatcher(cloudProvider provider.Provider, experimentsDetails *experimentTypes.ExperimentDetails, instanceNamesList []string, zonesList []string, chaosDetails *types.ChaosDetails) {
 	<-abort
 
 	log.Info("[Abort]: Chaos Revert Started")
@@ -276,20 +280,20 @@ func abortWatcher(computeService *compute.Service, experimentsDetails *experimen
 
 		for i := range instanceNamesList {
 
-			instanceState, err := gcplib.GetVMInstanceStatus(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, zonesList[i])
+			instanceState, err := cloudProvider.GetInstanceState(provider.Instance{ID: instanceNamesList[i], Zone: zonesList[i]})
 			if err != nil {
 				log.Errorf("Failed to get %s vm instance status when an abort signal is received, err: %v", instanceNamesList[i], err)
 			}
 
-			if instanceState != "RUNNING" {
+			if instanceState != provider.StateRunning {
 
 				log.Infof("[Abort]: Waiting for %s VM instance to shut down", instanceNamesList[i])
-				if err := gcplib.WaitForVMInstanceDown(computeService, experimentsDetails.Timeout, experimentsDetails.Delay, instanceNamesList[i], experimentsDetails.GCPProjectID, zonesList[i]); err != nil {
+				if err := provider.WaitForInstanceState(cloudProvider, provider.Instance{ID: instanceNamesList[i], Zone: zonesList[i]}, provider.StateStopped, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 					log.Errorf("Unable to wait till stop of %s instance, err: %v", instanceNamesList[i], err)
 				}
 
 				log.Infof("[Abort]: Starting %s VM instance as abort signal is received", instanceNamesList[i])
-				err := gcplib.VMInstanceStart(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, zonesList[i])
+				err := cloudProvider.StartInstance(provider.Instance{ID: instanceNamesList[i], Zone: zonesList[i]})
 				if err != nil {
 					log.Errorf("%s VM instance failed to start when an abort signal is received, err: %v", instanceNamesList[i], err)
 				}
@@ -0,0 +1,201 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	gcplib "github.com/litmuschaos/litmus-go/pkg/cloud/gcp"
+	"github.com/litmuschaos/litmus-go/pkg/events"
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/gcp/gcp-zone-outage/types"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/probe"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"google.golang.org/api/compute/v1"
+)
+
+var (
+	abort chan os.Signal
+	// the stopped instances, which are started after the chaos duration or when the abort signal is received
+	revertLock       sync.Mutex
+	stoppedInstances []string
+)
+
+// PrepareZoneOutage contains the preparation and injection steps for the experiment
+func PrepareZoneOutage(ctx context.Context, computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "PrepareGCPZoneOutageFault")
+	defer span.End()
+
+	// abort channel is used to transmit signal notifications.
+	abort = make(chan os.Signal, 1)
+	// Catch and relay certain signal(s) to abort channel.
+	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+
+	//Waiting for the ramp time before chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
+		common.WaitForDuration(experimentsDetails.RampTime)
+	}
+
+	log.Infof("[Chaos]: Number of instances targeted in the %v zone: %v", experimentsDetails.Zone, len(experimentsDetails.TargetVMInstanceNameList))
+
+	if chaosDetails.DryRun {
+		common.PlanTargets("VM", experimentsDetails.Zone, experimentsDetails.TargetVMInstanceNameList, chaosDetails)
+		return common.StopForDryRun(experimentsDetails, chaosDetails)
+	}
+
+	// watching for the abort signal and revert the chaos
+	go abortWatcher(computeService, experimentsDetails, chaosDetails)
+
+	// the instances are started after the chaos duration, the partially stopped instances are started as well before the failure is reported
+	err := injectChaos(ctx, computeService, experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails)
+	revertErr := revertChaos(computeService, experimentsDetails, chaosDetails)
+	if err != nil {
+		if revertErr != nil {
+			return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), stacktrace.RootCause(revertErr).Error())}
+		}
+		return stacktrace.Propagate(err, "could not inject the zone outage")
+	}
+	if revertErr != nil {
+		return stacktrace.Propagate(revertErr, "could not revert the zone outage")
+	}
+
+	//Waiting for the ramp time after chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
+		common.WaitForDuration(experimentsDetails.RampTime)
+	}
+	return nil
+}
+
+// injectChaos stops all the target instances of the zone for the chaos duration
+func injectChaos(ctx context.Context, computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectGCPZoneOutageFault")
+	defer span.End()
+
+	if experimentsDetails.EngineName != "" {
+		msg := "Injecting " + experimentsDetails.ExperimentName + " chaos in " + experimentsDetails.Zone + " zone"
+		types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
+		events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
+	}
+
+	for _, name := range experimentsDetails.TargetVMInstanceNameList {
+		log.Infof("[Chaos]: Stopping %s VM instance", name)
+		if err := gcplib.VMInstanceStop(computeService, name, experimentsDetails.GCPProjectID, experimentsDetails.Zone); err != nil {
+			return stacktrace.Propagate(err, "vm instance failed to stop")
+		}
+		revertLock.Lock()
+		stoppedInstances = append(stoppedInstances, name)
+		revertLock.Unlock()
+		common.SetTargets(name, "injected", "VM", chaosDetails)
+	}
+
+	for _, name := range experimentsDetails.TargetVMInstanceNameList {
+		log.Infof("[Wait]: Wait for VM instance %s to get in stopped state", name)
+		if err := gcplib.WaitForVMInstanceDown(computeService, experimentsDetails.Timeout, experimentsDetails.Delay, name, experimentsDetails.GCPProjectID, experimentsDetails.Zone); err != nil {
+			return stacktrace.Propagate(err, "vm instance failed to fully shutdown")
+		}
+	}
+
+	// run the probes during chaos
+	if len(resultDetails.ProbeDetails) != 0 {
+		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+			return stacktrace.Propagate(err, "failed to run probes")
+		}
+	}
+
+	//Wait for chaos duration
+	log.Infof("[Wait]: Waiting for the chaos duration of %vs", experimentsDetails.ChaosDuration)
+	common.WaitForDuration(experimentsDetails.ChaosDuration)
+	return nil
+}
+
+// revertChaos starts the stopped instances, the instances restarted by their managed instance group are only waited for
+// the reverted state is cleared, so that the chaos is reverted only once if the abort signal is received during the revert
+func revertChaos(computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails) error {
+	revertLock.Lock()
+	defer revertLock.Unlock()
+
+	var errs []string
+
+	for len(stoppedInstances) != 0 {
+		name := stoppedInstances[0]
+		if err := startInstance(computeService, experimentsDetails, name); err != nil {
+			errs = append(errs, stacktrace.RootCause(err).Error())
+		} else {
+			common.SetTargets(name, "reverted", "VM", chaosDetails)
+		}
+		stoppedInstances = stoppedInstances[1:]
+	}
+
+	if len(errs) != 0 {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{zone: %s, label: %s}", experimentsDetails.Zone, experimentsDetails.InstanceLabel), Reason: strings.Join(errs, ", ")}
+	}
+	return nil
+}
+
+// startInstance starts the stopped instance and waits for it to get in running state
+func startInstance(computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, name string) error {
+
+	instanceState, err := gcplib.GetVMInstanceStatus(computeService, name, experimentsDetails.GCPProjectID, experimentsDetails.Zone)
+	if err != nil {
+		return err
+	}
+
+	if instanceState != "RUNNING" && instanceState != "PROVISIONING" && instanceState != "STAGING" {
+		log.Infof("[Revert]: Starting back %s VM instance", name)
+		if err := gcplib.WaitForVMInstanceDown(computeService, experimentsDetails.Timeout, experimentsDetails.Delay, name, experimentsDetails.GCPProjectID, experimentsDetails.Zone); err != nil {
+			log.Errorf("Unable to wait till stop of %s instance, err: %v", name, err)
+		}
+		if err := gcplib.VMInstanceStart(computeService, name, experimentsDetails.GCPProjectID, experimentsDetails.Zone); err != nil {
+			return err
+		}
+	}
+
+	log.Infof("[Wait]: Wait for VM instance %s to get in RUNNING state", name)
+	return gcplib.WaitForVMInstanceUp(computeService, experimentsDetails.Timeout, experimentsDetails.Delay, name, experimentsDetails.GCPProjectID, experimentsDetails.Zone)
+}
+
+// SetTargetInstances selects the running instances of the zone, which are filtered by the instance label
+func SetTargetInstances(computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails) error {
+	if experimentsDetails.Zone == "" {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "no zone provided, please provide the target zone in ZONE env"}
+	}
+
+	instanceNames, err := gcplib.GetRunningInstancesByLabel(computeService, experimentsDetails.InstanceLabel, experimentsDetails.GCPProjectID, experimentsDetails.Zone)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to get the instances of the zone")
+	}
+	experimentsDetails.TargetVMInstanceNameList = instanceNames
+
+	log.InfoWithValues("[Info]: Targeting the running instances of the zone", logrus.Fields{
+		"Zone":             experimentsDetails.Zone,
+		"Instance Label":   experimentsDetails.InstanceLabel,
+		"Target Instances": experimentsDetails.TargetVMInstanceNameList,
+	})
+	return nil
+}
+
+// watching for the abort signal and revert the chaos
+func abortWatcher(computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails) {
+
+	<-abort
+
+	log.Info("[Abort]: Chaos Revert Started")
+	if err := revertChaos(computeService, experimentsDetails, chaosDetails); err != nil {
+		log.Errorf("Failed to revert the zone outage when an abort signal is received: %v", err)
+	}
+	log.Info("[Abort]: Chaos Revert Completed")
+	os.Exit(1)
+}
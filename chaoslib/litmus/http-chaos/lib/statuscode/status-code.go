@@ -4,10 +4,8 @@ import (
 	"context"
 	"fmt"
 	"math"
-	"math/rand"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/telemetry"
@@ -19,6 +17,7 @@ import (
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/http-chaos/types"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/random"
 	"github.com/sirupsen/logrus"
 )
 
@@ -65,11 +64,10 @@ func GetStatusCode(statusCode string) (string, error) {
 
 	if statusCode == "" {
 		log.Info("[Info]: No status code provided. Selecting a status code randomly from supported status codes")
-		return acceptedStatusCodes[rand.Intn(len(acceptedStatusCodes))], nil
+		return acceptedStatusCodes[random.Intn(len(acceptedStatusCodes))], nil
 	}
 
 	statusCodeList := strings.Split(statusCode, ",")
-	rand.Seed(time.Now().Unix())
 	if len(statusCodeList) == 1 {
 		if checkStatusCode(statusCodeList[0], acceptedStatusCodes) {
 			return statusCodeList[0], nil
@@ -79,7 +77,7 @@ func GetStatusCode(statusCode string) (string, error) {
 		if len(acceptedCodes) == 0 {
 			return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("invalid status code: %s", statusCode)}
 		}
-		return acceptedCodes[rand.Intn(len(acceptedCodes))], nil
+		return acceptedCodes[random.Intn(len(acceptedCodes))], nil
 	}
 	return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("status code '%s' is not supported. Supported status codes are: %v", statusCode, acceptedStatusCodes)}
 }
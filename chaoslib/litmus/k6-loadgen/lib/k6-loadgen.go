@@ -157,8 +157,7 @@ func createHelperPod(ctx context.Context, experimentsDetails *experimentTypes.Ex
 		},
 	}
 
-	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(context.Background(), helperPod, v1.CreateOptions{})
-	if err != nil {
+	if err := common.CreateHelperPod(experimentsDetails.ChaosNamespace, helperPod, clients, chaosDetails); err != nil {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("unable to create helper pod: %s", err.Error())}
 	}
 	return nil
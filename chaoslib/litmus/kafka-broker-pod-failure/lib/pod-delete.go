@@ -98,6 +98,10 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 			common.SetTargets(target.Name, "targeted", target.Kind, chaosDetails)
 		}
 
+		if chaosDetails.DryRun {
+			return common.StopForDryRun(experimentsDetails, chaosDetails)
+		}
+
 		if experimentsDetails.ChaoslibDetail.EngineName != "" {
 			msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on application pod"
 			types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
@@ -193,6 +197,10 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 			common.SetTargets(target.Name, "targeted", target.Kind, chaosDetails)
 		}
 
+		if chaosDetails.DryRun {
+			return common.StopForDryRun(experimentsDetails, chaosDetails)
+		}
+
 		if experimentsDetails.ChaoslibDetail.EngineName != "" {
 			msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on application pod"
 			types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
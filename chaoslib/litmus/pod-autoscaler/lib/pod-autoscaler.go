@@ -66,6 +66,11 @@ func PreparePodAutoscaler(ctx context.Context, experimentsDetails *experimentTyp
 			"Target Deployments":   deploymentList,
 		})
 
+		if chaosDetails.DryRun {
+			common.PlanTargets("deployment", experimentsDetails.AppNS, deploymentList, chaosDetails)
+			return common.StopForDryRun(experimentsDetails, chaosDetails)
+		}
+
 		//calling go routine which will continuously watch for the abort signal
 		go abortPodAutoScalerChaos(appsUnderTest, experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails)
 
@@ -93,6 +98,11 @@ func PreparePodAutoscaler(ctx context.Context, experimentsDetails *experimentTyp
 			"Target Statefulsets":    stsList,
 		})
 
+		if chaosDetails.DryRun {
+			common.PlanTargets("statefulset", experimentsDetails.AppNS, stsList, chaosDetails)
+			return common.StopForDryRun(experimentsDetails, chaosDetails)
+		}
+
 		//calling go routine which will continuously watch for the abort signal
 		go abortPodAutoScalerChaos(appsUnderTest, experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails)
 
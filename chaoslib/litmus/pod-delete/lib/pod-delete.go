@@ -105,6 +105,10 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 			common.SetTargets(target.Name, "targeted", target.Kind, chaosDetails)
 		}
 
+		if chaosDetails.DryRun {
+			return common.StopForDryRun(experimentsDetails, chaosDetails)
+		}
+
 		if experimentsDetails.EngineName != "" {
 			msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on application pod"
 			types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
@@ -203,6 +207,10 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 			common.SetTargets(target.Name, "targeted", target.Kind, chaosDetails)
 		}
 
+		if chaosDetails.DryRun {
+			return common.StopForDryRun(experimentsDetails, chaosDetails)
+		}
+
 		if experimentsDetails.EngineName != "" {
 			msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on application pod"
 			types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
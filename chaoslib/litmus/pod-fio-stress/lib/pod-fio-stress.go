@@ -127,6 +127,10 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 	for _, pod := range targetPodList.Items {
 
+		if chaosDetails.DryRun {
+			return common.StopForDryRun(experimentsDetails, chaosDetails)
+		}
+
 		if experimentsDetails.EngineName != "" {
 			msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on " + pod.Name + " pod"
 			types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
@@ -211,6 +215,10 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 
 	for _, pod := range targetPodList.Items {
 
+		if chaosDetails.DryRun {
+			return common.StopForDryRun(experimentsDetails, chaosDetails)
+		}
+
 		if experimentsDetails.EngineName != "" {
 			msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on " + pod.Name + " pod"
 			types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
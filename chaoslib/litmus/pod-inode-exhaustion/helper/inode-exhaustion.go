@@ -0,0 +1,351 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
+	"github.com/palantir/stacktrace"
+	"go.opentelemetry.io/otel"
+	"golang.org/x/sys/unix"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/events"
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/pod-inode-exhaustion/types"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/result"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/sirupsen/logrus"
+	clientTypes "k8s.io/apimachinery/pkg/types"
+)
+
+// filesPerDir is the number of files created inside a single directory of the chaos tree
+const filesPerDir = 10000
+
+var inject, abort chan os.Signal
+
+// Helper injects the inode-exhaustion chaos
+func Helper(ctx context.Context, clients clients.ClientSets) {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "SimulateInodeExhaustionFault")
+	defer span.End()
+
+	experimentsDetails := experimentTypes.ExperimentDetails{}
+	eventsDetails := types.EventDetails{}
+	chaosDetails := types.ChaosDetails{}
+	resultDetails := types.ResultDetails{}
+
+	// inject channel is used to transmit signal notifications.
+	inject = make(chan os.Signal, 1)
+	// Catch and relay certain signal(s) to inject channel.
+	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+
+	// abort channel is used to transmit signal notifications.
+	abort = make(chan os.Signal, 1)
+	// Catch and relay certain signal(s) to abort channel.
+	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+
+	//Fetching all the ENV passed in the helper pod
+	log.Info("[PreReq]: Getting the ENV variables")
+	getENV(&experimentsDetails)
+
+	// Intialise the chaos attributes
+	types.InitialiseChaosVariables(&chaosDetails)
+	chaosDetails.Phase = types.ChaosInjectPhase
+
+	// Intialise Chaos Result Parameters
+	types.SetResultAttributes(&resultDetails, chaosDetails)
+
+	// Set the chaos result uid
+	result.SetResultUID(&resultDetails, clients, &chaosDetails)
+
+	if err := inodeExhaustion(&experimentsDetails, clients, &eventsDetails, &chaosDetails, &resultDetails); err != nil {
+		// update failstep inside chaosresult
+		if resultErr := result.UpdateFailedStepFromHelper(&resultDetails, &chaosDetails, clients, err); resultErr != nil {
+			log.Fatalf("helper pod failed, err: %v, resultErr: %v", err, resultErr)
+		}
+		log.Fatalf("helper pod failed, err: %v", err)
+	}
+}
+
+// inodeExhaustion contains steps to inject inode-exhaustion chaos
+func inodeExhaustion(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
+
+	targetList, err := common.ParseTargets(chaosDetails.ChaosPodName)
+	if err != nil {
+		return stacktrace.Propagate(err, "could not parse targets")
+	}
+
+	var targets []*targetDetails
+
+	for _, t := range targetList.Target {
+		td := &targetDetails{
+			Name:            t.Name,
+			Namespace:       t.Namespace,
+			TargetContainer: t.TargetContainer,
+			Source:          chaosDetails.ChaosPodName,
+			Path:            experimentsDetails.VolumeMountPath,
+			TreeName:        "litmus-inode-exhaustion-" + t.Name,
+		}
+
+		// Derive the container id of the target container
+		td.ContainerId, err = common.GetContainerID(td.Namespace, td.Name, td.TargetContainer, clients, chaosDetails.ChaosPodName)
+		if err != nil {
+			return stacktrace.Propagate(err, "could not get container id")
+		}
+
+		// extract out the pid of the target container
+		td.TargetPID, err = common.GetPID(experimentsDetails.ContainerRuntime, td.ContainerId, experimentsDetails.SocketPath, td.Source)
+		if err != nil {
+			return err
+		}
+
+		if err = getInodesToConsume(td, experimentsDetails.FillPercentage); err != nil {
+			return stacktrace.Propagate(err, "could not get inodes to consume")
+		}
+
+		log.InfoWithValues("[Info]: Details of application under chaos injection", logrus.Fields{
+			"PodName":         td.Name,
+			"Namespace":       td.Namespace,
+			"TargetContainer": td.TargetContainer,
+			"Path":            td.Path,
+			"InodesToConsume": td.InodesToConsume,
+		})
+
+		targets = append(targets, td)
+	}
+
+	// record the event inside chaosengine
+	if experimentsDetails.EngineName != "" {
+		msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on application pod"
+		types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
+		events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
+	}
+
+	// watching for the abort signal and revert the chaos
+	go abortWatcher(targets, experimentsDetails, resultDetails.Name)
+
+	select {
+	case <-inject:
+		// stopping the chaos execution, if abort signal received
+		os.Exit(1)
+	default:
+	}
+
+	for _, t := range targets {
+		if t.InodesToConsume <= 0 {
+			log.Warnf("Inode usage is already above the fill percentage for target: {name: %s, namespace: %v}", t.Name, t.Namespace)
+			continue
+		}
+		if err := consumeInodes(t); err != nil {
+			if revertErr := revertTargets(targets); revertErr != nil {
+				return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), revertErr.Error())}
+			}
+			return stacktrace.Propagate(err, "could not consume inodes")
+		}
+		log.Infof("successfully injected chaos on target: {name: %s, namespace: %v, container: %v}", t.Name, t.Namespace, t.TargetContainer)
+		if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "injected", "pod", t.Name); err != nil {
+			if revertErr := revertTargets(targets); revertErr != nil {
+				return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), revertErr.Error())}
+			}
+			return stacktrace.Propagate(err, "could not annotate chaosresult")
+		}
+	}
+
+	log.Infof("[Chaos]: Waiting for %vs", experimentsDetails.ChaosDuration)
+
+	common.WaitForDuration(experimentsDetails.ChaosDuration)
+
+	log.Info("[Chaos]: Stopping the experiment")
+
+	var errList []string
+
+	for _, t := range targets {
+		if err = revertInodeExhaustion(t); err != nil {
+			errList = append(errList, err.Error())
+			continue
+		}
+		if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "reverted", "pod", t.Name); err != nil {
+			errList = append(errList, err.Error())
+		}
+	}
+
+	if len(errList) != 0 {
+		return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s]", strings.Join(errList, ","))}
+	}
+	return nil
+}
+
+// getInodesToConsume derive the number of inodes need to be consumed from the inode stats of the filesystem
+func getInodesToConsume(t *targetDetails, fillPercentage string) error {
+	// keep a handle of the target directory, so that the chaos tree can be removed even if the target container is restarted
+	dir, err := os.Open(fmt.Sprintf("/proc/%v/root%v", t.TargetPID, t.Path))
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeHelper, Source: t.Source, Target: t.target(), Reason: fmt.Sprintf("failed to open the target path: %s", err.Error())}
+	}
+	t.Dir = dir
+
+	var stat unix.Statfs_t
+	if err := unix.Fstatfs(int(dir.Fd()), &stat); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeHelper, Source: t.Source, Target: t.target(), Reason: fmt.Sprintf("failed to get filesystem stats: %s", err.Error())}
+	}
+	if stat.Files == 0 {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeHelper, Source: t.Source, Target: t.target(), Reason: "filesystem of the target path doesn't have a fixed number of inodes"}
+	}
+
+	percentage, err := strconv.Atoi(fillPercentage)
+	if err != nil || percentage <= 0 || percentage > 100 {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeHelper, Source: t.Source, Target: t.target(), Reason: fmt.Sprintf("invalid fill percentage '%s', it should be in the range (0,100]", fillPercentage)}
+	}
+
+	used := int64(stat.Files - stat.Ffree)
+	t.InodesToConsume = int64(stat.Files)*int64(percentage)/100 - used
+
+	log.InfoWithValues("[Info]: Inode details of the target path", logrus.Fields{
+		"Total": stat.Files,
+		"Used":  used,
+		"Free":  stat.Ffree,
+	})
+	return nil
+}
+
+// consumeInodes creates the empty files inside the chaos tree until the required number of inodes are consumed
+// the files are spread across the directories of the tree, as the directories themselves consume the inodes as well
+func consumeInodes(t *targetDetails) error {
+	dirFd := int(t.Dir.Fd())
+	if err := unix.Mkdirat(dirFd, t.TreeName, 0755); err != nil && err != unix.EEXIST {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Source: t.Source, Target: t.target(), Reason: fmt.Sprintf("failed to create the chaos tree: %s", err.Error())}
+	}
+	t.Created = true
+
+	log.Infof("[Chaos]: Creating %v files inside %v", t.InodesToConsume, filepath.Join(t.Path, t.TreeName))
+
+	consumed := int64(1)
+	for index := 0; consumed < t.InodesToConsume; index++ {
+		subDir := filepath.Join(t.TreeName, strconv.Itoa(index))
+		if err := unix.Mkdirat(dirFd, subDir, 0755); err != nil {
+			if err == unix.ENOSPC {
+				break
+			}
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Source: t.Source, Target: t.target(), Reason: fmt.Sprintf("failed to create directory: %s", err.Error())}
+		}
+		consumed++
+
+		for i := 0; i < filesPerDir && consumed < t.InodesToConsume; i++ {
+			fd, err := unix.Openat(dirFd, filepath.Join(subDir, strconv.Itoa(i)), unix.O_CREAT|unix.O_EXCL|unix.O_WRONLY|unix.O_CLOEXEC, 0644)
+			if err != nil {
+				if err == unix.ENOSPC {
+					log.Warnf("No inodes left after consuming %v inodes", consumed)
+					return nil
+				}
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Source: t.Source, Target: t.target(), Reason: fmt.Sprintf("failed to create file: %s", err.Error())}
+			}
+			unix.Close(fd)
+			consumed++
+		}
+	}
+	return nil
+}
+
+// revertTargets deletes the chaos trees of all the targets, when the injection fails
+// the trees of the earlier targets are already created by then
+func revertTargets(targets []*targetDetails) error {
+	var errList []string
+	for _, t := range targets {
+		if err := revertInodeExhaustion(t); err != nil {
+			errList = append(errList, stacktrace.RootCause(err).Error())
+		}
+	}
+	if len(errList) != 0 {
+		return cerrors.PreserveError{ErrString: strings.Join(errList, ",")}
+	}
+	return nil
+}
+
+// revertInodeExhaustion deletes the chaos tree, which was created during chaos execution
+func revertInodeExhaustion(t *targetDetails) error {
+	if t.Dir == nil {
+		return nil
+	}
+	if t.Created {
+		// the tree is removed via the directory handle, as the target container might be restarted in the meantime
+		if err := os.RemoveAll(fmt.Sprintf("/proc/self/fd/%d/%s", t.Dir.Fd(), t.TreeName)); err != nil {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Source: t.Source, Target: t.target(), Reason: fmt.Sprintf("failed to delete the chaos tree: %s", err.Error())}
+		}
+	}
+	t.Dir.Close()
+	t.Dir = nil
+
+	log.Infof("successfully reverted chaos on target: {name: %s, namespace: %v, container: %v}", t.Name, t.Namespace, t.TargetContainer)
+	return nil
+}
+
+// getENV fetches all the env variables from the runner pod
+func getENV(experimentDetails *experimentTypes.ExperimentDetails) {
+	experimentDetails.ExperimentName = types.Getenv("EXPERIMENT_NAME", "")
+	experimentDetails.InstanceID = types.Getenv("INSTANCE_ID", "")
+	experimentDetails.ChaosDuration, _ = strconv.Atoi(types.Getenv("TOTAL_CHAOS_DURATION", "30"))
+	experimentDetails.ChaosNamespace = types.Getenv("CHAOS_NAMESPACE", "litmus")
+	experimentDetails.EngineName = types.Getenv("CHAOSENGINE", "")
+	experimentDetails.ChaosUID = clientTypes.UID(types.Getenv("CHAOS_UID", ""))
+	experimentDetails.ChaosPodName = types.Getenv("POD_NAME", "")
+	experimentDetails.ContainerRuntime = types.Getenv("CONTAINER_RUNTIME", "")
+	experimentDetails.SocketPath = types.Getenv("SOCKET_PATH", "")
+	experimentDetails.VolumeMountPath = types.Getenv("VOLUME_MOUNT_PATH", "/tmp")
+	experimentDetails.FillPercentage = types.Getenv("FILL_PERCENTAGE", "80")
+}
+
+// abortWatcher continuously watch for the abort signals
+func abortWatcher(targets []*targetDetails, experimentsDetails *experimentTypes.ExperimentDetails, resultName string) {
+	// waiting till the abort signal received
+	<-abort
+
+	log.Info("[Chaos]: Killing process started because of terminated signal received")
+	log.Info("Chaos Revert Started")
+	// retry thrice for the chaos revert
+	retry := 3
+	for retry > 0 {
+		for _, t := range targets {
+			if t.Dir == nil {
+				continue
+			}
+			if err := revertInodeExhaustion(t); err != nil {
+				log.Errorf("unable to delete the chaos tree, err :%v", err)
+				continue
+			}
+			if err := result.AnnotateChaosResult(resultName, experimentsDetails.ChaosNamespace, "reverted", "pod", t.Name); err != nil {
+				log.Errorf("unable to annotate the chaosresult, err :%v", err)
+			}
+		}
+		retry--
+		time.Sleep(1 * time.Second)
+	}
+	log.Info("Chaos Revert Completed")
+	os.Exit(1)
+}
+
+type targetDetails struct {
+	Name            string
+	Namespace       string
+	TargetContainer string
+	ContainerId     string
+	TargetPID       int
+	Source          string
+	Path            string
+	TreeName        string
+	InodesToConsume int64
+	Created         bool
+	Dir             *os.File
+}
+
+func (t *targetDetails) target() string {
+	return fmt.Sprintf("{podName: %s, namespace: %s, container: %s}", t.Name, t.Namespace, t.TargetContainer)
+}
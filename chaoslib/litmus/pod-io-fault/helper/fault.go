@@ -0,0 +1,254 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hanwen/go-fuse/v2/fs"
+	"github.com/hanwen/go-fuse/v2/fuse"
+	"golang.org/x/sys/unix"
+
+	"github.com/litmuschaos/litmus-go/pkg/utils/random"
+)
+
+// supportedOperations contains the filesystem operations which can be faulted
+var supportedOperations = []string{"open", "create", "read", "write", "fsync", "flush", "mkdir", "unlink", "rmdir", "rename"}
+
+// faultSpec contains the fault which is injected on the matching filesystem operations
+type faultSpec struct {
+	Operations map[string]bool
+	Errno      syscall.Errno
+	Latency    time.Duration
+	PathGlob   string
+	Percentage int
+}
+
+// parseFaultSpec derive the fault spec from the tunables
+// the errno can be provided either by name (EIO, ENOSPC) or by number, "none" injects the latency only
+func parseFaultSpec(operations, errno, latency, pathGlob, percentage string) (*faultSpec, error) {
+	spec := &faultSpec{
+		Operations: map[string]bool{},
+		PathGlob:   strings.Trim(strings.TrimSpace(pathGlob), "/"),
+	}
+
+	for _, op := range strings.Split(operations, ",") {
+		op = strings.ToLower(strings.TrimSpace(op))
+		switch {
+		case op == "" || op == "all":
+			for _, o := range supportedOperations {
+				spec.Operations[o] = true
+			}
+		case isSupportedOperation(op):
+			spec.Operations[op] = true
+		default:
+			return nil, fmt.Errorf("unsupported operation '%s', supported operations are: %s", op, strings.Join(supportedOperations, ","))
+		}
+	}
+
+	var err error
+	if spec.Errno, err = parseErrno(errno); err != nil {
+		return nil, err
+	}
+
+	if latency != "" {
+		if spec.Latency, err = time.ParseDuration(latency); err != nil {
+			return nil, fmt.Errorf("invalid latency '%s', %s", latency, err.Error())
+		}
+	}
+
+	if spec.Errno == 0 && spec.Latency <= 0 {
+		return nil, fmt.Errorf("either errno or latency should be provided")
+	}
+
+	if spec.PathGlob != "" {
+		if _, err := filepath.Match(spec.PathGlob, ""); err != nil {
+			return nil, fmt.Errorf("invalid path glob '%s', %s", spec.PathGlob, err.Error())
+		}
+	}
+
+	spec.Percentage, err = strconv.Atoi(percentage)
+	if err != nil || spec.Percentage < 0 || spec.Percentage > 100 {
+		return nil, fmt.Errorf("invalid fault percentage '%s', it should be in the range [0,100]", percentage)
+	}
+	return spec, nil
+}
+
+// parseErrno converts the errno name or number into syscall.Errno
+func parseErrno(errno string) (syscall.Errno, error) {
+	errno = strings.TrimSpace(errno)
+	if errno == "" || strings.EqualFold(errno, "none") {
+		return 0, nil
+	}
+	if value, err := strconv.Atoi(errno); err == nil && value > 0 {
+		return syscall.Errno(value), nil
+	}
+	for e := syscall.Errno(1); e < 256; e++ {
+		if unix.ErrnoName(e) == strings.ToUpper(errno) {
+			return e, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported errno '%s'", errno)
+}
+
+func isSupportedOperation(op string) bool {
+	for _, o := range supportedOperations {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// matches checks whether the fault is applicable for the given operation and path
+// the path is relative to the volume mount path, a glob without '/' is matched against the file name
+func (f *faultSpec) matches(op, path string) bool {
+	if !f.Operations[op] {
+		return false
+	}
+	if f.PathGlob == "" {
+		return true
+	}
+	if !strings.Contains(f.PathGlob, "/") {
+		path = filepath.Base(path)
+	}
+	matched, _ := filepath.Match(f.PathGlob, path)
+	return matched
+}
+
+// inject delays and fails the operation, if it is selected by the fault spec
+func (f *faultSpec) inject(ctx context.Context, op, path string) syscall.Errno {
+	if !f.matches(op, path) || random.Intn(100) >= f.Percentage {
+		return 0
+	}
+	if f.Latency > 0 {
+		select {
+		case <-time.After(f.Latency):
+		case <-ctx.Done():
+			return syscall.EINTR
+		}
+	}
+	return f.Errno
+}
+
+// faultNode is a passthrough node of the underlying volume, which injects the faults before delegating the operations
+type faultNode struct {
+	fs.LoopbackNode
+	spec *faultSpec
+}
+
+// newFaultRoot creates the root node of the fault filesystem, serving the contents of the rootPath
+func newFaultRoot(rootPath string, dev uint64, spec *faultSpec) fs.InodeEmbedder {
+	root := &fs.LoopbackRoot{
+		Path: rootPath,
+		Dev:  dev,
+		NewNode: func(rootData *fs.LoopbackRoot, parent *fs.Inode, name string, st *syscall.Stat_t) fs.InodeEmbedder {
+			return &faultNode{LoopbackNode: fs.LoopbackNode{RootData: rootData}, spec: spec}
+		},
+	}
+	return root.NewNode(root, nil, "", nil)
+}
+
+// relPath returns the path of the node (or its child) relative to the volume mount path
+func (n *faultNode) relPath(child string) string {
+	return filepath.Join(n.Path(n.Root()), child)
+}
+
+var (
+	_ = (fs.NodeOpener)((*faultNode)(nil))
+	_ = (fs.NodeCreater)((*faultNode)(nil))
+	_ = (fs.NodeReader)((*faultNode)(nil))
+	_ = (fs.NodeWriter)((*faultNode)(nil))
+	_ = (fs.NodeFsyncer)((*faultNode)(nil))
+	_ = (fs.NodeFlusher)((*faultNode)(nil))
+	_ = (fs.NodeMkdirer)((*faultNode)(nil))
+	_ = (fs.NodeUnlinker)((*faultNode)(nil))
+	_ = (fs.NodeRmdirer)((*faultNode)(nil))
+	_ = (fs.NodeRenamer)((*faultNode)(nil))
+)
+
+func (n *faultNode) Open(ctx context.Context, flags uint32) (fs.FileHandle, uint32, syscall.Errno) {
+	if errno := n.spec.inject(ctx, "open", n.relPath("")); errno != 0 {
+		return nil, 0, errno
+	}
+	return n.LoopbackNode.Open(ctx, flags)
+}
+
+func (n *faultNode) Create(ctx context.Context, name string, flags uint32, mode uint32, out *fuse.EntryOut) (*fs.Inode, fs.FileHandle, uint32, syscall.Errno) {
+	if errno := n.spec.inject(ctx, "create", n.relPath(name)); errno != 0 {
+		return nil, nil, 0, errno
+	}
+	return n.LoopbackNode.Create(ctx, name, flags, mode, out)
+}
+
+func (n *faultNode) Read(ctx context.Context, f fs.FileHandle, dest []byte, off int64) (fuse.ReadResult, syscall.Errno) {
+	if errno := n.spec.inject(ctx, "read", n.relPath("")); errno != 0 {
+		return nil, errno
+	}
+	if r, ok := f.(fs.FileReader); ok {
+		return r.Read(ctx, dest, off)
+	}
+	return nil, syscall.ENOTSUP
+}
+
+func (n *faultNode) Write(ctx context.Context, f fs.FileHandle, data []byte, off int64) (uint32, syscall.Errno) {
+	if errno := n.spec.inject(ctx, "write", n.relPath("")); errno != 0 {
+		return 0, errno
+	}
+	if w, ok := f.(fs.FileWriter); ok {
+		return w.Write(ctx, data, off)
+	}
+	return 0, syscall.ENOTSUP
+}
+
+func (n *faultNode) Fsync(ctx context.Context, f fs.FileHandle, flags uint32) syscall.Errno {
+	if errno := n.spec.inject(ctx, "fsync", n.relPath("")); errno != 0 {
+		return errno
+	}
+	if s, ok := f.(fs.FileFsyncer); ok {
+		return s.Fsync(ctx, flags)
+	}
+	return syscall.ENOTSUP
+}
+
+func (n *faultNode) Flush(ctx context.Context, f fs.FileHandle) syscall.Errno {
+	if errno := n.spec.inject(ctx, "flush", n.relPath("")); errno != 0 {
+		return errno
+	}
+	if fl, ok := f.(fs.FileFlusher); ok {
+		return fl.Flush(ctx)
+	}
+	return 0
+}
+
+func (n *faultNode) Mkdir(ctx context.Context, name string, mode uint32, out *fuse.EntryOut) (*fs.Inode, syscall.Errno) {
+	if errno := n.spec.inject(ctx, "mkdir", n.relPath(name)); errno != 0 {
+		return nil, errno
+	}
+	return n.LoopbackNode.Mkdir(ctx, name, mode, out)
+}
+
+func (n *faultNode) Unlink(ctx context.Context, name string) syscall.Errno {
+	if errno := n.spec.inject(ctx, "unlink", n.relPath(name)); errno != 0 {
+		return errno
+	}
+	return n.LoopbackNode.Unlink(ctx, name)
+}
+
+func (n *faultNode) Rmdir(ctx context.Context, name string) syscall.Errno {
+	if errno := n.spec.inject(ctx, "rmdir", n.relPath(name)); errno != 0 {
+		return errno
+	}
+	return n.LoopbackNode.Rmdir(ctx, name)
+}
+
+func (n *faultNode) Rename(ctx context.Context, name string, newParent fs.InodeEmbedder, newName string, flags uint32) syscall.Errno {
+	if errno := n.spec.inject(ctx, "rename", n.relPath(name)); errno != 0 {
+		return errno
+	}
+	return n.LoopbackNode.Rename(ctx, name, newParent, newName, flags)
+}
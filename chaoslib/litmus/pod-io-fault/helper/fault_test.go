@@ -0,0 +1,176 @@
+package helper
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseErrno(t *testing.T) {
+	tests := []struct {
+		errno   string
+		want    syscall.Errno
+		wantErr bool
+	}{
+		{errno: "", want: 0},
+		{errno: "none", want: 0},
+		{errno: "NONE", want: 0},
+		{errno: "EIO", want: syscall.EIO},
+		{errno: " enospc ", want: syscall.ENOSPC},
+		{errno: "5", want: syscall.EIO},
+		{errno: "0", wantErr: true},
+		{errno: "-5", wantErr: true},
+		{errno: "EFOO", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.errno, func(t *testing.T) {
+			got, err := parseErrno(tt.errno)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseFaultSpec(t *testing.T) {
+	tests := []struct {
+		name                                             string
+		operations, errno, latency, pathGlob, percentage string
+		wantOperations                                   []string
+		wantErrno                                        syscall.Errno
+		wantLatency                                      time.Duration
+		wantPathGlob                                     string
+		wantErr                                          string
+	}{
+		{
+			name:       "all operations",
+			operations: "all", errno: "EIO", percentage: "100",
+			wantOperations: supportedOperations, wantErrno: syscall.EIO,
+		},
+		{
+			name:       "empty operations select all",
+			operations: "", errno: "EIO", percentage: "50",
+			wantOperations: supportedOperations, wantErrno: syscall.EIO,
+		},
+		{
+			name:       "selected operations",
+			operations: " Read, write ", errno: "28", percentage: "0",
+			wantOperations: []string{"read", "write"}, wantErrno: syscall.ENOSPC,
+		},
+		{
+			name:       "latency only",
+			operations: "fsync", errno: "none", latency: "200ms", pathGlob: "/data/*.db/", percentage: "100",
+			wantOperations: []string{"fsync"}, wantLatency: 200 * time.Millisecond, wantPathGlob: "data/*.db",
+		},
+		{
+			name:       "unsupported operation",
+			operations: "read,chmod", errno: "EIO", percentage: "100",
+			wantErr: "unsupported operation 'chmod'",
+		},
+		{
+			name:       "no errno and latency",
+			operations: "read", errno: "none", percentage: "100",
+			wantErr: "either errno or latency should be provided",
+		},
+		{
+			name:       "invalid latency",
+			operations: "read", latency: "10", percentage: "100",
+			wantErr: "invalid latency '10'",
+		},
+		{
+			name:       "invalid glob",
+			operations: "read", errno: "EIO", pathGlob: "[", percentage: "100",
+			wantErr: "invalid path glob '['",
+		},
+		{
+			name:       "percentage above range",
+			operations: "read", errno: "EIO", percentage: "101",
+			wantErr: "invalid fault percentage '101'",
+		},
+		{
+			name:       "percentage below range",
+			operations: "read", errno: "EIO", percentage: "-1",
+			wantErr: "invalid fault percentage '-1'",
+		},
+		{
+			name:       "percentage not a number",
+			operations: "read", errno: "EIO", percentage: "",
+			wantErr: "invalid fault percentage ''",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := parseFaultSpec(tt.operations, tt.errno, tt.latency, tt.pathGlob, tt.percentage)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var operations []string
+			for op := range spec.Operations {
+				operations = append(operations, op)
+			}
+			assert.ElementsMatch(t, tt.wantOperations, operations)
+			assert.Equal(t, tt.wantErrno, spec.Errno)
+			assert.Equal(t, tt.wantLatency, spec.Latency)
+			assert.Equal(t, tt.wantPathGlob, spec.PathGlob)
+		})
+	}
+}
+
+func TestFaultSpecMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		pathGlob string
+		op, path string
+		want     bool
+	}{
+		{name: "no glob", op: "read", path: "data/app.db", want: true},
+		{name: "unselected operation", op: "unlink", path: "data/app.db", want: false},
+		{name: "glob without slash matches the file name", pathGlob: "*.db", op: "read", path: "data/app.db", want: true},
+		{name: "glob without slash doesn't match", pathGlob: "*.log", op: "read", path: "data/app.db", want: false},
+		{name: "glob with slash matches the relative path", pathGlob: "data/*.db", op: "write", path: "data/app.db", want: true},
+		{name: "glob with slash doesn't match a nested path", pathGlob: "data/*.db", op: "write", path: "data/old/app.db", want: false},
+		{name: "glob with slash doesn't match the file name", pathGlob: "data/*.db", op: "write", path: "app.db", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &faultSpec{Operations: map[string]bool{"read": true, "write": true}, PathGlob: tt.pathGlob}
+			assert.Equal(t, tt.want, spec.matches(tt.op, tt.path))
+		})
+	}
+}
+
+func TestMountCommandPassesFuseDevice(t *testing.T) {
+	dir := t.TempDir()
+
+	// the fake nsutil prints the file behind fd 3 and its arguments
+	fakeNsutil := filepath.Join(dir, "nsutil")
+	require.NoError(t, os.WriteFile(fakeNsutil, []byte("#!/bin/sh\nreadlink /proc/self/fd/3\necho \"$@\"\n"), 0755))
+	defer func(old string) { nsutil = old }(nsutil)
+	nsutil = fakeNsutil
+
+	fuseDev, err := os.Create(filepath.Join(dir, "fuse"))
+	require.NoError(t, err)
+	defer fuseDev.Close()
+
+	cmd := mountCommand(42, "/data", syscall.S_IFDIR, fuseDev)
+	assert.Equal(t, fakeNsutil, cmd.Path, "the mount command should not be run through sudo")
+
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, fuseDev.Name(), lines[0], "the fuse device should be fd 3 of the mount command")
+	assert.Equal(t, "-t 42 -m -- mount -i -t fuse -o fd=3,rootmode=40000,user_id=0,group_id=0,allow_other,default_permissions litmus-io-fault /data", lines[1])
+}
@@ -0,0 +1,381 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hanwen/go-fuse/v2/fs"
+	"github.com/hanwen/go-fuse/v2/fuse"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
+	"github.com/palantir/stacktrace"
+	"go.opentelemetry.io/otel"
+	"golang.org/x/sys/unix"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/events"
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/pod-io-fault/types"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/result"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/sirupsen/logrus"
+	clientTypes "k8s.io/apimachinery/pkg/types"
+)
+
+var inject, abort chan os.Signal
+
+// nsutil is the binary used to run the mount commands inside the mount namespace of the target container
+var nsutil = "nsutil"
+
+// Helper injects the io-fault chaos
+func Helper(ctx context.Context, clients clients.ClientSets) {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "SimulateIOFault")
+	defer span.End()
+
+	experimentsDetails := experimentTypes.ExperimentDetails{}
+	eventsDetails := types.EventDetails{}
+	chaosDetails := types.ChaosDetails{}
+	resultDetails := types.ResultDetails{}
+
+	// inject channel is used to transmit signal notifications.
+	inject = make(chan os.Signal, 1)
+	// Catch and relay certain signal(s) to inject channel.
+	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+
+	// abort channel is used to transmit signal notifications.
+	abort = make(chan os.Signal, 1)
+	// Catch and relay certain signal(s) to abort channel.
+	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+
+	//Fetching all the ENV passed in the helper pod
+	log.Info("[PreReq]: Getting the ENV variables")
+	getENV(&experimentsDetails)
+
+	// Intialise the chaos attributes
+	types.InitialiseChaosVariables(&chaosDetails)
+	chaosDetails.Phase = types.ChaosInjectPhase
+
+	// Intialise Chaos Result Parameters
+	types.SetResultAttributes(&resultDetails, chaosDetails)
+
+	// Set the chaos result uid
+	result.SetResultUID(&resultDetails, clients, &chaosDetails)
+
+	if err := ioFault(&experimentsDetails, clients, &eventsDetails, &chaosDetails, &resultDetails); err != nil {
+		// update failstep inside chaosresult
+		if resultErr := result.UpdateFailedStepFromHelper(&resultDetails, &chaosDetails, clients, err); resultErr != nil {
+			log.Fatalf("helper pod failed, err: %v, resultErr: %v", err, resultErr)
+		}
+		log.Fatalf("helper pod failed, err: %v", err)
+	}
+}
+
+// ioFault contains steps to inject io-fault chaos
+func ioFault(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
+
+	spec, err := parseFaultSpec(experimentsDetails.FaultOperations, experimentsDetails.FaultErrno, experimentsDetails.FaultLatency, experimentsDetails.FaultPathGlob, experimentsDetails.FaultPercentage)
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeHelper, Source: chaosDetails.ChaosPodName, Reason: err.Error()}
+	}
+
+	targetList, err := common.ParseTargets(chaosDetails.ChaosPodName)
+	if err != nil {
+		return stacktrace.Propagate(err, "could not parse targets")
+	}
+
+	var targets []*targetDetails
+
+	for _, t := range targetList.Target {
+		td := &targetDetails{
+			Name:            t.Name,
+			Namespace:       t.Namespace,
+			TargetContainer: t.TargetContainer,
+			Source:          chaosDetails.ChaosPodName,
+			MountPath:       experimentsDetails.VolumeMountPath,
+		}
+
+		// Derive the container id of the target container
+		td.ContainerId, err = common.GetContainerID(td.Namespace, td.Name, td.TargetContainer, clients, chaosDetails.ChaosPodName)
+		if err != nil {
+			return stacktrace.Propagate(err, "could not get container id")
+		}
+
+		// extract out the pid of the target container
+		td.TargetPID, err = common.GetPID(experimentsDetails.ContainerRuntime, td.ContainerId, experimentsDetails.SocketPath, td.Source)
+		if err != nil {
+			return err
+		}
+
+		log.InfoWithValues("[Info]: Details of application under chaos injection", logrus.Fields{
+			"PodName":         td.Name,
+			"Namespace":       td.Namespace,
+			"TargetContainer": td.TargetContainer,
+			"MountPath":       td.MountPath,
+		})
+
+		targets = append(targets, td)
+	}
+
+	// record the event inside chaosengine
+	if experimentsDetails.EngineName != "" {
+		msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on application pod"
+		types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
+		events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
+	}
+
+	// watching for the abort signal and revert the chaos
+	go abortWatcher(targets, experimentsDetails, resultDetails.Name)
+
+	select {
+	case <-inject:
+		// stopping the chaos execution, if abort signal received
+		os.Exit(1)
+	default:
+	}
+
+	for _, t := range targets {
+		if err := mountFaultFS(t, spec); err != nil {
+			if revertErr := revertAll(targets); revertErr != nil {
+				return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), stacktrace.RootCause(revertErr).Error())}
+			}
+			return stacktrace.Propagate(err, "could not inject io fault")
+		}
+		log.Infof("successfully injected chaos on target: {name: %s, namespace: %v, container: %v}", t.Name, t.Namespace, t.TargetContainer)
+		if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "injected", "pod", t.Name); err != nil {
+			if revertErr := revertAll(targets); revertErr != nil {
+				return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), stacktrace.RootCause(revertErr).Error())}
+			}
+			return stacktrace.Propagate(err, "could not annotate chaosresult")
+		}
+	}
+
+	log.Infof("[Chaos]: Waiting for %vs", experimentsDetails.ChaosDuration)
+
+	common.WaitForDuration(experimentsDetails.ChaosDuration)
+
+	log.Info("[Chaos]: Stopping the experiment")
+
+	var errList []string
+
+	for _, t := range targets {
+		if err = revertIOFault(t); err != nil {
+			errList = append(errList, err.Error())
+			continue
+		}
+		if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "reverted", "pod", t.Name); err != nil {
+			errList = append(errList, err.Error())
+		}
+	}
+
+	if len(errList) != 0 {
+		return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s]", strings.Join(errList, ","))}
+	}
+	return nil
+}
+
+// mountFaultFS mounts the fault filesystem on top of the volume mount path inside the mount namespace of the target container
+// the fuse device is opened by the helper and handed over to the mount command, so that the helper can serve the filesystem
+// while the mount itself is only visible inside the target container
+func mountFaultFS(t *targetDetails, spec *faultSpec) error {
+	volumePath := fmt.Sprintf("/proc/%v/root%v", t.TargetPID, t.MountPath)
+
+	// keep a handle of the underlying directory, it is used as the backing
+	// store of the fault filesystem once the fault filesystem is mounted on top of it
+	backing, err := os.Open(volumePath)
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Source: t.Source, Target: t.target(), Reason: fmt.Sprintf("failed to open the volume mount path: %s", err.Error())}
+	}
+	var st syscall.Stat_t
+	if err := syscall.Fstat(int(backing.Fd()), &st); err != nil || st.Mode&syscall.S_IFMT != syscall.S_IFDIR {
+		backing.Close()
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Source: t.Source, Target: t.target(), Reason: fmt.Sprintf("%s is not a directory", t.MountPath)}
+	}
+
+	fuseDev, err := os.OpenFile("/dev/fuse", os.O_RDWR, 0)
+	if err != nil {
+		backing.Close()
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Source: t.Source, Target: t.target(), Reason: fmt.Sprintf("failed to open fuse device: %s", err.Error())}
+	}
+	t.Backing, t.FuseDev = backing, fuseDev
+
+	cmd := mountCommand(t.TargetPID, t.MountPath, st.Mode&syscall.S_IFMT, fuseDev)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		log.Error(err.Error())
+		t.close()
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Source: t.Source, Target: t.target(), Reason: fmt.Sprintf("failed to mount fault filesystem: %s", string(out))}
+	}
+	t.Mounted = true
+
+	root := newFaultRoot(fmt.Sprintf("/proc/self/fd/%d", backing.Fd()), uint64(st.Dev), spec)
+	server, err := fs.Mount(fmt.Sprintf("/dev/fd/%d", fuseDev.Fd()), root, &fs.Options{
+		MountOptions: fuse.MountOptions{
+			AllowOther: true,
+			FsName:     "litmus-io-fault",
+			Name:       "fuse",
+		},
+	})
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Source: t.Source, Target: t.target(), Reason: fmt.Sprintf("failed to serve fault filesystem: %s", err.Error())}
+	}
+	t.Server = server
+
+	// derive the fuse connection, it is used to abort the connection during revert
+	if err := syscall.Stat(volumePath, &st); err == nil {
+		t.Connection = unix.Minor(st.Dev)
+	}
+	return nil
+}
+
+// mountCommand returns the command which mounts the fault filesystem inside the mount namespace of the target container
+// the fuse device is passed as the first extra file, i.e, fd 3 of the mount command. The command isn't run through sudo,
+// as sudo closes the inherited descriptors above stderr and the helper is already privileged
+func mountCommand(pid int, mountPath string, rootMode uint32, fuseDev *os.File) *exec.Cmd {
+	options := fmt.Sprintf("fd=3,rootmode=%o,user_id=0,group_id=0,allow_other,default_permissions", rootMode)
+	cmd := exec.Command(nsutil, "-t", strconv.Itoa(pid), "-m", "--", "mount", "-i", "-t", "fuse", "-o", options, "litmus-io-fault", mountPath)
+	cmd.ExtraFiles = []*os.File{fuseDev}
+	return cmd
+}
+
+// revertIOFault unmounts the fault filesystem from the target container and stops serving it
+// it is idempotent and can be called for the targets which are not yet injected
+func revertIOFault(t *targetDetails) error {
+	if !t.Mounted {
+		return nil
+	}
+
+	// the mount namespace is gone along with the target container, nothing to unmount
+	if _, err := os.Stat(fmt.Sprintf("/proc/%v", t.TargetPID)); err == nil {
+		// lazy unmount detach the mount immediately, even if the files are still opened by the application
+		cmd := exec.Command(nsutil, "-t", strconv.Itoa(t.TargetPID), "-m", "--", "umount", "-l", t.MountPath)
+		if out, err := cmd.CombinedOutput(); err != nil && !strings.Contains(string(out), "not mounted") {
+			log.Error(err.Error())
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Source: t.Source, Target: t.target(), Reason: fmt.Sprintf("failed to unmount fault filesystem: %s", string(out))}
+		}
+	}
+
+	// abort the fuse connection, so that the files opened during chaos can't block the helper
+	if t.Connection != 0 {
+		if err := os.WriteFile(fmt.Sprintf("/sys/fs/fuse/connections/%d/abort", t.Connection), []byte("1"), 0200); err != nil && !os.IsNotExist(err) {
+			log.Warnf("unable to abort the fuse connection, err: %v", err)
+		}
+	}
+	if t.Server != nil {
+		done := make(chan struct{})
+		go func() {
+			t.Server.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(10 * time.Second):
+			log.Warnf("fault filesystem is still being served for target: %s", t.target())
+		}
+	}
+	t.close()
+	t.Mounted = false
+
+	log.Infof("successfully reverted chaos on target: {name: %s, namespace: %v, container: %v}", t.Name, t.Namespace, t.TargetContainer)
+	return nil
+}
+
+// revertAll reverts the io fault from all the targets
+func revertAll(targets []*targetDetails) error {
+	var errList []string
+	for _, t := range targets {
+		if err := revertIOFault(t); err != nil {
+			errList = append(errList, err.Error())
+		}
+	}
+	if len(errList) != 0 {
+		return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s]", strings.Join(errList, ","))}
+	}
+	return nil
+}
+
+// getENV fetches all the env variables from the runner pod
+func getENV(experimentDetails *experimentTypes.ExperimentDetails) {
+	experimentDetails.ExperimentName = types.Getenv("EXPERIMENT_NAME", "")
+	experimentDetails.InstanceID = types.Getenv("INSTANCE_ID", "")
+	experimentDetails.ChaosDuration, _ = strconv.Atoi(types.Getenv("TOTAL_CHAOS_DURATION", "30"))
+	experimentDetails.ChaosNamespace = types.Getenv("CHAOS_NAMESPACE", "litmus")
+	experimentDetails.EngineName = types.Getenv("CHAOSENGINE", "")
+	experimentDetails.ChaosUID = clientTypes.UID(types.Getenv("CHAOS_UID", ""))
+	experimentDetails.ChaosPodName = types.Getenv("POD_NAME", "")
+	experimentDetails.ContainerRuntime = types.Getenv("CONTAINER_RUNTIME", "")
+	experimentDetails.SocketPath = types.Getenv("SOCKET_PATH", "")
+	experimentDetails.VolumeMountPath = types.Getenv("VOLUME_MOUNT_PATH", "")
+	experimentDetails.FaultOperations = types.Getenv("FAULT_OPERATIONS", "all")
+	experimentDetails.FaultErrno = types.Getenv("FAULT_ERRNO", "")
+	experimentDetails.FaultLatency = types.Getenv("FAULT_LATENCY", "")
+	experimentDetails.FaultPathGlob = types.Getenv("FAULT_PATH_GLOB", "")
+	experimentDetails.FaultPercentage = types.Getenv("FAULT_PERCENTAGE", "100")
+}
+
+// abortWatcher continuously watch for the abort signals
+func abortWatcher(targets []*targetDetails, experimentsDetails *experimentTypes.ExperimentDetails, resultName string) {
+	// waiting till the abort signal received
+	<-abort
+
+	log.Info("[Chaos]: Killing process started because of terminated signal received")
+	log.Info("Chaos Revert Started")
+	// retry thrice for the chaos revert
+	retry := 3
+	for retry > 0 {
+		for _, t := range targets {
+			if !t.Mounted {
+				continue
+			}
+			if err := revertIOFault(t); err != nil {
+				log.Errorf("unable to unmount the fault filesystem, err :%v", err)
+				continue
+			}
+			if err := result.AnnotateChaosResult(resultName, experimentsDetails.ChaosNamespace, "reverted", "pod", t.Name); err != nil {
+				log.Errorf("unable to annotate the chaosresult, err :%v", err)
+			}
+		}
+		retry--
+		time.Sleep(1 * time.Second)
+	}
+	log.Info("Chaos Revert Completed")
+	os.Exit(1)
+}
+
+type targetDetails struct {
+	Name            string
+	Namespace       string
+	TargetContainer string
+	ContainerId     string
+	TargetPID       int
+	Source          string
+	MountPath       string
+	Mounted         bool
+	Connection      uint32
+	Backing         *os.File
+	FuseDev         *os.File
+	Server          *fuse.Server
+}
+
+func (t *targetDetails) target() string {
+	return fmt.Sprintf("{podName: %s, namespace: %s, container: %s}", t.Name, t.Namespace, t.TargetContainer)
+}
+
+// close releases the handles of the underlying directory and the fuse device
+func (t *targetDetails) close() {
+	if t.Backing != nil {
+		t.Backing.Close()
+		t.Backing = nil
+	}
+	if t.FuseDev != nil {
+		t.FuseDev.Close()
+		t.FuseDev = nil
+	}
+	t.Server = nil
+}
@@ -144,6 +144,10 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 			// creating err channel to receive the error from the go routine
 			stressErr := make(chan error)
 
+			if chaosDetails.DryRun {
+				return common.StopForDryRun(experimentsDetails, chaosDetails)
+			}
+
 			if experimentsDetails.EngineName != "" {
 				msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on " + pod.Name + " pod"
 				types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
@@ -239,6 +243,10 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 	default:
 		for _, pod := range targetPodList.Items {
 
+			if chaosDetails.DryRun {
+				return common.StopForDryRun(experimentsDetails, chaosDetails)
+			}
+
 			if experimentsDetails.EngineName != "" {
 				msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on " + pod.Name + " pod"
 				types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
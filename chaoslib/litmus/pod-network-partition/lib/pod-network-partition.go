@@ -90,6 +90,10 @@ func PrepareAndInjectChaos(ctx context.Context, experimentsDetails *experimentTy
 		"Ports":             np.Ports,
 	})
 
+	if chaosDetails.DryRun {
+		return common.StopForDryRun(experimentsDetails, chaosDetails)
+	}
+
 	// watching for the abort signal and revert the chaos
 	go abortWatcher(experimentsDetails, clients, chaosDetails, resultDetails, &targetPodList, runID)
 
@@ -0,0 +1,136 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	awslib "github.com/litmuschaos/litmus-go/pkg/cloud/aws/rds"
+	"github.com/litmuschaos/litmus-go/pkg/events"
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/kube-aws/rds-cluster-failover/types"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/probe"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/palantir/stacktrace"
+	"go.opentelemetry.io/otel"
+)
+
+// PrepareRDSClusterFailover contains the preparation and injection steps for the experiment
+func PrepareRDSClusterFailover(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "PrepareRDSClusterFailoverFault")
+	defer span.End()
+
+	// Waiting for the ramp time before chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
+		common.WaitForDuration(experimentsDetails.RampTime)
+	}
+
+	oldWriter, err := getClusterWriter(experimentsDetails)
+	if err != nil {
+		return stacktrace.Propagate(err, "could not get the writer of the cluster")
+	}
+	log.Infof("[Info]: The writer of the %v cluster is %v", experimentsDetails.RDSClusterIdentifier, oldWriter)
+
+	if chaosDetails.DryRun {
+		common.PlanTargets("RDS-Cluster", "", []string{experimentsDetails.RDSClusterIdentifier}, chaosDetails)
+		return common.StopForDryRun(experimentsDetails, chaosDetails)
+	}
+
+	if err := injectChaos(ctx, experimentsDetails, oldWriter, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+		return stacktrace.Propagate(err, "could not fail over the cluster")
+	}
+
+	// Waiting for the ramp time after chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
+		common.WaitForDuration(experimentsDetails.RampTime)
+	}
+	return nil
+}
+
+// injectChaos fails over the cluster and waits for the new writer to get available
+func injectChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, oldWriter string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectRDSClusterFailoverFault")
+	defer span.End()
+
+	if experimentsDetails.EngineName != "" {
+		msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on rds cluster"
+		types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
+		events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
+	}
+
+	// ChaosStartTimeStamp contains the start timestamp, when the chaos injection begin
+	ChaosStartTimeStamp := time.Now()
+
+	log.Infof("[Chaos]: Failing over the %v RDS cluster", experimentsDetails.RDSClusterIdentifier)
+	if err := awslib.RDSClusterFailover(experimentsDetails.RDSClusterIdentifier, experimentsDetails.TargetReplicaIdentifier, experimentsDetails.Region); err != nil {
+		return stacktrace.Propagate(err, "rds cluster failed to fail over")
+	}
+	common.SetTargets(experimentsDetails.RDSClusterIdentifier, "injected", "RDS-Cluster", chaosDetails)
+
+	// Run the probes during chaos
+	if len(resultDetails.ProbeDetails) != 0 {
+		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+			return stacktrace.Propagate(err, "failed to run probes")
+		}
+	}
+
+	// Wait for the writer of the cluster to change
+	log.Infof("[Wait]: Wait for RDS cluster '%v' to fail over", experimentsDetails.RDSClusterIdentifier)
+	newWriter, err := awslib.WaitForRDSClusterFailover(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.Region, experimentsDetails.RDSClusterIdentifier, oldWriter)
+	if err != nil {
+		return stacktrace.Propagate(err, "rds cluster failed to fail over")
+	}
+	if experimentsDetails.TargetReplicaIdentifier != "" && newWriter != experimentsDetails.TargetReplicaIdentifier {
+		log.Warnf("[Warning]: The cluster failed over to %v instead of the target replica %v", newWriter, experimentsDetails.TargetReplicaIdentifier)
+	}
+	common.RecordFailover("RDS-Cluster", experimentsDetails.RDSClusterIdentifier, oldWriter, newWriter, ChaosStartTimeStamp, chaosDetails)
+
+	// Wait for the old writer to get available as the reader
+	log.Infof("[Wait]: Wait for RDS cluster '%v' instances to get in available state", experimentsDetails.RDSClusterIdentifier)
+	if err := awslib.WaitForRDSClusterUp(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.Region, experimentsDetails.RDSClusterIdentifier); err != nil {
+		return stacktrace.Propagate(err, "rds cluster failed to get available")
+	}
+	common.SetTargets(experimentsDetails.RDSClusterIdentifier, "reverted", "RDS-Cluster", chaosDetails)
+
+	// Wait for the rest of the chaos duration, so that the probes observe the recovery of the clients
+	if remaining := experimentsDetails.ChaosDuration - int(time.Since(ChaosStartTimeStamp).Seconds()); remaining > 0 {
+		log.Infof("[Wait]: Waiting for the remaining chaos duration of %vs", remaining)
+		common.WaitForDuration(remaining)
+	}
+	return nil
+}
+
+// getClusterWriter returns the writer of the cluster, it validates that the target replica is a reader of the cluster
+func getClusterWriter(experimentsDetails *experimentTypes.ExperimentDetails) (string, error) {
+	if experimentsDetails.RDSClusterIdentifier == "" {
+		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "no RDS cluster identifier found to fail over"}
+	}
+
+	cluster, err := awslib.GetRDSCluster(experimentsDetails.RDSClusterIdentifier, experimentsDetails.Region)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "failed to get the rds cluster")
+	}
+	readers := awslib.GetClusterReaders(cluster)
+	if len(readers) == 0 {
+		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "no reader instance found in the RDS cluster to fail over to", Target: fmt.Sprintf("{RDS Cluster Identifier: %v}", experimentsDetails.RDSClusterIdentifier)}
+	}
+	if experimentsDetails.TargetReplicaIdentifier != "" && !contains(readers, experimentsDetails.TargetReplicaIdentifier) {
+		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "target replica is not a reader instance of the RDS cluster", Target: fmt.Sprintf("{RDS Cluster Identifier: %v, Target Replica: %v}", experimentsDetails.RDSClusterIdentifier, experimentsDetails.TargetReplicaIdentifier)}
+	}
+	return awslib.GetClusterWriter(cluster), nil
+}
+
+func contains(list []string, item string) bool {
+	for _, value := range list {
+		if value == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,225 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	awslib "github.com/litmuschaos/litmus-go/pkg/cloud/aws/rds"
+	"github.com/litmuschaos/litmus-go/pkg/events"
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/kube-aws/rds-instance-reboot/types"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/probe"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/palantir/stacktrace"
+	"go.opentelemetry.io/otel"
+)
+
+// reboot contains the rebooted instance, its availability zone before the reboot and the start time of the reboot
+type reboot struct {
+	identifier string
+	zone       string
+	startedAt  time.Time
+}
+
+// PrepareRDSInstanceReboot contains the preparation and injection steps for the experiment
+func PrepareRDSInstanceReboot(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "PrepareRDSInstanceRebootFault")
+	defer span.End()
+
+	// Waiting for the ramp time before chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
+		common.WaitForDuration(experimentsDetails.RampTime)
+	}
+
+	instanceIdentifierList, err := getTargetInstances(experimentsDetails)
+	if err != nil {
+		return stacktrace.Propagate(err, "could not get the target instances")
+	}
+	log.Infof("[Chaos]:Number of Instance targeted: %v", len(instanceIdentifierList))
+
+	if chaosDetails.DryRun {
+		common.PlanTargets("RDS", "", instanceIdentifierList, chaosDetails)
+		return common.StopForDryRun(experimentsDetails, chaosDetails)
+	}
+
+	switch strings.ToLower(experimentsDetails.Sequence) {
+	case "serial":
+		if err = injectChaosInSerialMode(ctx, experimentsDetails, instanceIdentifierList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+			return stacktrace.Propagate(err, "could not run chaos in serial mode")
+		}
+	case "parallel":
+		if err = injectChaosInParallelMode(ctx, experimentsDetails, instanceIdentifierList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+			return stacktrace.Propagate(err, "could not run chaos in parallel mode")
+		}
+	default:
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: fmt.Sprintf("'%s' sequence is not supported", experimentsDetails.Sequence)}
+	}
+
+	// Waiting for the ramp time after chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
+		common.WaitForDuration(experimentsDetails.RampTime)
+	}
+	return nil
+}
+
+// injectChaosInSerialMode will reboot the rds instances in serial mode that is one after other
+func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, instanceIdentifierList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+
+	// ChaosStartTimeStamp contains the start timestamp, when the chaos injection begin
+	ChaosStartTimeStamp := time.Now()
+
+	if experimentsDetails.EngineName != "" {
+		msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on rds instance"
+		types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
+		events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
+	}
+
+	for i, identifier := range instanceIdentifierList {
+
+		instanceReboot, err := rebootInstance(experimentsDetails, identifier, chaosDetails)
+		if err != nil {
+			return err
+		}
+
+		// Run the probes during chaos
+		// the OnChaos probes execution will start in the first iteration and keep running for the entire chaos duration
+		if len(resultDetails.ProbeDetails) != 0 && i == 0 {
+			if err = probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+				return stacktrace.Propagate(err, "failed to run probes")
+			}
+		}
+
+		if err := waitForRecovery(experimentsDetails, instanceReboot, chaosDetails); err != nil {
+			return err
+		}
+	}
+
+	waitForRemainingDuration(experimentsDetails.ChaosDuration, ChaosStartTimeStamp)
+	return nil
+}
+
+// injectChaosInParallelMode will reboot the rds instances in parallel mode that is all at once
+func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, instanceIdentifierList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+
+	// ChaosStartTimeStamp contains the start timestamp, when the chaos injection begin
+	ChaosStartTimeStamp := time.Now()
+
+	if experimentsDetails.EngineName != "" {
+		msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on rds instance"
+		types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
+		events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
+	}
+
+	var reboots []reboot
+	for _, identifier := range instanceIdentifierList {
+		instanceReboot, err := rebootInstance(experimentsDetails, identifier, chaosDetails)
+		if err != nil {
+			return err
+		}
+		reboots = append(reboots, instanceReboot)
+	}
+
+	// Run the probes during chaos
+	if len(resultDetails.ProbeDetails) != 0 {
+		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+			return stacktrace.Propagate(err, "failed to run probes")
+		}
+	}
+
+	for _, instanceReboot := range reboots {
+		if err := waitForRecovery(experimentsDetails, instanceReboot, chaosDetails); err != nil {
+			return err
+		}
+	}
+
+	waitForRemainingDuration(experimentsDetails.ChaosDuration, ChaosStartTimeStamp)
+	return nil
+}
+
+// rebootInstance reboots the rds instance, it fails over the multi-az instance to its standby if the failover is forced
+func rebootInstance(experimentsDetails *experimentTypes.ExperimentDetails, identifier string, chaosDetails *types.ChaosDetails) (reboot, error) {
+	instance, err := awslib.GetRDSInstance(identifier, experimentsDetails.Region)
+	if err != nil {
+		return reboot{}, stacktrace.Propagate(err, "failed to get the rds instance")
+	}
+	instanceReboot := reboot{identifier: identifier, zone: awssdk.StringValue(instance.AvailabilityZone), startedAt: time.Now()}
+
+	log.Infof("[Chaos]: Rebooting the %v RDS instance", identifier)
+	if err := awslib.RDSInstanceReboot(identifier, experimentsDetails.ForceFailover, experimentsDetails.Region); err != nil {
+		return reboot{}, stacktrace.Propagate(err, "rds instance failed to reboot")
+	}
+	common.SetTargets(identifier, "injected", "RDS", chaosDetails)
+	return instanceReboot, nil
+}
+
+// waitForRecovery waits for the rebooted instance to get in available state and records its recovery
+// the availability zones of the instance before and after the reboot are recorded as the writers of the multi-az instance
+func waitForRecovery(experimentsDetails *experimentTypes.ExperimentDetails, instanceReboot reboot, chaosDetails *types.ChaosDetails) error {
+	log.Infof("[Wait]: Wait for RDS instance '%v' to get in available state", instanceReboot.identifier)
+	if err := awslib.WaitForRDSInstanceUp(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.Region, instanceReboot.identifier); err != nil {
+		return stacktrace.Propagate(err, "rds instance failed to get available")
+	}
+
+	var oldWriter, newWriter string
+	if experimentsDetails.ForceFailover {
+		instance, err := awslib.GetRDSInstance(instanceReboot.identifier, experimentsDetails.Region)
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to get the rds instance")
+		}
+		oldWriter, newWriter = instanceReboot.zone, awssdk.StringValue(instance.AvailabilityZone)
+	}
+	common.RecordFailover("RDS", instanceReboot.identifier, oldWriter, newWriter, instanceReboot.startedAt, chaosDetails)
+	common.SetTargets(instanceReboot.identifier, "reverted", "RDS", chaosDetails)
+	return nil
+}
+
+// waitForRemainingDuration waits for the rest of the chaos duration, so that the probes observe the recovery of the clients
+func waitForRemainingDuration(chaosDuration int, chaosStartTimeStamp time.Time) {
+	if remaining := chaosDuration - int(time.Since(chaosStartTimeStamp).Seconds()); remaining > 0 {
+		log.Infof("[Wait]: Waiting for the remaining chaos duration of %vs", remaining)
+		common.WaitForDuration(remaining)
+	}
+}
+
+// getTargetInstances returns the target instances, which are either the given instances or the reader instances of the given aurora cluster
+func getTargetInstances(experimentsDetails *experimentTypes.ExperimentDetails) ([]string, error) {
+	var instanceIdentifierList []string
+	switch {
+	case experimentsDetails.RDSInstanceIdentifier != "":
+		for _, identifier := range strings.Split(experimentsDetails.RDSInstanceIdentifier, ",") {
+			if identifier = strings.TrimSpace(identifier); identifier != "" {
+				instanceIdentifierList = append(instanceIdentifierList, identifier)
+			}
+		}
+	case experimentsDetails.RDSClusterIdentifier != "":
+		cluster, err := awslib.GetRDSCluster(experimentsDetails.RDSClusterIdentifier, experimentsDetails.Region)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "failed to get the rds cluster")
+		}
+		instanceIdentifierList = awslib.GetClusterReaders(cluster)
+		if len(instanceIdentifierList) == 0 {
+			return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "no reader instance found in the RDS cluster", Target: fmt.Sprintf("{RDS Cluster Identifier: %v}", experimentsDetails.RDSClusterIdentifier)}
+		}
+	}
+	if len(instanceIdentifierList) == 0 {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "no RDS instance identifier or RDS cluster identifier found to reboot"}
+	}
+	return common.FilterBasedOnPercentage(experimentsDetails.InstanceAffectedPerc, instanceIdentifierList), nil
+}
+
+// StatusCheck verifies that the given instances or all the instances of the given cluster are in available state
+func StatusCheck(experimentsDetails *experimentTypes.ExperimentDetails) error {
+	if experimentsDetails.RDSInstanceIdentifier != "" {
+		return awslib.InstanceStatusCheckByInstanceIdentifier(experimentsDetails.RDSInstanceIdentifier, experimentsDetails.Region)
+	}
+	return awslib.ClusterStatusCheck(experimentsDetails.RDSClusterIdentifier, experimentsDetails.Region)
+}
@@ -58,6 +58,11 @@ func PrepareRDSInstanceStop(ctx context.Context, experimentsDetails *experimentT
 	instanceIdentifierList = common.FilterBasedOnPercentage(experimentsDetails.InstanceAffectedPerc, instanceIdentifierList)
 	log.Infof("[Chaos]:Number of Instance targeted: %v", len(instanceIdentifierList))
 
+	if chaosDetails.DryRun {
+		common.PlanTargets("RDS", "", instanceIdentifierList, chaosDetails)
+		return common.StopForDryRun(experimentsDetails, chaosDetails)
+	}
+
 	// Watching for the abort signal and revert the chaos
 	go abortWatcher(experimentsDetails, instanceIdentifierList, chaosDetails)
 
@@ -3,10 +3,14 @@ package lib
 import (
 	"context"
 	"fmt"
-	"time"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 
 	redfishLib "github.com/litmuschaos/litmus-go/pkg/baremetal/redfish"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/baremetal/redfish-node-restart/types"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	"github.com/litmuschaos/litmus-go/pkg/log"
@@ -18,24 +22,70 @@ import (
 	"go.opentelemetry.io/otel"
 )
 
-// injectChaos initiates node restart chaos on the target node
-func injectChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets) error {
+var (
+	abort chan os.Signal
+	// nodePoweredOff is set once the node is powered off by the reset, the node is powered on after the chaos duration or when the abort signal is received
+	revertLock     sync.Mutex
+	nodePoweredOff bool
+)
+
+// resetTypes are the supported reset types, mapped to whether they power off the node
+var resetTypes = map[string]bool{
+	redfishLib.ResetForceRestart:     false,
+	redfishLib.ResetPowerCycle:       false,
+	redfishLib.ResetNmi:              false,
+	redfishLib.ResetGracefulShutdown: true,
+	redfishLib.ResetForceOff:         true,
+}
+
+// injectChaos triggers the reset of the target node, it waits for the node to power off if the reset powers off the node
+func injectChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, client *redfishLib.Client, clients clients.ClientSets) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectRedfishNodeRestartFault")
 	defer span.End()
 
-	URL := fmt.Sprintf("https://%v/redfish/v1/Systems/System.Embedded.1/Actions/ComputerSystem.Reset", experimentsDetails.IPMIIP)
-	return redfishLib.RebootNode(URL, experimentsDetails.User, experimentsDetails.Password)
+	if !resetTypes[experimentsDetails.ResetType] {
+		return client.Reset(experimentsDetails.SystemID, experimentsDetails.ResetType)
+	}
+
+	// the node is marked as powered off before the request, so that it is powered on even if the reset request times out
+	revertLock.Lock()
+	nodePoweredOff = true
+	revertLock.Unlock()
+
+	if err := client.Reset(experimentsDetails.SystemID, experimentsDetails.ResetType); err != nil {
+		return err
+	}
+
+	log.Infof("[Wait]: Wait for the node to get in %v state", redfishLib.PowerStateOff)
+	return redfishLib.WaitForPowerState(experimentsDetails.Timeout, experimentsDetails.Delay, client, experimentsDetails.SystemID, redfishLib.PowerStateOff)
 }
 
-// experimentExecution function orchestrates the experiment by calling the injectChaos function
-func experimentExecution(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+// revertChaos powers on the node, if it was powered off by the reset
+// the powered off state is cleared, so that the node is powered on only once if the abort signal is received during the revert
+func revertChaos(experimentsDetails *experimentTypes.ExperimentDetails, client *redfishLib.Client, chaosDetails *types.ChaosDetails) error {
+	revertLock.Lock()
+	defer revertLock.Unlock()
 
-	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
-		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
-			return err
-		}
+	if !nodePoweredOff {
+		return nil
+	}
+
+	log.Info("[Revert]: Powering on the node")
+	if err := client.Reset(experimentsDetails.SystemID, redfishLib.ResetOn); err != nil {
+		return err
+	}
+
+	log.Infof("[Wait]: Wait for the node to get in %v state", redfishLib.PowerStateOn)
+	if err := redfishLib.WaitForPowerState(experimentsDetails.Timeout, experimentsDetails.Delay, client, experimentsDetails.SystemID, redfishLib.PowerStateOn); err != nil {
+		return err
 	}
+	nodePoweredOff = false
+	common.SetTargets(experimentsDetails.IPMIIP, "reverted", "node", chaosDetails)
+	return nil
+}
+
+// experimentExecution function orchestrates the experiment by calling the injectChaos function
+func experimentExecution(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, client *redfishLib.Client, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
 	if experimentsDetails.EngineName != "" {
 		msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on " + experimentsDetails.IPMIIP + " node"
@@ -43,30 +93,65 @@ func experimentExecution(ctx context.Context, experimentsDetails *experimentType
 		events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
 	}
 
-	if err := injectChaos(ctx, experimentsDetails, clients); err != nil {
+	if err := injectChaos(ctx, experimentsDetails, client, clients); err != nil {
 		return stacktrace.Propagate(err, "chaos injection failed")
 	}
+	common.SetTargets(experimentsDetails.IPMIIP, "injected", "node", chaosDetails)
+
+	// run the probes during chaos
+	if len(resultDetails.ProbeDetails) != 0 {
+		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+			return err
+		}
+	}
 
 	log.Infof("[Chaos]: Waiting for: %vs", experimentsDetails.ChaosDuration)
-	time.Sleep(time.Duration(experimentsDetails.ChaosDuration) * time.Second)
+	common.WaitForDuration(experimentsDetails.ChaosDuration)
 	return nil
 }
 
 // PrepareChaos contains the chaos prepration and injection steps
-func PrepareChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func PrepareChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, client *redfishLib.Client, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "PrepareRedfishNodeRestartFault")
 	defer span.End()
 
+	// abort channel is used to transmit signal notifications.
+	abort = make(chan os.Signal, 1)
+	// Catch and relay certain signal(s) to abort channel.
+	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+
+	if _, ok := resetTypes[experimentsDetails.ResetType]; !ok {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("'%s' reset type is not supported, the supported reset types are ForceRestart, GracefulShutdown, ForceOff, PowerCycle and Nmi", experimentsDetails.ResetType)}
+	}
+
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
 		common.WaitForDuration(experimentsDetails.RampTime)
 	}
+
+	if chaosDetails.DryRun {
+		common.PlanTargets("node", "", []string{experimentsDetails.IPMIIP}, chaosDetails)
+		return common.StopForDryRun(experimentsDetails, chaosDetails)
+	}
+
+	// watching for the abort signal and revert the chaos
+	go abortWatcher(experimentsDetails, client, chaosDetails)
+
 	//Starting the Redfish node restart experiment
-	if err := experimentExecution(ctx, experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+	// the node is powered on after the chaos duration, it is powered on as well before the failure is reported
+	err := experimentExecution(ctx, experimentsDetails, client, clients, resultDetails, eventsDetails, chaosDetails)
+	revertErr := revertChaos(experimentsDetails, client, chaosDetails)
+	if err != nil {
+		if revertErr != nil {
+			return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), stacktrace.RootCause(revertErr).Error())}
+		}
 		return err
 	}
-	common.SetTargets(experimentsDetails.IPMIIP, "targeted", "node", chaosDetails)
+	if revertErr != nil {
+		return stacktrace.Propagate(revertErr, "could not power on the node")
+	}
+
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
@@ -74,3 +159,16 @@ func PrepareChaos(ctx context.Context, experimentsDetails *experimentTypes.Exper
 	}
 	return nil
 }
+
+// watching for the abort signal and revert the chaos
+func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, client *redfishLib.Client, chaosDetails *types.ChaosDetails) {
+
+	<-abort
+
+	log.Info("[Abort]: Chaos Revert Started")
+	if err := revertChaos(experimentsDetails, client, chaosDetails); err != nil {
+		log.Errorf("Failed to power on the node when an abort signal is received: %v", err)
+	}
+	log.Info("[Abort]: Chaos Revert Completed")
+	os.Exit(1)
+}
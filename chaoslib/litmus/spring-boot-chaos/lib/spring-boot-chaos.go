@@ -243,6 +243,10 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 		os.Exit(0)
 	default:
 		for _, pod := range experimentsDetails.TargetPodList.Items {
+			if chaosDetails.DryRun {
+				return common.StopForDryRun(experimentsDetails, chaosDetails)
+			}
+
 			if experimentsDetails.EngineName != "" {
 				msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on " + pod.Name + " pod"
 				types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
@@ -333,6 +337,10 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 		os.Exit(0)
 	default:
 		for _, pod := range experimentsDetails.TargetPodList.Items {
+			if chaosDetails.DryRun {
+				return common.StopForDryRun(experimentsDetails, chaosDetails)
+			}
+
 			if experimentsDetails.EngineName != "" {
 				msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on " + pod.Name + " pod"
 				types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
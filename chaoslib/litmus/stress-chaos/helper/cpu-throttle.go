@@ -0,0 +1,315 @@
+package helper
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/containerd/cgroups"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	clients "github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/events"
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/stress-chaos/types"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/result"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+)
+
+const (
+	// cgroupRoot is the mount path of the cgroup filesystem inside the helper pod
+	cgroupRoot = "/sys/fs/cgroup"
+	// minCPUQuota is the minimum cfs quota (in microseconds) accepted by the kernel
+	minCPUQuota = 1000
+)
+
+// cpuQuota contains the original cfs quota and the throttling stats of a target container
+type cpuQuota struct {
+	Container          string
+	Path               string
+	Original           string
+	NrThrottled        uint64
+	ThrottledUsec      uint64
+	NrThrottledDelta   uint64
+	ThrottledUsecDelta uint64
+}
+
+// prepareCPUThrottle contains the chaos preparation and injection steps for the cpu throttle chaos
+func prepareCPUThrottle(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
+	quotaPercentage, err := strconv.Atoi(experimentsDetails.CPUQuotaPercentage)
+	if err != nil || quotaPercentage < 1 || quotaPercentage > 99 {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeHelper, Source: chaosDetails.ChaosPodName, Reason: fmt.Sprintf("invalid CPU_QUOTA_PERCENTAGE: '%s', it should be in the range of 1-99", experimentsDetails.CPUQuotaPercentage)}
+	}
+
+	targets, err := getTargets(experimentsDetails, clients, chaosDetails)
+	if err != nil {
+		return err
+	}
+
+	// watching for the abort signal and revert the chaos if an abort signal is received
+	go abortWatcher(targets, resultDetails.Name, chaosDetails.ChaosNamespace, revertCPUThrottle)
+
+	select {
+	case <-inject:
+		// stopping the chaos execution, if abort signal received
+		os.Exit(1)
+	default:
+	}
+
+	for index, t := range targets {
+		for i := range t.Pids {
+			if err := throttleCPU(t, i, quotaPercentage); err != nil {
+				if revertErr := revertChaosForAllTargets(targets, resultDetails, chaosDetails.ChaosNamespace, index, revertCPUThrottle); revertErr != nil {
+					return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), stacktrace.RootCause(revertErr).Error())}
+				}
+				return stacktrace.Propagate(err, "could not inject chaos")
+			}
+			log.Infof("successfully injected chaos on target: {name: %s, namespace: %v, container: %v}", t.Name, t.Namespace, t.TargetContainers[i])
+		}
+
+		if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "injected", "pod", t.Name); err != nil {
+			if revertErr := revertChaosForAllTargets(targets, resultDetails, chaosDetails.ChaosNamespace, index, revertCPUThrottle); revertErr != nil {
+				return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), stacktrace.RootCause(revertErr).Error())}
+			}
+			return stacktrace.Propagate(err, "could not annotate chaosresult")
+		}
+	}
+
+	// record the event inside chaosengine
+	if experimentsDetails.EngineName != "" {
+		msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on application pod"
+		types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
+		events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
+	}
+
+	log.Infof("[Chaos]: Waiting for %vs", experimentsDetails.ChaosDuration)
+	common.WaitForDuration(experimentsDetails.ChaosDuration)
+
+	log.Info("[Info]: Reverting Chaos")
+	if err := revertChaosForAllTargets(targets, resultDetails, chaosDetails.ChaosNamespace, len(targets)-1, revertCPUThrottle); err != nil {
+		return stacktrace.Propagate(err, "could not revert chaos")
+	}
+
+	recordThrottlingStats(targets, resultDetails.Name, chaosDetails.ChaosNamespace)
+	return nil
+}
+
+// throttleCPU reduces the cfs quota of the target container to the given percentage of its current quota
+func throttleCPU(t *targetDetails, index, quotaPercentage int) error {
+	target := fmt.Sprintf("{podName: %s, namespace: %s, container: %s}", t.Name, t.Namespace, t.TargetContainers[index])
+
+	path, err := getCgroupPath(t, index, cgroups.Cpu)
+	if err != nil {
+		return stacktrace.Propagate(err, "could not get cpu cgroup path")
+	}
+
+	quota, period, original, err := readCPUQuota(path)
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Source: t.Source, Target: target, Reason: fmt.Sprintf("failed to read the cpu quota: %s", err.Error())}
+	}
+	if quota < 0 {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Source: t.Source, Target: target, Reason: "target container doesn't have a cpu limit, cpu quota is unlimited"}
+	}
+
+	nrThrottled, throttledUsec, err := readThrottlingStats(path)
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Source: t.Source, Target: target, Reason: fmt.Sprintf("failed to read the cpu stats: %s", err.Error())}
+	}
+
+	throttledQuota := quota * int64(quotaPercentage) / 100
+	if throttledQuota < minCPUQuota {
+		throttledQuota = minCPUQuota
+	}
+
+	log.InfoWithValues("[Info]: Details of cpu quota:", logrus.Fields{
+		"Container":      t.TargetContainers[index],
+		"Period":         period,
+		"Quota":          quota,
+		"ThrottledQuota": throttledQuota,
+	})
+
+	if err := writeCPUQuota(path, throttledQuota, period); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Source: t.Source, Target: target, Reason: fmt.Sprintf("failed to update the cpu quota: %s", err.Error())}
+	}
+
+	t.CPUQuotas = append(t.CPUQuotas, &cpuQuota{
+		Container:     t.TargetContainers[index],
+		Path:          path,
+		Original:      original,
+		NrThrottled:   nrThrottled,
+		ThrottledUsec: throttledUsec,
+	})
+	return nil
+}
+
+// revertCPUThrottle restores the original cfs quota of all the throttled containers of the target
+func revertCPUThrottle(t *targetDetails) error {
+	var errList []string
+	for _, q := range t.CPUQuotas {
+		// the cgroup is removed if the container got restarted, nothing left to restore in that case
+		if _, err := os.Stat(q.Path); os.IsNotExist(err) {
+			log.Warnf("cgroup of target container is not present, skipping the revert for target: {name: %s, namespace: %v, container: %v}", t.Name, t.Namespace, q.Container)
+			continue
+		}
+
+		if nrThrottled, throttledUsec, err := readThrottlingStats(q.Path); err == nil {
+			q.NrThrottledDelta = nrThrottled - q.NrThrottled
+			q.ThrottledUsecDelta = throttledUsec - q.ThrottledUsec
+		}
+
+		if err := writeCgroupFile(q.Path, getCPUQuotaFile(), q.Original); err != nil {
+			errList = append(errList, cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Source: t.Source, Target: fmt.Sprintf("{podName: %s, namespace: %s, container: %s}", t.Name, t.Namespace, q.Container), Reason: fmt.Sprintf("failed to restore the cpu quota: %s", err.Error())}.Error())
+			continue
+		}
+		log.Infof("successfully reverted chaos on target: {name: %s, namespace: %v, container: %v}", t.Name, t.Namespace, q.Container)
+	}
+	if len(errList) != 0 {
+		return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s]", strings.Join(errList, ","))}
+	}
+	return nil
+}
+
+// recordThrottlingStats records the throttling observed during the chaos duration inside the chaosresult annotations
+func recordThrottlingStats(targets []*targetDetails, resultName, chaosNS string) {
+	for _, t := range targets {
+		var stats []string
+		for _, q := range t.CPUQuotas {
+			log.InfoWithValues("[Info]: Throttling observed during chaos:", logrus.Fields{
+				"PodName":       t.Name,
+				"Container":     q.Container,
+				"NrThrottled":   q.NrThrottledDelta,
+				"ThrottledUsec": q.ThrottledUsecDelta,
+			})
+			stats = append(stats, fmt.Sprintf("%s:nrThrottled=%d,throttledUsec=%d", q.Container, q.NrThrottledDelta, q.ThrottledUsecDelta))
+		}
+		if len(stats) == 0 {
+			continue
+		}
+		if err := result.AnnotateChaosResult(resultName, chaosNS, strings.Join(stats, ";"), "cpu-throttle", t.Name); err != nil {
+			log.Errorf("unable to record the throttling stats for %v pod, err: %v", t.Name, err)
+		}
+	}
+}
+
+// getCgroupPath returns the cgroup directory of the target container for the given subsystem
+// for cgroup v2 all the subsystems share the same directory
+func getCgroupPath(t *targetDetails, index int, subsystem cgroups.Name) (string, error) {
+	if cgroups.Mode() == cgroups.Unified {
+		_, err, groupPath := getCGroupManager(t, index)
+		if err != nil {
+			return "", stacktrace.Propagate(err, "could not get cgroup manager")
+		}
+		return filepath.Join(cgroupRoot, strings.TrimSpace(groupPath)), nil
+	}
+	path, err := pidPath(t, index)(subsystem)
+	if err != nil {
+		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeHelper, Source: t.Source, Target: fmt.Sprintf("{podName: %s, namespace: %s, container: %s}", t.Name, t.Namespace, t.TargetContainers[index]), Reason: fmt.Sprintf("fail to get the %s cgroup: %s", subsystem, err.Error())}
+	}
+	return filepath.Join(cgroupRoot, string(subsystem), path), nil
+}
+
+// getCPUQuotaFile returns the name of the file containing the cfs quota
+func getCPUQuotaFile() string {
+	if cgroups.Mode() == cgroups.Unified {
+		return "cpu.max"
+	}
+	return "cpu.cfs_quota_us"
+}
+
+// readCPUQuota reads the cfs quota and period of the given cgroup, quota is -1 if it is unlimited
+// it also returns the raw content of the quota file, which is used to restore the quota
+func readCPUQuota(path string) (int64, int64, string, error) {
+	content, err := os.ReadFile(filepath.Join(path, getCPUQuotaFile()))
+	if err != nil {
+		return 0, 0, "", err
+	}
+	original := strings.TrimSpace(string(content))
+
+	if cgroups.Mode() == cgroups.Unified {
+		// cpu.max contains the quota and period in "$MAX $PERIOD" format
+		fields := strings.Fields(original)
+		if len(fields) != 2 {
+			return 0, 0, "", fmt.Errorf("invalid cpu.max entry: %q", original)
+		}
+		period, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return 0, 0, "", err
+		}
+		if fields[0] == "max" {
+			return -1, period, original, nil
+		}
+		quota, err := strconv.ParseInt(fields[0], 10, 64)
+		return quota, period, original, err
+	}
+
+	quota, err := strconv.ParseInt(original, 10, 64)
+	if err != nil {
+		return 0, 0, "", err
+	}
+	content, err = os.ReadFile(filepath.Join(path, "cpu.cfs_period_us"))
+	if err != nil {
+		return 0, 0, "", err
+	}
+	period, err := strconv.ParseInt(strings.TrimSpace(string(content)), 10, 64)
+	return quota, period, original, err
+}
+
+// writeCPUQuota updates the cfs quota of the given cgroup
+func writeCPUQuota(path string, quota, period int64) error {
+	if cgroups.Mode() == cgroups.Unified {
+		return writeCgroupFile(path, getCPUQuotaFile(), fmt.Sprintf("%d %d", quota, period))
+	}
+	return writeCgroupFile(path, getCPUQuotaFile(), strconv.FormatInt(quota, 10))
+}
+
+// readThrottlingStats reads the number of throttled periods and the total throttled time (in microseconds) of the given cgroup
+func readThrottlingStats(path string) (uint64, uint64, error) {
+	file, err := os.Open(filepath.Join(path, "cpu.stat"))
+	if err != nil {
+		return 0, 0, err
+	}
+	defer file.Close()
+
+	var nrThrottled, throttledUsec uint64
+	s := bufio.NewScanner(file)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		switch fields[0] {
+		case "nr_throttled":
+			nrThrottled = value
+		case "throttled_usec":
+			throttledUsec = value
+		case "throttled_time":
+			// cgroup v1 reports the throttled time in nanoseconds
+			throttledUsec = value / 1000
+		}
+	}
+	return nrThrottled, throttledUsec, s.Err()
+}
+
+// writeCgroupFile writes the value inside the given cgroup file
+// for cgroup v2 it enters the cgroup namespace of the host, same as while adding the process to the cgroup
+func writeCgroupFile(path, file, value string) error {
+	if cgroups.Mode() == cgroups.Unified {
+		args := []string{"-t", "1", "-C", "--", "sudo", "sh", "-c", fmt.Sprintf("echo '%s' > %s", value, filepath.Join(path, file))}
+		if output, err := exec.Command("nsenter", args...).CombinedOutput(); err != nil {
+			return fmt.Errorf("%s: %v", string(output), err)
+		}
+		return nil
+	}
+	return os.WriteFile(filepath.Join(path, file), []byte(value), 0644)
+}
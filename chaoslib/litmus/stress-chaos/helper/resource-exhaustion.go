@@ -0,0 +1,195 @@
+package helper
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/containerd/cgroups"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/stress-chaos/types"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+)
+
+// prepareExhaustionStressor derive the stressor for the fd and pid exhaustion chaos of the given target container
+// the pid exhaustion stressor is a shell which holds the idle processes for the chaos duration, it runs inside the pid
+// namespace and cgroup of the target container and everything is released once the process group is killed
+// RLIMIT_NOFILE is a per process limit, so the file descriptors can't be exhausted from another process. The fd exhaustion
+// lowers the open files limit of the target processes instead, and the stressor only holds the chaos duration
+func prepareExhaustionStressor(experimentsDetails *experimentTypes.ExperimentDetails, t *targetDetails, index int) (string, error) {
+	target := fmt.Sprintf("{podName: %s, namespace: %s, container: %s}", t.Name, t.Namespace, t.TargetContainers[index])
+
+	percentage, err := strconv.Atoi(experimentsDetails.ExhaustionPercentage)
+	if err != nil || percentage < 1 || percentage > 100 {
+		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeHelper, Source: t.Source, Target: target, Reason: fmt.Sprintf("invalid EXHAUSTION_PERCENTAGE: '%s', it should be in the range of 1-100", experimentsDetails.ExhaustionPercentage)}
+	}
+
+	switch experimentsDetails.StressType {
+	case "pod-fd-exhaustion":
+		if err := limitOpenFiles(t, index, percentage); err != nil {
+			return "", stacktrace.Propagate(err, "could not limit the open files")
+		}
+		return fmt.Sprintf("sleep %d", experimentsDetails.ChaosDuration), nil
+
+	case "pod-pid-exhaustion":
+		limit, current, err := getPidsLimit(t, index)
+		if err != nil {
+			return "", stacktrace.Propagate(err, "could not get pids limit")
+		}
+		// the stressor shell itself and the wrapper processes are also accounted in the pids cgroup
+		count := limit*int64(percentage)/100 - current - 3
+		if count <= 0 {
+			return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeHelper, Source: t.Source, Target: target, Reason: fmt.Sprintf("pids usage of the target container is already above %d%% of the pids limit", percentage)}
+		}
+
+		log.InfoWithValues("[Info]: Details of Stressor:", logrus.Fields{
+			"Container":   t.TargetContainers[index],
+			"PidsMax":     limit,
+			"PidsCurrent": current,
+			"Processes":   count,
+			"Timeout":     experimentsDetails.ChaosDuration,
+		})
+		return fmt.Sprintf("bash -c 'for ((i=0; i<%d; i++)); do sleep %d & done; wait'", count, experimentsDetails.ChaosDuration), nil
+	}
+	return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeHelper, Source: t.Source, Target: target, Reason: fmt.Sprintf("stressor for %s is not supported", experimentsDetails.StressType)}
+}
+
+// openFilesLimit is the original open files limit of a target process, which is restored on revert
+type openFilesLimit struct {
+	Pid   int
+	Limit unix.Rlimit
+}
+
+// limitOpenFiles lowers the soft open files limit (RLIMIT_NOFILE) of all the processes of the target container, so that the
+// given percentage of their free file descriptors is exhausted, the processes get EMFILE once the lowered limit is reached
+// the already open file descriptors aren't closed, and the processes forked later inherit the lowered limit
+func limitOpenFiles(t *targetDetails, index, percentage int) error {
+	target := fmt.Sprintf("{podName: %s, namespace: %s, container: %s}", t.Name, t.Namespace, t.TargetContainers[index])
+
+	pids, err := getContainerProcesses(t, index)
+	if err != nil {
+		return stacktrace.Propagate(err, "could not get the processes of the target container")
+	}
+
+	for _, pid := range pids {
+		var limit unix.Rlimit
+		if err := unix.Prlimit(pid, unix.RLIMIT_NOFILE, nil, &limit); err != nil {
+			if err == unix.ESRCH {
+				continue
+			}
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Source: t.Source, Target: target, Reason: fmt.Sprintf("failed to get the open files limit of process %d: %s", pid, err.Error())}
+		}
+		fds, err := os.ReadDir(fmt.Sprintf("/proc/%d/fd", pid))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Source: t.Source, Target: target, Reason: fmt.Sprintf("failed to get the open files of process %d: %s", pid, err.Error())}
+		}
+
+		open := uint64(len(fds))
+		if limit.Cur == unix.RLIM_INFINITY || open >= limit.Cur {
+			log.Warnf("open files of process %d are already at the limit or the limit is unlimited, skipping it", pid)
+			continue
+		}
+		lowered := unix.Rlimit{Cur: open + (limit.Cur-open)*uint64(100-percentage)/100, Max: limit.Max}
+
+		log.InfoWithValues("[Info]: Details of Stressor:", logrus.Fields{
+			"Container":     t.TargetContainers[index],
+			"Pid":           pid,
+			"RLIMIT_NOFILE": limit.Cur,
+			"Open Files":    open,
+			"Lowered Limit": lowered.Cur,
+			"Exhausted (%)": percentage,
+		})
+		// the limit is recorded before it is lowered, so that it is restored even if the revert happens in between
+		t.OpenFilesLimits = append(t.OpenFilesLimits, openFilesLimit{Pid: pid, Limit: limit})
+		if err := unix.Prlimit(pid, unix.RLIMIT_NOFILE, &lowered, nil); err != nil && err != unix.ESRCH {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Source: t.Source, Target: target, Reason: fmt.Sprintf("failed to lower the open files limit of process %d: %s", pid, err.Error())}
+		}
+	}
+	return nil
+}
+
+// restoreOpenFilesLimits restores the open files limits of the target processes, the exited processes are skipped
+func restoreOpenFilesLimits(t *targetDetails) error {
+	var errList []string
+	for _, l := range t.OpenFilesLimits {
+		limit := l.Limit
+		if err := unix.Prlimit(l.Pid, unix.RLIMIT_NOFILE, &limit, nil); err != nil && err != unix.ESRCH {
+			errList = append(errList, cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Source: t.Source, Target: fmt.Sprintf("{podName: %s, namespace: %s}", t.Name, t.Namespace), Reason: fmt.Sprintf("failed to restore the open files limit of process %d: %s", l.Pid, err.Error())}.Error())
+		}
+	}
+	if len(errList) != 0 {
+		return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s]", strings.Join(errList, ","))}
+	}
+	t.OpenFilesLimits = nil
+	return nil
+}
+
+// getContainerProcesses returns the pids of all the processes of the target container from its cgroup
+func getContainerProcesses(t *targetDetails, index int) ([]int, error) {
+	path, err := getCgroupPath(t, index, cgroups.Pids)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "could not get pids cgroup path")
+	}
+	content, err := os.ReadFile(filepath.Join(path, "cgroup.procs"))
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeHelper, Source: t.Source, Target: fmt.Sprintf("{podName: %s, namespace: %s, container: %s}", t.Name, t.Namespace, t.TargetContainers[index]), Reason: fmt.Sprintf("failed to read cgroup.procs: %s", err.Error())}
+	}
+
+	var pids []int
+	for _, line := range strings.Fields(string(content)) {
+		pid, err := strconv.Atoi(line)
+		if err != nil {
+			continue
+		}
+		pids = append(pids, pid)
+	}
+	return pids, nil
+}
+
+// getPidsLimit returns the pids.max and pids.current of the nearest cgroup which limits the number of pids of the target container
+// the limit is mostly set on the pod cgroup (podPidsLimit of kubelet), so it walks up from the container cgroup
+func getPidsLimit(t *targetDetails, index int) (int64, int64, error) {
+	path, err := getCgroupPath(t, index, cgroups.Pids)
+	if err != nil {
+		return 0, 0, stacktrace.Propagate(err, "could not get pids cgroup path")
+	}
+
+	root := cgroupRoot
+	if cgroups.Mode() != cgroups.Unified {
+		root = filepath.Join(cgroupRoot, string(cgroups.Pids))
+	}
+
+	for dir := path; strings.HasPrefix(dir, root) && dir != root; dir = filepath.Dir(dir) {
+		content, err := os.ReadFile(filepath.Join(dir, "pids.max"))
+		if err != nil {
+			continue
+		}
+		if strings.TrimSpace(string(content)) == "max" {
+			continue
+		}
+		limit, err := strconv.ParseInt(strings.TrimSpace(string(content)), 10, 64)
+		if err != nil {
+			continue
+		}
+		current, err := os.ReadFile(filepath.Join(dir, "pids.current"))
+		if err != nil {
+			return 0, 0, cerrors.Error{ErrorCode: cerrors.ErrorTypeHelper, Source: t.Source, Target: fmt.Sprintf("{podName: %s, namespace: %s, container: %s}", t.Name, t.Namespace, t.TargetContainers[index]), Reason: fmt.Sprintf("failed to read pids.current: %s", err.Error())}
+		}
+		usage, err := strconv.ParseInt(strings.TrimSpace(string(current)), 10, 64)
+		if err != nil {
+			return 0, 0, cerrors.Error{ErrorCode: cerrors.ErrorTypeHelper, Source: t.Source, Target: fmt.Sprintf("{podName: %s, namespace: %s, container: %s}", t.Name, t.Namespace, t.TargetContainers[index]), Reason: fmt.Sprintf("failed to parse pids.current: %s", err.Error())}
+		}
+		log.Infof("pids limit found in cgroup: %s", dir)
+		return limit, usage, nil
+	}
+	return 0, 0, cerrors.Error{ErrorCode: cerrors.ErrorTypeHelper, Source: t.Source, Target: fmt.Sprintf("{podName: %s, namespace: %s, container: %s}", t.Name, t.Namespace, t.TargetContainers[index]), Reason: "target container doesn't have a pids limit, pids.max is unlimited"}
+}
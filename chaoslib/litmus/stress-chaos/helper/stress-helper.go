@@ -90,7 +90,15 @@ func Helper(ctx context.Context, clients clients.ClientSets) {
 	// Set the chaos result uid
 	result.SetResultUID(&resultDetails, clients, &chaosDetails)
 
-	if err := prepareStressChaos(&experimentsDetails, clients, &eventsDetails, &chaosDetails, &resultDetails); err != nil {
+	// cpu throttle tunes the cfs quota of the target containers, rest of the stress types run the stress process
+	switch experimentsDetails.StressType {
+	case "pod-cpu-throttle":
+		err = prepareCPUThrottle(&experimentsDetails, clients, &eventsDetails, &chaosDetails, &resultDetails)
+	default:
+		err = prepareStressChaos(&experimentsDetails, clients, &eventsDetails, &chaosDetails, &resultDetails)
+	}
+
+	if err != nil {
 		// update failstep inside chaosresult
 		if resultErr := result.UpdateFailedStepFromHelper(&resultDetails, &chaosDetails, clients, err); resultErr != nil {
 			log.Fatalf("helper pod failed, err: %v, resultErr: %v", err, resultErr)
@@ -101,66 +109,44 @@ func Helper(ctx context.Context, clients clients.ClientSets) {
 
 // prepareStressChaos contains the chaos preparation and injection steps
 func prepareStressChaos(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
-	// get stressors in list format
-	stressorList := prepareStressor(experimentsDetails)
-	if len(stressorList) == 0 {
-		return cerrors.Error{ErrorCode: cerrors.ErrorTypeHelper, Source: chaosDetails.ChaosPodName, Reason: "fail to prepare stressors"}
-	}
-	stressors := strings.Join(stressorList, " ")
-
-	targetList, err := common.ParseTargets(chaosDetails.ChaosPodName)
-	if err != nil {
-		return stacktrace.Propagate(err, "could not parse targets")
-	}
-
-	var targets []*targetDetails
-
-	for _, t := range targetList.Target {
-		td := &targetDetails{
-			Name:      t.Name,
-			Namespace: t.Namespace,
-			Source:    chaosDetails.ChaosPodName,
-		}
-
-		td.TargetContainers, err = common.GetTargetContainers(t.Name, t.Namespace, t.TargetContainer, chaosDetails.ChaosPodName, clients)
-		if err != nil {
-			return stacktrace.Propagate(err, "could not get target containers")
-		}
-
-		td.ContainerIds, err = common.GetContainerIDs(td.Namespace, td.Name, td.TargetContainers, clients, td.Source)
-		if err != nil {
-			return stacktrace.Propagate(err, "could not get container ids")
-		}
-
-		for _, cid := range td.ContainerIds {
-			// extract out the pid of the target container
-			pid, err := common.GetPID(experimentsDetails.ContainerRuntime, cid, experimentsDetails.SocketPath, td.Source)
-			if err != nil {
-				return stacktrace.Propagate(err, "could not get container pid")
+	var getStressors func(t *targetDetails, index int) (string, error)
+	revert := terminateProcess
+
+	switch experimentsDetails.StressType {
+	case "pod-fd-exhaustion", "pod-pid-exhaustion":
+		// the exhaustion stressors depend upon the limits of the individual target containers
+		getStressors = func(t *targetDetails, index int) (string, error) {
+			return prepareExhaustionStressor(experimentsDetails, t, index)
+		}
+		if experimentsDetails.StressType == "pod-fd-exhaustion" {
+			// the open files limits of the target processes are restored along with the termination of the stressor
+			revert = func(t *targetDetails) error {
+				restoreErr := restoreOpenFilesLimits(t)
+				if err := terminateProcess(t); err != nil {
+					return err
+				}
+				return restoreErr
 			}
-			td.Pids = append(td.Pids, pid)
 		}
-
-		for i := range td.Pids {
-			cGroupManagers, err, grpPath := getCGroupManager(td, i)
-			if err != nil {
-				return stacktrace.Propagate(err, "could not get cgroup manager")
-			}
-			td.GroupPath = grpPath
-			td.CGroupManagers = append(td.CGroupManagers, cGroupManagers)
+	default:
+		// get stressors in list format
+		stressorList := prepareStressor(experimentsDetails)
+		if len(stressorList) == 0 {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeHelper, Source: chaosDetails.ChaosPodName, Reason: "fail to prepare stressors"}
 		}
+		stressors := strings.Join(stressorList, " ")
+		getStressors = func(*targetDetails, int) (string, error) {
+			return stressors, nil
+		}
+	}
 
-		log.InfoWithValues("[Info]: Details of application under chaos injection", logrus.Fields{
-			"PodName":          td.Name,
-			"Namespace":        td.Namespace,
-			"TargetContainers": td.TargetContainers,
-		})
-
-		targets = append(targets, td)
+	targets, err := getTargets(experimentsDetails, clients, chaosDetails)
+	if err != nil {
+		return err
 	}
 
 	// watching for the abort signal and revert the chaos if an abort signal is received
-	go abortWatcher(targets, resultDetails.Name, chaosDetails.ChaosNamespace)
+	go abortWatcher(targets, resultDetails.Name, chaosDetails.ChaosNamespace, revert)
 
 	select {
 	case <-inject:
@@ -173,9 +159,16 @@ func prepareStressChaos(experimentsDetails *experimentTypes.ExperimentDetails, c
 
 	for index, t := range targets {
 		for i := range t.Pids {
+			stressors, err := getStressors(t, i)
+			if err != nil {
+				if revertErr := revertChaosForAllTargets(targets, resultDetails, chaosDetails.ChaosNamespace, index, revert); revertErr != nil {
+					return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), stacktrace.RootCause(revertErr).Error())}
+				}
+				return stacktrace.Propagate(err, "could not prepare stressors")
+			}
 			cmd, err := injectChaos(t, stressors, i, experimentsDetails.StressType)
 			if err != nil {
-				if revertErr := revertChaosForAllTargets(targets, resultDetails, chaosDetails.ChaosNamespace, index-1); revertErr != nil {
+				if revertErr := revertChaosForAllTargets(targets, resultDetails, chaosDetails.ChaosNamespace, index, revert); revertErr != nil {
 					return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), stacktrace.RootCause(revertErr).Error())}
 				}
 				return stacktrace.Propagate(err, "could not inject chaos")
@@ -185,7 +178,7 @@ func prepareStressChaos(experimentsDetails *experimentTypes.ExperimentDetails, c
 		}
 
 		if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "injected", "pod", t.Name); err != nil {
-			if revertErr := revertChaosForAllTargets(targets, resultDetails, chaosDetails.ChaosNamespace, index); revertErr != nil {
+			if revertErr := revertChaosForAllTargets(targets, resultDetails, chaosDetails.ChaosNamespace, index, revert); revertErr != nil {
 				return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), stacktrace.RootCause(revertErr).Error())}
 			}
 			return stacktrace.Propagate(err, "could not annotate chaosresult")
@@ -248,7 +241,7 @@ func prepareStressChaos(experimentsDetails *experimentTypes.ExperimentDetails, c
 		// the stress process gets timeout before completion
 		log.Infof("[Chaos] The stress process is not yet completed after the chaos duration of %vs", experimentsDetails.ChaosDuration+30)
 		log.Info("[Timeout]: Killing the stress process")
-		if err := revertChaosForAllTargets(targets, resultDetails, chaosDetails.ChaosNamespace, len(targets)-1); err != nil {
+		if err := revertChaosForAllTargets(targets, resultDetails, chaosDetails.ChaosNamespace, len(targets)-1, revert); err != nil {
 			return stacktrace.Propagate(err, "could not revert chaos")
 		}
 	case err := <-done:
@@ -268,7 +261,7 @@ func prepareStressChaos(experimentsDetails *experimentTypes.ExperimentDetails, c
 			return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Source: chaosDetails.ChaosPodName, Reason: err.Error()}
 		}
 		log.Info("[Info]: Reverting Chaos")
-		if err := revertChaosForAllTargets(targets, resultDetails, chaosDetails.ChaosNamespace, len(targets)-1); err != nil {
+		if err := revertChaosForAllTargets(targets, resultDetails, chaosDetails.ChaosNamespace, len(targets)-1, revert); err != nil {
 			return stacktrace.Propagate(err, "could not revert chaos")
 		}
 	}
@@ -276,10 +269,66 @@ func prepareStressChaos(experimentsDetails *experimentTypes.ExperimentDetails, c
 	return nil
 }
 
-func revertChaosForAllTargets(targets []*targetDetails, resultDetails *types.ResultDetails, chaosNs string, index int) error {
+// getTargets derive the container ids, pids and cgroups of all the target containers
+func getTargets(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) ([]*targetDetails, error) {
+	targetList, err := common.ParseTargets(chaosDetails.ChaosPodName)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "could not parse targets")
+	}
+
+	var targets []*targetDetails
+
+	for _, t := range targetList.Target {
+		td := &targetDetails{
+			Name:      t.Name,
+			Namespace: t.Namespace,
+			Source:    chaosDetails.ChaosPodName,
+		}
+
+		td.TargetContainers, err = common.GetTargetContainers(t.Name, t.Namespace, t.TargetContainer, chaosDetails.ChaosPodName, clients)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "could not get target containers")
+		}
+
+		td.ContainerIds, err = common.GetContainerIDs(td.Namespace, td.Name, td.TargetContainers, clients, td.Source)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "could not get container ids")
+		}
+
+		for _, cid := range td.ContainerIds {
+			// extract out the pid of the target container
+			pid, err := common.GetPID(experimentsDetails.ContainerRuntime, cid, experimentsDetails.SocketPath, td.Source)
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "could not get container pid")
+			}
+			td.Pids = append(td.Pids, pid)
+		}
+
+		for i := range td.Pids {
+			cGroupManagers, err, grpPath := getCGroupManager(td, i)
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "could not get cgroup manager")
+			}
+			td.GroupPath = grpPath
+			td.CGroupManagers = append(td.CGroupManagers, cGroupManagers)
+		}
+
+		log.InfoWithValues("[Info]: Details of application under chaos injection", logrus.Fields{
+			"PodName":          td.Name,
+			"Namespace":        td.Namespace,
+			"TargetContainers": td.TargetContainers,
+		})
+
+		targets = append(targets, td)
+	}
+	return targets, nil
+}
+
+// revertChaosForAllTargets reverts the chaos from the targets upto the given index using the provided revert function
+func revertChaosForAllTargets(targets []*targetDetails, resultDetails *types.ResultDetails, chaosNs string, index int, revert func(*targetDetails) error) error {
 	var errList []string
 	for i := 0; i <= index; i++ {
-		if err := terminateProcess(targets[i]); err != nil {
+		if err := revert(targets[i]); err != nil {
 			errList = append(errList, err.Error())
 			continue
 		}
@@ -553,10 +602,12 @@ func getENV(experimentDetails *experimentTypes.ExperimentDetails) {
 	experimentDetails.MemoryConsumption = types.Getenv("MEMORY_CONSUMPTION", "")
 	experimentDetails.VolumeMountPath = types.Getenv("VOLUME_MOUNT_PATH", "")
 	experimentDetails.StressType = types.Getenv("STRESS_TYPE", "")
+	experimentDetails.CPUQuotaPercentage = types.Getenv("CPU_QUOTA_PERCENTAGE", "")
+	experimentDetails.ExhaustionPercentage = types.Getenv("EXHAUSTION_PERCENTAGE", "")
 }
 
 // abortWatcher continuously watch for the abort signals
-func abortWatcher(targets []*targetDetails, resultName, chaosNS string) {
+func abortWatcher(targets []*targetDetails, resultName, chaosNS string, revert func(*targetDetails) error) {
 
 	<-abort
 
@@ -566,7 +617,7 @@ func abortWatcher(targets []*targetDetails, resultName, chaosNS string) {
 	retry := 3
 	for retry > 0 {
 		for _, t := range targets {
-			if err = terminateProcess(t); err != nil {
+			if err = revert(t); err != nil {
 				log.Errorf("[Abort]: unable to revert for %v pod, err :%v", t.Name, err)
 				continue
 			}
@@ -689,6 +740,8 @@ type targetDetails struct {
 	Cmds             []*Command
 	Source           string
 	GroupPath        string
+	CPUQuotas        []*cpuQuota
+	OpenFilesLimits  []openFilesLimit
 }
 
 type Command struct {
@@ -57,6 +57,20 @@ func PrepareAndInjectStressChaos(ctx context.Context, experimentsDetails *experi
 			"Sequence":                        experimentsDetails.Sequence,
 			"PodsAffectedPerc":                experimentsDetails.PodsAffectedPerc,
 		})
+
+	case "pod-cpu-throttle":
+		log.InfoWithValues("[Info]: The chaos tunables are:", logrus.Fields{
+			"CPU Quota Percentage": experimentsDetails.CPUQuotaPercentage,
+			"Sequence":             experimentsDetails.Sequence,
+			"PodsAffectedPerc":     experimentsDetails.PodsAffectedPerc,
+		})
+
+	case "pod-fd-exhaustion", "pod-pid-exhaustion":
+		log.InfoWithValues("[Info]: The chaos tunables are:", logrus.Fields{
+			"Exhaustion Percentage": experimentsDetails.ExhaustionPercentage,
+			"Sequence":              experimentsDetails.Sequence,
+			"PodsAffectedPerc":      experimentsDetails.PodsAffectedPerc,
+		})
 	}
 
 	// Get the target pod details for the chaos execution
@@ -265,7 +279,7 @@ func createHelperPod(ctx context.Context, experimentsDetails *experimentTypes.Ex
 		helperPod.Spec.Volumes = append(helperPod.Spec.Volumes, common.GetSidecarVolumes(chaosDetails)...)
 	}
 
-	if err := clients.CreatePod(experimentsDetails.ChaosNamespace, helperPod); err != nil {
+	if err := common.CreateHelperPod(experimentsDetails.ChaosNamespace, helperPod, clients, chaosDetails); err != nil {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("unable to create helper pod: %s", err.Error())}
 	}
 
@@ -292,6 +306,8 @@ func getPodEnv(ctx context.Context, experimentsDetails *experimentTypes.Experime
 		SetEnv("MEMORY_CONSUMPTION", experimentsDetails.MemoryConsumption).
 		SetEnv("VOLUME_MOUNT_PATH", experimentsDetails.VolumeMountPath).
 		SetEnv("STRESS_TYPE", experimentsDetails.StressType).
+		SetEnv("CPU_QUOTA_PERCENTAGE", experimentsDetails.CPUQuotaPercentage).
+		SetEnv("EXHAUSTION_PERCENTAGE", experimentsDetails.ExhaustionPercentage).
 		SetEnv("INSTANCE_ID", experimentsDetails.InstanceID).
 		SetEnv("OTEL_EXPORTER_OTLP_ENDPOINT", os.Getenv(telemetry.OTELExporterOTLPEndpoint)).
 		SetEnv("TRACE_PARENT", telemetry.GetMarshalledSpanFromContext(ctx)).
@@ -313,6 +329,8 @@ func SetChaosTunables(experimentsDetails *experimentTypes.ExperimentDetails) {
 	experimentsDetails.NumberOfWorkers = common.ValidateRange(experimentsDetails.NumberOfWorkers)
 	experimentsDetails.FilesystemUtilizationPercentage = common.ValidateRange(experimentsDetails.FilesystemUtilizationPercentage)
 	experimentsDetails.FilesystemUtilizationBytes = common.ValidateRange(experimentsDetails.FilesystemUtilizationBytes)
+	experimentsDetails.CPUQuotaPercentage = common.ValidateRange(experimentsDetails.CPUQuotaPercentage)
+	experimentsDetails.ExhaustionPercentage = common.ValidateRange(experimentsDetails.ExhaustionPercentage)
 	experimentsDetails.PodsAffectedPerc = common.ValidateRange(experimentsDetails.PodsAffectedPerc)
 	experimentsDetails.Sequence = common.GetRandomSequence(experimentsDetails.Sequence)
 }
@@ -11,7 +11,7 @@ import (
 
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
-	"github.com/litmuschaos/litmus-go/pkg/cloud/vmware"
+	"github.com/litmuschaos/litmus-go/pkg/cloud/provider"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/probe"
@@ -26,7 +26,7 @@ import (
 var inject, abort chan os.Signal
 
 // InjectVMPowerOffChaos injects the chaos in serial or parallel mode
-func InjectVMPowerOffChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, cookie string) error {
+func InjectVMPowerOffChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, cloudProvider provider.Provider) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "PrepareVMPowerOffFault")
 	defer span.End()
 	// inject channel is used to transmit signal notifications.
@@ -48,16 +48,21 @@ func InjectVMPowerOffChaos(ctx context.Context, experimentsDetails *experimentTy
 	//Fetching the target VM Ids
 	vmIdList := strings.Split(experimentsDetails.VMIds, ",")
 
+	if chaosDetails.DryRun {
+		common.PlanTargets("VM", "", vmIdList, chaosDetails)
+		return common.StopForDryRun(experimentsDetails, chaosDetails)
+	}
+
 	// Calling AbortWatcher go routine, it will continuously watch for the abort signal and generate the required events and result
-	go abortWatcher(experimentsDetails, vmIdList, clients, resultDetails, chaosDetails, eventsDetails, cookie)
+	go abortWatcher(experimentsDetails, vmIdList, clients, resultDetails, chaosDetails, eventsDetails, cloudProvider)
 
 	switch strings.ToLower(experimentsDetails.Sequence) {
 	case "serial":
-		if err := injectChaosInSerialMode(ctx, experimentsDetails, vmIdList, cookie, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+		if err := injectChaosInSerialMode(ctx, experimentsDetails, vmIdList, cloudProvider, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
 			return stacktrace.Propagate(err, "could not run chaos in serial mode")
 		}
 	case "parallel":
-		if err := injectChaosInParallelMode(ctx, experimentsDetails, vmIdList, cookie, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+		if err := injectChaosInParallelMode(ctx, experimentsDetails, vmIdList, cloudProvider, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
 			return stacktrace.Propagate(err, "could not run chaos in parallel mode")
 		}
 	default:
@@ -74,7 +79,7 @@ func InjectVMPowerOffChaos(ctx context.Context, experimentsDetails *experimentTy
 }
 
 // injectChaosInSerialMode stops VMs in serial mode i.e. one after the other
-func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, vmIdList []string, cookie string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, vmIdList []string, cloudProvider provider.Provider, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "injectVMPowerOffFaultInSerialMode")
 	defer span.End()
 
@@ -101,7 +106,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 				//Stopping the VM
 				log.Infof("[Chaos]: Stopping %s VM", vmId)
-				if err := vmware.StopVM(experimentsDetails.VcenterServer, vmId, cookie); err != nil {
+				if err := cloudProvider.StopInstance(provider.Instance{ID: vmId}); err != nil {
 					return stacktrace.Propagate(err, fmt.Sprintf("failed to stop %s vm", vmId))
 				}
 
@@ -109,7 +114,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 				//Wait for the VM to completely stop
 				log.Infof("[Wait]: Wait for VM '%s' to get in POWERED_OFF state", vmId)
-				if err := vmware.WaitForVMStop(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.VcenterServer, vmId, cookie); err != nil {
+				if err := provider.WaitForInstanceState(cloudProvider, provider.Instance{ID: vmId}, provider.StateStopped, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 					return stacktrace.Propagate(err, "VM shutdown failed")
 				}
 
@@ -127,13 +132,13 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 				//Starting the VM
 				log.Infof("[Chaos]: Starting back %s VM", vmId)
-				if err := vmware.StartVM(experimentsDetails.VcenterServer, vmId, cookie); err != nil {
+				if err := cloudProvider.StartInstance(provider.Instance{ID: vmId}); err != nil {
 					return stacktrace.Propagate(err, "failed to start back vm")
 				}
 
 				//Wait for the VM to completely start
 				log.Infof("[Wait]: Wait for VM '%s' to get in POWERED_ON state", vmId)
-				if err := vmware.WaitForVMStart(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.VcenterServer, vmId, cookie); err != nil {
+				if err := provider.WaitForInstanceState(cloudProvider, provider.Instance{ID: vmId}, provider.StateRunning, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 					return stacktrace.Propagate(err, "vm failed to start")
 				}
 
@@ -148,7 +153,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 }
 
 // injectChaosInParallelMode stops VMs in parallel mode i.e. all at once
-func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, vmIdList []string, cookie string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, vmIdList []string, cloudProvider provider.Provider, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "injectVMPowerOffFaultInParallelMode")
 	defer span.End()
 
@@ -175,7 +180,7 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 
 				//Stopping the VM
 				log.Infof("[Chaos]: Stopping %s VM", vmId)
-				if err := vmware.StopVM(experimentsDetails.VcenterServer, vmId, cookie); err != nil {
+				if err := cloudProvider.StopInstance(provider.Instance{ID: vmId}); err != nil {
 					return stacktrace.Propagate(err, fmt.Sprintf("failed to stop %s vm", vmId))
 				}
 
@@ -186,7 +191,7 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 
 				//Wait for the VM to completely stop
 				log.Infof("[Wait]: Wait for VM '%s' to get in POWERED_OFF state", vmId)
-				if err := vmware.WaitForVMStop(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.VcenterServer, vmId, cookie); err != nil {
+				if err := provider.WaitForInstanceState(cloudProvider, provider.Instance{ID: vmId}, provider.StateStopped, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 					return stacktrace.Propagate(err, "vm failed to shutdown")
 				}
 			}
@@ -206,7 +211,7 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 
 				//Starting the VM
 				log.Infof("[Chaos]: Starting back %s VM", vmId)
-				if err := vmware.StartVM(experimentsDetails.VcenterServer, vmId, cookie); err != nil {
+				if err := cloudProvider.StartInstance(provider.Instance{ID: vmId}); err != nil {
 					return stacktrace.Propagate(err, fmt.Sprintf("failed to start back %s vm", vmId))
 				}
 			}
@@ -215,7 +220,7 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 
 				//Wait for the VM to completely start
 				log.Infof("[Wait]: Wait for VM '%s' to get in POWERED_ON state", vmId)
-				if err := vmware.WaitForVMStart(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.VcenterServer, vmId, cookie); err != nil {
+				if err := provider.WaitForInstanceState(cloudProvider, provider.Instance{ID: vmId}, provider.StateRunning, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 					return stacktrace.Propagate(err, "vm failed to successfully start")
 				}
 			}
@@ -232,26 +237,26 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 }
 
 // abortWatcher watches for the abort signal and reverts the chaos
-func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, vmIdList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails, eventsDetails *types.EventDetails, cookie string) {
+func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, vmIdList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails, eventsDetails *types.EventDetails, cloudProvider provider.Provider) {
 	<-abort
 
 	log.Info("[Abort]: Chaos Revert Started")
 	for _, vmId := range vmIdList {
 
-		vmStatus, err := vmware.GetVMStatus(experimentsDetails.VcenterServer, vmId, cookie)
+		vmStatus, err := cloudProvider.GetInstanceState(provider.Instance{ID: vmId})
 		if err != nil {
 			log.Errorf("failed to get vm status of %s when an abort signal is received: %s", vmId, err.Error())
 		}
 
-		if vmStatus != "POWERED_ON" {
+		if vmStatus != provider.StateRunning {
 
 			log.Infof("[Abort]: Waiting for the VM %s to shutdown", vmId)
-			if err := vmware.WaitForVMStop(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.VcenterServer, vmId, cookie); err != nil {
+			if err := provider.WaitForInstanceState(cloudProvider, provider.Instance{ID: vmId}, provider.StateStopped, experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 				log.Errorf("vm %s failed to successfully shutdown when an abort signal was received: %s", vmId, err.Error())
 			}
 
 			log.Infof("[Abort]: Starting %s VM as abort signal has been received", vmId)
-			if err := vmware.StartVM(experimentsDetails.VcenterServer, vmId, cookie); err != nil {
+			if err := cloudProvider.StartInstance(provider.Instance{ID: vmId}); err != nil {
 				log.Errorf("vm %s failed to start when an abort signal was received: %s", vmId, err.Error())
 			}
 		}
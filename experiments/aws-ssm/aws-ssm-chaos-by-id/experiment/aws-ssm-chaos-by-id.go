@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/chaoslib/litmus/aws-ssm-chaos/lib"
 	litmusLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/aws-ssm-chaos/lib/ssm"
 	experimentEnv "github.com/litmuschaos/litmus-go/pkg/aws-ssm/aws-ssm-chaos/environment"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/aws-ssm/aws-ssm-chaos/types"
@@ -122,10 +123,10 @@ func AWSSSMChaosByID(ctx context.Context, clients clients.ClientSets) {
 	if err := litmusLIB.PrepareAWSSSMChaosByID(ctx, &experimentsDetails, clients, &resultDetails, &eventsDetails, &chaosDetails); err != nil {
 		log.Errorf("Chaos injection failed: %v", err)
 		result.RecordAfterFailure(&chaosDetails, &resultDetails, err, clients, &eventsDetails)
-		//Delete the ssm document on the given aws service monitoring docs
-		if experimentsDetails.IsDocsUploaded {
+		//Delete the ssm documents on the given aws service monitoring docs
+		if experimentsDetails.IsDocsUploaded || experimentsDetails.IsRevertDocsUploaded {
 			log.Info("[Recovery]: Delete the uploaded aws ssm docs")
-			if err := ssm.SSMDeleteDocument(experimentsDetails.DocumentName, experimentsDetails.Region); err != nil {
+			if err := lib.DeleteDocuments(&experimentsDetails); err != nil {
 				log.Errorf("Failed to delete ssm doc: %v", err)
 			}
 		}
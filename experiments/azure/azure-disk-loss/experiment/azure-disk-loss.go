@@ -11,6 +11,7 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	azureCommon "github.com/litmuschaos/litmus-go/pkg/cloud/azure/common"
 	azureStatus "github.com/litmuschaos/litmus-go/pkg/cloud/azure/disk"
+	"github.com/litmuschaos/litmus-go/pkg/cloud/provider"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/probe"
@@ -119,9 +120,16 @@ func AzureDiskLoss(ctx context.Context, clients clients.ClientSets) {
 		}
 	}
 
+	cloudProvider, err := provider.NewAzureFromOptions(provider.Options{SubscriptionID: experimentsDetails.SubscriptionID})
+	if err != nil {
+		log.Errorf("Failed to create the azure provider: %v", err)
+		result.RecordAfterFailure(&chaosDetails, &resultDetails, err, clients, &eventsDetails)
+		return
+	}
+
 	chaosDetails.Phase = types.ChaosInjectPhase
 
-	if err = litmusLIB.PrepareChaos(ctx, &experimentsDetails, clients, &resultDetails, &eventsDetails, &chaosDetails); err != nil {
+	if err = litmusLIB.PrepareChaos(ctx, &experimentsDetails, cloudProvider, clients, &resultDetails, &eventsDetails, &chaosDetails); err != nil {
 		result.RecordAfterFailure(&chaosDetails, &resultDetails, err, clients, &eventsDetails)
 		log.Errorf("Chaos injection failed: %v", err)
 		return
@@ -11,6 +11,7 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	azureCommon "github.com/litmuschaos/litmus-go/pkg/cloud/azure/common"
 	azureStatus "github.com/litmuschaos/litmus-go/pkg/cloud/azure/instance"
+	"github.com/litmuschaos/litmus-go/pkg/cloud/provider"
 
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	"github.com/litmuschaos/litmus-go/pkg/log"
@@ -121,9 +122,16 @@ func AzureInstanceStop(ctx context.Context, clients clients.ClientSets) {
 		log.Info("[Status]: Azure instance(s) is in running state (pre-chaos)")
 	}
 
+	cloudProvider, err := provider.NewAzureFromOptions(provider.Options{SubscriptionID: experimentsDetails.SubscriptionID})
+	if err != nil {
+		log.Errorf("Failed to create the azure provider: %v", err)
+		result.RecordAfterFailure(&chaosDetails, &resultDetails, err, clients, &eventsDetails)
+		return
+	}
+
 	chaosDetails.Phase = types.ChaosInjectPhase
 
-	if err = litmusLIB.PrepareAzureStop(ctx, &experimentsDetails, clients, &resultDetails, &eventsDetails, &chaosDetails); err != nil {
+	if err = litmusLIB.PrepareAzureStop(ctx, &experimentsDetails, cloudProvider, clients, &resultDetails, &eventsDetails, &chaosDetails); err != nil {
 		log.Errorf("Chaos injection failed: %v", err)
 		result.RecordAfterFailure(&chaosDetails, &resultDetails, err, clients, &eventsDetails)
 		return
@@ -66,10 +66,28 @@ func NodeRestart(ctx context.Context, clients clients.ClientSets) {
 	log.InfoWithValues("[Info]: The Node information is as follows", logrus.Fields{
 		"Node_IPMI_IP": experimentsDetails.IPMIIP,
 		"User":         experimentsDetails.User,
+		"Reset Type":   experimentsDetails.ResetType,
 	})
 
 	// Calling AbortWatcher go routine, it will continuously watch for the abort signal and generate the required events and result
-	go common.AbortWatcher(experimentsDetails.ExperimentName, clients, &resultDetails, &chaosDetails, &eventsDetails)
+	go common.AbortWatcherWithoutExit(experimentsDetails.ExperimentName, clients, &resultDetails, &chaosDetails, &eventsDetails)
+
+	// CREATE THE REDFISH CLIENT OF THE NODE
+	client, err := redfishLib.NewClient(redfishLib.Options{
+		Endpoint:           experimentsDetails.IPMIIP,
+		User:               experimentsDetails.User,
+		Password:           experimentsDetails.Password,
+		UseSession:         experimentsDetails.UseSession,
+		CACertFile:         experimentsDetails.CACertFile,
+		InsecureSkipVerify: experimentsDetails.InsecureSkipVerify,
+		Timeout:            experimentsDetails.RequestTimeout,
+	})
+	if err != nil {
+		result.RecordAfterFailure(&chaosDetails, &resultDetails, err, clients, &eventsDetails)
+		log.Errorf("Unable to create the redfish client, err: %v", err)
+		return
+	}
+	defer client.Logout()
 
 	//PRE-CHAOS APPLICATION STATUS CHECK
 	if chaosDetails.DefaultHealthCheck {
@@ -93,15 +111,9 @@ func NodeRestart(ctx context.Context, clients clients.ClientSets) {
 
 	// PRE-CHAOS NODE STATUS CHECK
 	log.Info("[Status]: Verify that the NUT (Node Under Test) is running (pre-chaos)")
-	nodeStatus, err := redfishLib.GetNodeStatus(experimentsDetails.IPMIIP, experimentsDetails.User, experimentsDetails.Password)
-	if err != nil {
-		result.RecordAfterFailure(&chaosDetails, &resultDetails, err, clients, &eventsDetails)
-		log.Errorf("[Verification]: Unable to get node power status(pre-chaos). Error: %v", err)
-		return
-	}
-	if nodeStatus != "On" {
+	if err := redfishLib.NodeStatusCheck(client, experimentsDetails.SystemID); err != nil {
 		result.RecordAfterFailure(&chaosDetails, &resultDetails, err, clients, &eventsDetails)
-		log.Errorf("[Verification]: Node is not in running state(pre-chaos)")
+		log.Errorf("[Verification]: Node is not in running state(pre-chaos), err: %v", err)
 		return
 	}
 	log.Info("[Verification]: Node is in running state(pre-chaos)")
@@ -130,7 +142,7 @@ func NodeRestart(ctx context.Context, clients clients.ClientSets) {
 
 	chaosDetails.Phase = types.ChaosInjectPhase
 
-	if err := litmusLIB.PrepareChaos(ctx, &experimentsDetails, clients, &resultDetails, &eventsDetails, &chaosDetails); err != nil {
+	if err := litmusLIB.PrepareChaos(ctx, &experimentsDetails, client, clients, &resultDetails, &eventsDetails, &chaosDetails); err != nil {
 		result.RecordAfterFailure(&chaosDetails, &resultDetails, err, clients, &eventsDetails)
 		log.Errorf("Chaos injection failed, err: %v", err)
 		return
@@ -144,7 +156,7 @@ func NodeRestart(ctx context.Context, clients clients.ClientSets) {
 	//POST-CHAOS APPLICATION STATUS CHECK
 	if chaosDetails.DefaultHealthCheck {
 		log.Info("[Status]: Verify that the AUT (Application Under Test) is running (post-chaos)")
-		if err = status.AUTStatusCheck(clients, &chaosDetails); err != nil {
+		if err := status.AUTStatusCheck(clients, &chaosDetails); err != nil {
 			log.Errorf("Application status check failed, err: %v", err)
 			result.RecordAfterFailure(&chaosDetails, &resultDetails, err, clients, &eventsDetails)
 			return
@@ -163,15 +175,9 @@ func NodeRestart(ctx context.Context, clients clients.ClientSets) {
 
 	//POST-CHAOS NODE STATUS CHECK
 	log.Info("[Status]: Verify that the NUT (Node Under Test) is running (post-chaos)")
-	nodeStatus, err = redfishLib.GetNodeStatus(experimentsDetails.IPMIIP, experimentsDetails.User, experimentsDetails.Password)
-	if err != nil {
-		result.RecordAfterFailure(&chaosDetails, &resultDetails, err, clients, &eventsDetails)
-		log.Errorf("[Verification]: Unable to get node power status. Error: %v ", err)
-		return
-	}
-	if nodeStatus != "On" {
+	if err := redfishLib.NodeStatusCheck(client, experimentsDetails.SystemID); err != nil {
 		result.RecordAfterFailure(&chaosDetails, &resultDetails, err, clients, &eventsDetails)
-		log.Errorf("[Verification]: Node is not in running state(post-chaos)")
+		log.Errorf("[Verification]: Node is not in running state(post-chaos), err: %v", err)
 		return
 	}
 	log.Info("[Verification]: Node is in running state(post-chaos)")
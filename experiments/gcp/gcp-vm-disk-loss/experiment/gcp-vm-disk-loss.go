@@ -8,6 +8,7 @@ import (
 	litmusLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/gcp-vm-disk-loss/lib"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/cloud/gcp"
+	"github.com/litmuschaos/litmus-go/pkg/cloud/provider"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	experimentEnv "github.com/litmuschaos/litmus-go/pkg/gcp/gcp-vm-disk-loss/environment"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/gcp/gcp-vm-disk-loss/types"
@@ -107,6 +108,9 @@ func VMDiskLoss(ctx context.Context, clients clients.ClientSets) {
 		return
 	}
 
+	// the chaos is injected through the gcp provider, which shares the compute service
+	cloudProvider := provider.NewGCPFromComputeService(computeService, experimentsDetails.GCPProjectID)
+
 	// Verify the vm instance is attached to disk volume
 	if chaosDetails.DefaultHealthCheck {
 		if err := gcp.DiskVolumeStateCheck(computeService, &experimentsDetails); err != nil {
@@ -126,7 +130,7 @@ func VMDiskLoss(ctx context.Context, clients clients.ClientSets) {
 
 	chaosDetails.Phase = types.ChaosInjectPhase
 
-	if err = litmusLIB.PrepareDiskVolumeLoss(ctx, computeService, &experimentsDetails, clients, &resultDetails, &eventsDetails, &chaosDetails); err != nil {
+	if err = litmusLIB.PrepareDiskVolumeLoss(ctx, computeService, cloudProvider, &experimentsDetails, clients, &resultDetails, &eventsDetails, &chaosDetails); err != nil {
 		log.Errorf("Chaos injection failed, err: %v", err)
 		result.RecordAfterFailure(&chaosDetails, &resultDetails, err, clients, &eventsDetails)
 		return
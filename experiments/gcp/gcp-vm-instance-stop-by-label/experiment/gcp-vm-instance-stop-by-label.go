@@ -8,6 +8,7 @@ import (
 	litmusLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/gcp-vm-instance-stop-by-label/lib"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/cloud/gcp"
+	"github.com/litmuschaos/litmus-go/pkg/cloud/provider"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	experimentEnv "github.com/litmuschaos/litmus-go/pkg/gcp/gcp-vm-instance-stop/environment"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/gcp/gcp-vm-instance-stop/types"
@@ -108,6 +109,9 @@ func GCPVMInstanceStopByLabel(ctx context.Context, clients clients.ClientSets) {
 		return
 	}
 
+	// the chaos is injected through the gcp provider, which shares the compute service
+	cloudProvider := provider.NewGCPFromComputeService(computeService, experimentsDetails.GCPProjectID)
+
 	//selecting the target instances (pre-chaos)
 	if err = gcp.SetTargetInstance(computeService, &experimentsDetails); err != nil {
 		log.Errorf("Failed to get the target VM instances, err: %v", err)
@@ -119,7 +123,7 @@ func GCPVMInstanceStopByLabel(ctx context.Context, clients clients.ClientSets) {
 
 	chaosDetails.Phase = types.ChaosInjectPhase
 
-	if err := litmusLIB.PrepareVMStopByLabel(ctx, computeService, &experimentsDetails, clients, &resultDetails, &eventsDetails, &chaosDetails); err != nil {
+	if err := litmusLIB.PrepareVMStopByLabel(ctx, cloudProvider, &experimentsDetails, clients, &resultDetails, &eventsDetails, &chaosDetails); err != nil {
 		log.Errorf("Chaos injection failed, err: %v", err)
 		result.RecordAfterFailure(&chaosDetails, &resultDetails, err, clients, &eventsDetails)
 		return
@@ -3,10 +3,12 @@ package experiment
 import (
 	"context"
 	"os"
+	"strings"
 
 	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
 	litmusLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/vm-poweroff/lib"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/cloud/provider"
 	"github.com/litmuschaos/litmus-go/pkg/cloud/vmware"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	"github.com/litmuschaos/litmus-go/pkg/log"
@@ -66,14 +68,32 @@ func VMPoweroff(ctx context.Context, clients clients.ClientSets) {
 		log.Errorf("Failed to create %v event inside chaosresult", types.AwaitedVerdict)
 	}
 
-	if experimentsDetails.VMTag != "" {
-		// GET VM IDs FROM TAG
-		experimentsDetails.VMIds, err = vmware.GetVMIDFromTag(experimentsDetails.VMTag)
+	// LOGIN TO VCENTER
+	client, err := vmware.NewClient(experimentsDetails.VcenterServer, experimentsDetails.VcenterUser, experimentsDetails.VcenterPass, experimentsDetails.Datacenter)
+	if err != nil {
+		result.RecordAfterFailure(&chaosDetails, &resultDetails, err, clients, &eventsDetails)
+		log.Errorf("Vcenter Login failed: %v", err)
+		return
+	}
+	defer client.Logout()
+
+	if experimentsDetails.VMTag != "" || experimentsDetails.VMFolder != "" || experimentsDetails.VMResourcePool != "" || experimentsDetails.VMNames != "" {
+		// GET VM IDs FROM TAG, FOLDER, RESOURCE POOL OR NAMES
+		selector := vmware.VMSelector{
+			Tag:          experimentsDetails.VMTag,
+			Folder:       experimentsDetails.VMFolder,
+			ResourcePool: experimentsDetails.VMResourcePool,
+		}
+		if experimentsDetails.VMNames != "" {
+			selector.Names = strings.Split(experimentsDetails.VMNames, ",")
+		}
+		vmIds, err := client.GetVMIds(selector)
 		if err != nil {
 			result.RecordAfterFailure(&chaosDetails, &resultDetails, err, clients, &eventsDetails)
 			log.Errorf("Unable to get the VM ID, err: %v", err)
 			return
 		}
+		experimentsDetails.VMIds = strings.Join(vmIds, ",")
 	}
 
 	//DISPLAY THE VM INFORMATION
@@ -86,17 +106,9 @@ func VMPoweroff(ctx context.Context, clients clients.ClientSets) {
 	// Calling AbortWatcher go routine, it will continuously watch for the abort signal and generate the required events and result
 	go common.AbortWatcherWithoutExit(experimentsDetails.ExperimentName, clients, &resultDetails, &chaosDetails, &eventsDetails)
 
-	// GET SESSION ID TO LOGIN TO VCENTER
-	cookie, err := vmware.GetVcenterSessionID(experimentsDetails.VcenterServer, experimentsDetails.VcenterUser, experimentsDetails.VcenterPass)
-	if err != nil {
-		result.RecordAfterFailure(&chaosDetails, &resultDetails, err, clients, &eventsDetails)
-		log.Errorf("Vcenter Login failed: %v", err)
-		return
-	}
-
 	if chaosDetails.DefaultHealthCheck {
 		// PRE-CHAOS VM STATUS CHECK
-		if err := vmware.VMStatusCheck(experimentsDetails.VcenterServer, experimentsDetails.VMIds, cookie); err != nil {
+		if err := vmware.VMStatusCheck(client, experimentsDetails.VMIds); err != nil {
 			log.Errorf("VM status check failed: %v", err)
 			result.RecordAfterFailure(&chaosDetails, &resultDetails, err, clients, &eventsDetails)
 			return
@@ -132,7 +144,7 @@ func VMPoweroff(ctx context.Context, clients clients.ClientSets) {
 
 	chaosDetails.Phase = types.ChaosInjectPhase
 
-	if err = litmusLIB.InjectVMPowerOffChaos(ctx, &experimentsDetails, clients, &resultDetails, &eventsDetails, &chaosDetails, cookie); err != nil {
+	if err = litmusLIB.InjectVMPowerOffChaos(ctx, &experimentsDetails, clients, &resultDetails, &eventsDetails, &chaosDetails, provider.NewVSphere(client)); err != nil {
 		log.Errorf("Chaos injection failed: %v", err)
 		result.RecordAfterFailure(&chaosDetails, &resultDetails, err, clients, &eventsDetails)
 		return
@@ -146,7 +158,7 @@ func VMPoweroff(ctx context.Context, clients clients.ClientSets) {
 	if chaosDetails.DefaultHealthCheck {
 		//POST-CHAOS VM STATUS CHECK
 		log.Info("[Status]: Verify that the IUT (Instance Under Test) is running (post-chaos)")
-		if err := vmware.VMStatusCheck(experimentsDetails.VcenterServer, experimentsDetails.VMIds, cookie); err != nil {
+		if err := vmware.VMStatusCheck(client, experimentsDetails.VMIds); err != nil {
 			log.Errorf("VM status check failed: %v", err)
 			result.RecordAfterFailure(&chaosDetails, &resultDetails, err, clients, &eventsDetails)
 			return
@@ -32,6 +32,16 @@ func GetENV(experimentDetails *experimentTypes.ExperimentDetails, expName string
 	experimentDetails.MemoryPercentage, _ = strconv.Atoi(types.Getenv("MEMORY_PERCENTAGE", "80"))
 	experimentDetails.InstallDependencies = types.Getenv("INSTALL_DEPENDENCIES", "True")
 	experimentDetails.Sequence = types.Getenv("SEQUENCE", "parallel")
+	experimentDetails.FaultName = types.Getenv("FAULT_NAME", "")
+	experimentDetails.NetworkInterface = types.Getenv("NETWORK_INTERFACE", "eth0")
+	experimentDetails.NetworkLatency, _ = strconv.Atoi(types.Getenv("NETWORK_LATENCY", "2000"))
+	experimentDetails.Jitter, _ = strconv.Atoi(types.Getenv("JITTER", "0"))
+	experimentDetails.PacketLossPercentage, _ = strconv.Atoi(types.Getenv("NETWORK_PACKET_LOSS_PERCENTAGE", "100"))
+	experimentDetails.FillPercentage, _ = strconv.Atoi(types.Getenv("FILL_PERCENTAGE", "80"))
+	experimentDetails.FillPath = types.Getenv("FILL_PATH", "/")
+	experimentDetails.ProcessName = types.Getenv("PROCESS_NAME", "")
+	experimentDetails.ServiceName = types.Getenv("SERVICE_NAME", "")
+	experimentDetails.RevertDocumentName = experimentDetails.DocumentName + "-Revert"
 	switch expName {
 	case "aws-ssm-chaos-by-tag":
 		experimentDetails.EC2InstanceTag = types.Getenv("EC2_INSTANCE_TAG", "")
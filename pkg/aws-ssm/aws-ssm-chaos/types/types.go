@@ -33,4 +33,18 @@ type ExperimentDetails struct {
 	IsDocsUploaded       bool
 	CommandIDs           []string
 	TargetInstanceIDList []string
+	// FaultName is the name of the bundled fault, its document is used instead of the document path if it is provided
+	FaultName             string
+	NetworkInterface      string
+	NetworkLatency        int
+	Jitter                int
+	PacketLossPercentage  int
+	FillPercentage        int
+	FillPath              string
+	ProcessName           string
+	ServiceName           string
+	RevertDocumentName    string
+	IsRevertDocsUploaded  bool
+	FaultParameters       map[string]string
+	RevertFaultParameters map[string]string
 }
@@ -0,0 +1,33 @@
+package environment
+
+import (
+	"strconv"
+	"strings"
+
+	clientTypes "k8s.io/apimachinery/pkg/types"
+
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/azure/nsg-block/types"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+)
+
+// GetENV fetches all the env variables from the runner pod
+func GetENV(experimentDetails *experimentTypes.ExperimentDetails) {
+	experimentDetails.ExperimentName = types.Getenv("EXPERIMENT_NAME", "azure-nsg-block")
+	experimentDetails.ChaosNamespace = types.Getenv("CHAOS_NAMESPACE", "litmus")
+	experimentDetails.EngineName = types.Getenv("CHAOSENGINE", "")
+	experimentDetails.ChaosDuration, _ = strconv.Atoi(types.Getenv("TOTAL_CHAOS_DURATION", "60"))
+	experimentDetails.RampTime, _ = strconv.Atoi(types.Getenv("RAMP_TIME", "0"))
+	experimentDetails.ChaosUID = clientTypes.UID(types.Getenv("CHAOS_UID", ""))
+	experimentDetails.InstanceID = types.Getenv("INSTANCE_ID", "")
+	experimentDetails.ChaosPodName = types.Getenv("POD_NAME", "")
+	experimentDetails.Delay, _ = strconv.Atoi(types.Getenv("STATUS_CHECK_DELAY", "2"))
+	experimentDetails.Timeout, _ = strconv.Atoi(types.Getenv("STATUS_CHECK_TIMEOUT", "180"))
+	experimentDetails.ResourceGroup = types.Getenv("RESOURCE_GROUP", "")
+	experimentDetails.NetworkSecurityGroup = strings.TrimSpace(types.Getenv("NETWORK_SECURITY_GROUP", ""))
+	experimentDetails.RulePriority, _ = strconv.Atoi(types.Getenv("RULE_PRIORITY", "100"))
+	experimentDetails.Direction = types.Getenv("DIRECTION", "Inbound")
+	experimentDetails.Protocol = types.Getenv("PROTOCOL", "*")
+	experimentDetails.SourceAddressPrefix = types.Getenv("SOURCE_ADDRESS_PREFIX", "*")
+	experimentDetails.DestinationAddressPrefix = types.Getenv("DESTINATION_ADDRESS_PREFIX", "*")
+	experimentDetails.DestinationPorts = types.Getenv("DESTINATION_PORTS", "*")
+}
@@ -0,0 +1,24 @@
+package types
+
+import (
+	clientTypes "k8s.io/apimachinery/pkg/types"
+)
+
+// ExperimentDetails is for collecting all the experiment-related details
+type ExperimentDetails struct {
+	ExperimentName                 string
+	EngineName                     string
+	RampTime                       int
+	ChaosDuration                  int
+	ChaosUID                       clientTypes.UID
+	InstanceID                     string
+	ChaosNamespace                 string
+	ChaosPodName                   string
+	Timeout                        int
+	Delay                          int
+	ResourceGroup                  string
+	SubscriptionID                 string
+	Zone                           string
+	TargetInstanceNameList         []string
+	TargetScaleSetInstanceNameList []string
+}
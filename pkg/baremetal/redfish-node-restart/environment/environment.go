@@ -26,4 +26,10 @@ func GetENV(experimentDetails *experimentTypes.ExperimentDetails) {
 	experimentDetails.IPMIIP = types.Getenv("IPMI_IP", "")
 	experimentDetails.User = types.Getenv("USER", "")
 	experimentDetails.Password = types.Getenv("PASSWORD", "")
+	experimentDetails.SystemID = types.Getenv("SYSTEM_ID", "")
+	experimentDetails.ResetType = types.Getenv("RESET_TYPE", "ForceRestart")
+	experimentDetails.UseSession, _ = strconv.ParseBool(types.Getenv("USE_SESSION", "false"))
+	experimentDetails.CACertFile = types.Getenv("CA_CERT_FILE", "")
+	experimentDetails.InsecureSkipVerify, _ = strconv.ParseBool(types.Getenv("INSECURE_SKIP_VERIFY", "true"))
+	experimentDetails.RequestTimeout, _ = strconv.Atoi(types.Getenv("REQUEST_TIMEOUT", "60"))
 }
@@ -0,0 +1,239 @@
+package redfish
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+)
+
+// sessionsPath is the collection of the sessions of the session service
+const sessionsPath = "/redfish/v1/SessionService/Sessions"
+
+// Options contains the details of the redfish service of the baremetal node
+type Options struct {
+	// Endpoint is the address of the BMC, like 10.0.0.10 or https://10.0.0.10:8443, https is used if the scheme isn't provided
+	Endpoint string
+	User     string
+	Password string
+	// UseSession authenticates the requests with the token of a redfish session, instead of the basic authentication
+	UseSession bool
+	// CACertFile is the path of the PEM encoded CA certificates, which are used to verify the certificate of the BMC
+	CACertFile string
+	// InsecureSkipVerify skips the verification of the certificate of the BMC, it is ignored if the CACertFile is provided
+	InsecureSkipVerify bool
+	// Timeout is the timeout of each request in seconds, the requests don't time out if it is zero
+	Timeout int
+}
+
+// Client is the client of the redfish service of the baremetal node
+type Client struct {
+	endpoint   string
+	user       string
+	password   string
+	useSession bool
+	httpClient *http.Client
+
+	// sessionLock guards the token and the uri of the redfish session
+	sessionLock sync.Mutex
+	token       string
+	sessionURI  string
+}
+
+// NewClient returns the client of the redfish service of the given options, the redfish session is created if UseSession is set
+func NewClient(opts Options) (*Client, error) {
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: opts.InsecureSkipVerify}
+	if opts.CACertFile != "" {
+		caCert, err := os.ReadFile(opts.CACertFile)
+		if err != nil {
+			return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("failed to read the CA certificate file: %v", err)}
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("no PEM encoded certificate found in the %v CA certificate file", opts.CACertFile)}
+		}
+		tlsConfig = &tls.Config{RootCAs: pool}
+	}
+
+	endpoint := strings.TrimSuffix(opts.Endpoint, "/")
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+
+	c := &Client{
+		endpoint:   endpoint,
+		user:       opts.User,
+		password:   opts.Password,
+		useSession: opts.UseSession,
+		httpClient: &http.Client{
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+			Timeout:   time.Duration(opts.Timeout) * time.Second,
+		},
+	}
+	if c.useSession {
+		if err := c.login(); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// login creates the redfish session, the token of the session authenticates the later requests
+func (c *Client) login() error {
+	c.sessionLock.Lock()
+	defer c.sessionLock.Unlock()
+
+	credentials := map[string]string{"UserName": c.user, "Password": c.password}
+	resp, err := c.send(http.MethodPost, sessionsPath, credentials, "")
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("failed to create the redfish session: %v", err)}
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("failed to create the redfish session: %v", err)}
+	}
+	token := resp.Header.Get("X-Auth-Token")
+	if token == "" {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: "failed to create the redfish session: no X-Auth-Token header in the response"}
+	}
+
+	// the location of the session can be an absolute url, only its path is kept to delete the session
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("failed to parse the location of the redfish session: %v", err)}
+	}
+
+	c.token = token
+	c.sessionURI = location.Path
+	return nil
+}
+
+// Logout deletes the redfish session of the client, if any
+func (c *Client) Logout() error {
+	c.sessionLock.Lock()
+	defer c.sessionLock.Unlock()
+
+	if c.sessionURI == "" {
+		return nil
+	}
+
+	resp, err := c.send(http.MethodDelete, c.sessionURI, nil, c.token)
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("failed to delete the redfish session: %v", err)}
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("failed to delete the redfish session: %v", err)}
+	}
+	c.token, c.sessionURI = "", ""
+	return nil
+}
+
+// do sends the request to the given path of the redfish service and decodes the response into out, if provided
+// the redfish session is created again and the request is retried once, if the session has expired
+func (c *Client) do(method, path string, body, out interface{}) error {
+
+	resp, err := c.request(method, path, body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && c.useSession {
+		resp.Body.Close()
+		log.Info("[Info]: The redfish session has expired, logging in again")
+		if err := c.login(); err != nil {
+			return err
+		}
+		if resp, err = c.request(method, path, body); err != nil {
+			return err
+		}
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return err
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode the response of %v: %v", path, err)
+	}
+	return nil
+}
+
+// request sends the request with the token of the redfish session, if any
+func (c *Client) request(method, path string, body interface{}) (*http.Response, error) {
+	c.sessionLock.Lock()
+	token := c.token
+	c.sessionLock.Unlock()
+
+	return c.send(method, path, body, token)
+}
+
+// send sends the request to the given path of the redfish service
+// it is authenticated with the token if provided, the basic authentication is used otherwise except for the creation of the session
+func (c *Client) send(method, path string, body interface{}, token string) (*http.Response, error) {
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode the request body: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.endpoint+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the %v request: %v", method, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	switch {
+	case token != "":
+		req.Header.Set("X-Auth-Token", token)
+	case path != sessionsPath:
+		req.SetBasicAuth(c.user, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%v request to %v failed: %v", method, path, err)
+	}
+	return resp, nil
+}
+
+// checkStatus returns the error of the response, if the request wasn't successful
+func checkStatus(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	// the redfish error contains the message of the failure, only the status is returned if it can't be decoded
+	var redfishErr struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&redfishErr); err != nil || redfishErr.Error.Message == "" {
+		return fmt.Errorf("%v request to %v failed with status: %v", resp.Request.Method, resp.Request.URL.Path, resp.Status)
+	}
+	return fmt.Errorf("%v request to %v failed with status: %v, %v", resp.Request.Method, resp.Request.URL.Path, resp.Status, redfishErr.Error.Message)
+}
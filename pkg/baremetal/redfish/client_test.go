@@ -0,0 +1,199 @@
+package redfish
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockServer is the redfish service of a baremetal node with two systems, it accepts the basic authentication and the session tokens
+type mockServer struct {
+	*httptest.Server
+
+	lock       sync.Mutex
+	sessions   map[string]bool
+	logins     int
+	powerState map[string]string
+	resets     []string
+}
+
+func newMockServer(t *testing.T) *mockServer {
+	m := &mockServer{
+		sessions:   map[string]bool{},
+		powerState: map[string]string{"1": PowerStateOn, "2": PowerStateOn},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/redfish/v1/SessionService/Sessions", func(w http.ResponseWriter, r *http.Request) {
+		var credentials map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&credentials); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if credentials["UserName"] != "admin" || credentials["Password"] != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		m.logins++
+		m.sessions["token"] = true
+		w.Header().Set("X-Auth-Token", "token")
+		w.Header().Set("Location", m.URL+"/redfish/v1/SessionService/Sessions/1")
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("/redfish/v1/SessionService/Sessions/1", m.authenticated(func(w http.ResponseWriter, r *http.Request) {
+		m.sessions = map[string]bool{}
+	}))
+	mux.HandleFunc("/redfish/v1/Systems", m.authenticated(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, map[string]interface{}{"Members": []map[string]string{{"@odata.id": "/redfish/v1/Systems/1"}, {"@odata.id": "/redfish/v1/Systems/2"}}})
+	}))
+	// the first system advertises its reset action, the second one uses the standard uri
+	mux.HandleFunc("/redfish/v1/Systems/1", m.authenticated(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, map[string]interface{}{
+			"@odata.id":  "/redfish/v1/Systems/1",
+			"Id":         "1",
+			"PowerState": m.powerState["1"],
+			"Actions": map[string]interface{}{
+				"#ComputerSystem.Reset": map[string]interface{}{
+					"target":                            "/redfish/v1/Systems/1/Actions/Reset",
+					"ResetType@Redfish.AllowableValues": []string{ResetOn, ResetForceOff, ResetGracefulShutdown, ResetForceRestart},
+				},
+			},
+		})
+	}))
+	mux.HandleFunc("/redfish/v1/Systems/2", m.authenticated(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, map[string]interface{}{"@odata.id": "/redfish/v1/Systems/2", "Id": "2", "PowerState": m.powerState["2"]})
+	}))
+	for id, target := range map[string]string{"1": "/redfish/v1/Systems/1/Actions/Reset", "2": "/redfish/v1/Systems/2/Actions/ComputerSystem.Reset"} {
+		id := id
+		mux.HandleFunc(target, m.authenticated(func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			m.resets = append(m.resets, id+":"+body["ResetType"])
+			switch body["ResetType"] {
+			case ResetForceOff, ResetGracefulShutdown:
+				m.powerState[id] = PowerStateOff
+			case ResetOn:
+				m.powerState[id] = PowerStateOn
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}))
+	}
+
+	m.Server = httptest.NewTLSServer(mux)
+	t.Cleanup(m.Close)
+	return m
+}
+
+// authenticated serves the request only if it has the basic authentication or the token of a session
+func (m *mockServer) authenticated(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+
+		user, password, ok := r.BasicAuth()
+		if !(ok && user == "admin" && password == "secret") && !m.sessions[r.Header.Get("X-Auth-Token")] {
+			w.WriteHeader(http.StatusUnauthorized)
+			writeJSON(nil, w, map[string]interface{}{"error": map[string]string{"message": "authentication required"}})
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// writeJSON writes the response, the failure is reported with assert as it runs inside the goroutine of the handler
+func writeJSON(t *testing.T, w http.ResponseWriter, response interface{}) {
+	err := json.NewEncoder(w).Encode(response)
+	if t != nil {
+		assert.NoError(t, err)
+	}
+}
+
+// caCertFile writes the certificate of the mock server to a PEM file
+func (m *mockServer) caCertFile(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "ca.crt")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: m.Certificate().Raw})
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	return path
+}
+
+func TestSystems(t *testing.T) {
+	server := newMockServer(t)
+	client, err := NewClient(Options{Endpoint: server.URL, User: "admin", Password: "secret", CACertFile: server.caCertFile(t)})
+	require.NoError(t, err)
+
+	systems, err := client.GetSystems()
+	require.NoError(t, err)
+	require.Len(t, systems, 2)
+	assert.Equal(t, "/redfish/v1/Systems/1/Actions/Reset", systems[0].ResetTarget)
+	assert.Equal(t, "/redfish/v1/Systems/2/Actions/ComputerSystem.Reset", systems[1].ResetTarget)
+
+	// the system should be provided if the redfish service has more than one system
+	_, err = client.GetSystem("")
+	assert.ErrorContains(t, err, "found 2 systems [1 2]")
+	_, err = client.GetSystem("3")
+	assert.ErrorContains(t, err, "system not found")
+	system, err := client.GetSystem("/redfish/v1/Systems/2")
+	require.NoError(t, err)
+	assert.Equal(t, "2", system.ID)
+
+	require.NoError(t, NodeStatusCheck(client, "1"))
+	require.NoError(t, client.Reset("1", ResetGracefulShutdown))
+	require.NoError(t, WaitForPowerState(1, 1, client, "1", PowerStateOff))
+	assert.ErrorContains(t, NodeStatusCheck(client, "1"), "node is not in On state, current state: Off")
+	require.NoError(t, client.Reset("1", ResetOn))
+	require.NoError(t, WaitForPowerState(1, 1, client, "1", PowerStateOn))
+
+	// the reset types, which aren't advertised by the system, aren't triggered
+	assert.ErrorContains(t, client.Reset("1", ResetNmi), "Nmi reset type is not supported by the system")
+	require.NoError(t, client.Reset("2", ResetNmi))
+	assert.Equal(t, []string{"1:GracefulShutdown", "1:On", "2:Nmi"}, server.resets)
+}
+
+func TestAuthentication(t *testing.T) {
+	server := newMockServer(t)
+
+	// the certificate of the mock server isn't trusted without the CA certificate
+	client, err := NewClient(Options{Endpoint: server.URL, User: "admin", Password: "secret"})
+	require.NoError(t, err)
+	_, err = client.GetSystems()
+	assert.ErrorContains(t, err, "certificate")
+
+	client, err = NewClient(Options{Endpoint: server.URL, User: "admin", Password: "wrong", InsecureSkipVerify: true})
+	require.NoError(t, err)
+	_, err = client.GetSystems()
+	assert.ErrorContains(t, err, "401 Unauthorized, authentication required")
+
+	_, err = NewClient(Options{Endpoint: server.URL, User: "admin", Password: "wrong", UseSession: true, InsecureSkipVerify: true})
+	assert.ErrorContains(t, err, "failed to create the redfish session")
+
+	client, err = NewClient(Options{Endpoint: server.URL, User: "admin", Password: "secret", UseSession: true, InsecureSkipVerify: true})
+	require.NoError(t, err)
+	assert.Equal(t, "/redfish/v1/SessionService/Sessions/1", client.sessionURI)
+	_, err = client.GetSystems()
+	require.NoError(t, err)
+
+	// the expired session is created again by the next request
+	server.lock.Lock()
+	server.sessions = map[string]bool{}
+	server.lock.Unlock()
+	_, err = client.GetSystems()
+	require.NoError(t, err)
+	assert.Equal(t, 2, server.logins)
+
+	require.NoError(t, client.Logout())
+	assert.Empty(t, server.sessions)
+	assert.Empty(t, client.token)
+}
@@ -0,0 +1,242 @@
+package redfish
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
+	"github.com/palantir/stacktrace"
+)
+
+// systemsPath is the collection of the computer systems of the redfish service
+const systemsPath = "/redfish/v1/Systems"
+
+// the reset types of the ComputerSystem.Reset action
+const (
+	ResetForceRestart     = "ForceRestart"
+	ResetGracefulShutdown = "GracefulShutdown"
+	ResetForceOff         = "ForceOff"
+	ResetOn               = "On"
+	ResetPowerCycle       = "PowerCycle"
+	ResetNmi              = "Nmi"
+)
+
+// the power states of the computer system
+const (
+	PowerStateOn  = "On"
+	PowerStateOff = "Off"
+)
+
+// System is the computer system of the redfish service
+type System struct {
+	// ID is the id of the system, like System.Embedded.1 for the Dell iDRAC
+	ID         string
+	Name       string
+	ODataID    string
+	PowerState string
+	// ResetTarget is the uri of the ComputerSystem.Reset action
+	ResetTarget string
+	// ResetTypes are the reset types allowed by the system, any reset type is triggered if the system doesn't advertise them
+	ResetTypes []string
+}
+
+// computerSystem is the response of the computer system resource
+type computerSystem struct {
+	ODataID    string `json:"@odata.id"`
+	ID         string `json:"Id"`
+	Name       string `json:"Name"`
+	PowerState string `json:"PowerState"`
+	Actions    struct {
+		Reset struct {
+			Target          string   `json:"target"`
+			AllowableValues []string `json:"ResetType@Redfish.AllowableValues"`
+		} `json:"#ComputerSystem.Reset"`
+	} `json:"Actions"`
+}
+
+// systemCollection is the response of the computer system collection
+type systemCollection struct {
+	Members []struct {
+		ODataID string `json:"@odata.id"`
+	} `json:"Members"`
+}
+
+// GetSystems returns the computer systems, which are the members of the systems collection
+func (c *Client) GetSystems() ([]System, error) {
+
+	var collection systemCollection
+	if err := c.do(http.MethodGet, systemsPath, nil, &collection); err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: fmt.Sprintf("failed to get the systems: %v", err)}
+	}
+
+	systems := make([]System, 0, len(collection.Members))
+	for _, member := range collection.Members {
+		var system computerSystem
+		if err := c.do(http.MethodGet, member.ODataID, nil, &system); err != nil {
+			return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: fmt.Sprintf("failed to get the system: %v", err), Target: fmt.Sprintf("{System: %v}", member.ODataID)}
+		}
+		systems = append(systems, toSystem(member.ODataID, system))
+	}
+
+	return systems, nil
+}
+
+// GetSystem returns the computer system of the given id or uri
+// the only system of the redfish service is returned if the id is empty
+func (c *Client) GetSystem(systemID string) (System, error) {
+
+	systems, err := c.GetSystems()
+	if err != nil {
+		return System{}, err
+	}
+
+	if systemID == "" {
+		if len(systems) == 1 {
+			return systems[0], nil
+		}
+		return System{}, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: fmt.Sprintf("found %v systems %v, the target system should be provided", len(systems), systemIDs(systems))}
+	}
+
+	for _, system := range systems {
+		if system.ID == systemID || strings.TrimSuffix(system.ODataID, "/") == strings.TrimSuffix(systemID, "/") {
+			return system, nil
+		}
+	}
+	return System{}, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: fmt.Sprintf("system not found, the available systems are %v", systemIDs(systems)), Target: fmt.Sprintf("{System: %v}", systemID)}
+}
+
+// GetPowerState returns the power state of the given computer system, like On or Off
+func (c *Client) GetPowerState(systemID string) (string, error) {
+
+	system, err := c.GetSystem(systemID)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "failed to get the power state of the system")
+	}
+	return system.PowerState, nil
+}
+
+// Reset triggers the ComputerSystem.Reset action of the given reset type on the computer system
+func (c *Client) Reset(systemID, resetType string) error {
+
+	errorCode := cerrors.ErrorTypeChaosInject
+	if resetType == ResetOn {
+		errorCode = cerrors.ErrorTypeChaosRevert
+	}
+
+	system, err := c.GetSystem(systemID)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to get the system to reset")
+	}
+	if len(system.ResetTypes) != 0 && !contains(system.ResetTypes, resetType) {
+		return cerrors.Error{
+			ErrorCode: errorCode,
+			Reason:    fmt.Sprintf("%v reset type is not supported by the system, the supported reset types are %v", resetType, system.ResetTypes),
+			Target:    fmt.Sprintf("{System: %v}", system.ID),
+		}
+	}
+
+	log.Infof("[Info]: Triggering the %v reset of the %v system", resetType, system.ID)
+	if err := c.do(http.MethodPost, system.ResetTarget, map[string]string{"ResetType": resetType}, nil); err != nil {
+		return cerrors.Error{
+			ErrorCode: errorCode,
+			Reason:    fmt.Sprintf("failed to trigger the %v reset: %v", resetType, err),
+			Target:    fmt.Sprintf("{System: %v}", system.ID),
+		}
+	}
+
+	return nil
+}
+
+// NodeStatusCheck checks that the given computer system is powered on
+func NodeStatusCheck(client *Client, systemID string) error {
+
+	powerState, err := client.GetPowerState(systemID)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to get the node status")
+	}
+	if powerState != PowerStateOn {
+		return cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeStatusChecks,
+			Reason:    fmt.Sprintf("node is not in %v state, current state: %v", PowerStateOn, powerState),
+			Target:    fmt.Sprintf("{System: %v}", systemID),
+		}
+	}
+
+	return nil
+}
+
+// WaitForPowerState waits for the given computer system to attain the given power state
+func WaitForPowerState(timeout, delay int, client *Client, systemID, state string) error {
+
+	errorCode := cerrors.ErrorTypeChaosInject
+	if state == PowerStateOn {
+		errorCode = cerrors.ErrorTypeChaosRevert
+	}
+
+	log.Info("[Status]: Checking the power state of the node")
+	return retry.Times(uint(timeout / delay)).
+		Wait(time.Duration(delay) * time.Second).
+		Try(func(attempt uint) error {
+
+			powerState, err := client.GetPowerState(systemID)
+			if err != nil {
+				return stacktrace.Propagate(err, "failed to get the node status")
+			}
+
+			log.Infof("The node power state is %v", powerState)
+			if powerState != state {
+				return cerrors.Error{
+					ErrorCode: errorCode,
+					Reason:    fmt.Sprintf("node is not in %v state", state),
+					Target:    fmt.Sprintf("{System: %v}", systemID),
+				}
+			}
+
+			return nil
+		})
+}
+
+// toSystem returns the system of the computer system resource of the given uri
+// the standard uri of the reset action is used, if the system doesn't advertise the target of its reset action
+func toSystem(uri string, system computerSystem) System {
+
+	if system.ODataID != "" {
+		uri = system.ODataID
+	}
+	resetTarget := system.Actions.Reset.Target
+	if resetTarget == "" {
+		resetTarget = strings.TrimSuffix(uri, "/") + "/Actions/ComputerSystem.Reset"
+	}
+
+	return System{
+		ID:          system.ID,
+		Name:        system.Name,
+		ODataID:     uri,
+		PowerState:  system.PowerState,
+		ResetTarget: resetTarget,
+		ResetTypes:  system.Actions.Reset.AllowableValues,
+	}
+}
+
+// systemIDs returns the ids of the given systems
+func systemIDs(systems []System) []string {
+	ids := make([]string, 0, len(systems))
+	for _, system := range systems {
+		ids = append(ids, system.ID)
+	}
+	return ids
+}
+
+// contains checks whether the value is present in the list
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
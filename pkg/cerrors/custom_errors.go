@@ -30,6 +30,9 @@ const (
 	FailureTypePromProbe       ErrorType = "PROM_PROBE_FAILURE"
 	ErrorTypeTimeout           ErrorType = "TIMEOUT"
 	FailureTypeProbeTimeout    ErrorType = "PROBE_TIMEOUT"
+	ErrorTypePolicyViolation   ErrorType = "POLICY_VIOLATION_ERROR"
+	FailureTypeGuardProbe      ErrorType = "GUARD_PROBE_FAILURE"
+	ErrorTypeDryRun            ErrorType = "DRY_RUN"
 )
 
 type userFriendly interface {
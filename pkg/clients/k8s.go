@@ -2,10 +2,12 @@ package clients
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	core_v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/litmuschaos/litmus-go/pkg/types"
@@ -193,3 +195,24 @@ func (clients *ClientSets) UpdateNode(chaosDetails *types.ChaosDetails, node *co
 			return err
 		})
 }
+
+// DeleteHelperPods deletes the helper pods of the run, the helpers revert the chaos on their own once they are terminated
+// the helpers are identified by the chaosUID of the run, so nothing is deleted if the chaosUID is not known
+func (clients *ClientSets) DeleteHelperPods(chaosDetails *types.ChaosDetails) error {
+	if chaosDetails.ChaosUID == "" {
+		return nil
+	}
+	pods, err := clients.ListPods(chaosDetails.ChaosNamespace, "chaosUID="+string(chaosDetails.ChaosUID))
+	if err != nil {
+		return err
+	}
+	for _, pod := range pods.Items {
+		if !strings.Contains(pod.Labels["app"], "-helper-") || pod.DeletionTimestamp != nil {
+			continue
+		}
+		if err := clients.KubeClient.CoreV1().Pods(pod.Namespace).Delete(context.Background(), pod.Name, v1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,18 +1,70 @@
 package common
 
 import (
+	"os"
+	"strconv"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/pkg/errors"
 )
 
+// SessionOptions contains the overrides of the aws session
+type SessionOptions struct {
+	Region string
+	// Endpoint overrides the endpoint of the aws services, like the localstack or the vpc endpoints
+	Endpoint string
+	// RoleARN is the role, which is assumed with the credentials of the shared config
+	RoleARN string
+	// MaxRetries overrides the number of retries of the failed aws requests
+	MaxRetries int
+}
+
 // GetAWSSession will return the aws session for a given region
+// the overrides of the session are derived from the AWS_ENDPOINT_URL, AWS_ASSUME_ROLE_ARN and AWS_MAX_RETRIES envs
 func GetAWSSession(region string) *session.Session {
-	return session.Must(session.NewSessionWithOptions(session.Options{
+	return session.Must(NewAWSSession(GetSessionOptionsFromENV(region)))
+}
+
+// GetSessionOptionsFromENV returns the overrides of the aws session for a given region from the envs
+func GetSessionOptionsFromENV(region string) SessionOptions {
+	maxRetries, err := strconv.Atoi(os.Getenv("AWS_MAX_RETRIES"))
+	if err != nil {
+		maxRetries = 0
+	}
+	return SessionOptions{
+		Region:     region,
+		Endpoint:   os.Getenv("AWS_ENDPOINT_URL"),
+		RoleARN:    os.Getenv("AWS_ASSUME_ROLE_ARN"),
+		MaxRetries: maxRetries,
+	}
+}
+
+// NewAWSSession returns the aws session with the given overrides
+func NewAWSSession(opts SessionOptions) (*session.Session, error) {
+	config := aws.Config{Region: aws.String(opts.Region)}
+	if opts.Endpoint != "" {
+		config.Endpoint = aws.String(opts.Endpoint)
+		// the custom endpoints, like localstack, don't serve the bucket subdomains
+		config.S3ForcePathStyle = aws.Bool(true)
+	}
+	if opts.MaxRetries > 0 {
+		config.MaxRetries = aws.Int(opts.MaxRetries)
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
 		SharedConfigState: session.SharedConfigEnable,
-		Config:            aws.Config{Region: aws.String(region)},
-	}))
+		Config:            config,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if opts.RoleARN != "" {
+		sess = sess.Copy(&aws.Config{Credentials: stscreds.NewCredentials(sess, opts.RoleARN)})
+	}
+	return sess, nil
 }
 
 // CheckAWSError will return the aws errors
@@ -0,0 +1,301 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/cloud/aws/common"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/sirupsen/logrus"
+)
+
+// chaosTagKey is the tag of the aws resources, which are created by the chaos
+const chaosTagKey = "litmuschaos.io/chaos-uid"
+
+// AZOutage contains the original network settings of the availability zone, which are restored after the outage
+type AZOutage struct {
+	Zone    string
+	ChaosID string
+	// SecurityGroups contains the original security groups of the isolated network interfaces
+	SecurityGroups map[string][]string
+	// DenyAllSecurityGroups contains the deny-all security group of each vpc
+	DenyAllSecurityGroups map[string]string
+	// CreatedSecurityGroups contains the deny-all security groups, which are created by the chaos
+	CreatedSecurityGroups []string
+	// NetworkACLAssociations contains the original network acl associations of the isolated subnets
+	NetworkACLAssociations map[string]NetworkACLAssociation
+	// DenyNetworkACLs contains the deny network acl of each vpc, these are created by the chaos
+	DenyNetworkACLs map[string]string
+}
+
+// NetworkACLAssociation is the association of the subnet with the network acl
+type NetworkACLAssociation struct {
+	// AssociationID is the current association of the subnet with the deny network acl
+	AssociationID string
+	// NetworkACLID is the original network acl of the subnet
+	NetworkACLID string
+}
+
+// NewAZOutage returns the empty outage of the availability zone
+// the chaosID tags the security groups and the network acls, which are created by the chaos
+func NewAZOutage(zone, chaosID string) *AZOutage {
+	return &AZOutage{
+		Zone:                   zone,
+		ChaosID:                chaosID,
+		SecurityGroups:         map[string][]string{},
+		DenyAllSecurityGroups:  map[string]string{},
+		NetworkACLAssociations: map[string]NetworkACLAssociation{},
+		DenyNetworkACLs:        map[string]string{},
+	}
+}
+
+// GetEC2Client returns the ec2 client for the given region
+func GetEC2Client(region string) ec2iface.EC2API {
+	return ec2.New(common.GetAWSSession(region))
+}
+
+// GetZoneInstances returns the running instances of the availability zone, which are filtered by the given instance tag
+func GetZoneInstances(ec2Svc ec2iface.EC2API, instanceTag, zone string) ([]*ec2.Instance, error) {
+	filters := []*ec2.Filter{
+		{Name: aws.String("availability-zone"), Values: []*string{aws.String(zone)}},
+		{Name: aws.String("instance-state-name"), Values: []*string{aws.String(ec2.InstanceStateNameRunning)}},
+	}
+	if instanceTag != "" {
+		tag := strings.SplitN(instanceTag, ":", 2)
+		if len(tag) != 2 {
+			return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{EC2 Instance Tag: %v, Zone: %v}", instanceTag, zone), Reason: "invalid instance tag, it should be in key:value format"}
+		}
+		filters = append(filters, &ec2.Filter{Name: aws.String("tag:" + tag[0]), Values: []*string{aws.String(tag[1])}})
+	}
+
+	var instances []*ec2.Instance
+	err := ec2Svc.DescribeInstancesPages(&ec2.DescribeInstancesInput{Filters: filters}, func(page *ec2.DescribeInstancesOutput, lastPage bool) bool {
+		for _, reservation := range page.Reservations {
+			instances = append(instances, reservation.Instances...)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{EC2 Instance Tag: %v, Zone: %v}", instanceTag, zone), Reason: fmt.Sprintf("failed to list the instances: %v", common.CheckAWSError(err).Error())}
+	}
+	return instances, nil
+}
+
+// IsolateBySecurityGroup replaces the security groups of the network interfaces of the instances with the deny-all security group
+// the given deny-all security group is used for all the instances, otherwise a deny-all security group is created in each vpc
+func IsolateBySecurityGroup(ec2Svc ec2iface.EC2API, instances []*ec2.Instance, denyAllSecurityGroupID string, outage *AZOutage) error {
+	for _, instance := range instances {
+		for _, eni := range instance.NetworkInterfaces {
+			eniID := aws.StringValue(eni.NetworkInterfaceId)
+			if _, ok := outage.SecurityGroups[eniID]; ok {
+				continue
+			}
+
+			denyAll := denyAllSecurityGroupID
+			if denyAll == "" {
+				var err error
+				if denyAll, err = getDenyAllSecurityGroup(ec2Svc, aws.StringValue(eni.VpcId), outage); err != nil {
+					return err
+				}
+			}
+
+			var groups []string
+			for _, group := range eni.Groups {
+				groups = append(groups, aws.StringValue(group.GroupId))
+			}
+			if err := setSecurityGroups(ec2Svc, eniID, []string{denyAll}); err != nil {
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: fmt.Sprintf("{EC2 Instance ID: %v, Network Interface: %v}", aws.StringValue(instance.InstanceId), eniID), Reason: fmt.Sprintf("failed to isolate the network interface: %v", err)}
+			}
+			outage.SecurityGroups[eniID] = groups
+
+			log.InfoWithValues("[Chaos]: Isolated the network interface with the deny-all security group", logrus.Fields{
+				"InstanceId":             aws.StringValue(instance.InstanceId),
+				"NetworkInterfaceId":     eniID,
+				"OriginalSecurityGroups": groups,
+			})
+		}
+	}
+	return nil
+}
+
+// IsolateByNetworkACL associates the subnets of the instances with a deny network acl
+// all the instances of these subnets are isolated, not only the given instances
+func IsolateByNetworkACL(ec2Svc ec2iface.EC2API, instances []*ec2.Instance, outage *AZOutage) error {
+	for _, instance := range instances {
+		subnetID, vpcID := aws.StringValue(instance.SubnetId), aws.StringValue(instance.VpcId)
+		if subnetID == "" {
+			continue
+		}
+		if _, ok := outage.NetworkACLAssociations[subnetID]; ok {
+			continue
+		}
+
+		association, err := getNetworkACLAssociation(ec2Svc, subnetID)
+		if err != nil {
+			return err
+		}
+		denyACL, err := getDenyNetworkACL(ec2Svc, vpcID, outage)
+		if err != nil {
+			return err
+		}
+		result, err := ec2Svc.ReplaceNetworkAclAssociation(&ec2.ReplaceNetworkAclAssociationInput{
+			AssociationId: aws.String(association.AssociationID),
+			NetworkAclId:  aws.String(denyACL),
+		})
+		if err != nil {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: fmt.Sprintf("{Subnet ID: %v, Zone: %v}", subnetID, outage.Zone), Reason: fmt.Sprintf("failed to associate the deny network acl: %v", common.CheckAWSError(err).Error())}
+		}
+		outage.NetworkACLAssociations[subnetID] = NetworkACLAssociation{AssociationID: aws.StringValue(result.NewAssociationId), NetworkACLID: association.NetworkACLID}
+
+		log.InfoWithValues("[Chaos]: Isolated the subnet with the deny network acl", logrus.Fields{
+			"SubnetId":           subnetID,
+			"OriginalNetworkAcl": association.NetworkACLID,
+			"DenyNetworkAcl":     denyACL,
+		})
+	}
+	return nil
+}
+
+// RestoreAZ restores the original security groups and network acl associations of the availability zone
+// and deletes the deny-all security groups and the deny network acls, which are created by the chaos
+// the restored settings are removed from the outage, so that it can be retried after the failures
+func RestoreAZ(ec2Svc ec2iface.EC2API, outage *AZOutage) error {
+	var errs []string
+
+	for eniID, groups := range outage.SecurityGroups {
+		if err := setSecurityGroups(ec2Svc, eniID, groups); err != nil {
+			errs = append(errs, fmt.Sprintf("failed to restore the security groups of %v network interface: %v", eniID, err))
+			continue
+		}
+		delete(outage.SecurityGroups, eniID)
+		log.Infof("[Revert]: Restored the security groups %v of the %v network interface", groups, eniID)
+	}
+
+	for subnetID, association := range outage.NetworkACLAssociations {
+		if _, err := ec2Svc.ReplaceNetworkAclAssociation(&ec2.ReplaceNetworkAclAssociationInput{
+			AssociationId: aws.String(association.AssociationID),
+			NetworkAclId:  aws.String(association.NetworkACLID),
+		}); err != nil {
+			errs = append(errs, fmt.Sprintf("failed to restore the network acl of %v subnet: %v", subnetID, common.CheckAWSError(err).Error()))
+			continue
+		}
+		delete(outage.NetworkACLAssociations, subnetID)
+		log.Infof("[Revert]: Restored the %v network acl of the %v subnet", association.NetworkACLID, subnetID)
+	}
+
+	// the chaos resources can only be deleted once they aren't used by any network interface or subnet
+	if len(errs) == 0 {
+		var remaining []string
+		for _, groupID := range outage.CreatedSecurityGroups {
+			if _, err := ec2Svc.DeleteSecurityGroup(&ec2.DeleteSecurityGroupInput{GroupId: aws.String(groupID)}); err != nil {
+				errs = append(errs, fmt.Sprintf("failed to delete the %v deny-all security group: %v", groupID, common.CheckAWSError(err).Error()))
+				remaining = append(remaining, groupID)
+			}
+		}
+		outage.CreatedSecurityGroups = remaining
+
+		for vpcID, aclID := range outage.DenyNetworkACLs {
+			if _, err := ec2Svc.DeleteNetworkAcl(&ec2.DeleteNetworkAclInput{NetworkAclId: aws.String(aclID)}); err != nil {
+				errs = append(errs, fmt.Sprintf("failed to delete the %v deny network acl: %v", aclID, common.CheckAWSError(err).Error()))
+				continue
+			}
+			delete(outage.DenyNetworkACLs, vpcID)
+		}
+	}
+
+	if len(errs) != 0 {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{Zone: %v}", outage.Zone), Reason: strings.Join(errs, ", ")}
+	}
+	return nil
+}
+
+func setSecurityGroups(ec2Svc ec2iface.EC2API, eniID string, groups []string) error {
+	_, err := ec2Svc.ModifyNetworkInterfaceAttribute(&ec2.ModifyNetworkInterfaceAttributeInput{
+		NetworkInterfaceId: aws.String(eniID),
+		Groups:             aws.StringSlice(groups),
+	})
+	if err != nil {
+		return common.CheckAWSError(err)
+	}
+	return nil
+}
+
+// getDenyAllSecurityGroup returns the deny-all security group of the vpc, it is created if it doesn't exist
+// the new security groups don't allow any inbound traffic, their default outbound rule is revoked to deny all the outbound traffic
+func getDenyAllSecurityGroup(ec2Svc ec2iface.EC2API, vpcID string, outage *AZOutage) (string, error) {
+	if groupID, ok := outage.DenyAllSecurityGroups[vpcID]; ok {
+		return groupID, nil
+	}
+
+	result, err := ec2Svc.CreateSecurityGroup(&ec2.CreateSecurityGroupInput{
+		GroupName:         aws.String(fmt.Sprintf("litmus-az-outage-%v-%v", outage.Zone, outage.ChaosID)),
+		Description:       aws.String("deny-all security group of the litmus aws-az-outage chaos"),
+		VpcId:             aws.String(vpcID),
+		TagSpecifications: chaosTags(ec2.ResourceTypeSecurityGroup, outage.ChaosID),
+	})
+	if err != nil {
+		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: fmt.Sprintf("{VPC ID: %v}", vpcID), Reason: fmt.Sprintf("failed to create the deny-all security group: %v", common.CheckAWSError(err).Error())}
+	}
+	groupID := aws.StringValue(result.GroupId)
+	outage.CreatedSecurityGroups = append(outage.CreatedSecurityGroups, groupID)
+	outage.DenyAllSecurityGroups[vpcID] = groupID
+
+	if _, err := ec2Svc.RevokeSecurityGroupEgress(&ec2.RevokeSecurityGroupEgressInput{
+		GroupId: aws.String(groupID),
+		IpPermissions: []*ec2.IpPermission{{
+			IpProtocol: aws.String("-1"),
+			IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+		}},
+	}); err != nil {
+		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: fmt.Sprintf("{VPC ID: %v, Security Group: %v}", vpcID, groupID), Reason: fmt.Sprintf("failed to revoke the outbound rule of the deny-all security group: %v", common.CheckAWSError(err).Error())}
+	}
+	return groupID, nil
+}
+
+// getDenyNetworkACL returns the deny network acl of the vpc, it is created if it doesn't exist
+// the new network acls deny all the inbound and outbound traffic
+func getDenyNetworkACL(ec2Svc ec2iface.EC2API, vpcID string, outage *AZOutage) (string, error) {
+	if aclID, ok := outage.DenyNetworkACLs[vpcID]; ok {
+		return aclID, nil
+	}
+
+	result, err := ec2Svc.CreateNetworkAcl(&ec2.CreateNetworkAclInput{
+		VpcId:             aws.String(vpcID),
+		TagSpecifications: chaosTags(ec2.ResourceTypeNetworkAcl, outage.ChaosID),
+	})
+	if err != nil {
+		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: fmt.Sprintf("{VPC ID: %v}", vpcID), Reason: fmt.Sprintf("failed to create the deny network acl: %v", common.CheckAWSError(err).Error())}
+	}
+	aclID := aws.StringValue(result.NetworkAcl.NetworkAclId)
+	outage.DenyNetworkACLs[vpcID] = aclID
+	return aclID, nil
+}
+
+// getNetworkACLAssociation returns the current network acl association of the subnet
+func getNetworkACLAssociation(ec2Svc ec2iface.EC2API, subnetID string) (NetworkACLAssociation, error) {
+	result, err := ec2Svc.DescribeNetworkAcls(&ec2.DescribeNetworkAclsInput{
+		Filters: []*ec2.Filter{{Name: aws.String("association.subnet-id"), Values: []*string{aws.String(subnetID)}}},
+	})
+	if err != nil {
+		return NetworkACLAssociation{}, cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: fmt.Sprintf("{Subnet ID: %v}", subnetID), Reason: fmt.Sprintf("failed to describe the network acls: %v", common.CheckAWSError(err).Error())}
+	}
+	for _, acl := range result.NetworkAcls {
+		for _, association := range acl.Associations {
+			if aws.StringValue(association.SubnetId) == subnetID {
+				return NetworkACLAssociation{AssociationID: aws.StringValue(association.NetworkAclAssociationId), NetworkACLID: aws.StringValue(acl.NetworkAclId)}, nil
+			}
+		}
+	}
+	return NetworkACLAssociation{}, cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: fmt.Sprintf("{Subnet ID: %v}", subnetID), Reason: "no network acl is associated with the subnet"}
+}
+
+func chaosTags(resourceType, chaosID string) []*ec2.TagSpecification {
+	return []*ec2.TagSpecification{{
+		ResourceType: aws.String(resourceType),
+		Tags:         []*ec2.Tag{{Key: aws.String(chaosTagKey), Value: aws.String(chaosID)}},
+	}}
+}
@@ -0,0 +1,192 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEC2 is the in-memory ec2 api, which contains the security groups of the network interfaces and the network acls of the subnets
+type fakeEC2 struct {
+	ec2iface.EC2API
+	instances      []*ec2.Instance
+	eniGroups      map[string][]string
+	securityGroups map[string]bool
+	// subnetACLs contains the network acl and the association id of each subnet
+	subnetACLs   map[string][2]string
+	networkACLs  map[string]bool
+	associations int
+	failModify   bool
+}
+
+func newFakeEC2() *fakeEC2 {
+	f := &fakeEC2{
+		eniGroups:      map[string][]string{"eni-1": {"sg-app", "sg-ssh"}, "eni-2": {"sg-app"}, "eni-3": {"sg-db"}},
+		securityGroups: map[string]bool{"sg-app": true, "sg-ssh": true, "sg-db": true},
+		subnetACLs:     map[string][2]string{"subnet-a": {"acl-default", "aclassoc-a"}, "subnet-b": {"acl-default", "aclassoc-b"}},
+		networkACLs:    map[string]bool{"acl-default": true},
+	}
+	f.instances = []*ec2.Instance{
+		{
+			InstanceId: aws.String("i-1"), SubnetId: aws.String("subnet-a"), VpcId: aws.String("vpc-1"),
+			NetworkInterfaces: []*ec2.InstanceNetworkInterface{f.eni("eni-1", "vpc-1"), f.eni("eni-2", "vpc-1")},
+		},
+		{
+			InstanceId: aws.String("i-2"), SubnetId: aws.String("subnet-a"), VpcId: aws.String("vpc-1"),
+			NetworkInterfaces: []*ec2.InstanceNetworkInterface{f.eni("eni-3", "vpc-1")},
+		},
+	}
+	return f
+}
+
+func (f *fakeEC2) eni(id, vpc string) *ec2.InstanceNetworkInterface {
+	eni := &ec2.InstanceNetworkInterface{NetworkInterfaceId: aws.String(id), VpcId: aws.String(vpc)}
+	for _, group := range f.eniGroups[id] {
+		eni.Groups = append(eni.Groups, &ec2.GroupIdentifier{GroupId: aws.String(group)})
+	}
+	return eni
+}
+
+func (f *fakeEC2) CreateSecurityGroup(input *ec2.CreateSecurityGroupInput) (*ec2.CreateSecurityGroupOutput, error) {
+	id := fmt.Sprintf("sg-deny-%s", *input.VpcId)
+	f.securityGroups[id] = true
+	return &ec2.CreateSecurityGroupOutput{GroupId: aws.String(id)}, nil
+}
+
+func (f *fakeEC2) RevokeSecurityGroupEgress(input *ec2.RevokeSecurityGroupEgressInput) (*ec2.RevokeSecurityGroupEgressOutput, error) {
+	return &ec2.RevokeSecurityGroupEgressOutput{}, nil
+}
+
+func (f *fakeEC2) DeleteSecurityGroup(input *ec2.DeleteSecurityGroupInput) (*ec2.DeleteSecurityGroupOutput, error) {
+	for eni, groups := range f.eniGroups {
+		for _, group := range groups {
+			if group == *input.GroupId {
+				return nil, fmt.Errorf("DependencyViolation: %s is used by %s", group, eni)
+			}
+		}
+	}
+	delete(f.securityGroups, *input.GroupId)
+	return &ec2.DeleteSecurityGroupOutput{}, nil
+}
+
+func (f *fakeEC2) ModifyNetworkInterfaceAttribute(input *ec2.ModifyNetworkInterfaceAttributeInput) (*ec2.ModifyNetworkInterfaceAttributeOutput, error) {
+	if f.failModify {
+		return nil, fmt.Errorf("RequestLimitExceeded")
+	}
+	f.eniGroups[*input.NetworkInterfaceId] = aws.StringValueSlice(input.Groups)
+	return &ec2.ModifyNetworkInterfaceAttributeOutput{}, nil
+}
+
+func (f *fakeEC2) CreateNetworkAcl(input *ec2.CreateNetworkAclInput) (*ec2.CreateNetworkAclOutput, error) {
+	id := fmt.Sprintf("acl-deny-%s", *input.VpcId)
+	f.networkACLs[id] = true
+	return &ec2.CreateNetworkAclOutput{NetworkAcl: &ec2.NetworkAcl{NetworkAclId: aws.String(id)}}, nil
+}
+
+func (f *fakeEC2) DeleteNetworkAcl(input *ec2.DeleteNetworkAclInput) (*ec2.DeleteNetworkAclOutput, error) {
+	for subnet, acl := range f.subnetACLs {
+		if acl[0] == *input.NetworkAclId {
+			return nil, fmt.Errorf("DependencyViolation: %s is associated with %s", acl[0], subnet)
+		}
+	}
+	delete(f.networkACLs, *input.NetworkAclId)
+	return &ec2.DeleteNetworkAclOutput{}, nil
+}
+
+func (f *fakeEC2) DescribeNetworkAcls(input *ec2.DescribeNetworkAclsInput) (*ec2.DescribeNetworkAclsOutput, error) {
+	subnet := *input.Filters[0].Values[0]
+	acl, ok := f.subnetACLs[subnet]
+	if !ok {
+		return &ec2.DescribeNetworkAclsOutput{}, nil
+	}
+	return &ec2.DescribeNetworkAclsOutput{NetworkAcls: []*ec2.NetworkAcl{{
+		NetworkAclId: aws.String(acl[0]),
+		Associations: []*ec2.NetworkAclAssociation{{SubnetId: aws.String(subnet), NetworkAclAssociationId: aws.String(acl[1])}},
+	}}}, nil
+}
+
+func (f *fakeEC2) ReplaceNetworkAclAssociation(input *ec2.ReplaceNetworkAclAssociationInput) (*ec2.ReplaceNetworkAclAssociationOutput, error) {
+	for subnet, acl := range f.subnetACLs {
+		if acl[1] == *input.AssociationId {
+			f.associations++
+			newAssociation := fmt.Sprintf("aclassoc-%d", f.associations)
+			f.subnetACLs[subnet] = [2]string{*input.NetworkAclId, newAssociation}
+			return &ec2.ReplaceNetworkAclAssociationOutput{NewAssociationId: aws.String(newAssociation)}, nil
+		}
+	}
+	return nil, fmt.Errorf("InvalidAssociationID.NotFound: %s", *input.AssociationId)
+}
+
+func TestIsolateBySecurityGroup(t *testing.T) {
+	t.Run("created deny-all security group", func(t *testing.T) {
+		client := newFakeEC2()
+		outage := NewAZOutage("us-east-1a", "uid")
+
+		require.NoError(t, IsolateBySecurityGroup(client, client.instances, "", outage))
+		for _, eni := range []string{"eni-1", "eni-2", "eni-3"} {
+			assert.Equal(t, []string{"sg-deny-vpc-1"}, client.eniGroups[eni])
+		}
+		assert.Equal(t, []string{"sg-deny-vpc-1"}, outage.CreatedSecurityGroups)
+
+		require.NoError(t, RestoreAZ(client, outage))
+		assert.Equal(t, []string{"sg-app", "sg-ssh"}, client.eniGroups["eni-1"])
+		assert.Equal(t, []string{"sg-app"}, client.eniGroups["eni-2"])
+		assert.Equal(t, []string{"sg-db"}, client.eniGroups["eni-3"])
+		assert.False(t, client.securityGroups["sg-deny-vpc-1"])
+		assert.Empty(t, outage.SecurityGroups)
+		assert.Empty(t, outage.CreatedSecurityGroups)
+	})
+
+	t.Run("given deny-all security group", func(t *testing.T) {
+		client := newFakeEC2()
+		client.securityGroups["sg-deny"] = true
+		outage := NewAZOutage("us-east-1a", "uid")
+
+		require.NoError(t, IsolateBySecurityGroup(client, client.instances, "sg-deny", outage))
+		assert.Equal(t, []string{"sg-deny"}, client.eniGroups["eni-1"])
+		assert.Empty(t, outage.CreatedSecurityGroups)
+
+		require.NoError(t, RestoreAZ(client, outage))
+		assert.Equal(t, []string{"sg-app", "sg-ssh"}, client.eniGroups["eni-1"])
+		// the given security group isn't deleted
+		assert.True(t, client.securityGroups["sg-deny"])
+	})
+
+	t.Run("failed restore is retried", func(t *testing.T) {
+		client := newFakeEC2()
+		outage := NewAZOutage("us-east-1a", "uid")
+		require.NoError(t, IsolateBySecurityGroup(client, client.instances, "", outage))
+
+		client.failModify = true
+		assert.ErrorContains(t, RestoreAZ(client, outage), "RequestLimitExceeded")
+		assert.Len(t, outage.SecurityGroups, 3)
+		assert.True(t, client.securityGroups["sg-deny-vpc-1"])
+
+		client.failModify = false
+		require.NoError(t, RestoreAZ(client, outage))
+		assert.Equal(t, []string{"sg-db"}, client.eniGroups["eni-3"])
+		assert.False(t, client.securityGroups["sg-deny-vpc-1"])
+	})
+}
+
+func TestIsolateByNetworkACL(t *testing.T) {
+	client := newFakeEC2()
+	outage := NewAZOutage("us-east-1a", "uid")
+
+	require.NoError(t, IsolateByNetworkACL(client, client.instances, outage))
+	// both the instances belong to the subnet-a, it is associated only once
+	assert.Equal(t, "acl-deny-vpc-1", client.subnetACLs["subnet-a"][0])
+	assert.Equal(t, "acl-default", client.subnetACLs["subnet-b"][0])
+	assert.Equal(t, 1, client.associations)
+
+	require.NoError(t, RestoreAZ(client, outage))
+	assert.Equal(t, "acl-default", client.subnetACLs["subnet-a"][0])
+	assert.False(t, client.networkACLs["acl-deny-vpc-1"])
+	assert.Empty(t, outage.NetworkACLAssociations)
+	assert.Empty(t, outage.DenyNetworkACLs)
+}
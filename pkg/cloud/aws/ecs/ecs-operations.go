@@ -0,0 +1,188 @@
+package aws
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/cloud/aws/common"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+)
+
+// GetServiceTasks will return the arns of the running tasks of the ecs service
+func GetServiceTasks(cluster, service, region string) ([]string, error) {
+
+	// Load session from shared config
+	sess := common.GetAWSSession(region)
+
+	// Create new ECS client
+	ecsSvc := ecs.New(sess)
+
+	input := &ecs.ListTasksInput{
+		Cluster:       aws.String(cluster),
+		ServiceName:   aws.String(service),
+		DesiredStatus: aws.String(ecs.DesiredStatusRunning),
+	}
+	var tasks []string
+	if err := ecsSvc.ListTasksPages(input, func(page *ecs.ListTasksOutput, lastPage bool) bool {
+		tasks = append(tasks, aws.StringValueSlice(page.TaskArns)...)
+		return true
+	}); err != nil {
+		return nil, cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeTargetSelection,
+			Reason:    fmt.Sprintf("failed to list the tasks: %v", common.CheckAWSError(err).Error()),
+			Target:    fmt.Sprintf("{ECS Cluster: %v, Service: %v, Region: %v}", cluster, service, region),
+		}
+	}
+	return tasks, nil
+}
+
+// StopTask will stop the ecs task, the service starts a new task to replace it
+func StopTask(cluster, task, reason, region string) error {
+
+	// Load session from shared config
+	sess := common.GetAWSSession(region)
+
+	// Create new ECS client
+	ecsSvc := ecs.New(sess)
+
+	input := &ecs.StopTaskInput{
+		Cluster: aws.String(cluster),
+		Task:    aws.String(task),
+		Reason:  aws.String(reason),
+	}
+	result, err := ecsSvc.StopTask(input)
+	if err != nil {
+		return cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeChaosInject,
+			Reason:    fmt.Sprintf("failed to stop the task: %v", common.CheckAWSError(err).Error()),
+			Target:    fmt.Sprintf("{ECS Cluster: %v, Task: %v, Region: %v}", cluster, task, region),
+		}
+	}
+
+	log.InfoWithValues("Stopping ECS task:", logrus.Fields{
+		"TaskArn":       aws.StringValue(result.Task.TaskArn),
+		"LastStatus":    aws.StringValue(result.Task.LastStatus),
+		"DesiredStatus": aws.StringValue(result.Task.DesiredStatus),
+	})
+	return nil
+}
+
+// GetService will return the details of the ecs service
+func GetService(cluster, service, region string) (*ecs.Service, error) {
+
+	// Load session from shared config
+	sess := common.GetAWSSession(region)
+
+	// Create new ECS client
+	ecsSvc := ecs.New(sess)
+
+	result, err := ecsSvc.DescribeServices(&ecs.DescribeServicesInput{
+		Cluster:  aws.String(cluster),
+		Services: []*string{aws.String(service)},
+	})
+	if err != nil || len(result.Services) == 0 {
+		reason := "ECS service not found"
+		if err != nil {
+			reason = fmt.Sprintf("failed to describe the service: %v", common.CheckAWSError(err).Error())
+		}
+		return nil, cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeStatusChecks,
+			Reason:    reason,
+			Target:    fmt.Sprintf("{ECS Cluster: %v, Service: %v, Region: %v}", cluster, service, region),
+		}
+	}
+	return result.Services[0], nil
+}
+
+// ServiceStatusCheck is used to check that the ecs service is active and runs the desired count of tasks
+func ServiceStatusCheck(cluster, service, region string) error {
+
+	details, err := GetService(cluster, service, region)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to get the ecs service")
+	}
+	if status := aws.StringValue(details.Status); status != "ACTIVE" {
+		return cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeStatusChecks,
+			Reason:    fmt.Sprintf("ECS service is not in ACTIVE state, current state: %v", status),
+			Target:    fmt.Sprintf("{ECS Cluster: %v, Service: %v, Region: %v}", cluster, service, region),
+		}
+	}
+	if aws.Int64Value(details.RunningCount) != aws.Int64Value(details.DesiredCount) || aws.Int64Value(details.PendingCount) != 0 {
+		return cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeStatusChecks,
+			Reason:    fmt.Sprintf("ECS service is not running the desired count of tasks, desired: %v, running: %v, pending: %v", aws.Int64Value(details.DesiredCount), aws.Int64Value(details.RunningCount), aws.Int64Value(details.PendingCount)),
+			Target:    fmt.Sprintf("{ECS Cluster: %v, Service: %v, Region: %v}", cluster, service, region),
+		}
+	}
+	return nil
+}
+
+// WaitForTasksStopped will wait for the ecs tasks to get in stopped state
+func WaitForTasksStopped(timeout, delay int, cluster string, tasks []string, region string) error {
+
+	// Load session from shared config
+	sess := common.GetAWSSession(region)
+
+	// Create new ECS client
+	ecsSvc := ecs.New(sess)
+
+	log.Info("[Status]: Checking ECS task status")
+	return retry.
+		Times(uint(timeout / delay)).
+		Wait(time.Duration(delay) * time.Second).
+		Try(func(attempt uint) error {
+
+			// the tasks are described in the batches of 100 tasks, which is the limit of the api
+			for start := 0; start < len(tasks); start += 100 {
+				end := start + 100
+				if end > len(tasks) {
+					end = len(tasks)
+				}
+				result, err := ecsSvc.DescribeTasks(&ecs.DescribeTasksInput{Cluster: aws.String(cluster), Tasks: aws.StringSlice(tasks[start:end])})
+				if err != nil {
+					return cerrors.Error{
+						ErrorCode: cerrors.ErrorTypeStatusChecks,
+						Reason:    fmt.Sprintf("failed to describe the tasks: %v", common.CheckAWSError(err).Error()),
+						Target:    fmt.Sprintf("{ECS Cluster: %v, Region: %v}", cluster, region),
+					}
+				}
+				for _, task := range result.Tasks {
+					if status := aws.StringValue(task.LastStatus); status != ecs.DesiredStatusStopped {
+						log.Infof("The task %v state is %v", aws.StringValue(task.TaskArn), status)
+						return cerrors.Error{
+							ErrorCode: cerrors.ErrorTypeChaosInject,
+							Reason:    "ECS task is not in STOPPED state",
+							Target:    fmt.Sprintf("{ECS Cluster: %v, Task: %v, Region: %v}", cluster, aws.StringValue(task.TaskArn), region),
+						}
+					}
+				}
+			}
+			log.Info("The tasks are in STOPPED state")
+			return nil
+		})
+}
+
+// WaitForServiceSteady will wait for the ecs service to run the desired count of tasks
+func WaitForServiceSteady(timeout, delay int, cluster, service, region string) error {
+
+	log.Info("[Status]: Checking ECS service status")
+	return retry.
+		Times(uint(timeout / delay)).
+		Wait(time.Duration(delay) * time.Second).
+		Try(func(attempt uint) error {
+
+			if err := ServiceStatusCheck(cluster, service, region); err != nil {
+				log.Infof("The service isn't steady: %v", stacktrace.RootCause(err))
+				return err
+			}
+			log.Info("The service is running the desired count of tasks")
+			return nil
+		})
+}
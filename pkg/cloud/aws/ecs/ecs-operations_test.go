@@ -0,0 +1,181 @@
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeECS is the ecs api of a cluster with a single service, the requests are served by their action, like ListTasks
+type fakeECS struct {
+	lock     sync.Mutex
+	requests map[string][]map[string]interface{}
+}
+
+// newFakeECS serves the ecs api through AWS_ENDPOINT_URL, the handler returns the response and the error code of the action, if any
+func newFakeECS(t *testing.T, handler func(action string, input map[string]interface{}) (interface{}, string)) *fakeECS {
+	f := &fakeECS{requests: map[string][]map[string]interface{}{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		action := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "AmazonEC2ContainerServiceV20141113.")
+		var input map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.lock.Lock()
+		f.requests[action] = append(f.requests[action], input)
+		f.lock.Unlock()
+
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		response, errorCode := handler(action, input)
+		if errorCode != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			response = map[string]string{"__type": errorCode, "message": errorCode + " raised by the fake"}
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	t.Cleanup(server.Close)
+
+	t.Setenv("AWS_ENDPOINT_URL", server.URL)
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	t.Setenv("AWS_MAX_RETRIES", "1")
+	return f
+}
+
+func (f *fakeECS) calls(action string) []map[string]interface{} {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.requests[action]
+}
+
+func TestGetServiceTasks(t *testing.T) {
+	fake := newFakeECS(t, func(action string, input map[string]interface{}) (interface{}, string) {
+		if input["nextToken"] == nil {
+			return map[string]interface{}{"taskArns": []string{"task-1", "task-2"}, "nextToken": "page-2"}, ""
+		}
+		return map[string]interface{}{"taskArns": []string{"task-3"}}, ""
+	})
+
+	tasks, err := GetServiceTasks("cluster", "web", "us-east-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"task-1", "task-2", "task-3"}, tasks)
+
+	calls := fake.calls("ListTasks")
+	require.Len(t, calls, 2)
+	assert.Equal(t, "web", calls[0]["serviceName"])
+	assert.Equal(t, ecs.DesiredStatusRunning, calls[0]["desiredStatus"])
+	assert.Equal(t, "page-2", calls[1]["nextToken"])
+}
+
+func TestGetServiceTasksFailure(t *testing.T) {
+	newFakeECS(t, func(action string, input map[string]interface{}) (interface{}, string) {
+		return nil, "ClusterNotFoundException"
+	})
+
+	_, err := GetServiceTasks("cluster", "web", "us-east-1")
+	assert.ErrorContains(t, err, "ClusterNotFoundException")
+}
+
+func TestStopTask(t *testing.T) {
+	fake := newFakeECS(t, func(action string, input map[string]interface{}) (interface{}, string) {
+		return map[string]interface{}{"task": map[string]string{"taskArn": "task-1", "lastStatus": "RUNNING", "desiredStatus": "STOPPED"}}, ""
+	})
+
+	require.NoError(t, StopTask("cluster", "task-1", "stopped by litmus", "us-east-1"))
+	calls := fake.calls("StopTask")
+	require.Len(t, calls, 1)
+	assert.Equal(t, "task-1", calls[0]["task"])
+	assert.Equal(t, "stopped by litmus", calls[0]["reason"])
+}
+
+func TestServiceStatusCheck(t *testing.T) {
+	tests := map[string]struct {
+		services []map[string]interface{}
+		wantErr  string
+	}{
+		"steady service": {
+			services: []map[string]interface{}{{"status": "ACTIVE", "desiredCount": 2, "runningCount": 2, "pendingCount": 0}},
+		},
+		"inactive service": {
+			services: []map[string]interface{}{{"status": "DRAINING", "desiredCount": 2, "runningCount": 2, "pendingCount": 0}},
+			wantErr:  "current state: DRAINING",
+		},
+		"missing tasks": {
+			services: []map[string]interface{}{{"status": "ACTIVE", "desiredCount": 2, "runningCount": 1, "pendingCount": 0}},
+			wantErr:  "desired: 2, running: 1, pending: 0",
+		},
+		"pending tasks": {
+			services: []map[string]interface{}{{"status": "ACTIVE", "desiredCount": 2, "runningCount": 2, "pendingCount": 1}},
+			wantErr:  "desired: 2, running: 2, pending: 1",
+		},
+		"service not found": {
+			wantErr: "ECS service not found",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			newFakeECS(t, func(action string, input map[string]interface{}) (interface{}, string) {
+				return map[string]interface{}{"services": tt.services}, ""
+			})
+
+			err := ServiceStatusCheck("cluster", "web", "us-east-1")
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestWaitForTasksStopped(t *testing.T) {
+	var tasks []string
+	for i := 0; i < 150; i++ {
+		tasks = append(tasks, fmt.Sprintf("task-%d", i))
+	}
+
+	tests := map[string]struct {
+		running string
+		wantErr bool
+	}{
+		"all the tasks are stopped": {},
+		"a task is still running":   {running: "task-120", wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			fake := newFakeECS(t, func(action string, input map[string]interface{}) (interface{}, string) {
+				var described []map[string]string
+				for _, task := range input["tasks"].([]interface{}) {
+					status := ecs.DesiredStatusStopped
+					if task == tt.running {
+						status = ecs.DesiredStatusRunning
+					}
+					described = append(described, map[string]string{"taskArn": task.(string), "lastStatus": status})
+				}
+				return map[string]interface{}{"tasks": described}, ""
+			})
+
+			err := WaitForTasksStopped(1, 1, "cluster", tasks, "us-east-1")
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "{ECS Cluster: cluster, Task: task-120, Region: us-east-1}")
+			} else {
+				assert.NoError(t, err)
+			}
+
+			// the tasks are described in the batches of 100 tasks
+			calls := fake.calls("DescribeTasks")
+			require.Len(t, calls, 2)
+			assert.Len(t, calls[0]["tasks"], 100)
+			assert.Len(t, calls[1]["tasks"], 50)
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package aws
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/cloud/aws/common"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+)
+
+// GetNodegroup will return the details of the eks managed nodegroup
+func GetNodegroup(cluster, nodegroup, region string) (*eks.Nodegroup, error) {
+
+	// Load session from shared config
+	sess := common.GetAWSSession(region)
+
+	// Create new EKS client
+	eksSvc := eks.New(sess)
+
+	result, err := eksSvc.DescribeNodegroup(&eks.DescribeNodegroupInput{
+		ClusterName:   aws.String(cluster),
+		NodegroupName: aws.String(nodegroup),
+	})
+	if err != nil {
+		return nil, cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeStatusChecks,
+			Reason:    fmt.Sprintf("failed to describe the nodegroup: %v", common.CheckAWSError(err).Error()),
+			Target:    fmt.Sprintf("{EKS Cluster: %v, Nodegroup: %v, Region: %v}", cluster, nodegroup, region),
+		}
+	}
+	return result.Nodegroup, nil
+}
+
+// UpdateNodegroupScaling will update the scaling config of the eks managed nodegroup and returns the id of the update
+// the update is retried while the previous update of the nodegroup is in progress, as the nodegroup accepts one update at a time
+func UpdateNodegroupScaling(timeout, delay int, cluster, nodegroup string, scalingConfig *eks.NodegroupScalingConfig, region string) (string, error) {
+
+	// Load session from shared config
+	sess := common.GetAWSSession(region)
+
+	// Create new EKS client
+	eksSvc := eks.New(sess)
+
+	// only the update rejected by the in-progress update is retried, the other failures are reported after the retries are stopped
+	var (
+		result    *eks.UpdateNodegroupConfigOutput
+		updateErr error
+	)
+	err := retry.
+		Times(uint(timeout / delay)).
+		Wait(time.Duration(delay) * time.Second).
+		Try(func(attempt uint) error {
+
+			output, err := eksSvc.UpdateNodegroupConfig(&eks.UpdateNodegroupConfigInput{
+				ClusterName:   aws.String(cluster),
+				NodegroupName: aws.String(nodegroup),
+				ScalingConfig: scalingConfig,
+			})
+			if err == nil {
+				result = output
+				return nil
+			}
+			updateErr = cerrors.Error{
+				ErrorCode: cerrors.ErrorTypeChaosInject,
+				Reason:    fmt.Sprintf("failed to update the scaling config of the nodegroup: %v", common.CheckAWSError(err).Error()),
+				Target:    fmt.Sprintf("{EKS Cluster: %v, Nodegroup: %v, Region: %v}", cluster, nodegroup, region),
+			}
+			if isUpdateInProgress(err) {
+				log.Infof("[Wait]: The previous update of the %v nodegroup is in progress, retrying the update", nodegroup)
+				return updateErr
+			}
+			return nil
+		})
+	if err != nil {
+		return "", err
+	}
+	if result == nil {
+		return "", updateErr
+	}
+
+	log.InfoWithValues("Updating the scaling config of the EKS nodegroup:", logrus.Fields{
+		"Nodegroup":   nodegroup,
+		"MinSize":     aws.Int64Value(scalingConfig.MinSize),
+		"MaxSize":     aws.Int64Value(scalingConfig.MaxSize),
+		"DesiredSize": aws.Int64Value(scalingConfig.DesiredSize),
+		"UpdateID":    aws.StringValue(result.Update.Id),
+	})
+	return aws.StringValue(result.Update.Id), nil
+}
+
+// isUpdateInProgress checks whether the update is rejected, because the previous update of the nodegroup is in progress
+func isUpdateInProgress(err error) bool {
+	aerr, ok := err.(awserr.Error)
+	return ok && aerr.Code() == eks.ErrCodeResourceInUseException
+}
+
+// ScaleDownConfig returns the scaling config of the nodegroup with the given desired size
+// the min size is lowered to the desired size if it is greater than the desired size
+func ScaleDownConfig(scalingConfig *eks.NodegroupScalingConfig, desiredSize int64) (*eks.NodegroupScalingConfig, error) {
+	if desiredSize < 0 || desiredSize >= aws.Int64Value(scalingConfig.DesiredSize) {
+		return nil, cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeGeneric,
+			Reason:    fmt.Sprintf("desired size %v must be lower than the current desired size %v of the nodegroup", desiredSize, aws.Int64Value(scalingConfig.DesiredSize)),
+		}
+	}
+	minSize := aws.Int64Value(scalingConfig.MinSize)
+	if minSize > desiredSize {
+		minSize = desiredSize
+	}
+	return &eks.NodegroupScalingConfig{
+		MinSize:     aws.Int64(minSize),
+		MaxSize:     scalingConfig.MaxSize,
+		DesiredSize: aws.Int64(desiredSize),
+	}, nil
+}
+
+// WaitForNodegroupUpdate will wait for the update of the eks managed nodegroup to get successful
+func WaitForNodegroupUpdate(timeout, delay int, cluster, nodegroup, updateID, region string) error {
+
+	// Load session from shared config
+	sess := common.GetAWSSession(region)
+
+	// Create new EKS client
+	eksSvc := eks.New(sess)
+
+	// the failed update isn't retried, it is reported with its errors after the retries are stopped
+	var updateErr error
+	log.Info("[Status]: Checking EKS nodegroup update status")
+	err := retry.
+		Times(uint(timeout / delay)).
+		Wait(time.Duration(delay) * time.Second).
+		Try(func(attempt uint) error {
+
+			result, err := eksSvc.DescribeUpdate(&eks.DescribeUpdateInput{
+				Name:          aws.String(cluster),
+				NodegroupName: aws.String(nodegroup),
+				UpdateId:      aws.String(updateID),
+			})
+			if err != nil {
+				return cerrors.Error{
+					ErrorCode: cerrors.ErrorTypeStatusChecks,
+					Reason:    fmt.Sprintf("failed to describe the update: %v", common.CheckAWSError(err).Error()),
+					Target:    fmt.Sprintf("{EKS Cluster: %v, Nodegroup: %v, Update ID: %v, Region: %v}", cluster, nodegroup, updateID, region),
+				}
+			}
+			switch status := aws.StringValue(result.Update.Status); status {
+			case eks.UpdateStatusSuccessful:
+				log.Infof("The update %v is successful", updateID)
+				return nil
+			case eks.UpdateStatusFailed, eks.UpdateStatusCancelled:
+				updateErr = cerrors.Error{
+					ErrorCode: cerrors.ErrorTypeStatusChecks,
+					Reason:    fmt.Sprintf("nodegroup update is %v: %v", status, getUpdateErrors(result.Update)),
+					Target:    fmt.Sprintf("{EKS Cluster: %v, Nodegroup: %v, Update ID: %v, Region: %v}", cluster, nodegroup, updateID, region),
+				}
+				return nil
+			default:
+				log.Infof("The update %v status is %v", updateID, status)
+				return cerrors.Error{
+					ErrorCode: cerrors.ErrorTypeStatusChecks,
+					Reason:    "nodegroup update is not successful",
+					Target:    fmt.Sprintf("{EKS Cluster: %v, Nodegroup: %v, Update ID: %v, Region: %v}", cluster, nodegroup, updateID, region),
+				}
+			}
+		})
+	if err != nil {
+		return err
+	}
+	return updateErr
+}
+
+// NodegroupStatusCheck is used to check that the eks managed nodegroup is in active state
+func NodegroupStatusCheck(cluster, nodegroup, region string) error {
+
+	details, err := GetNodegroup(cluster, nodegroup, region)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to get the nodegroup")
+	}
+	if status := aws.StringValue(details.Status); status != eks.NodegroupStatusActive {
+		return cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeStatusChecks,
+			Reason:    fmt.Sprintf("EKS nodegroup is not in ACTIVE state, current state: %v", status),
+			Target:    fmt.Sprintf("{EKS Cluster: %v, Nodegroup: %v, Region: %v}", cluster, nodegroup, region),
+		}
+	}
+	return nil
+}
+
+func getUpdateErrors(update *eks.Update) []string {
+	var errs []string
+	for _, updateErr := range update.Errors {
+		errs = append(errs, fmt.Sprintf("%v: %v", aws.StringValue(updateErr.ErrorCode), aws.StringValue(updateErr.ErrorMessage)))
+	}
+	return errs
+}
@@ -0,0 +1,107 @@
+package aws
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScaleDownConfig(t *testing.T) {
+	original := &eks.NodegroupScalingConfig{MinSize: aws.Int64(2), MaxSize: aws.Int64(6), DesiredSize: aws.Int64(4)}
+
+	tests := map[string]struct {
+		desiredSize int64
+		wantMin     int64
+		wantErr     bool
+	}{
+		"desired size above the min size": {desiredSize: 3, wantMin: 2},
+		"desired size below the min size": {desiredSize: 1, wantMin: 1},
+		"scale down to zero":              {desiredSize: 0, wantMin: 0},
+		"desired size isn't lower":        {desiredSize: 4, wantErr: true},
+		"negative desired size":           {desiredSize: -1, wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			config, err := ScaleDownConfig(original, tt.desiredSize)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.desiredSize, aws.Int64Value(config.DesiredSize))
+			assert.Equal(t, tt.wantMin, aws.Int64Value(config.MinSize))
+			assert.Equal(t, int64(6), aws.Int64Value(config.MaxSize))
+		})
+	}
+	// the original scaling config is kept for the revert
+	assert.Equal(t, int64(2), aws.Int64Value(original.MinSize))
+	assert.Equal(t, int64(4), aws.Int64Value(original.DesiredSize))
+}
+
+// newFakeEKS serves the update of the nodegroup config through AWS_ENDPOINT_URL
+// the update is rejected with the given error code for the given number of requests, before it is accepted
+func newFakeEKS(t *testing.T, errorCode string, rejections int) *int {
+	var (
+		lock     sync.Mutex
+		requests int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/clusters/cluster/node-groups/workers/update-config" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		if requests <= rejections {
+			w.Header().Set("X-Amzn-Errortype", errorCode)
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"message": "the nodegroup is being updated"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"update": {"id": "update-2", "status": "InProgress"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	t.Setenv("AWS_ENDPOINT_URL", server.URL)
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	t.Setenv("AWS_MAX_RETRIES", "1")
+	return &requests
+}
+
+func TestUpdateNodegroupScaling(t *testing.T) {
+	scalingConfig := &eks.NodegroupScalingConfig{MinSize: aws.Int64(2), MaxSize: aws.Int64(6), DesiredSize: aws.Int64(4)}
+
+	t.Run("retried while the previous update is in progress", func(t *testing.T) {
+		requests := newFakeEKS(t, eks.ErrCodeResourceInUseException, 2)
+
+		updateID, err := UpdateNodegroupScaling(3, 1, "cluster", "workers", scalingConfig, "us-east-1")
+		require.NoError(t, err)
+		assert.Equal(t, "update-2", updateID)
+		assert.Equal(t, 3, *requests)
+	})
+
+	t.Run("failed once the previous update doesn't complete", func(t *testing.T) {
+		requests := newFakeEKS(t, eks.ErrCodeResourceInUseException, 5)
+
+		_, err := UpdateNodegroupScaling(2, 1, "cluster", "workers", scalingConfig, "us-east-1")
+		assert.ErrorContains(t, err, "ResourceInUseException")
+		assert.Equal(t, 2, *requests)
+	})
+
+	t.Run("other failures aren't retried", func(t *testing.T) {
+		requests := newFakeEKS(t, eks.ErrCodeInvalidParameterException, 5)
+
+		_, err := UpdateNodegroupScaling(3, 1, "cluster", "workers", scalingConfig, "us-east-1")
+		assert.ErrorContains(t, err, "InvalidParameterException")
+		assert.Equal(t, 1, *requests)
+	})
+}
@@ -0,0 +1,129 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/cloud/aws/common"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/sirupsen/logrus"
+)
+
+// DeregisterTargets will deregister the targets from the target group
+func DeregisterTargets(targetGroupARN string, targets []*elbv2.TargetDescription, region string) error {
+
+	// Load session from shared config
+	sess := common.GetAWSSession(region)
+
+	// Create new ELBv2 client
+	elbSvc := elbv2.New(sess)
+
+	input := &elbv2.DeregisterTargetsInput{
+		TargetGroupArn: aws.String(targetGroupARN),
+		Targets:        targets,
+	}
+	if _, err := elbSvc.DeregisterTargets(input); err != nil {
+		return cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeChaosInject,
+			Reason:    fmt.Sprintf("failed to deregister the targets: %v", common.CheckAWSError(err).Error()),
+			Target:    fmt.Sprintf("{Target Group: %v, Targets: %v, Region: %v}", targetGroupARN, GetTargetKeys(targets), region),
+		}
+	}
+
+	log.InfoWithValues("Deregistering the targets from the target group:", logrus.Fields{
+		"TargetGroupArn": targetGroupARN,
+		"Targets":        GetTargetKeys(targets),
+	})
+	return nil
+}
+
+// RegisterTargets will register back the targets with the target group
+func RegisterTargets(targetGroupARN string, targets []*elbv2.TargetDescription, region string) error {
+
+	// Load session from shared config
+	sess := common.GetAWSSession(region)
+
+	// Create new ELBv2 client
+	elbSvc := elbv2.New(sess)
+
+	input := &elbv2.RegisterTargetsInput{
+		TargetGroupArn: aws.String(targetGroupARN),
+		Targets:        targets,
+	}
+	if _, err := elbSvc.RegisterTargets(input); err != nil {
+		return cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeChaosRevert,
+			Reason:    fmt.Sprintf("failed to register the targets: %v", common.CheckAWSError(err).Error()),
+			Target:    fmt.Sprintf("{Target Group: %v, Targets: %v, Region: %v}", targetGroupARN, GetTargetKeys(targets), region),
+		}
+	}
+
+	log.InfoWithValues("Registering the targets with the target group:", logrus.Fields{
+		"TargetGroupArn": targetGroupARN,
+		"Targets":        GetTargetKeys(targets),
+	})
+	return nil
+}
+
+// SelectTargets will select the healthy targets of the target group, which match the given target ids
+// the target ids are the instance ids or the ips of the targets, all the ports of the matching targets are selected
+// it selects all the healthy targets if no target ids are provided
+func SelectTargets(targetHealth []*elbv2.TargetHealthDescription, targetIDs string) ([]*elbv2.TargetDescription, error) {
+
+	healthy := map[string]*elbv2.TargetDescription{}
+	var healthyKeys []string
+	for _, description := range targetHealth {
+		if description.Target == nil || description.TargetHealth == nil || aws.StringValue(description.TargetHealth.State) != elbv2.TargetHealthStateEnumHealthy {
+			continue
+		}
+		key := getTargetKey(description.Target)
+		healthy[key] = description.Target
+		healthyKeys = append(healthyKeys, key)
+	}
+	if len(healthyKeys) == 0 {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "no healthy targets found in the target group"}
+	}
+
+	selectedKeys := healthyKeys
+	if strings.TrimSpace(targetIDs) != "" {
+		selectedKeys = nil
+		for _, id := range strings.Split(targetIDs, ",") {
+			id = strings.TrimSpace(id)
+			found := false
+			for _, key := range healthyKeys {
+				if aws.StringValue(healthy[key].Id) == id {
+					selectedKeys = append(selectedKeys, key)
+					found = true
+				}
+			}
+			if !found {
+				return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{Target ID: %v}", id), Reason: "target is not a healthy target of the target group"}
+			}
+		}
+	}
+
+	var targets []*elbv2.TargetDescription
+	for _, key := range selectedKeys {
+		targets = append(targets, healthy[key])
+	}
+	return targets, nil
+}
+
+// GetTargetKeys returns the keys of the targets in id:port format
+func GetTargetKeys(targets []*elbv2.TargetDescription) []string {
+	var keys []string
+	for _, target := range targets {
+		keys = append(keys, getTargetKey(target))
+	}
+	return keys
+}
+
+func getTargetKey(target *elbv2.TargetDescription) string {
+	if target.Port == nil {
+		return aws.StringValue(target.Id)
+	}
+	return fmt.Sprintf("%v:%v", aws.StringValue(target.Id), aws.Int64Value(target.Port))
+}
@@ -0,0 +1,52 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func targetHealth(id string, port int64, state string) *elbv2.TargetHealthDescription {
+	return &elbv2.TargetHealthDescription{
+		Target:       &elbv2.TargetDescription{Id: aws.String(id), Port: aws.Int64(port)},
+		TargetHealth: &elbv2.TargetHealth{State: aws.String(state)},
+	}
+}
+
+func TestSelectTargets(t *testing.T) {
+	health := []*elbv2.TargetHealthDescription{
+		targetHealth("i-1", 80, elbv2.TargetHealthStateEnumHealthy),
+		targetHealth("i-1", 8080, elbv2.TargetHealthStateEnumHealthy),
+		targetHealth("i-2", 80, elbv2.TargetHealthStateEnumUnhealthy),
+		targetHealth("10.0.0.5", 80, elbv2.TargetHealthStateEnumHealthy),
+	}
+
+	tests := map[string]struct {
+		targetIDs string
+		want      []string
+		wantErr   string
+	}{
+		"all the healthy targets": {targetIDs: "", want: []string{"i-1:80", "i-1:8080", "10.0.0.5:80"}},
+		"all the ports of the id": {targetIDs: "i-1", want: []string{"i-1:80", "i-1:8080"}},
+		"ip target":               {targetIDs: " 10.0.0.5 ", want: []string{"10.0.0.5:80"}},
+		"unhealthy target":        {targetIDs: "i-1,i-2", wantErr: "target is not a healthy target of the target group"},
+		"unregistered target":     {targetIDs: "i-3", wantErr: "target is not a healthy target of the target group"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			targets, err := SelectTargets(health, tt.targetIDs)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, GetTargetKeys(targets))
+		})
+	}
+
+	_, err := SelectTargets([]*elbv2.TargetHealthDescription{targetHealth("i-2", 80, elbv2.TargetHealthStateEnumDraining)}, "")
+	assert.ErrorContains(t, err, "no healthy targets found in the target group")
+}
@@ -0,0 +1,117 @@
+package aws
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/cloud/aws/common"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
+	"github.com/palantir/stacktrace"
+)
+
+// GetTargetHealth will return the health of the targets of the target group
+// the health of all the registered targets is returned if no targets are provided
+func GetTargetHealth(targetGroupARN string, targets []*elbv2.TargetDescription, region string) ([]*elbv2.TargetHealthDescription, error) {
+
+	// Load session from shared config
+	sess := common.GetAWSSession(region)
+
+	// Create new ELBv2 client
+	elbSvc := elbv2.New(sess)
+
+	input := &elbv2.DescribeTargetHealthInput{
+		TargetGroupArn: aws.String(targetGroupARN),
+	}
+	if len(targets) != 0 {
+		input.Targets = targets
+	}
+	result, err := elbSvc.DescribeTargetHealth(input)
+	if err != nil {
+		return nil, cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeStatusChecks,
+			Reason:    fmt.Sprintf("failed to describe the target health: %v", common.CheckAWSError(err).Error()),
+			Target:    fmt.Sprintf("{Target Group: %v, Region: %v}", targetGroupARN, region),
+		}
+	}
+	return result.TargetHealthDescriptions, nil
+}
+
+// WaitForTargetsDeregistration will wait for the targets to get in draining or unused state
+func WaitForTargetsDeregistration(timeout, delay int, targetGroupARN string, targets []*elbv2.TargetDescription, region string) error {
+
+	log.Info("[Status]: Checking the deregistration of the targets")
+	return waitForTargetState(timeout, delay, targetGroupARN, targets, region, cerrors.ErrorTypeChaosInject, elbv2.TargetHealthStateEnumDraining, elbv2.TargetHealthStateEnumUnused)
+}
+
+// WaitForTargetsHealthy will wait for the targets to get in healthy state
+func WaitForTargetsHealthy(timeout, delay int, targetGroupARN string, targets []*elbv2.TargetDescription, region string) error {
+
+	log.Info("[Status]: Checking the health of the targets")
+	return waitForTargetState(timeout, delay, targetGroupARN, targets, region, cerrors.ErrorTypeChaosRevert, elbv2.TargetHealthStateEnumHealthy)
+}
+
+// TargetHealthCheck will verify that the targets are healthy
+func TargetHealthCheck(targetGroupARN string, targets []*elbv2.TargetDescription, region string) error {
+
+	targetHealth, err := GetTargetHealth(targetGroupARN, targets, region)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to get the target health")
+	}
+	for _, description := range targetHealth {
+		if state := getTargetState(description); state != elbv2.TargetHealthStateEnumHealthy {
+			return cerrors.Error{
+				ErrorCode: cerrors.ErrorTypeStatusChecks,
+				Reason:    fmt.Sprintf("target is in %v state", state),
+				Target:    fmt.Sprintf("{Target Group: %v, Target: %v, Region: %v}", targetGroupARN, getTargetKey(description.Target), region),
+			}
+		}
+	}
+	return nil
+}
+
+// waitForTargetState will wait for all the targets to get in one of the given states
+func waitForTargetState(timeout, delay int, targetGroupARN string, targets []*elbv2.TargetDescription, region string, errorCode cerrors.ErrorType, states ...string) error {
+	return retry.
+		Times(uint(timeout / delay)).
+		Wait(time.Duration(delay) * time.Second).
+		Try(func(attempt uint) error {
+
+			targetHealth, err := GetTargetHealth(targetGroupARN, targets, region)
+			if err != nil {
+				return stacktrace.Propagate(err, "failed to get the target health")
+			}
+			for _, description := range targetHealth {
+				state := getTargetState(description)
+				if !isOneOf(state, states) {
+					log.Infof("The target %v state is %v", getTargetKey(description.Target), state)
+					return cerrors.Error{
+						ErrorCode: errorCode,
+						Reason:    fmt.Sprintf("target is not in %v state", states),
+						Target:    fmt.Sprintf("{Target Group: %v, Target: %v, Region: %v}", targetGroupARN, getTargetKey(description.Target), region),
+					}
+				}
+			}
+			log.Infof("The targets are in %v state", states)
+			return nil
+		})
+}
+
+func getTargetState(description *elbv2.TargetHealthDescription) string {
+	if description.TargetHealth == nil {
+		return ""
+	}
+	return aws.StringValue(description.TargetHealth.State)
+}
+
+func isOneOf(state string, states []string) bool {
+	for _, s := range states {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,229 @@
+package aws
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/cloud/aws/common"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+)
+
+// RDSInstanceReboot will reboot an aws rds instance, the multi-az instance fails over to its standby if forceFailover is set
+func RDSInstanceReboot(identifier string, forceFailover bool, region string) error {
+
+	// Load session from shared config
+	sess := common.GetAWSSession(region)
+
+	// Create new RDS client
+	rdsSvc := rds.New(sess)
+
+	input := &rds.RebootDBInstanceInput{
+		DBInstanceIdentifier: aws.String(identifier),
+	}
+	// ForceFailover can't be set for the instances, which aren't configured for multi-az
+	if forceFailover {
+		input.ForceFailover = aws.Bool(true)
+	}
+	result, err := rdsSvc.RebootDBInstance(input)
+	if err != nil {
+		return cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeChaosInject,
+			Reason:    fmt.Sprintf("failed to reboot RDS instance: %v", common.CheckAWSError(err).Error()),
+			Target:    fmt.Sprintf("{RDS Instance Identifier: %v, Region: %v}", identifier, region),
+		}
+	}
+
+	log.InfoWithValues("Rebooting RDS instance:", logrus.Fields{
+		"DBInstanceStatus":     aws.StringValue(result.DBInstance.DBInstanceStatus),
+		"DBInstanceIdentifier": aws.StringValue(result.DBInstance.DBInstanceIdentifier),
+		"ForceFailover":        forceFailover,
+	})
+
+	return nil
+}
+
+// RDSClusterFailover will fail over an aws aurora cluster to the target replica
+// aurora chooses the replica with the highest failover priority if no target replica is provided
+func RDSClusterFailover(clusterIdentifier, targetReplica, region string) error {
+
+	// Load session from shared config
+	sess := common.GetAWSSession(region)
+
+	// Create new RDS client
+	rdsSvc := rds.New(sess)
+
+	input := &rds.FailoverDBClusterInput{
+		DBClusterIdentifier: aws.String(clusterIdentifier),
+	}
+	if targetReplica != "" {
+		input.TargetDBInstanceIdentifier = aws.String(targetReplica)
+	}
+	result, err := rdsSvc.FailoverDBCluster(input)
+	if err != nil {
+		return cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeChaosInject,
+			Reason:    fmt.Sprintf("failed to fail over RDS cluster: %v", common.CheckAWSError(err).Error()),
+			Target:    fmt.Sprintf("{RDS Cluster Identifier: %v, Target Replica: %v, Region: %v}", clusterIdentifier, targetReplica, region),
+		}
+	}
+
+	log.InfoWithValues("Failing over RDS cluster:", logrus.Fields{
+		"DBClusterStatus":     aws.StringValue(result.DBCluster.Status),
+		"DBClusterIdentifier": aws.StringValue(result.DBCluster.DBClusterIdentifier),
+		"TargetReplica":       targetReplica,
+	})
+
+	return nil
+}
+
+// GetRDSInstance will return the details of the rds instance
+func GetRDSInstance(identifier, region string) (*rds.DBInstance, error) {
+
+	// Load session from shared config
+	sess := common.GetAWSSession(region)
+
+	// Create new RDS client
+	rdsSvc := rds.New(sess)
+
+	result, err := rdsSvc.DescribeDBInstances(&rds.DescribeDBInstancesInput{DBInstanceIdentifier: aws.String(identifier)})
+	if err != nil || len(result.DBInstances) == 0 {
+		reason := "RDS instance not found"
+		if err != nil {
+			reason = fmt.Sprintf("failed to describe the instance: %v", common.CheckAWSError(err).Error())
+		}
+		return nil, cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeStatusChecks,
+			Reason:    reason,
+			Target:    fmt.Sprintf("{RDS Instance Identifier: %v, Region: %v}", identifier, region),
+		}
+	}
+	return result.DBInstances[0], nil
+}
+
+// GetRDSCluster will return the details of the rds cluster
+func GetRDSCluster(identifier, region string) (*rds.DBCluster, error) {
+
+	// Load session from shared config
+	sess := common.GetAWSSession(region)
+
+	// Create new RDS client
+	rdsSvc := rds.New(sess)
+
+	result, err := rdsSvc.DescribeDBClusters(&rds.DescribeDBClustersInput{DBClusterIdentifier: aws.String(identifier)})
+	if err != nil || len(result.DBClusters) == 0 {
+		reason := "RDS cluster not found"
+		if err != nil {
+			reason = fmt.Sprintf("failed to describe the cluster: %v", common.CheckAWSError(err).Error())
+		}
+		return nil, cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeStatusChecks,
+			Reason:    reason,
+			Target:    fmt.Sprintf("{RDS Cluster Identifier: %v, Region: %v}", identifier, region),
+		}
+	}
+	return result.DBClusters[0], nil
+}
+
+// GetClusterWriter returns the identifier of the writer instance of the cluster
+func GetClusterWriter(cluster *rds.DBCluster) string {
+	for _, member := range cluster.DBClusterMembers {
+		if aws.BoolValue(member.IsClusterWriter) {
+			return aws.StringValue(member.DBInstanceIdentifier)
+		}
+	}
+	return ""
+}
+
+// GetClusterReaders returns the identifiers of the reader instances of the cluster
+func GetClusterReaders(cluster *rds.DBCluster) []string {
+	var readers []string
+	for _, member := range cluster.DBClusterMembers {
+		if !aws.BoolValue(member.IsClusterWriter) {
+			readers = append(readers, aws.StringValue(member.DBInstanceIdentifier))
+		}
+	}
+	return readers
+}
+
+// WaitForRDSClusterFailover will wait for the writer of the rds cluster to change from the old writer and the cluster to get in available state
+// it returns the new writer of the cluster
+func WaitForRDSClusterFailover(timeout, delay int, region, identifier, oldWriter string) (string, error) {
+
+	var newWriter string
+	log.Info("[Status]: Checking RDS cluster status")
+	err := retry.
+		Times(uint(timeout / delay)).
+		Wait(time.Duration(delay) * time.Second).
+		Try(func(attempt uint) error {
+
+			cluster, err := GetRDSCluster(identifier, region)
+			if err != nil {
+				return stacktrace.Propagate(err, "failed to get the status of RDS cluster")
+			}
+			newWriter = GetClusterWriter(cluster)
+			if newWriter == oldWriter || newWriter == "" {
+				log.Infof("The cluster writer is %v", newWriter)
+				return cerrors.Error{
+					ErrorCode: cerrors.ErrorTypeStatusChecks,
+					Reason:    "RDS cluster writer is not changed",
+					Target:    fmt.Sprintf("{RDS Cluster Identifier: %v, Writer: %v, Region: %v}", identifier, newWriter, region),
+				}
+			}
+			if status := aws.StringValue(cluster.Status); status != "available" {
+				log.Infof("The cluster state is %v", status)
+				return cerrors.Error{
+					ErrorCode: cerrors.ErrorTypeStatusChecks,
+					Reason:    "RDS cluster is not in available state",
+					Target:    fmt.Sprintf("{RDS Cluster Identifier: %v, Region: %v}", identifier, region),
+				}
+			}
+			log.Infof("The cluster writer is %v and the cluster state is available", newWriter)
+			return nil
+		})
+	return newWriter, err
+}
+
+// WaitForRDSClusterUp will wait for the rds cluster and all its instances to get in available state
+func WaitForRDSClusterUp(timeout, delay int, region, identifier string) error {
+
+	log.Info("[Status]: Checking RDS cluster status")
+	return retry.
+		Times(uint(timeout / delay)).
+		Wait(time.Duration(delay) * time.Second).
+		Try(func(attempt uint) error {
+
+			if err := ClusterStatusCheck(identifier, region); err != nil {
+				log.Infof("The cluster isn't available: %v", stacktrace.RootCause(err))
+				return err
+			}
+			log.Info("The cluster state is available")
+			return nil
+		})
+}
+
+// ClusterStatusCheck is used to check that the rds cluster and all its instances are in available state
+func ClusterStatusCheck(identifier, region string) error {
+
+	cluster, err := GetRDSCluster(identifier, region)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to get the status of RDS cluster")
+	}
+	if status := aws.StringValue(cluster.Status); status != "available" {
+		return cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeStatusChecks,
+			Reason:    fmt.Sprintf("RDS cluster is not in available state, current state: %v", status),
+			Target:    fmt.Sprintf("{RDS Cluster Identifier: %v, Region: %v}", identifier, region),
+		}
+	}
+	var members []string
+	for _, member := range cluster.DBClusterMembers {
+		members = append(members, aws.StringValue(member.DBInstanceIdentifier))
+	}
+	return InstanceStatusCheck(members, region)
+}
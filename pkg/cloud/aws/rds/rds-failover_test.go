@@ -0,0 +1,25 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterMembers(t *testing.T) {
+	cluster := &rds.DBCluster{DBClusterMembers: []*rds.DBClusterMember{
+		{DBInstanceIdentifier: aws.String("db-1"), IsClusterWriter: aws.Bool(false)},
+		{DBInstanceIdentifier: aws.String("db-2"), IsClusterWriter: aws.Bool(true)},
+		{DBInstanceIdentifier: aws.String("db-3")},
+	}}
+
+	assert.Equal(t, "db-2", GetClusterWriter(cluster))
+	assert.Equal(t, []string{"db-1", "db-3"}, GetClusterReaders(cluster))
+
+	// the cluster without writer, like the cluster during the failover
+	cluster.DBClusterMembers[1].IsClusterWriter = aws.Bool(false)
+	assert.Empty(t, GetClusterWriter(cluster))
+	assert.Len(t, GetClusterReaders(cluster), 3)
+}
@@ -13,7 +13,6 @@ import (
 // CreateAndUploadDocument will create and add the ssm document in aws service monitoring docs.
 func CreateAndUploadDocument(documentName, documentType, documentFormat, documentPath, region string) error {
 
-	sesh := common.GetAWSSession(region)
 	openFile, err := os.ReadFile(documentPath)
 	if err != nil {
 		return cerrors.Error{
@@ -22,11 +21,16 @@ func CreateAndUploadDocument(documentName, documentType, documentFormat, documen
 			Target:    fmt.Sprintf("{SSM Document Path: %v/%v.%v, Region: %v}", documentPath, documentName, documentFormat, region),
 		}
 	}
-	documentContent := string(openFile)
 
-	// Load session from shared config
+	return UploadDocument(documentName, documentType, documentFormat, string(openFile), region)
+}
+
+// UploadDocument will add the ssm document with the given content in aws service monitoring docs.
+func UploadDocument(documentName, documentType, documentFormat, documentContent, region string) error {
+
+	sesh := common.GetAWSSession(region)
 	ssmClient := ssm.New(sesh)
-	_, err = ssmClient.CreateDocument(&ssm.CreateDocumentInput{
+	_, err := ssmClient.CreateDocument(&ssm.CreateDocumentInput{
 		Content:        &documentContent,
 		Name:           aws.String(documentName),
 		DocumentType:   aws.String(documentType),
@@ -38,7 +42,7 @@ func CreateAndUploadDocument(documentName, documentType, documentFormat, documen
 		return cerrors.Error{
 			ErrorCode: cerrors.ErrorTypeChaosInject,
 			Reason:    fmt.Sprintf("failed to upload docs: %v", err),
-			Target:    fmt.Sprintf("{SSM Document Path: %v/%v.%v, Region: %v}", documentPath, documentName, documentFormat, region),
+			Target:    fmt.Sprintf("{SSM Document Name: %v, Region: %v}", documentName, region),
 		}
 	}
 	return nil
@@ -0,0 +1,280 @@
+package ssm
+
+import (
+	"embed"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/aws-ssm/aws-ssm-chaos/types"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+)
+
+// docs contains the vetted ssm documents of the bundled faults
+//
+//go:embed docs/*.yml
+var docs embed.FS
+
+// FaultDocumentFormat is the format of the bundled documents, which doesn't depend on the DOCUMENT_FORMAT env
+const FaultDocumentFormat = "YAML"
+
+// Fault is the bundled fault, which is injected by its ssm document and reverted by its revert document
+type Fault struct {
+	// Document is the file name of the ssm document inside the docs directory
+	Document string
+	// RevertDocument is the file name of the ssm document, which reverts the fault if the chaos is aborted
+	RevertDocument string
+	// Parameters are the parameters of the ssm document
+	Parameters []FaultParameter
+	// RevertParameters are the names of the parameters, which are also sent to the revert document
+	RevertParameters []string
+}
+
+// FaultParameter is the parameter of the ssm document, which is derived from the experiment details and validated before sending the command
+type FaultParameter struct {
+	Name     string
+	Value    func(experimentsDetails *experimentTypes.ExperimentDetails) string
+	Validate func(value string) error
+}
+
+var (
+	interfacePattern   = regexp.MustCompile(`^[A-Za-z0-9._-]{1,15}$`)
+	pathPattern        = regexp.MustCompile(`^/[A-Za-z0-9._/-]*$`)
+	processPattern     = regexp.MustCompile(`^[A-Za-z0-9._-]{1,15}$`)
+	serviceNamePattern = regexp.MustCompile(`^[A-Za-z0-9@:._-]+$`)
+)
+
+var (
+	durationParameter = FaultParameter{
+		Name:     "Duration",
+		Value:    func(e *experimentTypes.ExperimentDetails) string { return strconv.Itoa(e.ChaosDuration) },
+		Validate: inRange(1, 43200),
+	}
+	installDependenciesParameter = FaultParameter{
+		Name:     "InstallDependencies",
+		Value:    func(e *experimentTypes.ExperimentDetails) string { return e.InstallDependencies },
+		Validate: oneOf("True", "False"),
+	}
+	interfaceParameter = FaultParameter{
+		Name:     "Interface",
+		Value:    func(e *experimentTypes.ExperimentDetails) string { return e.NetworkInterface },
+		Validate: matches(interfacePattern, "a network interface name"),
+	}
+)
+
+// Faults contains the bundled faults by their name, which is provided by the FAULT_NAME env
+var Faults = map[string]Fault{
+	"cpu-stress": {
+		Document:       "cpu-stress.yml",
+		RevertDocument: "stress-revert.yml",
+		Parameters: []FaultParameter{
+			durationParameter,
+			{
+				Name:     "CPU",
+				Value:    func(e *experimentTypes.ExperimentDetails) string { return strconv.Itoa(e.Cpu) },
+				Validate: inRange(0, 1024),
+			},
+			installDependenciesParameter,
+		},
+	},
+	"memory-stress": {
+		Document:       "memory-stress.yml",
+		RevertDocument: "stress-revert.yml",
+		Parameters: []FaultParameter{
+			durationParameter,
+			{
+				Name:     "Workers",
+				Value:    func(e *experimentTypes.ExperimentDetails) string { return strconv.Itoa(e.NumberOfWorkers) },
+				Validate: inRange(1, 256),
+			},
+			{
+				Name:     "Percent",
+				Value:    func(e *experimentTypes.ExperimentDetails) string { return strconv.Itoa(e.MemoryPercentage) },
+				Validate: inRange(1, 100),
+			},
+			installDependenciesParameter,
+		},
+	},
+	"disk-fill": {
+		Document:       "disk-fill.yml",
+		RevertDocument: "disk-fill-revert.yml",
+		Parameters: []FaultParameter{
+			durationParameter,
+			{
+				Name:     "FillPercentage",
+				Value:    func(e *experimentTypes.ExperimentDetails) string { return strconv.Itoa(e.FillPercentage) },
+				Validate: inRange(1, 100),
+			},
+			{
+				Name:     "FillPath",
+				Value:    func(e *experimentTypes.ExperimentDetails) string { return e.FillPath },
+				Validate: matches(pathPattern, "an absolute path"),
+			},
+		},
+		RevertParameters: []string{"FillPath"},
+	},
+	"network-latency": {
+		Document:       "network-latency.yml",
+		RevertDocument: "network-revert.yml",
+		Parameters: []FaultParameter{
+			durationParameter,
+			interfaceParameter,
+			{
+				Name:     "Latency",
+				Value:    func(e *experimentTypes.ExperimentDetails) string { return strconv.Itoa(e.NetworkLatency) },
+				Validate: inRange(1, 60000),
+			},
+			{
+				Name:     "Jitter",
+				Value:    func(e *experimentTypes.ExperimentDetails) string { return strconv.Itoa(e.Jitter) },
+				Validate: inRange(0, 60000),
+			},
+		},
+		RevertParameters: []string{"Interface"},
+	},
+	"network-loss": {
+		Document:       "network-loss.yml",
+		RevertDocument: "network-revert.yml",
+		Parameters: []FaultParameter{
+			durationParameter,
+			interfaceParameter,
+			{
+				Name:     "LossPercentage",
+				Value:    func(e *experimentTypes.ExperimentDetails) string { return strconv.Itoa(e.PacketLossPercentage) },
+				Validate: inRange(1, 100),
+			},
+		},
+		RevertParameters: []string{"Interface"},
+	},
+	"process-kill": {
+		// the killed processes can't be brought back, the command is only cancelled on abort
+		Document: "process-kill.yml",
+		Parameters: []FaultParameter{
+			durationParameter,
+			{
+				Name:     "ProcessName",
+				Value:    func(e *experimentTypes.ExperimentDetails) string { return e.ProcessName },
+				Validate: matches(processPattern, "a process name of at most 15 characters"),
+			},
+		},
+	},
+	"service-stop": {
+		Document:       "service-stop.yml",
+		RevertDocument: "service-stop-revert.yml",
+		Parameters: []FaultParameter{
+			durationParameter,
+			{
+				Name:     "ServiceName",
+				Value:    func(e *experimentTypes.ExperimentDetails) string { return e.ServiceName },
+				Validate: matches(serviceNamePattern, "a systemd service name"),
+			},
+		},
+		RevertParameters: []string{"ServiceName"},
+	},
+}
+
+// GetFault returns the bundled fault with the given name
+func GetFault(name string) (Fault, error) {
+	fault, ok := Faults[name]
+	if !ok {
+		return Fault{}, cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeGeneric,
+			Reason:    fmt.Sprintf("unsupported fault, supported faults are %v", FaultNames()),
+			Target:    fmt.Sprintf("{Fault Name: %v}", name),
+		}
+	}
+	return fault, nil
+}
+
+// FaultNames returns the sorted names of the bundled faults
+func FaultNames() []string {
+	names := make([]string, 0, len(Faults))
+	for name := range Faults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetFaultParameters validates the parameters of the fault and returns them along with the parameters of its revert document
+func GetFaultParameters(experimentsDetails *experimentTypes.ExperimentDetails) (map[string]string, map[string]string, error) {
+	fault, err := GetFault(experimentsDetails.FaultName)
+	if err != nil {
+		return nil, nil, err
+	}
+	parameters := map[string]string{}
+	for _, parameter := range fault.Parameters {
+		value := parameter.Value(experimentsDetails)
+		if err := parameter.Validate(value); err != nil {
+			return nil, nil, cerrors.Error{
+				ErrorCode: cerrors.ErrorTypeGeneric,
+				Reason:    fmt.Sprintf("invalid %v parameter: %v", parameter.Name, err),
+				Target:    fmt.Sprintf("{Fault Name: %v}", experimentsDetails.FaultName),
+			}
+		}
+		parameters[parameter.Name] = value
+	}
+	revertParameters := map[string]string{}
+	for _, name := range fault.RevertParameters {
+		revertParameters[name] = parameters[name]
+	}
+	return parameters, revertParameters, nil
+}
+
+// GetFaultDocuments returns the content of the ssm document and the revert document of the fault
+// the revert document is empty if the fault can't be reverted
+func GetFaultDocuments(name string) (string, string, error) {
+	fault, err := GetFault(name)
+	if err != nil {
+		return "", "", err
+	}
+	document, err := docs.ReadFile("docs/" + fault.Document)
+	if err != nil {
+		return "", "", cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("failed to read the ssm document: %v", err), Target: fmt.Sprintf("{Fault Name: %v}", name)}
+	}
+	if fault.RevertDocument == "" {
+		return string(document), "", nil
+	}
+	revertDocument, err := docs.ReadFile("docs/" + fault.RevertDocument)
+	if err != nil {
+		return "", "", cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("failed to read the revert ssm document: %v", err), Target: fmt.Sprintf("{Fault Name: %v}", name)}
+	}
+	return string(document), string(revertDocument), nil
+}
+
+// inRange validates that the value is an integer within the given range
+func inRange(min, max int) func(string) error {
+	return func(value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("'%v' is not an integer", value)
+		}
+		if n < min || n > max {
+			return fmt.Errorf("%v is not between %v and %v", n, min, max)
+		}
+		return nil
+	}
+}
+
+// oneOf validates that the value is one of the allowed values
+func oneOf(allowed ...string) func(string) error {
+	return func(value string) error {
+		for _, a := range allowed {
+			if value == a {
+				return nil
+			}
+		}
+		return fmt.Errorf("'%v' is not one of %v", value, allowed)
+	}
+}
+
+// matches validates that the value matches the pattern, it keeps the values which can break the shell script out of the document
+func matches(pattern *regexp.Regexp, description string) func(string) error {
+	return func(value string) error {
+		if !pattern.MatchString(value) {
+			return fmt.Errorf("'%v' is not %v", value, description)
+		}
+		return nil
+	}
+}
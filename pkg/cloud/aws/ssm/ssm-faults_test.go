@@ -0,0 +1,100 @@
+package ssm
+
+import (
+	"testing"
+
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/aws-ssm/aws-ssm-chaos/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func validDetails(faultName string) *experimentTypes.ExperimentDetails {
+	return &experimentTypes.ExperimentDetails{
+		FaultName:            faultName,
+		ChaosDuration:        60,
+		Cpu:                  0,
+		NumberOfWorkers:      1,
+		MemoryPercentage:     80,
+		InstallDependencies:  "True",
+		NetworkInterface:     "eth0",
+		NetworkLatency:       2000,
+		PacketLossPercentage: 100,
+		FillPercentage:       80,
+		FillPath:             "/var/lib",
+		ProcessName:          "nginx",
+		ServiceName:          "nginx.service",
+	}
+}
+
+// TestFaultDocuments checks that the documents of every fault declare the parameters sent to them
+func TestFaultDocuments(t *testing.T) {
+	type document struct {
+		SchemaVersion string                 `yaml:"schemaVersion"`
+		Parameters    map[string]interface{} `yaml:"parameters"`
+		MainSteps     []interface{}          `yaml:"mainSteps"`
+	}
+	for _, name := range FaultNames() {
+		t.Run(name, func(t *testing.T) {
+			content, revertContent, err := GetFaultDocuments(name)
+			require.NoError(t, err)
+			parameters, revertParameters, err := GetFaultParameters(validDetails(name))
+			require.NoError(t, err)
+
+			var doc document
+			require.NoError(t, yaml.Unmarshal([]byte(content), &doc))
+			assert.Equal(t, "2.2", doc.SchemaVersion)
+			assert.NotEmpty(t, doc.MainSteps)
+			assert.Len(t, doc.Parameters, len(parameters))
+			for parameter := range parameters {
+				assert.Contains(t, doc.Parameters, parameter)
+			}
+
+			if revertContent == "" {
+				assert.Empty(t, revertParameters)
+				return
+			}
+			var revertDoc document
+			require.NoError(t, yaml.Unmarshal([]byte(revertContent), &revertDoc))
+			assert.Len(t, revertDoc.Parameters, len(revertParameters))
+			for parameter := range revertParameters {
+				assert.Contains(t, revertDoc.Parameters, parameter)
+			}
+		})
+	}
+}
+
+func TestGetFaultParameters(t *testing.T) {
+	tests := map[string]struct {
+		fault   string
+		modify  func(*experimentTypes.ExperimentDetails)
+		wantErr string
+	}{
+		"valid network latency": {fault: "network-latency"},
+		"unsupported fault":     {fault: "cpu-hog", wantErr: "unsupported fault"},
+		"zero duration":         {fault: "cpu-stress", modify: func(e *experimentTypes.ExperimentDetails) { e.ChaosDuration = 0 }, wantErr: "invalid Duration parameter"},
+		"memory above 100%":     {fault: "memory-stress", modify: func(e *experimentTypes.ExperimentDetails) { e.MemoryPercentage = 120 }, wantErr: "invalid Percent parameter"},
+		"invalid dependencies":  {fault: "cpu-stress", modify: func(e *experimentTypes.ExperimentDetails) { e.InstallDependencies = "yes" }, wantErr: "invalid InstallDependencies parameter"},
+		"relative fill path":    {fault: "disk-fill", modify: func(e *experimentTypes.ExperimentDetails) { e.FillPath = "var/lib" }, wantErr: "invalid FillPath parameter"},
+		"shell in interface":    {fault: "network-loss", modify: func(e *experimentTypes.ExperimentDetails) { e.NetworkInterface = "eth0; reboot" }, wantErr: "invalid Interface parameter"},
+		"missing process name":  {fault: "process-kill", modify: func(e *experimentTypes.ExperimentDetails) { e.ProcessName = "" }, wantErr: "invalid ProcessName parameter"},
+		"shell in service name": {fault: "service-stop", modify: func(e *experimentTypes.ExperimentDetails) { e.ServiceName = "nginx$(id)" }, wantErr: "invalid ServiceName parameter"},
+		"long process name":     {fault: "process-kill", modify: func(e *experimentTypes.ExperimentDetails) { e.ProcessName = "a-very-long-process-name" }, wantErr: "invalid ProcessName parameter"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			details := validDetails(tt.fault)
+			if tt.modify != nil {
+				tt.modify(details)
+			}
+			parameters, revertParameters, err := GetFaultParameters(details)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"Duration": "60", "Interface": "eth0", "Latency": "2000", "Jitter": "0"}, parameters)
+			assert.Equal(t, map[string]string{"Interface": "eth0"}, revertParameters)
+		})
+	}
+}
@@ -39,11 +39,39 @@ func awsErrHasCode(err error, code string) bool {
 // SendSSMCommand will create and add the ssm document in aws service monitoring docs.
 func SendSSMCommand(experimentsDetails *experimentTypes.ExperimentDetails, ec2InstanceID []string) (string, error) {
 
-	sesh := common.GetAWSSession(experimentsDetails.Region)
-	ssmClient := ssm.New(sesh)
 	timeout := int64(experimentsDetails.ChaosDuration + 30)
+	commandID, err := sendCommand(experimentsDetails.DocumentName, getParameters(experimentsDetails), ec2InstanceID, timeout, experimentsDetails.Region)
+	if err != nil {
+		return "", cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeChaosInject,
+			Reason:    fmt.Sprintf("failed to send SSM command: %v", common.CheckAWSError(err).Error()),
+			Target:    fmt.Sprintf("{EC2 Instance ID: %v, Region: %v}", ec2InstanceID, experimentsDetails.Region),
+		}
+	}
+	return commandID, nil
+}
+
+// SendRevertCommand will send the revert document of the bundled fault to the target instances
+func SendRevertCommand(experimentsDetails *experimentTypes.ExperimentDetails, ec2InstanceID []string) (string, error) {
+
+	commandID, err := sendCommand(experimentsDetails.RevertDocumentName, toSSMParameters(experimentsDetails.RevertFaultParameters), ec2InstanceID, int64(experimentsDetails.Timeout), experimentsDetails.Region)
+	if err != nil {
+		return "", cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeChaosRevert,
+			Reason:    fmt.Sprintf("failed to send SSM revert command: %v", common.CheckAWSError(err).Error()),
+			Target:    fmt.Sprintf("{EC2 Instance ID: %v, Region: %v}", ec2InstanceID, experimentsDetails.Region),
+		}
+	}
+	return commandID, nil
+}
+
+// sendCommand will run the ssm document with the given parameters on the instances
+func sendCommand(documentName string, parameters map[string][]*string, ec2InstanceID []string, timeout int64, region string) (string, error) {
+
+	sesh := common.GetAWSSession(region)
+	ssmClient := ssm.New(sesh)
 	res, err := ssmClient.SendCommand(&ssm.SendCommandInput{
-		DocumentName: aws.String(experimentsDetails.DocumentName),
+		DocumentName: aws.String(documentName),
 
 		Targets: []*ssm.Target{
 			{
@@ -51,17 +79,13 @@ func SendSSMCommand(experimentsDetails *experimentTypes.ExperimentDetails, ec2In
 				Values: aws.StringSlice(ec2InstanceID),
 			},
 		},
-		Parameters:     getParameters(experimentsDetails),
+		Parameters:     parameters,
 		TimeoutSeconds: aws.Int64(timeout),
 		MaxConcurrency: aws.String("50"),
 		MaxErrors:      aws.String("0"),
 	})
 	if err != nil {
-		return "", cerrors.Error{
-			ErrorCode: cerrors.ErrorTypeChaosInject,
-			Reason:    fmt.Sprintf("failed to send SSM command: %v", common.CheckAWSError(err).Error()),
-			Target:    fmt.Sprintf("{EC2 Instance ID: %v, Region: %v}", ec2InstanceID, experimentsDetails.Region),
-		}
+		return "", err
 	}
 
 	return *res.Command.CommandId, nil
@@ -69,8 +93,12 @@ func SendSSMCommand(experimentsDetails *experimentTypes.ExperimentDetails, ec2In
 
 // getParameters will return the parameters bases on the doccumentPath
 // for custom path no parameter will be sent to the docs
+// for the bundled fault the validated parameters of its document are sent
 func getParameters(experimentsDetails *experimentTypes.ExperimentDetails) map[string][]*string {
 
+	if experimentsDetails.FaultName != "" {
+		return toSSMParameters(experimentsDetails.FaultParameters)
+	}
 	if experimentsDetails.DocumentPath != DefaultSSMDocsDirectory {
 		return nil
 	}
@@ -94,6 +122,18 @@ func getParameters(experimentsDetails *experimentTypes.ExperimentDetails) map[st
 	return parameter
 }
 
+// toSSMParameters converts the parameters to the format of the ssm command
+func toSSMParameters(parameters map[string]string) map[string][]*string {
+	if len(parameters) == 0 {
+		return nil
+	}
+	ssmParameters := map[string][]*string{}
+	for name, value := range parameters {
+		ssmParameters[name] = []*string{aws.String(value)}
+	}
+	return ssmParameters
+}
+
 // WaitForCommandStatus will wait until the ssm command comes in target status
 func WaitForCommandStatus(status, commandID, ec2InstanceID, region string, timeout, delay int) error {
 
@@ -122,6 +162,27 @@ func WaitForCommandStatus(status, commandID, ec2InstanceID, region string, timeo
 // getSSMCommandStatus will create and add the ssm document in aws service monitoring docs.
 func getSSMCommandStatus(commandID, ec2InstanceID, region string) (string, error) {
 
+	invocation, err := getCommandInvocation(commandID, ec2InstanceID, region)
+	if err != nil {
+		return "", err
+	}
+	return *invocation.Status, nil
+}
+
+// GetCommandOutput returns the status, the standard output and the standard error of the ssm command on the instance
+// the output is truncated by the ssm to its first 24000 characters
+func GetCommandOutput(commandID, ec2InstanceID, region string) (string, string, string, error) {
+
+	invocation, err := getCommandInvocation(commandID, ec2InstanceID, region)
+	if err != nil {
+		return "", "", "", err
+	}
+	return aws.StringValue(invocation.Status), aws.StringValue(invocation.StandardOutputContent), aws.StringValue(invocation.StandardErrorContent), nil
+}
+
+// getCommandInvocation returns the invocation of the ssm command on the instance
+func getCommandInvocation(commandID, ec2InstanceID, region string) (*ssm.GetCommandInvocationOutput, error) {
+
 	sesh := common.GetAWSSession(region)
 	ssmClient := ssm.New(sesh)
 
@@ -130,13 +191,13 @@ func getSSMCommandStatus(commandID, ec2InstanceID, region string) (string, error
 		InstanceId: aws.String(ec2InstanceID),
 	})
 	if err != nil {
-		return "", cerrors.Error{
+		return nil, cerrors.Error{
 			ErrorCode: cerrors.ErrorTypeChaosInject,
 			Reason:    fmt.Sprintf("failed to get SSM command status: %v", common.CheckAWSError(err).Error()),
 			Target:    fmt.Sprintf("{Command ID: %v, EC2 Instance ID: %v, Region: %v}", commandID, ec2InstanceID, region),
 		}
 	}
-	return *cmdOutput.Status, nil
+	return cmdOutput, nil
 }
 
 // CheckInstanceInformation checks if the instance has permission to do SSM API calls,
@@ -0,0 +1,375 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/monitor/mgmt/insights"
+	"github.com/Azure/azure-sdk-for-go/profiles/latest/compute/mgmt/compute"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/cloud/azure/common"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
+	"github.com/palantir/stacktrace"
+)
+
+// GetScaleSetCapacity returns the capacity of the scale set
+func GetScaleSetCapacity(subscriptionID, resourceGroup, scaleSetName string) (int64, error) {
+
+	vmssClient := compute.NewVirtualMachineScaleSetsClient(subscriptionID)
+
+	authorizer, err := auth.NewAuthorizerFromFile(azure.PublicCloud.ResourceManagerEndpoint)
+	if err != nil {
+		return 0, cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeStatusChecks,
+			Reason:    fmt.Sprintf("authorization set up failed: %v", err),
+			Target:    fmt.Sprintf("{Azure Scale Set Name: %v, Resource Group: %v}", scaleSetName, resourceGroup),
+		}
+	}
+
+	vmssClient.Authorizer = authorizer
+
+	scaleSet, err := vmssClient.Get(context.TODO(), resourceGroup, scaleSetName, "")
+	if err != nil {
+		return 0, cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeStatusChecks,
+			Reason:    fmt.Sprintf("failed to get the scale set: %v", err),
+			Target:    fmt.Sprintf("{Azure Scale Set Name: %v, Resource Group: %v}", scaleSetName, resourceGroup),
+		}
+	}
+	if scaleSet.Sku == nil || scaleSet.Sku.Capacity == nil {
+		return 0, cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeStatusChecks,
+			Reason:    "failed to get the scale set capacity",
+			Target:    fmt.Sprintf("{Azure Scale Set Name: %v, Resource Group: %v}", scaleSetName, resourceGroup),
+		}
+	}
+
+	return *scaleSet.Sku.Capacity, nil
+}
+
+// GetScaleSetAutoscaleMinimum returns the minimum instance count of the enabled autoscale setting of the scale set
+// the deleted instances lower the capacity of the scale set, these are replaced only by the autoscale, up to its minimum instance count
+func GetScaleSetAutoscaleMinimum(subscriptionID, resourceGroup, scaleSetName string) (int64, error) {
+
+	autoscaleClient := insights.NewAutoscaleSettingsClient(subscriptionID)
+
+	authorizer, err := auth.NewAuthorizerFromFile(azure.PublicCloud.ResourceManagerEndpoint)
+	if err != nil {
+		return 0, cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeTargetSelection,
+			Reason:    fmt.Sprintf("authorization set up failed: %v", err),
+			Target:    fmt.Sprintf("{Azure Scale Set Name: %v, Resource Group: %v}", scaleSetName, resourceGroup),
+		}
+	}
+
+	autoscaleClient.Authorizer = authorizer
+
+	// the autoscale setting can be created in any resource group of the subscription
+	var settings []insights.AutoscaleSettingResource
+	result, err := autoscaleClient.ListBySubscriptionComplete(context.TODO())
+	for err == nil && result.NotDone() {
+		settings = append(settings, result.Value())
+		err = result.NextWithContext(context.TODO())
+	}
+	if err != nil {
+		return 0, cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeTargetSelection,
+			Reason:    fmt.Sprintf("failed to list the autoscale settings: %v", err),
+			Target:    fmt.Sprintf("{Azure Scale Set Name: %v, Resource Group: %v}", scaleSetName, resourceGroup),
+		}
+	}
+
+	scaleSetID := fmt.Sprintf("/subscriptions/%v/resourceGroups/%v/providers/Microsoft.Compute/virtualMachineScaleSets/%v", subscriptionID, resourceGroup, scaleSetName)
+	minimum, err := autoscaleMinimum(settings, scaleSetID)
+	if err != nil {
+		return 0, cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeTargetSelection,
+			Reason:    err.Error(),
+			Target:    fmt.Sprintf("{Azure Scale Set Name: %v, Resource Group: %v}", scaleSetName, resourceGroup),
+		}
+	}
+
+	return minimum, nil
+}
+
+// autoscaleMinimum returns the minimum instance count of the enabled autoscale setting of the given scale set
+// the lowest minimum of its profiles is used, as the active profile depends on the schedule of the profiles
+func autoscaleMinimum(settings []insights.AutoscaleSettingResource, scaleSetID string) (int64, error) {
+
+	for _, setting := range settings {
+		if setting.AutoscaleSetting == nil || setting.TargetResourceURI == nil || !strings.EqualFold(*setting.TargetResourceURI, scaleSetID) {
+			continue
+		}
+		if setting.Enabled != nil && !*setting.Enabled {
+			return 0, fmt.Errorf("the autoscale setting of the scale set is disabled, the deleted instances are not replaced without the autoscale")
+		}
+		if setting.Profiles == nil || len(*setting.Profiles) == 0 {
+			return 0, fmt.Errorf("the autoscale setting of the scale set has no profiles")
+		}
+
+		minimum := int64(-1)
+		for _, profile := range *setting.Profiles {
+			if profile.Capacity == nil || profile.Capacity.Minimum == nil {
+				continue
+			}
+			count, err := strconv.ParseInt(*profile.Capacity.Minimum, 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid minimum instance count '%v' in the autoscale setting of the scale set", *profile.Capacity.Minimum)
+			}
+			if minimum == -1 || count < minimum {
+				minimum = count
+			}
+		}
+		if minimum < 1 {
+			return 0, fmt.Errorf("the autoscale setting of the scale set has no minimum instance count, the deleted instances are not replaced")
+		}
+		return minimum, nil
+	}
+
+	return 0, fmt.Errorf("no autoscale setting found for the scale set, the deleted instances are not replaced without the autoscale")
+}
+
+// GetScaleSets returns the names of the scale sets in the resource group
+func GetScaleSets(subscriptionID, resourceGroup string) ([]string, error) {
+
+	vmssClient := compute.NewVirtualMachineScaleSetsClient(subscriptionID)
+
+	authorizer, err := auth.NewAuthorizerFromFile(azure.PublicCloud.ResourceManagerEndpoint)
+	if err != nil {
+		return nil, cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeTargetSelection,
+			Reason:    fmt.Sprintf("authorization set up failed: %v", err),
+			Target:    fmt.Sprintf("{Resource Group: %v}", resourceGroup),
+		}
+	}
+
+	vmssClient.Authorizer = authorizer
+
+	var scaleSetNames []string
+	scaleSets, err := vmssClient.ListComplete(context.TODO(), resourceGroup)
+	for err == nil && scaleSets.NotDone() {
+		if scaleSet := scaleSets.Value(); scaleSet.Name != nil {
+			scaleSetNames = append(scaleSetNames, *scaleSet.Name)
+		}
+		err = scaleSets.NextWithContext(context.TODO())
+	}
+	if err != nil {
+		return nil, cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeTargetSelection,
+			Reason:    fmt.Sprintf("failed to list the scale sets: %v", err),
+			Target:    fmt.Sprintf("{Resource Group: %v}", resourceGroup),
+		}
+	}
+
+	return scaleSetNames, nil
+}
+
+// GetScaleSetInstances returns the instances of the scale set along with their instance view
+func GetScaleSetInstances(subscriptionID, resourceGroup, scaleSetName string) ([]compute.VirtualMachineScaleSetVM, error) {
+
+	vmssClient := compute.NewVirtualMachineScaleSetVMsClient(subscriptionID)
+
+	authorizer, err := auth.NewAuthorizerFromFile(azure.PublicCloud.ResourceManagerEndpoint)
+	if err != nil {
+		return nil, cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeStatusChecks,
+			Reason:    fmt.Sprintf("authorization set up failed: %v", err),
+			Target:    fmt.Sprintf("{Azure Scale Set Name: %v, Resource Group: %v}", scaleSetName, resourceGroup),
+		}
+	}
+
+	vmssClient.Authorizer = authorizer
+
+	var instances []compute.VirtualMachineScaleSetVM
+	vms, err := vmssClient.ListComplete(context.TODO(), resourceGroup, scaleSetName, "", "", "instanceView")
+	for err == nil && vms.NotDone() {
+		instances = append(instances, vms.Value())
+		err = vms.NextWithContext(context.TODO())
+	}
+	if err != nil {
+		return nil, cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeStatusChecks,
+			Reason:    fmt.Sprintf("failed to list the scale set instances: %v", err),
+			Target:    fmt.Sprintf("{Azure Scale Set Name: %v, Resource Group: %v}", scaleSetName, resourceGroup),
+		}
+	}
+
+	return instances, nil
+}
+
+// GetRunningScaleSetInstances returns the names of the running instances of the scale set
+// the names are of format <scale set name>_<instance id>, which is accepted by the other scale set helpers
+func GetRunningScaleSetInstances(subscriptionID, resourceGroup, scaleSetName string) ([]string, error) {
+
+	instances, err := GetScaleSetInstances(subscriptionID, resourceGroup, scaleSetName)
+	if err != nil {
+		return nil, err
+	}
+
+	return runningScaleSetInstances(scaleSetName, instances, ""), nil
+}
+
+// runningScaleSetInstances filters the running instances of the scale set, which are provisioned successfully
+// the instances are filtered by the zone as well, if it is provided
+func runningScaleSetInstances(scaleSetName string, instances []compute.VirtualMachineScaleSetVM, zone string) []string {
+
+	var instanceNames []string
+	for _, instance := range instances {
+		if instance.InstanceID == nil || instance.VirtualMachineScaleSetVMProperties == nil || instance.InstanceView == nil {
+			continue
+		}
+		if zone != "" && !inZone(instance.Zones, zone) {
+			continue
+		}
+		if isInstanceRunning(instance.InstanceView.Statuses) {
+			instanceNames = append(instanceNames, fmt.Sprintf("%v_%v", scaleSetName, *instance.InstanceID))
+		}
+	}
+
+	return instanceNames
+}
+
+// isInstanceRunning checks whether the instance view statuses contain the succeeded provisioning state and the running power state
+func isInstanceRunning(statuses *[]compute.InstanceViewStatus) bool {
+
+	if statuses == nil {
+		return false
+	}
+
+	var provisioned, running bool
+	for _, status := range *statuses {
+		if status.Code == nil {
+			continue
+		}
+		switch *status.Code {
+		case "ProvisioningState/succeeded":
+			provisioned = true
+		case "PowerState/running":
+			running = true
+		}
+	}
+
+	return provisioned && running
+}
+
+// AzureScaleSetInstanceDelete deletes the target instance in the scale set and waits for it to get deleted
+func AzureScaleSetInstanceDelete(timeout int, subscriptionID, resourceGroup, azureInstanceName string) error {
+
+	vmssClient := compute.NewVirtualMachineScaleSetVMsClient(subscriptionID)
+
+	authorizer, err := auth.NewAuthorizerFromFile(azure.PublicCloud.ResourceManagerEndpoint)
+	if err != nil {
+		return cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeChaosInject,
+			Reason:    fmt.Sprintf("authorization set up failed: %v", err),
+			Target:    fmt.Sprintf("{Azure Instance Name: %v, Resource Group: %v}", azureInstanceName, resourceGroup),
+		}
+	}
+
+	vmssClient.Authorizer = authorizer
+
+	virtualMachineScaleSetName, virtualMachineId := common.GetScaleSetNameAndInstanceId(azureInstanceName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	log.Infof("[Info]: Deleting the %v instance", azureInstanceName)
+	future, err := vmssClient.Delete(ctx, resourceGroup, virtualMachineScaleSetName, virtualMachineId, nil)
+	if err == nil {
+		err = future.WaitForCompletionRef(ctx, vmssClient.Client)
+	}
+	if err != nil {
+		return cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeChaosInject,
+			Reason:    fmt.Sprintf("failed to delete the instance: %v", err),
+			Target:    fmt.Sprintf("{Azure Instance Name: %v, Resource Group: %v}", azureInstanceName, resourceGroup),
+		}
+	}
+
+	return nil
+}
+
+// ValidateScaleSetDeletion checks that the deletion of the given number of instances takes the running instances of the scale set below the autoscale minimum
+// the autoscale replaces the deleted instances only up to its minimum instance count, so the recovery can't be verified otherwise
+func ValidateScaleSetDeletion(runningInstances, deletedInstances, minimum int) error {
+
+	if runningInstances-deletedInstances >= minimum {
+		return fmt.Errorf("deleting %v out of %v running instances keeps the scale set at or above its autoscale minimum of %v instances, the deleted instances are not replaced by the autoscale", deletedInstances, runningInstances, minimum)
+	}
+
+	return nil
+}
+
+// isScaleSetHealed checks whether the scale set has the given number of running instances, apart from the deleted instances
+func isScaleSetHealed(runningInstances []string, instanceCount int, deletedInstances []string) error {
+
+	var running int
+	for _, instanceName := range runningInstances {
+		if !common.StringInSlice(instanceName, deletedInstances) {
+			running++
+		}
+	}
+
+	if running < instanceCount {
+		return fmt.Errorf("%v out of %v instances are running", running, instanceCount)
+	}
+
+	return nil
+}
+
+// ScaleSetStatusCheck checks whether all the instances of the scale set capacity are running
+func ScaleSetStatusCheck(subscriptionID, resourceGroup, scaleSetName string) error {
+
+	capacity, err := GetScaleSetCapacity(subscriptionID, resourceGroup, scaleSetName)
+	if err != nil {
+		return err
+	}
+
+	runningInstances, err := GetRunningScaleSetInstances(subscriptionID, resourceGroup, scaleSetName)
+	if err != nil {
+		return err
+	}
+
+	if err := isScaleSetHealed(runningInstances, int(capacity), nil); err != nil {
+		return cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeStatusChecks,
+			Reason:    fmt.Sprintf("scale set is not at its capacity, %v", err),
+			Target:    fmt.Sprintf("{Azure Scale Set Name: %v, Resource Group: %v}", scaleSetName, resourceGroup),
+		}
+	}
+
+	return nil
+}
+
+// WaitForScaleSetHealed will wait for the scale set to replace the deleted instances, till it has the given number of running instances
+func WaitForScaleSetHealed(timeout, delay int, subscriptionID, resourceGroup, scaleSetName string, instanceCount int, deletedInstances []string) error {
+
+	log.Infof("[Status]: Checking %v scale set status", scaleSetName)
+	return retry.
+		Times(uint(timeout / delay)).
+		Wait(time.Duration(delay) * time.Second).
+		Try(func(attempt uint) error {
+
+			runningInstances, err := GetRunningScaleSetInstances(subscriptionID, resourceGroup, scaleSetName)
+			if err != nil {
+				return stacktrace.Propagate(err, "failed to get the scale set instances")
+			}
+
+			if err := isScaleSetHealed(runningInstances, instanceCount, deletedInstances); err != nil {
+				log.Infof("The %v scale set is not yet healed, %v", scaleSetName, err)
+				return cerrors.Error{
+					ErrorCode: cerrors.ErrorTypeStatusChecks,
+					Reason:    fmt.Sprintf("scale set is not healed within timeout, %v", err),
+					Target:    fmt.Sprintf("{Azure Scale Set Name: %v, Resource Group: %v}", scaleSetName, resourceGroup),
+				}
+			}
+
+			return nil
+		})
+}
@@ -0,0 +1,113 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/monitor/mgmt/insights"
+	"github.com/Azure/azure-sdk-for-go/profiles/latest/compute/mgmt/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/stretchr/testify/assert"
+)
+
+func scaleSetInstance(instanceID, zone string, codes ...string) compute.VirtualMachineScaleSetVM {
+	var statuses []compute.InstanceViewStatus
+	for _, code := range codes {
+		statuses = append(statuses, compute.InstanceViewStatus{Code: to.StringPtr(code)})
+	}
+	return compute.VirtualMachineScaleSetVM{
+		InstanceID: to.StringPtr(instanceID),
+		Zones:      &[]string{zone},
+		VirtualMachineScaleSetVMProperties: &compute.VirtualMachineScaleSetVMProperties{
+			InstanceView: &compute.VirtualMachineScaleSetVMInstanceView{Statuses: &statuses},
+		},
+	}
+}
+
+func TestRunningScaleSetInstances(t *testing.T) {
+	instances := []compute.VirtualMachineScaleSetVM{
+		scaleSetInstance("0", "1", "ProvisioningState/succeeded", "PowerState/running"),
+		scaleSetInstance("1", "2", "ProvisioningState/succeeded", "PowerState/running"),
+		scaleSetInstance("2", "1", "ProvisioningState/creating", "PowerState/starting"),
+		scaleSetInstance("3", "1", "ProvisioningState/succeeded", "PowerState/stopped"),
+		{InstanceID: to.StringPtr("4")},
+	}
+
+	assert.Equal(t, []string{"pool_0", "pool_1"}, runningScaleSetInstances("pool", instances, ""))
+	assert.Equal(t, []string{"pool_0"}, runningScaleSetInstances("pool", instances, "1"))
+	assert.Empty(t, runningScaleSetInstances("pool", instances, "3"))
+}
+
+func TestIsScaleSetHealed(t *testing.T) {
+	// the deleted instance is still running while it is being deleted
+	assert.ErrorContains(t, isScaleSetHealed([]string{"pool_0", "pool_1"}, 2, []string{"pool_1"}), "1 out of 2 instances are running")
+	assert.NoError(t, isScaleSetHealed([]string{"pool_0", "pool_2"}, 2, []string{"pool_1"}))
+	assert.NoError(t, isScaleSetHealed([]string{"pool_0", "pool_1", "pool_2"}, 2, nil))
+}
+
+func TestValidateScaleSetDeletion(t *testing.T) {
+	assert.NoError(t, ValidateScaleSetDeletion(2, 1, 2))
+	assert.NoError(t, ValidateScaleSetDeletion(5, 4, 2))
+	assert.ErrorContains(t, ValidateScaleSetDeletion(5, 1, 2), "deleting 1 out of 5 running instances keeps the scale set at or above its autoscale minimum of 2 instances")
+	assert.Error(t, ValidateScaleSetDeletion(5, 3, 2))
+}
+
+func autoscaleSetting(target string, enabled bool, minimums ...string) insights.AutoscaleSettingResource {
+	var profiles []insights.AutoscaleProfile
+	for _, minimum := range minimums {
+		profiles = append(profiles, insights.AutoscaleProfile{Capacity: &insights.ScaleCapacity{Minimum: to.StringPtr(minimum)}})
+	}
+	return insights.AutoscaleSettingResource{
+		AutoscaleSetting: &insights.AutoscaleSetting{TargetResourceURI: to.StringPtr(target), Enabled: to.BoolPtr(enabled), Profiles: &profiles},
+	}
+}
+
+func TestAutoscaleMinimum(t *testing.T) {
+	const scaleSetID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachineScaleSets/pool"
+	other := autoscaleSetting("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachineScaleSets/other", true, "5")
+
+	tests := map[string]struct {
+		settings []insights.AutoscaleSettingResource
+		want     int64
+		wantErr  string
+	}{
+		"lowest minimum of the profiles": {
+			settings: []insights.AutoscaleSettingResource{other, autoscaleSetting(scaleSetID, true, "3", "2")},
+			want:     2,
+		},
+		"resource id is case insensitive": {
+			settings: []insights.AutoscaleSettingResource{autoscaleSetting("/subscriptions/sub/resourcegroups/RG/providers/microsoft.compute/virtualmachinescalesets/pool", true, "3")},
+			want:     3,
+		},
+		"no autoscale setting": {
+			settings: []insights.AutoscaleSettingResource{other},
+			wantErr:  "no autoscale setting found for the scale set",
+		},
+		"disabled autoscale setting": {
+			settings: []insights.AutoscaleSettingResource{autoscaleSetting(scaleSetID, false, "3")},
+			wantErr:  "the autoscale setting of the scale set is disabled",
+		},
+		"no profiles": {
+			settings: []insights.AutoscaleSettingResource{autoscaleSetting(scaleSetID, true)},
+			wantErr:  "the autoscale setting of the scale set has no profiles",
+		},
+		"zero minimum": {
+			settings: []insights.AutoscaleSettingResource{autoscaleSetting(scaleSetID, true, "0")},
+			wantErr:  "the autoscale setting of the scale set has no minimum instance count",
+		},
+		"invalid minimum": {
+			settings: []insights.AutoscaleSettingResource{autoscaleSetting(scaleSetID, true, "two")},
+			wantErr:  "invalid minimum instance count 'two'",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			minimum, err := autoscaleMinimum(tt.settings, scaleSetID)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, minimum)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/latest/compute/mgmt/compute"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/palantir/stacktrace"
+)
+
+// GetRunningInstancesByZone returns the running virtual machines and the running scale set instances in the zone of the resource group
+func GetRunningInstancesByZone(subscriptionID, resourceGroup, zone string) ([]string, []string, error) {
+
+	instanceNames, err := getInstancesByZone(subscriptionID, resourceGroup, zone)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var runningInstanceNames []string
+	for _, instanceName := range instanceNames {
+		instanceState, err := GetAzureInstanceStatus(subscriptionID, resourceGroup, instanceName)
+		if err != nil {
+			return nil, nil, stacktrace.Propagate(err, "failed to get the instance status")
+		}
+		if instanceState == "VM running" {
+			runningInstanceNames = append(runningInstanceNames, instanceName)
+		}
+	}
+
+	scaleSetNames, err := GetScaleSets(subscriptionID, resourceGroup)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var runningScaleSetInstanceNames []string
+	for _, scaleSetName := range scaleSetNames {
+		instances, err := GetScaleSetInstances(subscriptionID, resourceGroup, scaleSetName)
+		if err != nil {
+			return nil, nil, err
+		}
+		runningScaleSetInstanceNames = append(runningScaleSetInstanceNames, runningScaleSetInstances(scaleSetName, instances, zone)...)
+	}
+
+	return runningInstanceNames, runningScaleSetInstanceNames, nil
+}
+
+// getInstancesByZone returns the names of the virtual machines in the zone of the resource group
+func getInstancesByZone(subscriptionID, resourceGroup, zone string) ([]string, error) {
+
+	vmClient := compute.NewVirtualMachinesClient(subscriptionID)
+
+	authorizer, err := auth.NewAuthorizerFromFile(azure.PublicCloud.ResourceManagerEndpoint)
+	if err != nil {
+		return nil, cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeTargetSelection,
+			Reason:    fmt.Sprintf("authorization set up failed: %v", err),
+			Target:    fmt.Sprintf("{Zone: %v, Resource Group: %v}", zone, resourceGroup),
+		}
+	}
+
+	vmClient.Authorizer = authorizer
+
+	var instanceNames []string
+	vms, err := vmClient.ListComplete(context.TODO(), resourceGroup, "")
+	for err == nil && vms.NotDone() {
+		if vm := vms.Value(); vm.Name != nil && inZone(vm.Zones, zone) {
+			instanceNames = append(instanceNames, *vm.Name)
+		}
+		err = vms.NextWithContext(context.TODO())
+	}
+	if err != nil {
+		return nil, cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeTargetSelection,
+			Reason:    fmt.Sprintf("failed to list the instances: %v", err),
+			Target:    fmt.Sprintf("{Zone: %v, Resource Group: %v}", zone, resourceGroup),
+		}
+	}
+
+	return instanceNames, nil
+}
+
+// inZone checks whether the zone is one of the zones of the instance
+func inZone(zones *[]string, zone string) bool {
+
+	if zones == nil {
+		return false
+	}
+
+	for _, z := range *zones {
+		if z == zone {
+			return true
+		}
+	}
+	return false
+}
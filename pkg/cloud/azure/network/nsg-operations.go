@@ -0,0 +1,192 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/latest/network/mgmt/network"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+)
+
+// DenyRule contains the details of the deny rule, which is inserted into the network security group
+type DenyRule struct {
+	Name                     string
+	Priority                 int
+	Direction                string
+	Protocol                 string
+	SourceAddressPrefix      string
+	DestinationAddressPrefix string
+	DestinationPorts         string
+}
+
+// NewSecurityRule returns the security rule, which denies the traffic matched by the deny rule
+// the destination ports are comma separated ports or port ranges
+func NewSecurityRule(rule DenyRule) network.SecurityRule {
+
+	properties := &network.SecurityRulePropertiesFormat{
+		Description:              to.StringPtr("Inserted by litmus, it is removed once the chaos is reverted"),
+		Protocol:                 network.SecurityRuleProtocol(rule.Protocol),
+		Access:                   network.SecurityRuleAccessDeny,
+		Priority:                 to.Int32Ptr(int32(rule.Priority)),
+		Direction:                network.SecurityRuleDirection(rule.Direction),
+		SourcePortRange:          to.StringPtr("*"),
+		SourceAddressPrefix:      to.StringPtr(rule.SourceAddressPrefix),
+		DestinationAddressPrefix: to.StringPtr(rule.DestinationAddressPrefix),
+	}
+
+	ports := strings.Split(rule.DestinationPorts, ",")
+	for i := range ports {
+		ports[i] = strings.TrimSpace(ports[i])
+	}
+	if len(ports) == 1 {
+		properties.DestinationPortRange = to.StringPtr(ports[0])
+	} else {
+		properties.DestinationPortRanges = &ports
+	}
+
+	return network.SecurityRule{
+		Name:                         to.StringPtr(rule.Name),
+		SecurityRulePropertiesFormat: properties,
+	}
+}
+
+// ValidateDenyRule validates the deny rule against the security rules of the network security group
+// the priority of the deny rule should not be taken by any other rule of the same direction
+func ValidateDenyRule(rule DenyRule, securityRules []network.SecurityRule) error {
+
+	if rule.Priority < 100 || rule.Priority > 4096 {
+		return fmt.Errorf("priority %v is not between 100 and 4096", rule.Priority)
+	}
+	if rule.Direction != string(network.SecurityRuleDirectionInbound) && rule.Direction != string(network.SecurityRuleDirectionOutbound) {
+		return fmt.Errorf("direction '%v' is not one of %v", rule.Direction, network.PossibleSecurityRuleDirectionValues())
+	}
+	validProtocol := false
+	for _, protocol := range network.PossibleSecurityRuleProtocolValues() {
+		if rule.Protocol == string(protocol) {
+			validProtocol = true
+		}
+	}
+	if !validProtocol {
+		return fmt.Errorf("protocol '%v' is not one of %v", rule.Protocol, network.PossibleSecurityRuleProtocolValues())
+	}
+
+	for _, securityRule := range securityRules {
+		if securityRule.SecurityRulePropertiesFormat == nil || securityRule.Priority == nil || securityRule.Name == nil {
+			continue
+		}
+		if int(*securityRule.Priority) == rule.Priority && string(securityRule.Direction) == rule.Direction {
+			return fmt.Errorf("priority %v is already taken by the %v %v rule", rule.Priority, *securityRule.Name, rule.Direction)
+		}
+	}
+
+	return nil
+}
+
+// GetSecurityRules returns the security rules of the network security group
+func GetSecurityRules(subscriptionID, resourceGroup, networkSecurityGroup string) ([]network.SecurityRule, error) {
+
+	rulesClient := network.NewSecurityRulesClient(subscriptionID)
+
+	authorizer, err := auth.NewAuthorizerFromFile(azure.PublicCloud.ResourceManagerEndpoint)
+	if err != nil {
+		return nil, cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeStatusChecks,
+			Reason:    fmt.Sprintf("authorization set up failed: %v", err),
+			Target:    fmt.Sprintf("{Network Security Group: %v, Resource Group: %v}", networkSecurityGroup, resourceGroup),
+		}
+	}
+
+	rulesClient.Authorizer = authorizer
+
+	var securityRules []network.SecurityRule
+	rules, err := rulesClient.ListComplete(context.TODO(), resourceGroup, networkSecurityGroup)
+	for err == nil && rules.NotDone() {
+		securityRules = append(securityRules, rules.Value())
+		err = rules.NextWithContext(context.TODO())
+	}
+	if err != nil {
+		return nil, cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeStatusChecks,
+			Reason:    fmt.Sprintf("failed to list the security rules: %v", err),
+			Target:    fmt.Sprintf("{Network Security Group: %v, Resource Group: %v}", networkSecurityGroup, resourceGroup),
+		}
+	}
+
+	return securityRules, nil
+}
+
+// CreateDenyRule inserts the deny rule into the network security group and waits for it to get provisioned
+func CreateDenyRule(timeout int, subscriptionID, resourceGroup, networkSecurityGroup string, rule DenyRule) error {
+
+	rulesClient := network.NewSecurityRulesClient(subscriptionID)
+
+	authorizer, err := auth.NewAuthorizerFromFile(azure.PublicCloud.ResourceManagerEndpoint)
+	if err != nil {
+		return cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeChaosInject,
+			Reason:    fmt.Sprintf("authorization set up failed: %v", err),
+			Target:    fmt.Sprintf("{Network Security Group: %v, Resource Group: %v}", networkSecurityGroup, resourceGroup),
+		}
+	}
+
+	rulesClient.Authorizer = authorizer
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	log.Infof("[Info]: Inserting the %v deny rule with %v priority", rule.Name, rule.Priority)
+	future, err := rulesClient.CreateOrUpdate(ctx, resourceGroup, networkSecurityGroup, rule.Name, NewSecurityRule(rule))
+	if err == nil {
+		err = future.WaitForCompletionRef(ctx, rulesClient.Client)
+	}
+	if err != nil {
+		return cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeChaosInject,
+			Reason:    fmt.Sprintf("failed to insert the deny rule: %v", err),
+			Target:    fmt.Sprintf("{Network Security Group: %v, Resource Group: %v, Rule Name: %v}", networkSecurityGroup, resourceGroup, rule.Name),
+		}
+	}
+
+	return nil
+}
+
+// DeleteSecurityRule removes the security rule from the network security group and waits for it to get deleted
+func DeleteSecurityRule(timeout int, subscriptionID, resourceGroup, networkSecurityGroup, ruleName string) error {
+
+	rulesClient := network.NewSecurityRulesClient(subscriptionID)
+
+	authorizer, err := auth.NewAuthorizerFromFile(azure.PublicCloud.ResourceManagerEndpoint)
+	if err != nil {
+		return cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeChaosRevert,
+			Reason:    fmt.Sprintf("authorization set up failed: %v", err),
+			Target:    fmt.Sprintf("{Network Security Group: %v, Resource Group: %v}", networkSecurityGroup, resourceGroup),
+		}
+	}
+
+	rulesClient.Authorizer = authorizer
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	log.Infof("[Info]: Removing the %v rule", ruleName)
+	future, err := rulesClient.Delete(ctx, resourceGroup, networkSecurityGroup, ruleName)
+	if err == nil {
+		err = future.WaitForCompletionRef(ctx, rulesClient.Client)
+	}
+	if err != nil {
+		return cerrors.Error{
+			ErrorCode: cerrors.ErrorTypeChaosRevert,
+			Reason:    fmt.Sprintf("failed to remove the rule: %v", err),
+			Target:    fmt.Sprintf("{Network Security Group: %v, Resource Group: %v, Rule Name: %v}", networkSecurityGroup, resourceGroup, ruleName),
+		}
+	}
+
+	return nil
+}
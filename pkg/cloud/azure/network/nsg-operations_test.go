@@ -0,0 +1,46 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/latest/network/mgmt/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSecurityRule(t *testing.T) {
+	rule := DenyRule{Name: "litmus-nsg-block", Priority: 100, Direction: "Inbound", Protocol: "Tcp", SourceAddressPrefix: "*", DestinationAddressPrefix: "10.0.0.0/24", DestinationPorts: "443"}
+
+	securityRule := NewSecurityRule(rule)
+	assert.Equal(t, "litmus-nsg-block", *securityRule.Name)
+	assert.Equal(t, network.SecurityRuleAccessDeny, securityRule.Access)
+	assert.Equal(t, int32(100), *securityRule.Priority)
+	assert.Equal(t, "443", *securityRule.DestinationPortRange)
+	assert.Nil(t, securityRule.DestinationPortRanges)
+
+	rule.DestinationPorts = "80, 8000-8080"
+	securityRule = NewSecurityRule(rule)
+	assert.Nil(t, securityRule.DestinationPortRange)
+	assert.Equal(t, []string{"80", "8000-8080"}, *securityRule.DestinationPortRanges)
+}
+
+func TestValidateDenyRule(t *testing.T) {
+	existing := []network.SecurityRule{
+		{Name: to.StringPtr("allow-https"), SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{Priority: to.Int32Ptr(100), Direction: network.SecurityRuleDirectionInbound}},
+	}
+	rule := DenyRule{Name: "litmus-nsg-block", Priority: 100, Direction: "Outbound", Protocol: "*"}
+
+	assert.NoError(t, ValidateDenyRule(rule, existing))
+
+	rule.Direction = "Inbound"
+	assert.ErrorContains(t, ValidateDenyRule(rule, existing), "priority 100 is already taken by the allow-https Inbound rule")
+
+	rule.Priority = 4097
+	assert.ErrorContains(t, ValidateDenyRule(rule, existing), "priority 4097 is not between 100 and 4096")
+
+	rule.Priority, rule.Protocol = 101, "tcp"
+	assert.ErrorContains(t, ValidateDenyRule(rule, existing), "protocol 'tcp' is not one of")
+
+	rule.Protocol, rule.Direction = "Udp", "Both"
+	assert.ErrorContains(t, ValidateDenyRule(rule, existing), "direction 'Both' is not one of")
+}
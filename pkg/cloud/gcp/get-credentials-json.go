@@ -117,7 +117,8 @@ func doesFileExist(fileName string) bool {
 }
 
 // GetGCPComputeService returns a new compute service created using the GCP Service Account credentials
-func GetGCPComputeService() (*compute.Service, error) {
+// the given client options, like the endpoint, are applied on top of the credentials
+func GetGCPComputeService(opts ...option.ClientOption) (*compute.Service, error) {
 
 	// create an empty context
 	ctx := context.Background()
@@ -135,7 +136,7 @@ func GetGCPComputeService() (*compute.Service, error) {
 			}
 
 			// create a new GCP Compute Service client using the GCP service account credentials provided through the secret
-			computeService, err := compute.NewService(ctx, option.WithCredentialsJSON(json))
+			computeService, err := compute.NewService(ctx, append([]option.ClientOption{option.WithCredentialsJSON(json)}, opts...)...)
 			if err != nil {
 				return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("failed to authenticate a new compute service using the given credentials, %s", err.Error())}
 			}
@@ -147,7 +148,7 @@ func GetGCPComputeService() (*compute.Service, error) {
 	log.Info("[Info]: Using the default GCP Service Account credentials from Worflow Identity")
 
 	// create a new GCP Compute Service client using default GCP service account credentials (using Workload Identity)
-	computeService, err := compute.NewService(ctx)
+	computeService, err := compute.NewService(ctx, opts...)
 	if err != nil {
 		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("failed to authenticate a new compute service using gke workload identity, %s", err.Error())}
 	}
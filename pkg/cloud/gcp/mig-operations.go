@@ -0,0 +1,225 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/api/compute/v1"
+)
+
+// ManagedInstanceGroup is the zonal or the regional managed instance group, the region is set only for the regional group
+type ManagedInstanceGroup struct {
+	Name   string
+	Zone   string
+	Region string
+}
+
+// String returns the target of the managed instance group for the errors
+func (mig ManagedInstanceGroup) String() string {
+	if mig.Region != "" {
+		return fmt.Sprintf("{migName: %s, region: %s}", mig.Name, mig.Region)
+	}
+	return fmt.Sprintf("{migName: %s, zone: %s}", mig.Name, mig.Zone)
+}
+
+// MIGInstance is the instance of the managed instance group along with its zone
+type MIGInstance struct {
+	Name string
+	Zone string
+}
+
+// GetMIG returns the managed instance group
+func GetMIG(computeService *compute.Service, gcpProjectID string, mig ManagedInstanceGroup) (*compute.InstanceGroupManager, error) {
+
+	var (
+		response *compute.InstanceGroupManager
+		err      error
+	)
+
+	if mig.Region != "" {
+		response, err = computeService.RegionInstanceGroupManagers.Get(gcpProjectID, mig.Region, mig.Name).Do()
+	} else {
+		response, err = computeService.InstanceGroupManagers.Get(gcpProjectID, mig.Zone, mig.Name).Do()
+	}
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: mig.String(), Reason: err.Error()}
+	}
+
+	return response, nil
+}
+
+// GetMIGInstances returns the managed instances of the managed instance group
+func GetMIGInstances(computeService *compute.Service, gcpProjectID string, mig ManagedInstanceGroup) ([]*compute.ManagedInstance, error) {
+
+	var (
+		instances []*compute.ManagedInstance
+		err       error
+	)
+
+	if mig.Region != "" {
+		err = computeService.RegionInstanceGroupManagers.ListManagedInstances(gcpProjectID, mig.Region, mig.Name).Pages(context.Background(), func(page *compute.RegionInstanceGroupManagersListInstancesResponse) error {
+			instances = append(instances, page.ManagedInstances...)
+			return nil
+		})
+	} else {
+		err = computeService.InstanceGroupManagers.ListManagedInstances(gcpProjectID, mig.Zone, mig.Name).Pages(context.Background(), func(page *compute.InstanceGroupManagersListManagedInstancesResponse) error {
+			instances = append(instances, page.ManagedInstances...)
+			return nil
+		})
+	}
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: mig.String(), Reason: fmt.Sprintf("failed to list the managed instances, %s", err.Error())}
+	}
+
+	return instances, nil
+}
+
+// GetRunningMIGInstances returns the instances of the managed instance group, which are running and have no pending action
+func GetRunningMIGInstances(computeService *compute.Service, gcpProjectID string, mig ManagedInstanceGroup) ([]MIGInstance, error) {
+
+	managedInstances, err := GetMIGInstances(computeService, gcpProjectID, mig)
+	if err != nil {
+		return nil, err
+	}
+
+	return runningMIGInstances(managedInstances), nil
+}
+
+// runningMIGInstances filters the running managed instances, which have no pending action
+func runningMIGInstances(managedInstances []*compute.ManagedInstance) []MIGInstance {
+
+	var instances []MIGInstance
+	for _, instance := range managedInstances {
+		if instance.InstanceStatus != "RUNNING" || instance.CurrentAction != "NONE" {
+			continue
+		}
+		instances = append(instances, parseInstanceURL(instance.Instance))
+	}
+
+	return instances
+}
+
+// parseInstanceURL returns the name and the zone of the instance from its url
+// the url is of format https://www.googleapis.com/compute/v1/projects/<project>/zones/<zone>/instances/<name>
+func parseInstanceURL(url string) MIGInstance {
+
+	parts := strings.Split(url, "/")
+	instance := MIGInstance{Name: parts[len(parts)-1]}
+	for i := range parts[:len(parts)-1] {
+		if parts[i] == "zones" {
+			instance.Zone = parts[i+1]
+		}
+	}
+
+	return instance
+}
+
+// isMIGStable checks whether the managed instance group is stable and all the instances of its target size are running
+func isMIGStable(group *compute.InstanceGroupManager, managedInstances []*compute.ManagedInstance) error {
+
+	if group.Status == nil || !group.Status.IsStable {
+		return fmt.Errorf("managed instance group is not stable")
+	}
+
+	if running := len(runningMIGInstances(managedInstances)); int64(running) != group.TargetSize {
+		return fmt.Errorf("%d out of %d instances are running", running, group.TargetSize)
+	}
+
+	return nil
+}
+
+// MIGStatusCheck checks whether the managed instance group is stable at its target size
+func MIGStatusCheck(computeService *compute.Service, gcpProjectID string, mig ManagedInstanceGroup) error {
+
+	group, err := GetMIG(computeService, gcpProjectID, mig)
+	if err != nil {
+		return err
+	}
+
+	managedInstances, err := GetMIGInstances(computeService, gcpProjectID, mig)
+	if err != nil {
+		return err
+	}
+
+	if err := isMIGStable(group, managedInstances); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: mig.String(), Reason: err.Error()}
+	}
+
+	return nil
+}
+
+// WaitForMIGStable will wait for the managed instance group to get stable at its target size
+func WaitForMIGStable(computeService *compute.Service, timeout, delay int, gcpProjectID string, mig ManagedInstanceGroup) error {
+
+	log.Infof("[Status]: Checking %s managed instance group status", mig.Name)
+
+	return retry.
+		Times(uint(timeout / delay)).
+		Wait(time.Duration(delay) * time.Second).
+		Try(func(attempt uint) error {
+
+			if err := MIGStatusCheck(computeService, gcpProjectID, mig); err != nil {
+				log.Infof("The %s managed instance group is not yet stable, %v", mig.Name, err)
+				return stacktrace.Propagate(err, "managed instance group is not yet stable")
+			}
+
+			return nil
+		})
+}
+
+// VMInstanceDelete deletes a VM instance and returns the name of the delete operation
+func VMInstanceDelete(computeService *compute.Service, instanceName string, gcpProjectID string, instanceZone string) (string, error) {
+
+	// delete the requisite VM instance
+	operation, err := computeService.Instances.Delete(gcpProjectID, instanceZone, instanceName).Do()
+	if err != nil {
+		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: fmt.Sprintf("{vmName: %s, zone: %s}", instanceName, instanceZone), Reason: err.Error()}
+	}
+
+	log.InfoWithValues("Deleting VM instance:", logrus.Fields{
+		"InstanceName": instanceName,
+		"InstanceZone": instanceZone,
+	})
+
+	return operation.Name, nil
+}
+
+// WaitForZoneOperation will wait for the zonal operation to get done
+func WaitForZoneOperation(computeService *compute.Service, timeout, delay int, operationName string, gcpProjectID string, zone string) error {
+
+	var operationErr error
+
+	err := retry.
+		Times(uint(timeout / delay)).
+		Wait(time.Duration(delay) * time.Second).
+		Try(func(attempt uint) error {
+
+			operation, err := computeService.ZoneOperations.Get(gcpProjectID, zone, operationName).Do()
+			if err != nil {
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{operation: %s, zone: %s}", operationName, zone), Reason: err.Error()}
+			}
+
+			if operation.Status != "DONE" {
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{operation: %s, zone: %s}", operationName, zone), Reason: fmt.Sprintf("operation is not yet done, current status: %s", operation.Status)}
+			}
+
+			// the failed operation is done, it isn't retried
+			if operation.Error != nil && len(operation.Error.Errors) != 0 {
+				operationErr = cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: fmt.Sprintf("{operation: %s, zone: %s}", operationName, zone), Reason: fmt.Sprintf("operation failed, %s", operation.Error.Errors[0].Message)}
+			}
+
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	return operationErr
+}
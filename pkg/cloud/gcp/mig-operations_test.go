@@ -0,0 +1,89 @@
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+)
+
+// newFakeComputeService returns the compute service, which is served by the given managed instance groups and their instances
+func newFakeComputeService(t *testing.T, groups map[string]*compute.InstanceGroupManager, instances map[string][]*compute.ManagedInstance) *compute.Service {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimSuffix(r.URL.Path, "/listManagedInstances")
+		var response interface{}
+		switch {
+		case path != r.URL.Path && strings.Contains(path, "/regions/"):
+			response = &compute.RegionInstanceGroupManagersListInstancesResponse{ManagedInstances: instances[path]}
+		case path != r.URL.Path:
+			response = &compute.InstanceGroupManagersListManagedInstancesResponse{ManagedInstances: instances[path]}
+		case groups[path] != nil:
+			response = groups[path]
+		default:
+			http.Error(w, `{"error": {"code": 404, "message": "not found"}}`, http.StatusNotFound)
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	t.Cleanup(server.Close)
+
+	service, err := compute.NewService(context.Background(), option.WithEndpoint(server.URL+"/"), option.WithoutAuthentication())
+	require.NoError(t, err)
+	return service
+}
+
+func managedInstance(zone, name, status, action string) *compute.ManagedInstance {
+	return &compute.ManagedInstance{
+		Instance:       "https://www.googleapis.com/compute/v1/projects/project/zones/" + zone + "/instances/" + name,
+		InstanceStatus: status,
+		CurrentAction:  action,
+	}
+}
+
+func TestMIGStatusCheck(t *testing.T) {
+	zonal := ManagedInstanceGroup{Name: "web", Zone: "us-central1-a"}
+	regional := ManagedInstanceGroup{Name: "api", Region: "us-central1"}
+	zonalPath := "/projects/project/zones/us-central1-a/instanceGroupManagers/web"
+	regionalPath := "/projects/project/regions/us-central1/instanceGroupManagers/api"
+
+	groups := map[string]*compute.InstanceGroupManager{
+		zonalPath:    {Name: "web", TargetSize: 2, Status: &compute.InstanceGroupManagerStatus{IsStable: true}},
+		regionalPath: {Name: "api", TargetSize: 2, Status: &compute.InstanceGroupManagerStatus{IsStable: true}},
+	}
+	instances := map[string][]*compute.ManagedInstance{
+		zonalPath: {
+			managedInstance("us-central1-a", "web-1", "RUNNING", "NONE"),
+			managedInstance("us-central1-a", "web-2", "RUNNING", "NONE"),
+		},
+		regionalPath: {
+			managedInstance("us-central1-a", "api-1", "RUNNING", "NONE"),
+			managedInstance("us-central1-b", "api-2", "STAGING", "RECREATING"),
+		},
+	}
+	service := newFakeComputeService(t, groups, instances)
+
+	require.NoError(t, MIGStatusCheck(service, "project", zonal))
+	running, err := GetRunningMIGInstances(service, "project", zonal)
+	require.NoError(t, err)
+	assert.Equal(t, []MIGInstance{{Name: "web-1", Zone: "us-central1-a"}, {Name: "web-2", Zone: "us-central1-a"}}, running)
+
+	// the recreated instance isn't counted until it is running
+	assert.ErrorContains(t, MIGStatusCheck(service, "project", regional), "1 out of 2 instances are running")
+	running, err = GetRunningMIGInstances(service, "project", regional)
+	require.NoError(t, err)
+	assert.Equal(t, []MIGInstance{{Name: "api-1", Zone: "us-central1-a"}}, running)
+
+	groups[zonalPath].Status.IsStable = false
+	assert.ErrorContains(t, MIGStatusCheck(service, "project", zonal), "managed instance group is not stable")
+	assert.ErrorContains(t, WaitForMIGStable(service, 1, 1, "project", zonal), "managed instance group is not stable")
+
+	_, err = GetMIG(service, "project", ManagedInstanceGroup{Name: "db", Zone: "us-central1-a"})
+	assert.ErrorContains(t, err, "not found")
+}
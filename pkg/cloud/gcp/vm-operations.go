@@ -1,6 +1,7 @@
 package gcp
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -100,40 +101,54 @@ func WaitForVMInstanceUp(computeService *compute.Service, timeout int, delay int
 // SetTargetInstance will select the target vm instances which are in RUNNING state and filtered from the given label
 func SetTargetInstance(computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails) error {
 
+	instanceNames, err := GetRunningInstancesByLabel(computeService, experimentsDetails.InstanceLabel, experimentsDetails.GCPProjectID, experimentsDetails.Zones)
+	if err != nil {
+		return err
+	}
+	experimentsDetails.TargetVMInstanceNameList = append(experimentsDetails.TargetVMInstanceNameList, instanceNames...)
+
+	log.InfoWithValues("[Info]: Targeting the RUNNING VM instances filtered from instance label", logrus.Fields{
+		"Number of running instances filtered": len(experimentsDetails.TargetVMInstanceNameList),
+	})
+
+	return nil
+}
+
+// GetRunningInstancesByLabel returns the vm instances of the zone which are in RUNNING state and filtered from the given label
+func GetRunningInstancesByLabel(computeService *compute.Service, instanceLabel string, gcpProjectID string, zone string) ([]string, error) {
+
 	var (
-		response *compute.InstanceList
-		err      error
+		instanceNames []string
+		filter        string
 	)
 
-	if experimentsDetails.InstanceLabel == "" {
-		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{label: %s}", experimentsDetails.InstanceLabel), Reason: "label not found, please provide a valid label"}
+	if instanceLabel == "" {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{label: %s}", instanceLabel), Reason: "label not found, please provide a valid label"}
 	}
 
-	if strings.Contains(experimentsDetails.InstanceLabel, ":") {
+	if strings.Contains(instanceLabel, ":") {
 		// the label is of format key:value
-		response, err = computeService.Instances.List(experimentsDetails.GCPProjectID, experimentsDetails.Zones).Filter("labels." + experimentsDetails.InstanceLabel).Do()
+		filter = "labels." + instanceLabel
 	} else {
 		// the label only has key
-		response, err = computeService.Instances.List(experimentsDetails.GCPProjectID, experimentsDetails.Zones).Filter("labels." + experimentsDetails.InstanceLabel + ":*").Do()
-	}
-	if err != nil {
-		return err
-		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{label: %s, zone: %s}", experimentsDetails.InstanceLabel, experimentsDetails.Zones), Reason: err.Error()}
+		filter = "labels." + instanceLabel + ":*"
 	}
 
-	for _, instance := range response.Items {
-		if instance.Status == "RUNNING" {
-			experimentsDetails.TargetVMInstanceNameList = append(experimentsDetails.TargetVMInstanceNameList, instance.Name)
+	err := computeService.Instances.List(gcpProjectID, zone).Filter(filter).Pages(context.Background(), func(page *compute.InstanceList) error {
+		for _, instance := range page.Items {
+			if instance.Status == "RUNNING" {
+				instanceNames = append(instanceNames, instance.Name)
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{label: %s, zone: %s}", instanceLabel, zone), Reason: err.Error()}
 	}
 
-	if len(experimentsDetails.TargetVMInstanceNameList) == 0 {
-		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{label: %s, zone: %s}", experimentsDetails.InstanceLabel, experimentsDetails.Zones), Reason: "no running vm instances found with the given label"}
+	if len(instanceNames) == 0 {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{label: %s, zone: %s}", instanceLabel, zone), Reason: "no running vm instances found with the given label"}
 	}
 
-	log.InfoWithValues("[Info]: Targeting the RUNNING VM instances filtered from instance label", logrus.Fields{
-		"Number of running instances filtered": len(experimentsDetails.TargetVMInstanceNameList),
-	})
-
-	return nil
+	return instanceNames, nil
 }
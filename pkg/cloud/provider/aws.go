@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/cloud/aws/common"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+)
+
+type awsProvider struct {
+	client ec2iface.EC2API
+}
+
+// NewAWS returns the aws provider, which uses the given ec2 client
+func NewAWS(client ec2iface.EC2API) Provider {
+	return &awsProvider{client: client}
+}
+
+// NewAWSFromOptions returns the aws provider for the region of the given options
+// the endpoint, role and retries of the options override the ones of the shared config
+func NewAWSFromOptions(opts Options) (Provider, error) {
+	sess, err := common.NewAWSSession(common.SessionOptions{
+		Region:     opts.Region,
+		Endpoint:   opts.Endpoint,
+		RoleARN:    opts.RoleARN,
+		MaxRetries: opts.MaxRetries,
+	})
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{Region: %s}", opts.Region), Reason: fmt.Sprintf("failed to create the aws session: %v", err)}
+	}
+	return NewAWS(ec2.New(sess)), nil
+}
+
+// NewAWSFromENV returns the aws provider for the given region
+// the endpoint, role and retries are derived from the AWS_ENDPOINT_URL, AWS_ASSUME_ROLE_ARN and AWS_MAX_RETRIES envs
+func NewAWSFromENV(region string) (Provider, error) {
+	opts := common.GetSessionOptionsFromENV(region)
+	return NewAWSFromOptions(Options{Region: opts.Region, Endpoint: opts.Endpoint, RoleARN: opts.RoleARN, MaxRetries: opts.MaxRetries})
+}
+
+func (p *awsProvider) Name() string {
+	return AWS
+}
+
+func (p *awsProvider) StopInstance(instance Instance) error {
+	if _, err := p.client.StopInstances(&ec2.StopInstancesInput{InstanceIds: []*string{aws.String(instance.ID)}}); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: instanceTarget(p.Name(), instance), Reason: fmt.Sprintf("failed to stop the instance: %v", common.CheckAWSError(err).Error())}
+	}
+	log.Infof("[Chaos]: Stopping the %s instance", instance.ID)
+	return nil
+}
+
+func (p *awsProvider) StartInstance(instance Instance) error {
+	if _, err := p.client.StartInstances(&ec2.StartInstancesInput{InstanceIds: []*string{aws.String(instance.ID)}}); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: instanceTarget(p.Name(), instance), Reason: fmt.Sprintf("failed to start the instance: %v", common.CheckAWSError(err).Error())}
+	}
+	log.Infof("[Chaos]: Starting the %s instance", instance.ID)
+	return nil
+}
+
+func (p *awsProvider) GetInstanceState(instance Instance) (State, error) {
+	result, err := p.client.DescribeInstances(&ec2.DescribeInstancesInput{InstanceIds: []*string{aws.String(instance.ID)}})
+	if err != nil {
+		return StateUnknown, cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: instanceTarget(p.Name(), instance), Reason: fmt.Sprintf("failed to describe the instance: %v", common.CheckAWSError(err).Error())}
+	}
+	for _, reservation := range result.Reservations {
+		for _, i := range reservation.Instances {
+			if aws.StringValue(i.InstanceId) == instance.ID && i.State != nil {
+				return awsInstanceState(aws.StringValue(i.State.Name)), nil
+			}
+		}
+	}
+	return StateUnknown, cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: instanceTarget(p.Name(), instance), Reason: "instance not found"}
+}
+
+func (p *awsProvider) DetachVolume(volume Volume) error {
+	input := &ec2.DetachVolumeInput{VolumeId: aws.String(volume.ID)}
+	if volume.InstanceID != "" {
+		input.InstanceId = aws.String(volume.InstanceID)
+	}
+	if _, err := p.client.DetachVolume(input); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: volumeTarget(p.Name(), volume), Reason: fmt.Sprintf("failed to detach the volume: %v", common.CheckAWSError(err).Error())}
+	}
+	log.Infof("[Chaos]: Detaching the %s volume", volume.ID)
+	return nil
+}
+
+func (p *awsProvider) AttachVolume(volume Volume) error {
+	input := &ec2.AttachVolumeInput{
+		Device:     aws.String(volume.Device),
+		InstanceId: aws.String(volume.InstanceID),
+		VolumeId:   aws.String(volume.ID),
+	}
+	if _, err := p.client.AttachVolume(input); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: volumeTarget(p.Name(), volume), Reason: fmt.Sprintf("failed to attach the volume: %v", common.CheckAWSError(err).Error())}
+	}
+	log.Infof("[Chaos]: Attaching the %s volume", volume.ID)
+	return nil
+}
+
+func (p *awsProvider) GetVolumeState(volume Volume) (State, error) {
+	result, err := p.client.DescribeVolumes(&ec2.DescribeVolumesInput{VolumeIds: []*string{aws.String(volume.ID)}})
+	if err != nil {
+		return StateUnknown, cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: volumeTarget(p.Name(), volume), Reason: fmt.Sprintf("failed to describe the volume: %v", common.CheckAWSError(err).Error())}
+	}
+	for _, v := range result.Volumes {
+		if aws.StringValue(v.VolumeId) != volume.ID {
+			continue
+		}
+		for _, attachment := range v.Attachments {
+			if volume.InstanceID == "" || aws.StringValue(attachment.InstanceId) == volume.InstanceID {
+				return awsAttachmentState(aws.StringValue(attachment.State)), nil
+			}
+		}
+		return StateDetached, nil
+	}
+	return StateUnknown, cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: volumeTarget(p.Name(), volume), Reason: "volume not found"}
+}
+
+// awsInstanceState maps the ec2 instance state to the provider state
+func awsInstanceState(state string) State {
+	switch state {
+	case ec2.InstanceStateNameRunning:
+		return StateRunning
+	case ec2.InstanceStateNameStopped:
+		return StateStopped
+	case ec2.InstanceStateNameTerminated:
+		return StateTerminated
+	case ec2.InstanceStateNamePending, ec2.InstanceStateNameStopping, ec2.InstanceStateNameShuttingDown:
+		return StateTransitioning
+	}
+	return StateUnknown
+}
+
+// awsAttachmentState maps the ebs attachment state to the provider state
+func awsAttachmentState(state string) State {
+	switch state {
+	case ec2.VolumeAttachmentStateAttached:
+		return StateAttached
+	case ec2.VolumeAttachmentStateDetached:
+		return StateDetached
+	case ec2.VolumeAttachmentStateAttaching, ec2.VolumeAttachmentStateDetaching, ec2.VolumeAttachmentStateBusy:
+		return StateTransitioning
+	}
+	return StateUnknown
+}
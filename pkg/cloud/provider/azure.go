@@ -0,0 +1,343 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/latest/compute/mgmt/compute"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/cloud/azure/common"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+)
+
+// AzureComputeAPI contains the compute operations, which are used by the azure provider
+// the instance is the name of the vm, if the scale set is empty, otherwise it is the instance id of the scale set vm
+type AzureComputeAPI interface {
+	PowerOff(resourceGroup, scaleSet, instance string) error
+	Start(resourceGroup, scaleSet, instance string) error
+	GetStatuses(resourceGroup, scaleSet, instance string) ([]compute.InstanceViewStatus, error)
+	GetDataDisks(resourceGroup, scaleSet, instance string) ([]compute.DataDisk, error)
+	UpdateDataDisks(resourceGroup, scaleSet, instance string, disks []compute.DataDisk) error
+	GetDisk(resourceGroup, disk string) (compute.Disk, error)
+}
+
+// azureComputeClients implements the AzureComputeAPI with the virtual machines, the scale set vms and the disks clients
+type azureComputeClients struct {
+	vms         compute.VirtualMachinesClient
+	scaleSetVMs compute.VirtualMachineScaleSetVMsClient
+	disks       compute.DisksClient
+}
+
+func (c azureComputeClients) PowerOff(resourceGroup, scaleSet, instance string) error {
+	if scaleSet != "" {
+		_, err := c.scaleSetVMs.PowerOff(context.TODO(), resourceGroup, scaleSet, instance, &c.scaleSetVMs.SkipResourceProviderRegistration)
+		return err
+	}
+	_, err := c.vms.PowerOff(context.TODO(), resourceGroup, instance, &c.vms.SkipResourceProviderRegistration)
+	return err
+}
+
+func (c azureComputeClients) Start(resourceGroup, scaleSet, instance string) error {
+	if scaleSet != "" {
+		_, err := c.scaleSetVMs.Start(context.TODO(), resourceGroup, scaleSet, instance)
+		return err
+	}
+	_, err := c.vms.Start(context.TODO(), resourceGroup, instance)
+	return err
+}
+
+func (c azureComputeClients) GetStatuses(resourceGroup, scaleSet, instance string) ([]compute.InstanceViewStatus, error) {
+	var statuses *[]compute.InstanceViewStatus
+	if scaleSet != "" {
+		view, err := c.scaleSetVMs.GetInstanceView(context.TODO(), resourceGroup, scaleSet, instance)
+		if err != nil {
+			return nil, err
+		}
+		statuses = view.Statuses
+	} else {
+		view, err := c.vms.InstanceView(context.TODO(), resourceGroup, instance)
+		if err != nil {
+			return nil, err
+		}
+		statuses = view.Statuses
+	}
+	if statuses == nil {
+		return nil, nil
+	}
+	return *statuses, nil
+}
+
+func (c azureComputeClients) GetDataDisks(resourceGroup, scaleSet, instance string) ([]compute.DataDisk, error) {
+	var storageProfile *compute.StorageProfile
+	if scaleSet != "" {
+		vm, err := c.scaleSetVMs.Get(context.TODO(), resourceGroup, scaleSet, instance, compute.InstanceViewTypes("instanceView"))
+		if err != nil {
+			return nil, err
+		}
+		if vm.VirtualMachineScaleSetVMProperties != nil {
+			storageProfile = vm.StorageProfile
+		}
+	} else {
+		vm, err := c.vms.Get(context.TODO(), resourceGroup, instance, compute.InstanceViewTypes("instanceView"))
+		if err != nil {
+			return nil, err
+		}
+		if vm.VirtualMachineProperties != nil {
+			storageProfile = vm.StorageProfile
+		}
+	}
+	if storageProfile == nil || storageProfile.DataDisks == nil {
+		return nil, nil
+	}
+	return *storageProfile.DataDisks, nil
+}
+
+func (c azureComputeClients) UpdateDataDisks(resourceGroup, scaleSet, instance string, disks []compute.DataDisk) error {
+	// the data disks are updated with the empty list, instead of nil, when the last disk is detached
+	if disks == nil {
+		disks = []compute.DataDisk{}
+	}
+	if scaleSet != "" {
+		vm, err := c.scaleSetVMs.Get(context.TODO(), resourceGroup, scaleSet, instance, compute.InstanceViewTypes("instanceView"))
+		if err != nil {
+			return err
+		}
+		if vm.VirtualMachineScaleSetVMProperties == nil || vm.StorageProfile == nil {
+			return fmt.Errorf("storage profile of the instance not found")
+		}
+		vm.StorageProfile.DataDisks = &disks
+		// the image reference is removed, so that the image of the scale set vm isn't updated
+		vm.StorageProfile.ImageReference = nil
+		_, err = c.scaleSetVMs.Update(context.TODO(), resourceGroup, scaleSet, instance, vm)
+		return err
+	}
+	vm, err := c.vms.Get(context.TODO(), resourceGroup, instance, compute.InstanceViewTypes("instanceView"))
+	if err != nil {
+		return err
+	}
+	if vm.VirtualMachineProperties == nil || vm.StorageProfile == nil {
+		return fmt.Errorf("storage profile of the instance not found")
+	}
+	vm.StorageProfile.DataDisks = &disks
+	_, err = c.vms.CreateOrUpdate(context.TODO(), resourceGroup, instance, vm)
+	return err
+}
+
+func (c azureComputeClients) GetDisk(resourceGroup, disk string) (compute.Disk, error) {
+	return c.disks.Get(context.TODO(), resourceGroup, disk)
+}
+
+type azureProvider struct {
+	client AzureComputeAPI
+	// detached contains the detached data disks, so that they are attached back with their lun and caching
+	detached map[string]compute.DataDisk
+	mu       sync.Mutex
+}
+
+// NewAzure returns the azure provider, which uses the given compute client
+func NewAzure(client AzureComputeAPI) Provider {
+	return &azureProvider{client: client, detached: map[string]compute.DataDisk{}}
+}
+
+// NewAzureFromClients returns the azure provider, which uses the given virtual machines, scale set vms and disks clients
+func NewAzureFromClients(vms compute.VirtualMachinesClient, scaleSetVMs compute.VirtualMachineScaleSetVMsClient, disks compute.DisksClient) Provider {
+	return NewAzure(azureComputeClients{vms: vms, scaleSetVMs: scaleSetVMs, disks: disks})
+}
+
+// NewAzureFromOptions returns the azure provider for the subscription of the given options
+// the subscription defaults to the one of the azure auth file and the clients are authorized with the auth file
+func NewAzureFromOptions(opts Options) (Provider, error) {
+	subscriptionID := opts.SubscriptionID
+	if subscriptionID == "" {
+		var err error
+		if subscriptionID, err = common.GetSubscriptionID(); err != nil {
+			return nil, err
+		}
+	}
+	authorizer, err := auth.NewAuthorizerFromFile(azure.PublicCloud.ResourceManagerEndpoint)
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("authorization set up failed: %v", err)}
+	}
+
+	baseURI := compute.DefaultBaseURI
+	if opts.Endpoint != "" {
+		baseURI = opts.Endpoint
+	}
+	vms := compute.NewVirtualMachinesClientWithBaseURI(baseURI, subscriptionID)
+	scaleSetVMs := compute.NewVirtualMachineScaleSetVMsClientWithBaseURI(baseURI, subscriptionID)
+	disks := compute.NewDisksClientWithBaseURI(baseURI, subscriptionID)
+	for _, client := range []*autorest.Client{&vms.Client, &scaleSetVMs.Client, &disks.Client} {
+		client.Authorizer = authorizer
+		if opts.MaxRetries > 0 {
+			client.RetryAttempts = opts.MaxRetries
+		}
+	}
+	return NewAzureFromClients(vms, scaleSetVMs, disks), nil
+}
+
+func (p *azureProvider) Name() string {
+	return Azure
+}
+
+func (p *azureProvider) StopInstance(instance Instance) error {
+	if err := p.client.PowerOff(instance.ResourceGroup, instance.ScaleSet, instance.ID); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: instanceTarget(p.Name(), instance), Reason: fmt.Sprintf("failed to stop the instance: %v", err)}
+	}
+	log.Infof("[Chaos]: Stopping the %s instance", instance.ID)
+	return nil
+}
+
+func (p *azureProvider) StartInstance(instance Instance) error {
+	if err := p.client.Start(instance.ResourceGroup, instance.ScaleSet, instance.ID); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: instanceTarget(p.Name(), instance), Reason: fmt.Sprintf("failed to start the instance: %v", err)}
+	}
+	log.Infof("[Chaos]: Starting the %s instance", instance.ID)
+	return nil
+}
+
+func (p *azureProvider) GetInstanceState(instance Instance) (State, error) {
+	statuses, err := p.client.GetStatuses(instance.ResourceGroup, instance.ScaleSet, instance.ID)
+	if err != nil {
+		return StateUnknown, cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: instanceTarget(p.Name(), instance), Reason: fmt.Sprintf("failed to get the instance view: %v", err)}
+	}
+	// the statuses contain the provisioning state and the power state of the instance, like PowerState/running
+	for _, status := range statuses {
+		if code := stringValue(status.Code); strings.HasPrefix(code, "PowerState/") {
+			return azurePowerState(strings.TrimPrefix(code, "PowerState/")), nil
+		}
+	}
+	return StateUnknown, nil
+}
+
+func (p *azureProvider) DetachVolume(volume Volume) error {
+	dataDisks, err := p.client.GetDataDisks(volume.ResourceGroup, volume.ScaleSet, volume.InstanceID)
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: volumeTarget(p.Name(), volume), Reason: fmt.Sprintf("failed to get the instance: %v", err)}
+	}
+
+	keepAttached := []compute.DataDisk{}
+	for _, disk := range dataDisks {
+		if strings.EqualFold(stringValue(disk.Name), volume.ID) {
+			p.mu.Lock()
+			p.detached[azureDiskKey(volume)] = disk
+			p.mu.Unlock()
+			continue
+		}
+		keepAttached = append(keepAttached, disk)
+	}
+	if len(keepAttached) == len(dataDisks) {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: volumeTarget(p.Name(), volume), Reason: "disk is not attached to the instance"}
+	}
+
+	if err := p.client.UpdateDataDisks(volume.ResourceGroup, volume.ScaleSet, volume.InstanceID, keepAttached); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: volumeTarget(p.Name(), volume), Reason: fmt.Sprintf("failed to detach the disk: %v", err)}
+	}
+	log.Infof("[Chaos]: Detaching the %s disk", volume.ID)
+	return nil
+}
+
+func (p *azureProvider) AttachVolume(volume Volume) error {
+	dataDisks, err := p.client.GetDataDisks(volume.ResourceGroup, volume.ScaleSet, volume.InstanceID)
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: volumeTarget(p.Name(), volume), Reason: fmt.Sprintf("failed to get the instance: %v", err)}
+	}
+
+	p.mu.Lock()
+	disk, ok := p.detached[azureDiskKey(volume)]
+	p.mu.Unlock()
+	if !ok {
+		// the disk wasn't detached by this provider, it is attached at the first free lun
+		details, err := p.client.GetDisk(volume.ResourceGroup, volume.ID)
+		if err != nil {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: volumeTarget(p.Name(), volume), Reason: fmt.Sprintf("failed to get the disk: %v", err)}
+		}
+		disk = compute.DataDisk{
+			Name:        details.Name,
+			Lun:         freeLun(dataDisks),
+			ManagedDisk: &compute.ManagedDiskParameters{ID: details.ID},
+		}
+	}
+	disk.CreateOption = compute.DiskCreateOptionTypesAttach
+
+	if err := p.client.UpdateDataDisks(volume.ResourceGroup, volume.ScaleSet, volume.InstanceID, append(dataDisks, disk)); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: volumeTarget(p.Name(), volume), Reason: fmt.Sprintf("failed to attach the disk: %v", err)}
+	}
+	p.mu.Lock()
+	delete(p.detached, azureDiskKey(volume))
+	p.mu.Unlock()
+	log.Infof("[Chaos]: Attaching the %s disk", volume.ID)
+	return nil
+}
+
+func (p *azureProvider) GetVolumeState(volume Volume) (State, error) {
+	disk, err := p.client.GetDisk(volume.ResourceGroup, volume.ID)
+	if err != nil {
+		return StateUnknown, cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: volumeTarget(p.Name(), volume), Reason: fmt.Sprintf("failed to get the disk: %v", err)}
+	}
+	if disk.DiskProperties == nil {
+		return StateUnknown, nil
+	}
+	switch string(disk.DiskProperties.DiskState) {
+	case "Attached", "Reserved":
+		// the managedBy of the disk is the id of the attached instance, like .../virtualMachines/vm or .../virtualMachineScaleSets/vmss/virtualMachines/0
+		if volume.InstanceID != "" && !strings.HasSuffix(strings.ToLower(stringValue(disk.ManagedBy)), strings.ToLower(azureInstancePath(volume))) {
+			return StateDetached, nil
+		}
+		return StateAttached, nil
+	case "Unattached":
+		return StateDetached, nil
+	}
+	return StateUnknown, nil
+}
+
+// azureInstancePath returns the trailing path of the id of the instance, which the volume is attached to
+func azureInstancePath(volume Volume) string {
+	if volume.ScaleSet != "" {
+		return "/virtualMachineScaleSets/" + volume.ScaleSet + "/virtualMachines/" + volume.InstanceID
+	}
+	return "/virtualMachines/" + volume.InstanceID
+}
+
+// freeLun returns the lowest lun, which isn't used by the given data disks
+func freeLun(disks []compute.DataDisk) *int32 {
+	used := map[int32]bool{}
+	for _, disk := range disks {
+		if disk.Lun != nil {
+			used[*disk.Lun] = true
+		}
+	}
+	lun := int32(0)
+	for used[lun] {
+		lun++
+	}
+	return &lun
+}
+
+// azurePowerState maps the power state of the azure instance to the provider state
+func azurePowerState(state string) State {
+	switch state {
+	case "running":
+		return StateRunning
+	case "stopped", "deallocated":
+		return StateStopped
+	case "starting", "stopping", "deallocating":
+		return StateTransitioning
+	}
+	return StateUnknown
+}
+
+func azureDiskKey(volume Volume) string {
+	return strings.ToLower(volume.ResourceGroup + "/" + volume.ScaleSet + "/" + volume.InstanceID + "/" + volume.ID)
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
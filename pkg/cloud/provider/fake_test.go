@@ -0,0 +1,262 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/latest/compute/mgmt/compute"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	gcompute "google.golang.org/api/compute/v1"
+)
+
+// fakeEC2 is the in-memory ec2 api, which contains the instance states and the volume attachments
+type fakeEC2 struct {
+	ec2iface.EC2API
+	instances   map[string]string
+	attachments map[string]*ec2.VolumeAttachment
+}
+
+func newFakeEC2() *fakeEC2 {
+	return &fakeEC2{instances: map[string]string{}, attachments: map[string]*ec2.VolumeAttachment{}}
+}
+
+func (f *fakeEC2) StopInstances(input *ec2.StopInstancesInput) (*ec2.StopInstancesOutput, error) {
+	for _, id := range input.InstanceIds {
+		if _, ok := f.instances[*id]; !ok {
+			return nil, fmt.Errorf("InvalidInstanceID.NotFound: %s", *id)
+		}
+		f.instances[*id] = ec2.InstanceStateNameStopped
+	}
+	return &ec2.StopInstancesOutput{}, nil
+}
+
+func (f *fakeEC2) StartInstances(input *ec2.StartInstancesInput) (*ec2.StartInstancesOutput, error) {
+	for _, id := range input.InstanceIds {
+		if _, ok := f.instances[*id]; !ok {
+			return nil, fmt.Errorf("InvalidInstanceID.NotFound: %s", *id)
+		}
+		f.instances[*id] = ec2.InstanceStateNameRunning
+	}
+	return &ec2.StartInstancesOutput{}, nil
+}
+
+func (f *fakeEC2) DescribeInstances(input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+	reservation := &ec2.Reservation{}
+	for _, id := range input.InstanceIds {
+		if state, ok := f.instances[*id]; ok {
+			reservation.Instances = append(reservation.Instances, &ec2.Instance{InstanceId: id, State: &ec2.InstanceState{Name: aws.String(state)}})
+		}
+	}
+	return &ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{reservation}}, nil
+}
+
+func (f *fakeEC2) DetachVolume(input *ec2.DetachVolumeInput) (*ec2.VolumeAttachment, error) {
+	attachment, ok := f.attachments[*input.VolumeId]
+	if !ok || *attachment.State != ec2.VolumeAttachmentStateAttached {
+		return nil, fmt.Errorf("IncorrectState: %s is not attached", *input.VolumeId)
+	}
+	attachment.State = aws.String(ec2.VolumeAttachmentStateDetached)
+	return attachment, nil
+}
+
+func (f *fakeEC2) AttachVolume(input *ec2.AttachVolumeInput) (*ec2.VolumeAttachment, error) {
+	if attachment, ok := f.attachments[*input.VolumeId]; ok && *attachment.State == ec2.VolumeAttachmentStateAttached {
+		return nil, fmt.Errorf("VolumeInUse: %s is already attached", *input.VolumeId)
+	}
+	f.attachments[*input.VolumeId] = &ec2.VolumeAttachment{VolumeId: input.VolumeId, InstanceId: input.InstanceId, Device: input.Device, State: aws.String(ec2.VolumeAttachmentStateAttached)}
+	return f.attachments[*input.VolumeId], nil
+}
+
+func (f *fakeEC2) DescribeVolumes(input *ec2.DescribeVolumesInput) (*ec2.DescribeVolumesOutput, error) {
+	output := &ec2.DescribeVolumesOutput{}
+	for _, id := range input.VolumeIds {
+		volume := &ec2.Volume{VolumeId: id}
+		if attachment, ok := f.attachments[*id]; ok && *attachment.State == ec2.VolumeAttachmentStateAttached {
+			volume.Attachments = []*ec2.VolumeAttachment{attachment}
+		}
+		output.Volumes = append(output.Volumes, volume)
+	}
+	return output, nil
+}
+
+// fakeGCPCompute is the in-memory gcp compute api, which contains the instance statuses and the disk users
+type fakeGCPCompute struct {
+	instances map[string]string
+	// disks contains the attached instance of the disks
+	disks map[string]string
+}
+
+func (f *fakeGCPCompute) setStatus(zone, instance, status string) error {
+	key := zone + "/" + instance
+	if _, ok := f.instances[key]; !ok {
+		return fmt.Errorf("instance %s not found", key)
+	}
+	f.instances[key] = status
+	return nil
+}
+
+func (f *fakeGCPCompute) StopInstance(project, zone, instance string) error {
+	return f.setStatus(zone, instance, "TERMINATED")
+}
+
+func (f *fakeGCPCompute) StartInstance(project, zone, instance string) error {
+	return f.setStatus(zone, instance, "RUNNING")
+}
+
+func (f *fakeGCPCompute) GetInstance(project, zone, instance string) (*gcompute.Instance, error) {
+	status, ok := f.instances[zone+"/"+instance]
+	if !ok {
+		return nil, fmt.Errorf("instance %s not found", instance)
+	}
+	return &gcompute.Instance{Name: instance, Status: status}, nil
+}
+
+func (f *fakeGCPCompute) DetachDisk(project, zone, instance, deviceName string) error {
+	for disk, user := range f.disks {
+		if user == instance && strings.HasSuffix(disk, "/"+deviceName) {
+			f.disks[disk] = ""
+			return nil
+		}
+	}
+	return fmt.Errorf("device %s not found", deviceName)
+}
+
+func (f *fakeGCPCompute) AttachDisk(project, zone, instance string, disk *gcompute.AttachedDisk) error {
+	name := disk.Source[strings.LastIndex(disk.Source, "/")+1:]
+	f.disks[zone+"/"+name] = instance
+	return nil
+}
+
+func (f *fakeGCPCompute) GetDisk(project, zone, disk string) (*gcompute.Disk, error) {
+	user, ok := f.disks[zone+"/"+disk]
+	if !ok {
+		return nil, fmt.Errorf("disk %s not found", disk)
+	}
+	details := &gcompute.Disk{Name: disk, SelfLink: fmt.Sprintf("projects/%s/zones/%s/disks/%s", project, zone, disk)}
+	if user != "" {
+		details.Users = []string{fmt.Sprintf("projects/%s/zones/%s/instances/%s", project, zone, user)}
+	}
+	return details, nil
+}
+
+// fakeAzureCompute is the in-memory azure compute api, which contains the vms and the disks
+// the vms are keyed by their name or by the scale set and the instance id for the scale set vms, like vmss/0
+type fakeAzureCompute struct {
+	powerStates map[string]string
+	dataDisks   map[string][]compute.DataDisk
+	disks       map[string]compute.Disk
+	updates     int
+}
+
+func fakeAzureKey(scaleSet, instance string) string {
+	if scaleSet != "" {
+		return scaleSet + "/" + instance
+	}
+	return instance
+}
+
+func (f *fakeAzureCompute) PowerOff(resourceGroup, scaleSet, instance string) error {
+	f.powerStates[fakeAzureKey(scaleSet, instance)] = "stopped"
+	return nil
+}
+
+func (f *fakeAzureCompute) Start(resourceGroup, scaleSet, instance string) error {
+	f.powerStates[fakeAzureKey(scaleSet, instance)] = "running"
+	return nil
+}
+
+func (f *fakeAzureCompute) GetStatuses(resourceGroup, scaleSet, instance string) ([]compute.InstanceViewStatus, error) {
+	state, ok := f.powerStates[fakeAzureKey(scaleSet, instance)]
+	if !ok {
+		return nil, fmt.Errorf("vm %s not found", fakeAzureKey(scaleSet, instance))
+	}
+	return []compute.InstanceViewStatus{
+		{Code: stringPtr("ProvisioningState/succeeded")},
+		{Code: stringPtr("PowerState/" + state)},
+	}, nil
+}
+
+func (f *fakeAzureCompute) GetDataDisks(resourceGroup, scaleSet, instance string) ([]compute.DataDisk, error) {
+	disks, ok := f.dataDisks[fakeAzureKey(scaleSet, instance)]
+	if !ok {
+		return nil, fmt.Errorf("vm %s not found", fakeAzureKey(scaleSet, instance))
+	}
+	// the disks are copied, so that the provider only updates them through UpdateDataDisks
+	return append([]compute.DataDisk{}, disks...), nil
+}
+
+func (f *fakeAzureCompute) UpdateDataDisks(resourceGroup, scaleSet, instance string, disks []compute.DataDisk) error {
+	f.updates++
+	f.dataDisks[fakeAzureKey(scaleSet, instance)] = disks
+	for name, disk := range f.disks {
+		disk.DiskProperties.DiskState = "Unattached"
+		disk.ManagedBy = nil
+		f.disks[name] = disk
+	}
+	for key, dataDisks := range f.dataDisks {
+		managedBy := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/" + key
+		if scaleSet, instance, ok := strings.Cut(key, "/"); ok {
+			managedBy = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachineScaleSets/" + scaleSet + "/virtualMachines/" + instance
+		}
+		for _, dataDisk := range dataDisks {
+			disk := f.disks[*dataDisk.Name]
+			disk.DiskProperties.DiskState = "Attached"
+			disk.ManagedBy = stringPtr(managedBy)
+			f.disks[*dataDisk.Name] = disk
+		}
+	}
+	return nil
+}
+
+func (f *fakeAzureCompute) GetDisk(resourceGroup, disk string) (compute.Disk, error) {
+	details, ok := f.disks[disk]
+	if !ok {
+		return compute.Disk{}, fmt.Errorf("disk %s not found", disk)
+	}
+	return details, nil
+}
+
+// fakeVSphere is the in-memory vsphere api, which fails the given number of requests before serving them
+type fakeVSphere struct {
+	powerStates map[string]string
+	failures    int
+	requests    int
+}
+
+func (f *fakeVSphere) fail() error {
+	f.requests++
+	if f.failures > 0 {
+		f.failures--
+		return fmt.Errorf("service unavailable")
+	}
+	return nil
+}
+
+func (f *fakeVSphere) PowerOn(vmID string) error {
+	if err := f.fail(); err != nil {
+		return err
+	}
+	f.powerStates[vmID] = "POWERED_ON"
+	return nil
+}
+
+func (f *fakeVSphere) PowerOff(vmID string) error {
+	if err := f.fail(); err != nil {
+		return err
+	}
+	f.powerStates[vmID] = "POWERED_OFF"
+	return nil
+}
+
+func (f *fakeVSphere) GetPowerState(vmID string) (string, error) {
+	if err := f.fail(); err != nil {
+		return "", err
+	}
+	return f.powerStates[vmID], nil
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/cloud/gcp"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+)
+
+// GCPComputeAPI contains the compute operations, which are used by the gcp provider
+type GCPComputeAPI interface {
+	StopInstance(project, zone, instance string) error
+	StartInstance(project, zone, instance string) error
+	GetInstance(project, zone, instance string) (*compute.Instance, error)
+	DetachDisk(project, zone, instance, deviceName string) error
+	AttachDisk(project, zone, instance string, disk *compute.AttachedDisk) error
+	GetDisk(project, zone, disk string) (*compute.Disk, error)
+}
+
+// gcpComputeService implements the GCPComputeAPI with the compute service
+type gcpComputeService struct {
+	service *compute.Service
+}
+
+func (s gcpComputeService) StopInstance(project, zone, instance string) error {
+	_, err := s.service.Instances.Stop(project, zone, instance).Do()
+	return err
+}
+
+func (s gcpComputeService) StartInstance(project, zone, instance string) error {
+	_, err := s.service.Instances.Start(project, zone, instance).Do()
+	return err
+}
+
+func (s gcpComputeService) GetInstance(project, zone, instance string) (*compute.Instance, error) {
+	return s.service.Instances.Get(project, zone, instance).Do()
+}
+
+func (s gcpComputeService) DetachDisk(project, zone, instance, deviceName string) error {
+	_, err := s.service.Instances.DetachDisk(project, zone, instance, deviceName).Do()
+	return err
+}
+
+func (s gcpComputeService) AttachDisk(project, zone, instance string, disk *compute.AttachedDisk) error {
+	_, err := s.service.Instances.AttachDisk(project, zone, instance, disk).Do()
+	return err
+}
+
+func (s gcpComputeService) GetDisk(project, zone, disk string) (*compute.Disk, error) {
+	return s.service.Disks.Get(project, zone, disk).Do()
+}
+
+type gcpProvider struct {
+	client  GCPComputeAPI
+	project string
+}
+
+// NewGCP returns the gcp provider for the given project, which uses the given compute client
+func NewGCP(client GCPComputeAPI, project string) Provider {
+	return &gcpProvider{client: client, project: project}
+}
+
+// NewGCPFromComputeService returns the gcp provider for the given project, which uses the given compute service
+func NewGCPFromComputeService(service *compute.Service, project string) Provider {
+	return NewGCP(gcpComputeService{service: service}, project)
+}
+
+// NewGCPFromOptions returns the gcp provider for the project of the given options
+// the compute service is authenticated with the service account of the secret or the workload identity
+func NewGCPFromOptions(opts Options) (Provider, error) {
+	var clientOptions []option.ClientOption
+	if opts.Endpoint != "" {
+		clientOptions = append(clientOptions, option.WithEndpoint(opts.Endpoint))
+	}
+	service, err := gcp.GetGCPComputeService(clientOptions...)
+	if err != nil {
+		return nil, err
+	}
+	return NewGCPFromComputeService(service, opts.Project), nil
+}
+
+func (p *gcpProvider) Name() string {
+	return GCP
+}
+
+func (p *gcpProvider) StopInstance(instance Instance) error {
+	if err := p.client.StopInstance(p.project, instance.Zone, instance.ID); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: instanceTarget(p.Name(), instance), Reason: fmt.Sprintf("failed to stop the instance: %v", err)}
+	}
+	log.Infof("[Chaos]: Stopping the %s instance", instance.ID)
+	return nil
+}
+
+func (p *gcpProvider) StartInstance(instance Instance) error {
+	if err := p.client.StartInstance(p.project, instance.Zone, instance.ID); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: instanceTarget(p.Name(), instance), Reason: fmt.Sprintf("failed to start the instance: %v", err)}
+	}
+	log.Infof("[Chaos]: Starting the %s instance", instance.ID)
+	return nil
+}
+
+func (p *gcpProvider) GetInstanceState(instance Instance) (State, error) {
+	details, err := p.client.GetInstance(p.project, instance.Zone, instance.ID)
+	if err != nil {
+		return StateUnknown, cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: instanceTarget(p.Name(), instance), Reason: fmt.Sprintf("failed to get the instance: %v", err)}
+	}
+	return gcpInstanceState(details.Status), nil
+}
+
+func (p *gcpProvider) DetachVolume(volume Volume) error {
+	if err := p.client.DetachDisk(p.project, volume.Zone, volume.InstanceID, volume.Device); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: volumeTarget(p.Name(), volume), Reason: fmt.Sprintf("failed to detach the disk: %v", err)}
+	}
+	log.Infof("[Chaos]: Detaching the %s disk", volume.ID)
+	return nil
+}
+
+func (p *gcpProvider) AttachVolume(volume Volume) error {
+	disk, err := p.client.GetDisk(p.project, volume.Zone, volume.ID)
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: volumeTarget(p.Name(), volume), Reason: fmt.Sprintf("failed to get the disk: %v", err)}
+	}
+	if err := p.client.AttachDisk(p.project, volume.Zone, volume.InstanceID, &compute.AttachedDisk{DeviceName: volume.Device, Source: disk.SelfLink}); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: volumeTarget(p.Name(), volume), Reason: fmt.Sprintf("failed to attach the disk: %v", err)}
+	}
+	log.Infof("[Chaos]: Attaching the %s disk", volume.ID)
+	return nil
+}
+
+func (p *gcpProvider) GetVolumeState(volume Volume) (State, error) {
+	disk, err := p.client.GetDisk(p.project, volume.Zone, volume.ID)
+	if err != nil {
+		return StateUnknown, cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: volumeTarget(p.Name(), volume), Reason: fmt.Sprintf("failed to get the disk: %v", err)}
+	}
+	// the users of the disk are the urls of the attached instances in the form: projects/project/zones/zone/instances/instance
+	for _, user := range disk.Users {
+		if volume.InstanceID == "" || user[strings.LastIndex(user, "/")+1:] == volume.InstanceID {
+			return StateAttached, nil
+		}
+	}
+	return StateDetached, nil
+}
+
+// gcpInstanceState maps the status of the gcp instance to the provider state
+func gcpInstanceState(status string) State {
+	switch status {
+	case "RUNNING":
+		return StateRunning
+	case "TERMINATED", "STOPPED", "SUSPENDED":
+		return StateStopped
+	case "PROVISIONING", "STAGING", "STOPPING", "SUSPENDING", "REPAIRING":
+		return StateTransitioning
+	}
+	return StateUnknown
+}
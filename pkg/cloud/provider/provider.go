@@ -0,0 +1,248 @@
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
+	"github.com/palantir/stacktrace"
+)
+
+// supported cloud providers
+const (
+	AWS     = "aws"
+	GCP     = "gcp"
+	Azure   = "azure"
+	VSphere = "vsphere"
+)
+
+// State is the provider agnostic state of the instances and the volumes
+type State string
+
+// instance and volume states
+const (
+	StateRunning       State = "running"
+	StateStopped       State = "stopped"
+	StateTerminated    State = "terminated"
+	StateAttached      State = "attached"
+	StateDetached      State = "detached"
+	StateTransitioning State = "transitioning"
+	StateUnknown       State = "unknown"
+)
+
+// Instance is the compute instance of the cloud provider
+type Instance struct {
+	// ID is the instance id for aws, the instance name for gcp and azure and the vm id for vsphere
+	ID string
+	// Zone is the zone of the gcp instance
+	Zone string
+	// ResourceGroup is the resource group of the azure instance
+	ResourceGroup string
+	// ScaleSet is the scale set of the azure instance, the ID is then the instance id of the scale set vm
+	ScaleSet string
+}
+
+// Volume is the block volume of the cloud provider
+type Volume struct {
+	// ID is the volume id for aws and the disk name for gcp and azure
+	ID string
+	// InstanceID is the instance, which the volume is attached to
+	InstanceID string
+	// Device is the device name of the aws and gcp volumes
+	Device string
+	// Zone is the zone of the gcp disk
+	Zone string
+	// ResourceGroup is the resource group of the azure disk
+	ResourceGroup string
+	// ScaleSet is the scale set of the azure instance, which the disk is attached to
+	ScaleSet string
+}
+
+// Provider performs the instance and the volume operations of the cloud experiments
+type Provider interface {
+	// Name returns the name of the provider
+	Name() string
+	StopInstance(instance Instance) error
+	StartInstance(instance Instance) error
+	GetInstanceState(instance Instance) (State, error)
+	DetachVolume(volume Volume) error
+	AttachVolume(volume Volume) error
+	GetVolumeState(volume Volume) (State, error)
+}
+
+// Options contains the settings of the cloud provider
+type Options struct {
+	// Region is the region of the aws provider
+	Region string
+	// Project is the project of the gcp provider
+	Project string
+	// SubscriptionID is the subscription of the azure provider
+	SubscriptionID string
+	// Endpoint overrides the api endpoint of the provider, it is the vcenter server for vsphere
+	Endpoint string
+	// RoleARN is the role, which is assumed by the aws provider
+	RoleARN string
+	// MaxRetries is the number of retries of the failed requests
+	MaxRetries int
+	// RetryDelay is the delay between the retries of the failed requests, it defaults to 2s
+	RetryDelay time.Duration
+	// User and Password are the credentials of the vsphere provider
+	User     string
+	Password string
+}
+
+// New returns the cloud provider of the given name, which creates its clients from the given options
+func New(name string, opts Options) (Provider, error) {
+	var (
+		p   Provider
+		err error
+	)
+	switch name {
+	case AWS:
+		// the aws sdk retries the failed requests itself
+		return NewAWSFromOptions(opts)
+	case GCP:
+		p, err = NewGCPFromOptions(opts)
+	case Azure:
+		p, err = NewAzureFromOptions(opts)
+	case VSphere:
+		p, err = NewVSphereFromOptions(opts)
+	default:
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("unsupported cloud provider '%s', supported providers are: %s, %s, %s, %s", name, AWS, GCP, Azure, VSphere)}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return WithRetries(p, opts.MaxRetries, opts.RetryDelay), nil
+}
+
+// WithRetries returns the provider, which retries the failed operations of the given provider
+func WithRetries(p Provider, retries int, delay time.Duration) Provider {
+	if retries <= 0 {
+		return p
+	}
+	if delay <= 0 {
+		delay = 2 * time.Second
+	}
+	return &retryingProvider{provider: p, retries: retries, delay: delay}
+}
+
+type retryingProvider struct {
+	provider Provider
+	retries  int
+	delay    time.Duration
+}
+
+func (r *retryingProvider) try(action func() error) error {
+	var err error
+	for attempt := 0; attempt <= r.retries; attempt++ {
+		if attempt > 0 {
+			log.Infof("[Retry]: %s request failed, retrying in %v: %v", r.provider.Name(), r.delay, err)
+			time.Sleep(r.delay)
+		}
+		if err = action(); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func (r *retryingProvider) Name() string {
+	return r.provider.Name()
+}
+
+func (r *retryingProvider) StopInstance(instance Instance) error {
+	return r.try(func() error { return r.provider.StopInstance(instance) })
+}
+
+func (r *retryingProvider) StartInstance(instance Instance) error {
+	return r.try(func() error { return r.provider.StartInstance(instance) })
+}
+
+func (r *retryingProvider) GetInstanceState(instance Instance) (State, error) {
+	var state State
+	err := r.try(func() error {
+		var err error
+		state, err = r.provider.GetInstanceState(instance)
+		return err
+	})
+	return state, err
+}
+
+func (r *retryingProvider) DetachVolume(volume Volume) error {
+	return r.try(func() error { return r.provider.DetachVolume(volume) })
+}
+
+func (r *retryingProvider) AttachVolume(volume Volume) error {
+	return r.try(func() error { return r.provider.AttachVolume(volume) })
+}
+
+func (r *retryingProvider) GetVolumeState(volume Volume) (State, error) {
+	var state State
+	err := r.try(func() error {
+		var err error
+		state, err = r.provider.GetVolumeState(volume)
+		return err
+	})
+	return state, err
+}
+
+// WaitForInstanceState waits for the instance to attain the given state
+func WaitForInstanceState(p Provider, instance Instance, state State, timeout, delay int) error {
+	log.Infof("[Status]: Waiting for the %s instance %s to be %s", p.Name(), instance.ID, state)
+	return retry.
+		Times(uint(timeout / delay)).
+		Wait(time.Duration(delay) * time.Second).
+		Try(func(attempt uint) error {
+			current, err := p.GetInstanceState(instance)
+			if err != nil {
+				return stacktrace.Propagate(err, "failed to get the instance state")
+			}
+			if current != state {
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: instanceTarget(p.Name(), instance), Reason: fmt.Sprintf("instance is in %s state, expected %s state", current, state)}
+			}
+			log.Infof("[Status]: The instance state is %v", current)
+			return nil
+		})
+}
+
+// WaitForVolumeState waits for the volume to attain the given state
+func WaitForVolumeState(p Provider, volume Volume, state State, timeout, delay int) error {
+	log.Infof("[Status]: Waiting for the %s volume %s to be %s", p.Name(), volume.ID, state)
+	return retry.
+		Times(uint(timeout / delay)).
+		Wait(time.Duration(delay) * time.Second).
+		Try(func(attempt uint) error {
+			current, err := p.GetVolumeState(volume)
+			if err != nil {
+				return stacktrace.Propagate(err, "failed to get the volume state")
+			}
+			if current != state {
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: volumeTarget(p.Name(), volume), Reason: fmt.Sprintf("volume is in %s state, expected %s state", current, state)}
+			}
+			log.Infof("[Status]: The volume state is %v", current)
+			return nil
+		})
+}
+
+func instanceTarget(provider string, instance Instance) string {
+	switch {
+	case instance.Zone != "":
+		return fmt.Sprintf("{%s Instance: %s, Zone: %s}", provider, instance.ID, instance.Zone)
+	case instance.ResourceGroup != "":
+		return fmt.Sprintf("{%s Instance: %s, Resource Group: %s}", provider, instance.ID, instance.ResourceGroup)
+	}
+	return fmt.Sprintf("{%s Instance: %s}", provider, instance.ID)
+}
+
+func volumeTarget(provider string, volume Volume) string {
+	switch {
+	case volume.Zone != "":
+		return fmt.Sprintf("{%s Volume: %s, Instance: %s, Zone: %s}", provider, volume.ID, volume.InstanceID, volume.Zone)
+	case volume.ResourceGroup != "":
+		return fmt.Sprintf("{%s Volume: %s, Instance: %s, Resource Group: %s}", provider, volume.ID, volume.InstanceID, volume.ResourceGroup)
+	}
+	return fmt.Sprintf("{%s Volume: %s, Instance: %s}", provider, volume.ID, volume.InstanceID)
+}
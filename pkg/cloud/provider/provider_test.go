@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/latest/compute/mgmt/compute"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/litmuschaos/litmus-go/pkg/cloud/aws/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testInstanceChaos stops and starts the instance with the provider and checks its states
+func testInstanceChaos(t *testing.T, p Provider, instance Instance) {
+	assertInstanceState(t, p, instance, StateRunning)
+	require.NoError(t, p.StopInstance(instance))
+	assertInstanceState(t, p, instance, StateStopped)
+	require.NoError(t, p.StartInstance(instance))
+	assertInstanceState(t, p, instance, StateRunning)
+}
+
+// testVolumeChaos detaches and attaches the volume with the provider and checks its states
+func testVolumeChaos(t *testing.T, p Provider, volume Volume) {
+	assertVolumeState(t, p, volume, StateAttached)
+	require.NoError(t, p.DetachVolume(volume))
+	assertVolumeState(t, p, volume, StateDetached)
+	require.NoError(t, p.AttachVolume(volume))
+	assertVolumeState(t, p, volume, StateAttached)
+}
+
+func assertInstanceState(t *testing.T, p Provider, instance Instance, want State) {
+	state, err := p.GetInstanceState(instance)
+	require.NoError(t, err)
+	assert.Equal(t, want, state)
+}
+
+func assertVolumeState(t *testing.T, p Provider, volume Volume, want State) {
+	state, err := p.GetVolumeState(volume)
+	require.NoError(t, err)
+	assert.Equal(t, want, state)
+}
+
+func TestAWSProvider(t *testing.T) {
+	client := newFakeEC2()
+	client.instances["i-1"] = ec2.InstanceStateNameRunning
+	client.attachments["vol-1"] = &ec2.VolumeAttachment{VolumeId: aws.String("vol-1"), InstanceId: aws.String("i-1"), Device: aws.String("/dev/sdf"), State: aws.String(ec2.VolumeAttachmentStateAttached)}
+	p := NewAWS(client)
+
+	testInstanceChaos(t, p, Instance{ID: "i-1"})
+	require.NoError(t, p.StopInstance(Instance{ID: "i-1"}))
+	require.NoError(t, WaitForInstanceState(p, Instance{ID: "i-1"}, StateStopped, 1, 1))
+	assert.Error(t, WaitForInstanceState(p, Instance{ID: "i-1"}, StateRunning, 1, 1))
+	require.NoError(t, p.StartInstance(Instance{ID: "i-1"}))
+
+	testVolumeChaos(t, p, Volume{ID: "vol-1", InstanceID: "i-1", Device: "/dev/sdf"})
+	assert.Equal(t, "i-1", *client.attachments["vol-1"].InstanceId)
+
+	// the terminated instance is not treated as stopped
+	client.instances["i-3"] = ec2.InstanceStateNameTerminated
+	assertInstanceState(t, p, Instance{ID: "i-3"}, StateTerminated)
+	assert.Error(t, WaitForInstanceState(p, Instance{ID: "i-3"}, StateStopped, 1, 1))
+
+	_, err := p.GetInstanceState(Instance{ID: "i-2"})
+	assert.ErrorContains(t, err, "instance not found")
+	assert.ErrorContains(t, p.StopInstance(Instance{ID: "i-2"}), "InvalidInstanceID.NotFound")
+}
+
+func TestGCPProvider(t *testing.T) {
+	client := &fakeGCPCompute{
+		instances: map[string]string{"us-central1-a/vm-1": "RUNNING"},
+		disks:     map[string]string{"us-central1-a/disk-1": "vm-1"},
+	}
+	p := NewGCP(client, "project")
+
+	testInstanceChaos(t, p, Instance{ID: "vm-1", Zone: "us-central1-a"})
+	testVolumeChaos(t, p, Volume{ID: "disk-1", InstanceID: "vm-1", Device: "disk-1", Zone: "us-central1-a"})
+
+	// the disk attached to another instance is detached from the target instance
+	client.disks["us-central1-a/disk-1"] = "vm-2"
+	state, err := p.GetVolumeState(Volume{ID: "disk-1", InstanceID: "vm-1", Zone: "us-central1-a"})
+	require.NoError(t, err)
+	assert.Equal(t, StateDetached, state)
+}
+
+func TestAzureProvider(t *testing.T) {
+	lun := int32(3)
+	client := &fakeAzureCompute{
+		powerStates: map[string]string{"vm-1": "running", "vmss/0": "running"},
+		dataDisks:   map[string][]compute.DataDisk{"vm-1": {}, "vmss/0": {}},
+		disks: map[string]compute.Disk{
+			"disk-1": {Name: stringPtr("disk-1"), ID: stringPtr("/disks/disk-1"), DiskProperties: &compute.DiskProperties{}},
+			"disk-2": {Name: stringPtr("disk-2"), ID: stringPtr("/disks/disk-2"), DiskProperties: &compute.DiskProperties{}},
+			"disk-3": {Name: stringPtr("disk-3"), ID: stringPtr("/disks/disk-3"), DiskProperties: &compute.DiskProperties{}},
+		},
+	}
+	require.NoError(t, client.UpdateDataDisks("rg", "", "vm-1", []compute.DataDisk{{Name: stringPtr("disk-1"), Lun: &lun, Caching: compute.CachingTypesReadOnly}}))
+	require.NoError(t, client.UpdateDataDisks("rg", "vmss", "0", []compute.DataDisk{{Name: stringPtr("disk-3"), Lun: &lun}}))
+	p := NewAzure(client)
+
+	testInstanceChaos(t, p, Instance{ID: "vm-1", ResourceGroup: "rg"})
+	testVolumeChaos(t, p, Volume{ID: "disk-1", InstanceID: "vm-1", ResourceGroup: "rg"})
+
+	// the detached disk is attached back with its lun and caching
+	disks := client.dataDisks["vm-1"]
+	require.Len(t, disks, 1)
+	assert.Equal(t, lun, *disks[0].Lun)
+	assert.Equal(t, compute.CachingTypesReadOnly, disks[0].Caching)
+	assert.Equal(t, compute.DiskCreateOptionTypesAttach, disks[0].CreateOption)
+
+	// the unknown disk is attached at the first free lun
+	require.NoError(t, p.AttachVolume(Volume{ID: "disk-2", InstanceID: "vm-1", ResourceGroup: "rg"}))
+	disks = client.dataDisks["vm-1"]
+	require.Len(t, disks, 2)
+	assert.Equal(t, int32(0), *disks[1].Lun)
+	assert.Equal(t, "/disks/disk-2", *disks[1].ManagedDisk.ID)
+
+	updates := client.updates
+	assert.ErrorContains(t, p.DetachVolume(Volume{ID: "disk-3", InstanceID: "vm-1", ResourceGroup: "rg"}), "disk is not attached to the instance")
+	assert.Equal(t, updates, client.updates)
+
+	// the scale set vms are addressed by their scale set and instance id
+	testInstanceChaos(t, p, Instance{ID: "0", ResourceGroup: "rg", ScaleSet: "vmss"})
+	testVolumeChaos(t, p, Volume{ID: "disk-3", InstanceID: "0", ResourceGroup: "rg", ScaleSet: "vmss"})
+	assertVolumeState(t, p, Volume{ID: "disk-3", InstanceID: "0", ResourceGroup: "rg", ScaleSet: "other"}, StateDetached)
+}
+
+func TestVSphereProvider(t *testing.T) {
+	client := &fakeVSphere{powerStates: map[string]string{"vm-1": "POWERED_ON"}}
+	p := NewVSphere(client)
+
+	testInstanceChaos(t, p, Instance{ID: "vm-1"})
+
+	_, err := p.GetVolumeState(Volume{ID: "disk-1"})
+	assert.ErrorContains(t, err, "volume operations are not supported")
+}
+
+func TestWithRetries(t *testing.T) {
+	tests := map[string]struct {
+		failures int
+		retries  int
+		wantErr  bool
+		requests int
+	}{
+		"success without retries": {failures: 0, retries: 0, requests: 1},
+		"failure without retries": {failures: 1, retries: 0, wantErr: true, requests: 1},
+		"success after retries":   {failures: 2, retries: 2, requests: 3},
+		"failure after retries":   {failures: 3, retries: 2, wantErr: true, requests: 3},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &fakeVSphere{powerStates: map[string]string{"vm-1": "POWERED_ON"}, failures: tt.failures}
+			p := WithRetries(NewVSphere(client), tt.retries, time.Millisecond)
+
+			err := p.StopInstance(Instance{ID: "vm-1"})
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, "POWERED_ON", client.powerStates["vm-1"])
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "POWERED_OFF", client.powerStates["vm-1"])
+			}
+			assert.Equal(t, tt.requests, client.requests)
+		})
+	}
+}
+
+func TestNewAWSSession(t *testing.T) {
+	sess, err := common.NewAWSSession(common.SessionOptions{Region: "us-east-1", Endpoint: "http://localhost:4566", MaxRetries: 5})
+	require.NoError(t, err)
+	assert.Equal(t, "us-east-1", aws.StringValue(sess.Config.Region))
+	assert.Equal(t, "http://localhost:4566", aws.StringValue(sess.Config.Endpoint))
+	assert.Equal(t, 5, aws.IntValue(sess.Config.MaxRetries))
+
+	base, err := common.NewAWSSession(common.SessionOptions{Region: "us-east-1"})
+	require.NoError(t, err)
+	assumed, err := common.NewAWSSession(common.SessionOptions{Region: "us-east-1", RoleARN: "arn:aws:iam::123456789012:role/chaos"})
+	require.NoError(t, err)
+	assert.NotSame(t, base.Config.Credentials, assumed.Config.Credentials)
+	assert.Nil(t, base.Config.Endpoint)
+}
+
+func TestNewUnsupportedProvider(t *testing.T) {
+	_, err := New("openstack", Options{})
+	assert.ErrorContains(t, err, "unsupported cloud provider 'openstack'")
+}
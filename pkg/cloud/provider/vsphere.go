@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/cloud/vmware"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+)
+
+// VSphereAPI contains the vm operations, which are used by the vsphere provider
+type VSphereAPI interface {
+	PowerOn(vmID string) error
+	PowerOff(vmID string) error
+	// GetPowerState returns the power state of the vm, like POWERED_ON
+	GetPowerState(vmID string) (string, error)
+}
+
+type vsphereProvider struct {
+	client VSphereAPI
+}
+
+// NewVSphere returns the vsphere provider, which uses the given vm client
+func NewVSphere(client VSphereAPI) Provider {
+	return &vsphereProvider{client: client}
+}
+
+// NewVSphereFromOptions returns the vsphere provider for the vcenter server of the given options
+// the session of the vcenter is created with the user and the password of the options
+func NewVSphereFromOptions(opts Options) (Provider, error) {
+	client, err := vmware.NewClient(opts.Endpoint, opts.User, opts.Password, "")
+	if err != nil {
+		return nil, err
+	}
+	return NewVSphere(client), nil
+}
+
+func (p *vsphereProvider) Name() string {
+	return VSphere
+}
+
+func (p *vsphereProvider) StopInstance(instance Instance) error {
+	if err := p.client.PowerOff(instance.ID); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: instanceTarget(p.Name(), instance), Reason: fmt.Sprintf("failed to stop the vm: %v", err)}
+	}
+	log.Infof("[Chaos]: Stopping the %s vm", instance.ID)
+	return nil
+}
+
+func (p *vsphereProvider) StartInstance(instance Instance) error {
+	if err := p.client.PowerOn(instance.ID); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: instanceTarget(p.Name(), instance), Reason: fmt.Sprintf("failed to start the vm: %v", err)}
+	}
+	log.Infof("[Chaos]: Starting the %s vm", instance.ID)
+	return nil
+}
+
+func (p *vsphereProvider) GetInstanceState(instance Instance) (State, error) {
+	state, err := p.client.GetPowerState(instance.ID)
+	if err != nil {
+		return StateUnknown, cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: instanceTarget(p.Name(), instance), Reason: fmt.Sprintf("failed to get the vm power state: %v", err)}
+	}
+	switch state {
+	case "POWERED_ON":
+		return StateRunning, nil
+	case "POWERED_OFF", "SUSPENDED":
+		return StateStopped, nil
+	}
+	return StateUnknown, nil
+}
+
+// the hot detachment of the vm disks isn't supported by the vsphere provider
+
+func (p *vsphereProvider) DetachVolume(volume Volume) error {
+	return p.unsupported(volume, cerrors.ErrorTypeChaosInject)
+}
+
+func (p *vsphereProvider) AttachVolume(volume Volume) error {
+	return p.unsupported(volume, cerrors.ErrorTypeChaosRevert)
+}
+
+func (p *vsphereProvider) GetVolumeState(volume Volume) (State, error) {
+	return StateUnknown, p.unsupported(volume, cerrors.ErrorTypeStatusChecks)
+}
+
+func (p *vsphereProvider) unsupported(volume Volume, errorCode cerrors.ErrorType) error {
+	return cerrors.Error{ErrorCode: errorCode, Target: volumeTarget(p.Name(), volume), Reason: "volume operations are not supported by the vsphere provider"}
+}
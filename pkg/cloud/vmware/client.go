@@ -0,0 +1,136 @@
+package vmware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/vmware/govmomi/session"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// Client is the vSphere client of the vcenter server, it uses the vSphere api for the vm operations and the vSphere automation api for the tags
+// the session of the vcenter is created again with the same credentials, if it expires
+type Client struct {
+	vimClient      *vim25.Client
+	restClient     *rest.Client
+	sessionManager *session.Manager
+	userinfo       *url.Userinfo
+	datacenter     string
+	loginLock      sync.Mutex
+}
+
+// NewClient returns the vSphere client of the vcenter server, which is logged in with the given user and password
+// the datacenter is used to look up the vms by the inventory paths, the default datacenter is used if it is empty
+func NewClient(vcenterServer, vcenterUser, vcenterPass, datacenter string) (*Client, error) {
+
+	u, err := soap.ParseURL(vcenterServer)
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("failed to parse the vcenter server url: %v", err)}
+	}
+	u.User = url.UserPassword(vcenterUser, vcenterPass)
+
+	return newClient(u, datacenter)
+}
+
+// newClient returns the vSphere client of the vcenter server of the url, the url contains the user and the password
+func newClient(u *url.URL, datacenter string) (*Client, error) {
+
+	// the certificate of the vcenter server isn't verified, as with the rest api of the vcenter
+	soapClient := soap.NewClient(u, true)
+	vimClient, err := vim25.NewClient(context.TODO(), soapClient)
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("failed to create the vcenter client: %v", err)}
+	}
+
+	c := &Client{
+		vimClient:      vimClient,
+		restClient:     rest.NewClient(vimClient),
+		sessionManager: session.NewManager(vimClient),
+		userinfo:       u.User,
+		datacenter:     datacenter,
+	}
+	if err := c.login(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// login creates the sessions of the vSphere api and the vSphere automation api
+func (c *Client) login() error {
+	c.loginLock.Lock()
+	defer c.loginLock.Unlock()
+
+	if err := c.sessionManager.Login(context.TODO(), c.userinfo); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("error during authentication: %v", err)}
+	}
+	if err := c.restClient.Login(context.TODO(), c.userinfo); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("error during authentication of the automation api: %v", err)}
+	}
+
+	return nil
+}
+
+// Logout ends the sessions of the vcenter
+func (c *Client) Logout() error {
+	if err := c.restClient.Logout(context.TODO()); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("failed to logout: %v", err)}
+	}
+	if err := c.sessionManager.Logout(context.TODO()); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("failed to logout: %v", err)}
+	}
+	return nil
+}
+
+// withSession runs the request, it logs in again and retries the request once if the session has expired
+func (c *Client) withSession(request func() error) error {
+
+	err := request()
+	if !isNotAuthenticated(err) {
+		return err
+	}
+
+	log.Info("[Info]: The vcenter session has expired, logging in again")
+	if err := c.login(); err != nil {
+		return err
+	}
+	return request()
+}
+
+// isNotAuthenticated checks whether the request failed because the session has expired
+func isNotAuthenticated(err error) bool {
+	if err == nil {
+		return false
+	}
+	// the tags manager doesn't wrap the status errors of the automation api
+	if strings.HasSuffix(err.Error(), fmt.Sprintf("%d %s", http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))) {
+		return true
+	}
+
+	for ; err != nil; err = errors.Unwrap(err) {
+		if soap.IsSoapFault(err) {
+			if _, ok := soap.ToSoapFault(err).VimFault().(types.NotAuthenticated); ok {
+				return true
+			}
+		}
+		if soap.IsVimFault(err) {
+			if _, ok := soap.ToVimFault(err).(*types.NotAuthenticated); ok {
+				return true
+			}
+		}
+		if rest.IsStatusError(err, http.StatusUnauthorized) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package vmware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vapi/tags"
+
+	// registers the vSphere automation api, which serves the tags, on the simulator
+	_ "github.com/vmware/govmomi/vapi/simulator"
+)
+
+// newSimulatorClient returns the client of the vcsim vcenter, which has a datacenter with a host and a cluster of two VMs each
+func newSimulatorClient(t *testing.T) *Client {
+	model := simulator.VPX()
+	require.NoError(t, model.Create())
+	t.Cleanup(model.Remove)
+
+	model.Service.RegisterEndpoints = true
+	server := model.Service.NewServer()
+	t.Cleanup(server.Close)
+
+	client, err := newClient(server.URL, "")
+	require.NoError(t, err)
+	return client
+}
+
+func TestVMLookup(t *testing.T) {
+	client := newSimulatorClient(t)
+
+	hostVMs, err := client.GetVMsByName([]string{"DC0_H0_VM0", "/DC0/vm/DC0_H0_VM1"})
+	require.NoError(t, err)
+	require.Len(t, hostVMs, 2)
+
+	_, err = client.GetVMsByName([]string{"missing-vm"})
+	assert.ErrorContains(t, err, "missing-vm")
+
+	poolVMs, err := client.GetVMsByResourcePool("/DC0/host/DC0_C0/Resources")
+	require.NoError(t, err)
+	assert.Len(t, poolVMs, 2)
+	assert.NotContains(t, poolVMs, hostVMs[0])
+
+	folderVMs, err := client.GetVMsByFolder("/DC0/vm")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, append(hostVMs, poolVMs...), folderVMs)
+
+	manager := tags.NewManager(client.restClient)
+	categoryID, err := manager.CreateCategory(context.Background(), &tags.Category{Name: "chaos", Cardinality: "MULTIPLE"})
+	require.NoError(t, err)
+	tagID, err := manager.CreateTag(context.Background(), &tags.Tag{Name: "litmus", CategoryID: categoryID})
+	require.NoError(t, err)
+	for _, vmId := range []string{hostVMs[0], poolVMs[0]} {
+		require.NoError(t, manager.AttachTag(context.Background(), tagID, client.virtualMachine(vmId)))
+	}
+
+	taggedVMs, err := client.GetVMsByTag("litmus")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{hostVMs[0], poolVMs[0]}, taggedVMs)
+
+	// the VMs selected by more than one field are returned once
+	vmIds, err := client.GetVMIds(VMSelector{Tag: "litmus", ResourcePool: "/DC0/host/DC0_C0/Resources", Names: []string{"DC0_H0_VM0"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{hostVMs[0], poolVMs[0], poolVMs[1]}, vmIds)
+
+	_, err = client.GetVMIds(VMSelector{Tag: "missing-tag"})
+	assert.ErrorContains(t, err, "failed to get the VMs of the tag")
+}
+
+func TestVMPowerOperations(t *testing.T) {
+	client := newSimulatorClient(t)
+
+	vmIds, err := client.GetVMsByName([]string{"DC0_H0_VM0"})
+	require.NoError(t, err)
+	vmId := vmIds[0]
+
+	require.NoError(t, VMStatusCheck(client, vmId))
+
+	require.NoError(t, client.PowerOff(vmId))
+	require.NoError(t, WaitForVMStop(1, 1, client, vmId))
+	assert.ErrorContains(t, VMStatusCheck(client, vmId), "VM is not in POWERED_ON state")
+	assert.ErrorContains(t, client.PowerOff(vmId), "failed to stop VM")
+
+	require.NoError(t, client.PowerOn(vmId))
+	require.NoError(t, WaitForVMStart(1, 1, client, vmId))
+
+	require.NoError(t, client.Suspend(vmId))
+	require.NoError(t, WaitForVMSuspend(1, 1, client, vmId))
+	require.NoError(t, client.PowerOn(vmId))
+
+	require.NoError(t, client.Reset(vmId))
+	require.NoError(t, WaitForVMStart(1, 1, client, vmId))
+
+	require.NoError(t, client.ShutdownGuest(vmId))
+	require.NoError(t, WaitForVMStop(1, 1, client, vmId))
+
+	_, err = client.GetPowerState("vm-missing")
+	assert.ErrorContains(t, err, "failed to get VM status")
+}
+
+func TestVMSnapshots(t *testing.T) {
+	client := newSimulatorClient(t)
+
+	vmIds, err := client.GetVMsByName([]string{"DC0_H0_VM0"})
+	require.NoError(t, err)
+	vmId := vmIds[0]
+
+	require.NoError(t, client.CreateSnapshot(vmId, "litmus", "before chaos", false))
+	require.NoError(t, client.PowerOff(vmId))
+	require.NoError(t, client.RevertToSnapshot(vmId, "litmus"))
+	require.NoError(t, client.RemoveSnapshot(vmId, "litmus"))
+
+	assert.ErrorContains(t, client.RevertToSnapshot(vmId, "litmus"), "failed to revert to the snapshot of VM")
+}
+
+func TestSessionRefresh(t *testing.T) {
+	client := newSimulatorClient(t)
+
+	vmIds, err := client.GetVMsByName([]string{"DC0_H0_VM0"})
+	require.NoError(t, err)
+
+	// the expired sessions are created again by the next request
+	require.NoError(t, client.Logout())
+	userSession, err := client.sessionManager.UserSession(context.Background())
+	require.NoError(t, err)
+	require.Nil(t, userSession)
+
+	state, err := client.GetPowerState(vmIds[0])
+	require.NoError(t, err)
+	assert.Equal(t, "POWERED_ON", state)
+
+	require.NoError(t, client.Logout())
+	_, err = client.GetVMsByTag("missing-tag")
+	assert.ErrorContains(t, err, "failed to get the VMs of the tag")
+	assert.NotContains(t, err.Error(), "401")
+}
@@ -0,0 +1,211 @@
+package vmware
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25/mo"
+)
+
+// VMSelector selects the target VMs by their tag, folder, resource pool or names
+// the VMs selected by all the provided fields are returned
+type VMSelector struct {
+	// Tag is the name or the id of the tag attached to the VMs
+	Tag string
+	// Folder is the inventory path of the folder, which contains the VMs
+	Folder string
+	// ResourcePool is the inventory path of the resource pool, which contains the VMs
+	ResourcePool string
+	// Names are the names or the inventory paths of the VMs
+	Names []string
+}
+
+// GetVMIds returns the managed object ids of the VMs selected by the selector, without any duplicates
+func (c *Client) GetVMIds(selector VMSelector) ([]string, error) {
+
+	var vmIds []string
+	add := func(ids []string, err error) error {
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if !contains(vmIds, id) {
+				vmIds = append(vmIds, id)
+			}
+		}
+		return nil
+	}
+
+	if selector.Tag != "" {
+		if err := add(c.GetVMsByTag(selector.Tag)); err != nil {
+			return nil, err
+		}
+	}
+	if selector.Folder != "" {
+		if err := add(c.GetVMsByFolder(selector.Folder)); err != nil {
+			return nil, err
+		}
+	}
+	if selector.ResourcePool != "" {
+		if err := add(c.GetVMsByResourcePool(selector.ResourcePool)); err != nil {
+			return nil, err
+		}
+	}
+	if len(selector.Names) != 0 {
+		if err := add(c.GetVMsByName(selector.Names)); err != nil {
+			return nil, err
+		}
+	}
+
+	return vmIds, nil
+}
+
+// GetVMsByTag returns the managed object ids of the VMs, which have the given tag attached
+func (c *Client) GetVMsByTag(tag string) ([]string, error) {
+
+	var vmIds []string
+	err := c.withSession(func() error {
+		manager := tags.NewManager(c.restClient)
+		t, err := manager.GetTag(context.TODO(), tag)
+		if err != nil {
+			return err
+		}
+		refs, err := manager.ListAttachedObjects(context.TODO(), t.ID)
+		if err != nil {
+			return err
+		}
+		vmIds = nil
+		for _, ref := range refs {
+			if ref.Reference().Type == "VirtualMachine" {
+				vmIds = append(vmIds, ref.Reference().Value)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: fmt.Sprintf("failed to get the VMs of the tag: %v", err), Target: fmt.Sprintf("{VM Tag: %v}", tag)}
+	}
+
+	return vmIds, nil
+}
+
+// GetVMsByFolder returns the managed object ids of the VMs, which are the direct children of the given folder
+func (c *Client) GetVMsByFolder(folder string) ([]string, error) {
+
+	var vmIds []string
+	err := c.withSession(func() error {
+		finder, err := c.finder()
+		if err != nil {
+			return err
+		}
+		f, err := finder.Folder(context.TODO(), folder)
+		if err != nil {
+			return err
+		}
+		children, err := f.Children(context.TODO())
+		if err != nil {
+			return err
+		}
+		vmIds = nil
+		for _, child := range children {
+			if child.Reference().Type == "VirtualMachine" {
+				vmIds = append(vmIds, child.Reference().Value)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: fmt.Sprintf("failed to get the VMs of the folder: %v", err), Target: fmt.Sprintf("{VM Folder: %v}", folder)}
+	}
+
+	return vmIds, nil
+}
+
+// GetVMsByResourcePool returns the managed object ids of the VMs of the given resource pool
+func (c *Client) GetVMsByResourcePool(resourcePool string) ([]string, error) {
+
+	var vmIds []string
+	err := c.withSession(func() error {
+		finder, err := c.finder()
+		if err != nil {
+			return err
+		}
+		pool, err := finder.ResourcePool(context.TODO(), resourcePool)
+		if err != nil {
+			return err
+		}
+		var properties mo.ResourcePool
+		if err := pool.Properties(context.TODO(), pool.Reference(), []string{"vm"}, &properties); err != nil {
+			return err
+		}
+		vmIds = nil
+		for _, vm := range properties.Vm {
+			vmIds = append(vmIds, vm.Value)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: fmt.Sprintf("failed to get the VMs of the resource pool: %v", err), Target: fmt.Sprintf("{Resource Pool: %v}", resourcePool)}
+	}
+
+	return vmIds, nil
+}
+
+// GetVMsByName returns the managed object ids of the VMs of the given names or inventory paths
+func (c *Client) GetVMsByName(names []string) ([]string, error) {
+
+	var vmIds []string
+	err := c.withSession(func() error {
+		finder, err := c.finder()
+		if err != nil {
+			return err
+		}
+		vmIds = nil
+		for _, name := range names {
+			vm, err := finder.VirtualMachine(context.TODO(), name)
+			if err != nil {
+				return err
+			}
+			vmIds = append(vmIds, vm.Reference().Value)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: fmt.Sprintf("failed to get the VMs: %v", err), Target: fmt.Sprintf("{VM Names: %v}", strings.Join(names, ","))}
+	}
+
+	return vmIds, nil
+}
+
+// finder returns the inventory finder of the datacenter of the client
+func (c *Client) finder() (*find.Finder, error) {
+
+	finder := find.NewFinder(c.vimClient, true)
+	if c.datacenter == "" {
+		dc, err := finder.DefaultDatacenter(context.TODO())
+		if err != nil {
+			return nil, err
+		}
+		return finder.SetDatacenter(dc), nil
+	}
+
+	dc, err := finder.Datacenter(context.TODO(), c.datacenter)
+	if err != nil {
+		return nil, err
+	}
+	return finder.SetDatacenter(dc), nil
+}
+
+// contains checks whether the value is present in the list
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -1,73 +1,37 @@
 package vmware
 
 import (
-	"crypto/tls"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
 	"time"
 
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	"github.com/palantir/stacktrace"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
 )
 
-// StartVM starts a given powered-off VM
-func StartVM(vcenterServer, vmId, cookie string) error {
-
-	req, err := http.NewRequest("POST", "https://"+vcenterServer+"/rest/vcenter/vm/"+vmId+"/power/start", nil)
-	if err != nil {
-		return cerrors.Error{
-			ErrorCode: cerrors.ErrorTypeChaosRevert,
-			Reason:    fmt.Sprintf("failed to start VM: %v", err.Error()),
-			Target:    fmt.Sprintf("{VM ID: %v}", vmId),
-		}
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Cookie", cookie)
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-	}
-
-	client := &http.Client{Transport: tr}
-	resp, err := client.Do(req)
-	if err != nil {
-		return cerrors.Error{
-			ErrorCode: cerrors.ErrorTypeChaosRevert,
-			Reason:    fmt.Sprintf("failed to start VM: %v", err.Error()),
-			Target:    fmt.Sprintf("{VM ID: %v}", vmId),
-		}
-	}
-
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return cerrors.Error{
-				ErrorCode: cerrors.ErrorTypeChaosRevert,
-				Reason:    fmt.Sprintf("failed to start VM: %v", err.Error()),
-				Target:    fmt.Sprintf("{VM ID: %v}", vmId),
-			}
-		}
+// virtualMachine returns the vm of the given managed object id, like vm-42
+func (c *Client) virtualMachine(vmId string) *object.VirtualMachine {
+	return object.NewVirtualMachine(c.vimClient, types.ManagedObjectReference{Type: "VirtualMachine", Value: vmId})
+}
 
-		var errorResponse ErrorResponse
-		var reason string
+// runTask runs the vm operation and waits for its task to complete
+func (c *Client) runTask(errorCode cerrors.ErrorType, operation, vmId string, run func(vm *object.VirtualMachine) (*object.Task, error)) error {
 
-		err = json.Unmarshal(body, &errorResponse)
+	err := c.withSession(func() error {
+		task, err := run(c.virtualMachine(vmId))
 		if err != nil {
-			reason = fmt.Sprintf("failed to unmarshal error response: %v", err)
-		} else {
-			reason = fmt.Sprintf("failed to start VM: %v", errorResponse.MsgValue.MsgMessages[0].MsgDefaultMessage)
+			return err
 		}
-
+		return task.Wait(context.TODO())
+	})
+	if err != nil {
 		return cerrors.Error{
-			ErrorCode: cerrors.ErrorTypeChaosRevert,
-			Reason:    reason,
+			ErrorCode: errorCode,
+			Reason:    fmt.Sprintf("failed to %v VM: %v", operation, err),
 			Target:    fmt.Sprintf("{VM ID: %v}", vmId),
 		}
 	}
@@ -75,59 +39,45 @@ func StartVM(vcenterServer, vmId, cookie string) error {
 	return nil
 }
 
-// StopVM stops a given powered-on VM
-func StopVM(vcenterServer, vmId, cookie string) error {
-
-	req, err := http.NewRequest("POST", "https://"+vcenterServer+"/rest/vcenter/vm/"+vmId+"/power/stop", nil)
-	if err != nil {
-		return cerrors.Error{
-			ErrorCode: cerrors.ErrorTypeChaosInject,
-			Reason:    fmt.Sprintf("failed to stop VM: %v", err.Error()),
-			Target:    fmt.Sprintf("{VM ID: %v}", vmId),
-		}
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Cookie", cookie)
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-	}
-
-	client := &http.Client{Transport: tr}
-	resp, err := client.Do(req)
-	if err != nil {
-		return cerrors.Error{
-			ErrorCode: cerrors.ErrorTypeChaosInject,
-			Reason:    fmt.Sprintf("failed to stop VM: %v", err.Error()),
-			Target:    fmt.Sprintf("{VM ID: %v}", vmId),
-		}
-	}
+// PowerOn starts a given powered-off or suspended VM
+func (c *Client) PowerOn(vmId string) error {
+	return c.runTask(cerrors.ErrorTypeChaosRevert, "start", vmId, func(vm *object.VirtualMachine) (*object.Task, error) {
+		return vm.PowerOn(context.TODO())
+	})
+}
 
-	defer resp.Body.Close()
+// PowerOff stops a given powered-on VM
+func (c *Client) PowerOff(vmId string) error {
+	return c.runTask(cerrors.ErrorTypeChaosInject, "stop", vmId, func(vm *object.VirtualMachine) (*object.Task, error) {
+		return vm.PowerOff(context.TODO())
+	})
+}
 
-	if resp.StatusCode != http.StatusOK {
+// Suspend suspends a given powered-on VM, it is started back by PowerOn
+func (c *Client) Suspend(vmId string) error {
+	return c.runTask(cerrors.ErrorTypeChaosInject, "suspend", vmId, func(vm *object.VirtualMachine) (*object.Task, error) {
+		return vm.Suspend(context.TODO())
+	})
+}
 
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return cerrors.Error{
-				ErrorCode: cerrors.ErrorTypeChaosInject,
-				Reason:    fmt.Sprintf("failed to stop VM: %v", err.Error()),
-				Target:    fmt.Sprintf("{VM ID: %v}", vmId),
-			}
-		}
+// Reset resets a given powered-on VM, the VM is powered on once the reset completes
+func (c *Client) Reset(vmId string) error {
+	return c.runTask(cerrors.ErrorTypeChaosInject, "reset", vmId, func(vm *object.VirtualMachine) (*object.Task, error) {
+		return vm.Reset(context.TODO())
+	})
+}
 
-		var errorResponse ErrorResponse
-		var reason string
+// ShutdownGuest issues the shutdown of the guest operating system of a given powered-on VM, the VMware tools should be running in the guest
+// the shutdown is only initiated, the VM can be waited for to get in POWERED_OFF state
+func (c *Client) ShutdownGuest(vmId string) error {
 
-		err = json.Unmarshal(body, &errorResponse)
-		if err != nil {
-			reason = fmt.Sprintf("failed to unmarshal error response: %v", err)
-		} else {
-			reason = fmt.Sprintf("failed to stop VM: %v", errorResponse.MsgValue.MsgMessages[0].MsgDefaultMessage)
-		}
+	err := c.withSession(func() error {
+		return c.virtualMachine(vmId).ShutdownGuest(context.TODO())
+	})
+	if err != nil {
 		return cerrors.Error{
 			ErrorCode: cerrors.ErrorTypeChaosInject,
-			Reason:    reason,
+			Reason:    fmt.Sprintf("failed to shutdown the guest of VM: %v", err),
 			Target:    fmt.Sprintf("{VM ID: %v}", vmId),
 		}
 	}
@@ -135,56 +85,65 @@ func StopVM(vcenterServer, vmId, cookie string) error {
 	return nil
 }
 
-// WaitForVMStart waits for the given VM to attain the POWERED_ON state
-func WaitForVMStart(timeout, delay int, vcenterServer, vmId, cookie string) error {
-
-	log.Infof("[Status]: Checking %v VM status", vmId)
-	return retry.Times(uint(timeout / delay)).
-		Wait(time.Duration(delay) * time.Second).
-		Try(func(attempt uint) error {
+// CreateSnapshot creates the snapshot of a given VM, the memory of the VM is included if memory is set
+func (c *Client) CreateSnapshot(vmId, snapshotName, description string, memory bool) error {
+	return c.runTask(cerrors.ErrorTypeChaosInject, "snapshot", vmId, func(vm *object.VirtualMachine) (*object.Task, error) {
+		return vm.CreateSnapshot(context.TODO(), snapshotName, description, memory, false)
+	})
+}
 
-			vmStatus, err := GetVMStatus(vcenterServer, vmId, cookie)
-			if err != nil {
-				return stacktrace.Propagate(err, "failed to get VM status")
-			}
+// RevertToSnapshot reverts a given VM to its snapshot of the given name
+// the VM gets the power state of the snapshot, it is powered off if the snapshot doesn't include the memory
+func (c *Client) RevertToSnapshot(vmId, snapshotName string) error {
+	return c.runTask(cerrors.ErrorTypeChaosRevert, "revert to the snapshot of", vmId, func(vm *object.VirtualMachine) (*object.Task, error) {
+		return vm.RevertToSnapshot(context.TODO(), snapshotName, true)
+	})
+}
 
-			if vmStatus != "POWERED_ON" {
-				log.Infof("%v VM state is %v", vmId, vmStatus)
-				return cerrors.Error{
-					ErrorCode: cerrors.ErrorTypeChaosRevert,
-					Reason:    "VM is not in POWERED_ON state",
-					Target:    fmt.Sprintf("{VM ID: %v}", vmId),
-				}
-			}
+// RemoveSnapshot removes the snapshot of the given name from a given VM, the children of the snapshot are kept
+func (c *Client) RemoveSnapshot(vmId, snapshotName string) error {
+	return c.runTask(cerrors.ErrorTypeChaosRevert, "remove the snapshot of", vmId, func(vm *object.VirtualMachine) (*object.Task, error) {
+		return vm.RemoveSnapshot(context.TODO(), snapshotName, false, nil)
+	})
+}
 
-			log.Infof("%v VM state is %v", vmId, vmStatus)
-			return nil
-		})
+// WaitForVMStart waits for the given VM to attain the POWERED_ON state
+func WaitForVMStart(timeout, delay int, client *Client, vmId string) error {
+	return waitForVMState(timeout, delay, client, vmId, "POWERED_ON", cerrors.ErrorTypeChaosRevert)
 }
 
 // WaitForVMStop waits for the given VM to attain the POWERED_OFF state
-func WaitForVMStop(timeout, delay int, vcenterServer, vmId, cookie string) error {
+func WaitForVMStop(timeout, delay int, client *Client, vmId string) error {
+	return waitForVMState(timeout, delay, client, vmId, "POWERED_OFF", cerrors.ErrorTypeChaosInject)
+}
+
+// WaitForVMSuspend waits for the given VM to attain the SUSPENDED state
+func WaitForVMSuspend(timeout, delay int, client *Client, vmId string) error {
+	return waitForVMState(timeout, delay, client, vmId, "SUSPENDED", cerrors.ErrorTypeChaosInject)
+}
+
+// waitForVMState waits for the given VM to attain the given power state
+func waitForVMState(timeout, delay int, client *Client, vmId, state string, errorCode cerrors.ErrorType) error {
 
 	log.Infof("[Status]: Checking %v VM status", vmId)
 	return retry.Times(uint(timeout / delay)).
 		Wait(time.Duration(delay) * time.Second).
 		Try(func(attempt uint) error {
 
-			vmStatus, err := GetVMStatus(vcenterServer, vmId, cookie)
+			vmStatus, err := client.GetPowerState(vmId)
 			if err != nil {
 				return stacktrace.Propagate(err, "failed to get VM status")
 			}
 
-			if vmStatus != "POWERED_OFF" {
-				log.Infof("%v VM state is %v", vmId, vmStatus)
+			log.Infof("%v VM state is %v", vmId, vmStatus)
+			if vmStatus != state {
 				return cerrors.Error{
-					ErrorCode: cerrors.ErrorTypeChaosInject,
-					Reason:    "VM is not in POWERED_OFF state",
+					ErrorCode: errorCode,
+					Reason:    fmt.Sprintf("VM is not in %v state", state),
 					Target:    fmt.Sprintf("{VM ID: %v}", vmId),
 				}
 			}
 
-			log.Infof("%v VM state is %v", vmId, vmStatus)
 			return nil
 		})
 }
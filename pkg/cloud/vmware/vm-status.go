@@ -1,94 +1,53 @@
 package vmware
 
 import (
-	"crypto/tls"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
 	"strings"
 
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/palantir/stacktrace"
+	"github.com/vmware/govmomi/vim25/types"
 )
 
-// GetVMStatus returns the current status of a given VM
-func GetVMStatus(vcenterServer, vmId, cookie string) (string, error) {
-
-	type VMStatus struct {
-		MsgValue struct {
-			MsgState string `json:"state"`
-		} `json:"value"`
-	}
-
-	req, err := http.NewRequest("GET", "https://"+vcenterServer+"/rest/vcenter/vm/"+vmId+"/power/", nil)
-	if err != nil {
-		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks,
-			Reason: fmt.Sprintf("failed to get VM status: %v", err.Error()),
-			Target: fmt.Sprintf("{VM ID: %v}", vmId),
-		}
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Cookie", cookie)
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-	}
-
-	client := &http.Client{Transport: tr}
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", cerrors.Error{
-			ErrorCode: cerrors.ErrorTypeStatusChecks,
-			Reason:    fmt.Sprintf("failed to get VM status: %v", err.Error()),
-			Target:    fmt.Sprintf("{VM ID: %v}", vmId),
-		}
-	}
+// powerStates maps the power states of the vSphere api to the power states of the rest api of the vcenter
+var powerStates = map[types.VirtualMachinePowerState]string{
+	types.VirtualMachinePowerStatePoweredOn:  "POWERED_ON",
+	types.VirtualMachinePowerStatePoweredOff: "POWERED_OFF",
+	types.VirtualMachinePowerStateSuspended:  "SUSPENDED",
+}
 
-	defer resp.Body.Close()
+// GetPowerState returns the current power state of a given VM, which is one of POWERED_ON, POWERED_OFF and SUSPENDED
+func (c *Client) GetPowerState(vmId string) (string, error) {
 
-	body, err := io.ReadAll(resp.Body)
+	var powerState types.VirtualMachinePowerState
+	err := c.withSession(func() error {
+		var err error
+		powerState, err = c.virtualMachine(vmId).PowerState(context.TODO())
+		return err
+	})
 	if err != nil {
 		return "", cerrors.Error{
 			ErrorCode: cerrors.ErrorTypeStatusChecks,
-			Reason:    fmt.Sprintf("failed to get VM status: %v", err.Error()),
-			Target:    fmt.Sprintf("{VM ID: %v}", vmId),
-		}
-	}
-
-	if resp.StatusCode != http.StatusOK {
-
-		var errorResponse ErrorResponse
-		var reason string
-
-		err = json.Unmarshal(body, &errorResponse)
-		if err != nil {
-			reason = fmt.Sprintf("failed to unmarshal error response: %v", err)
-		} else {
-			reason = fmt.Sprintf("failed to fetch VM status: %v", errorResponse.MsgValue.MsgMessages[0].MsgDefaultMessage)
-		}
-
-		return "", cerrors.Error{
-			ErrorCode: cerrors.ErrorTypeStatusChecks,
-			Reason:    reason,
+			Reason:    fmt.Sprintf("failed to get VM status: %v", err),
 			Target:    fmt.Sprintf("{VM ID: %v}", vmId),
 		}
 	}
 
-	var vmStatus VMStatus
-	if err = json.Unmarshal(body, &vmStatus); err != nil {
+	state, ok := powerStates[powerState]
+	if !ok {
 		return "", cerrors.Error{
 			ErrorCode: cerrors.ErrorTypeStatusChecks,
-			Reason:    fmt.Sprintf("failed to unmarshal VM status: %v", err),
+			Reason:    fmt.Sprintf("unknown VM power state: %v", powerState),
 			Target:    fmt.Sprintf("{VM ID: %v}", vmId),
 		}
 	}
 
-	return vmStatus.MsgValue.MsgState, nil
+	return state, nil
 }
 
 // VMStatusCheck validates the steady state for the given vm ids
-func VMStatusCheck(vcenterServer, vmIds, cookie string) error {
+func VMStatusCheck(client *Client, vmIds string) error {
 
 	vmIdList := strings.Split(vmIds, ",")
 	if vmIds == "" || len(vmIdList) == 0 {
@@ -100,7 +59,7 @@ func VMStatusCheck(vcenterServer, vmIds, cookie string) error {
 
 	for _, vmId := range vmIdList {
 
-		vmStatus, err := GetVMStatus(vcenterServer, vmId, cookie)
+		vmStatus, err := client.GetPowerState(vmId)
 		if err != nil {
 			return stacktrace.Propagate(err, "failed to get status of VM")
 		}
@@ -0,0 +1,23 @@
+package types
+
+import (
+	clientTypes "k8s.io/apimachinery/pkg/types"
+)
+
+// ExperimentDetails is for collecting all the experiment-related details
+type ExperimentDetails struct {
+	ExperimentName           string
+	EngineName               string
+	ChaosDuration            int
+	RampTime                 int
+	ChaosUID                 clientTypes.UID
+	InstanceID               string
+	ChaosNamespace           string
+	ChaosPodName             string
+	Timeout                  int
+	Delay                    int
+	GCPProjectID             string
+	Zone                     string
+	InstanceLabel            string
+	TargetVMInstanceNameList []string
+}
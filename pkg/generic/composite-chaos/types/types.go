@@ -0,0 +1,30 @@
+package types
+
+import (
+	clientTypes "k8s.io/apimachinery/pkg/types"
+)
+
+// ExperimentDetails is for collecting all the experiment-related details
+type ExperimentDetails struct {
+	ExperimentName string
+	EngineName     string
+	ChaosDuration  int
+	ChaosUID       clientTypes.UID
+	InstanceID     string
+	ChaosNamespace string
+	ChaosPodName   string
+	Timeout        int
+	Delay          int
+	FaultsSpec     string
+	Faults         []FaultSpec
+}
+
+// FaultSpec is the fault injected by the composite chaos
+type FaultSpec struct {
+	// Name is the name of the fault, e.g. pod-network-latency
+	Name string `yaml:"name"`
+	// Offset is the delay in seconds, after which the fault is injected
+	Offset int `yaml:"offset"`
+	// Env contains the envs of the fault, the envs of the experiment are used for the envs which are not provided
+	Env map[string]string `yaml:"env"`
+}
@@ -35,4 +35,8 @@ func GetENV(experimentDetails *experimentTypes.ExperimentDetails) {
 	experimentDetails.DataBlockSize, _ = strconv.Atoi(types.Getenv("DATA_BLOCK_SIZE", "256"))
 	experimentDetails.NodeLabel = types.Getenv("NODE_LABEL", "")
 	experimentDetails.SetHelperData = types.Getenv("SET_HELPER_DATA", "true")
+	experimentDetails.VolumeMountPath = types.Getenv("VOLUME_MOUNT_PATH", "")
+	experimentDetails.PVCName = types.Getenv("PVC_NAME", "")
+	experimentDetails.FillMode = types.Getenv("FILL_MODE", "hold")
+	experimentDetails.FillStepInterval, _ = strconv.Atoi(types.Getenv("FILL_STEP_INTERVAL", "10"))
 }
@@ -36,4 +36,8 @@ type ExperimentDetails struct {
 	NodeLabel                     string
 	IsTargetContainerProvided     bool
 	SetHelperData                 string
+	VolumeMountPath               string
+	PVCName                       string
+	FillMode                      string
+	FillStepInterval              int
 }
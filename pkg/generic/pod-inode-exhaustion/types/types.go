@@ -0,0 +1,34 @@
+package types
+
+import (
+	clientTypes "k8s.io/apimachinery/pkg/types"
+)
+
+// ExperimentDetails is for collecting all the experiment-related details
+type ExperimentDetails struct {
+	ExperimentName                string
+	EngineName                    string
+	ChaosDuration                 int
+	RampTime                      int
+	ChaosUID                      clientTypes.UID
+	InstanceID                    string
+	ChaosNamespace                string
+	ChaosPodName                  string
+	TargetContainer               string
+	ContainerRuntime              string
+	SocketPath                    string
+	Timeout                       int
+	Delay                         int
+	LIBImage                      string
+	LIBImagePullPolicy            string
+	TargetPods                    string
+	PodsAffectedPerc              string
+	Sequence                      string
+	ChaosServiceAccount           string
+	TerminationGracePeriodSeconds int
+	NodeLabel                     string
+	IsTargetContainerProvided     bool
+	SetHelperData                 string
+	VolumeMountPath               string
+	FillPercentage                string
+}
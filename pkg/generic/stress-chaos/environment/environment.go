@@ -51,5 +51,17 @@ func GetENV(experimentDetails *experimentTypes.ExperimentDetails, expName string
 		experimentDetails.VolumeMountPath = types.Getenv("VOLUME_MOUNT_PATH", "")
 		experimentDetails.CPUcores = types.Getenv("CPU_CORES", "0")
 		experimentDetails.StressType = "pod-io-stress"
+
+	case "pod-cpu-throttle":
+		experimentDetails.CPUQuotaPercentage = types.Getenv("CPU_QUOTA_PERCENTAGE", "20")
+		experimentDetails.StressType = "pod-cpu-throttle"
+
+	case "pod-fd-exhaustion":
+		experimentDetails.ExhaustionPercentage = types.Getenv("EXHAUSTION_PERCENTAGE", "90")
+		experimentDetails.StressType = "pod-fd-exhaustion"
+
+	case "pod-pid-exhaustion":
+		experimentDetails.ExhaustionPercentage = types.Getenv("EXHAUSTION_PERCENTAGE", "90")
+		experimentDetails.StressType = "pod-pid-exhaustion"
 	}
 }
@@ -41,4 +41,6 @@ type ExperimentDetails struct {
 	IsTargetContainerProvided       bool
 	NodeLabel                       string
 	SetHelperData                   string
+	CPUQuotaPercentage              string
+	ExhaustionPercentage            string
 }
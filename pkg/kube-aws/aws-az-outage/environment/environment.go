@@ -0,0 +1,32 @@
+package environment
+
+import (
+	"strconv"
+	"strings"
+
+	clientTypes "k8s.io/apimachinery/pkg/types"
+
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/kube-aws/aws-az-outage/types"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+)
+
+// GetENV fetches all the env variables from the runner pod
+func GetENV(experimentDetails *experimentTypes.ExperimentDetails) {
+	experimentDetails.ExperimentName = types.Getenv("EXPERIMENT_NAME", "aws-az-outage")
+	experimentDetails.ChaosNamespace = types.Getenv("CHAOS_NAMESPACE", "litmus")
+	experimentDetails.EngineName = types.Getenv("CHAOSENGINE", "")
+	experimentDetails.ChaosDuration, _ = strconv.Atoi(types.Getenv("TOTAL_CHAOS_DURATION", "60"))
+	experimentDetails.RampTime, _ = strconv.Atoi(types.Getenv("RAMP_TIME", "0"))
+	experimentDetails.ChaosUID = clientTypes.UID(types.Getenv("CHAOS_UID", ""))
+	experimentDetails.InstanceID = types.Getenv("INSTANCE_ID", "")
+	experimentDetails.ChaosPodName = types.Getenv("POD_NAME", "")
+	experimentDetails.Delay, _ = strconv.Atoi(types.Getenv("STATUS_CHECK_DELAY", "2"))
+	experimentDetails.Timeout, _ = strconv.Atoi(types.Getenv("STATUS_CHECK_TIMEOUT", "180"))
+	experimentDetails.Region = types.Getenv("REGION", "")
+	experimentDetails.Zone = strings.TrimSpace(types.Getenv("ZONE", ""))
+	experimentDetails.Ec2InstanceTag = strings.TrimSpace(types.Getenv("EC2_INSTANCE_TAG", ""))
+	experimentDetails.OutageMode = strings.ToLower(types.Getenv("OUTAGE_MODE", "isolate"))
+	experimentDetails.IsolationMode = strings.ToLower(types.Getenv("ISOLATION_MODE", "security-group"))
+	experimentDetails.DenyAllSecurityGroupID = strings.TrimSpace(types.Getenv("DENY_ALL_SECURITY_GROUP_ID", ""))
+	experimentDetails.DetachVolumes, _ = strconv.ParseBool(types.Getenv("DETACH_VOLUMES", "false"))
+}
@@ -0,0 +1,28 @@
+package types
+
+import (
+	"github.com/aws/aws-sdk-go/service/ec2"
+	clientTypes "k8s.io/apimachinery/pkg/types"
+)
+
+// ExperimentDetails is for collecting all the experiment-related details
+type ExperimentDetails struct {
+	ExperimentName         string
+	EngineName             string
+	RampTime               int
+	ChaosDuration          int
+	ChaosUID               clientTypes.UID
+	InstanceID             string
+	ChaosNamespace         string
+	ChaosPodName           string
+	Timeout                int
+	Delay                  int
+	Region                 string
+	Zone                   string
+	Ec2InstanceTag         string
+	OutageMode             string
+	IsolationMode          string
+	DenyAllSecurityGroupID string
+	DetachVolumes          bool
+	TargetInstances        []*ec2.Instance
+}
@@ -0,0 +1,25 @@
+package types
+
+import (
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	clientTypes "k8s.io/apimachinery/pkg/types"
+)
+
+// ExperimentDetails is for collecting all the experiment-related details
+type ExperimentDetails struct {
+	ExperimentName      string
+	EngineName          string
+	RampTime            int
+	ChaosDuration       int
+	ChaosUID            clientTypes.UID
+	InstanceID          string
+	ChaosNamespace      string
+	ChaosPodName        string
+	Timeout             int
+	Delay               int
+	Region              string
+	TargetGroupARN      string
+	TargetIDs           string
+	TargetsAffectedPerc int
+	TargetDescriptions  []*elbv2.TargetDescription
+}
@@ -0,0 +1,270 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/result"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/palantir/stacktrace"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// PolicyKey is the key of the policy inside the policy configmap
+	PolicyKey = "policy.yaml"
+	// ApprovedExperimentsAnnotation is the chaosengine annotation which approves the experiments requiring an approval
+	ApprovedExperimentsAnnotation = "litmuschaos.io/approved-experiments"
+)
+
+// affectedPercentageENVs are the envs which contain the percentage of the targets affected by a run
+var affectedPercentageENVs = []string{"PODS_AFFECTED_PERC", "NODES_AFFECTED_PERC", "INSTANCE_AFFECTED_PERC", "VOLUME_AFFECTED_PERC", "DISK_AFFECTED_PERC"}
+
+// Policy contains the cluster level guardrails of the chaos experiments
+// it is read from the CHAOS_POLICY_CONFIGMAP (default: litmus-chaos-policy) configmap in CHAOS_NAMESPACE, e.g.
+//
+//	deniedNamespaces: [kube-system, litmus]
+//	deniedLabels: ["tier=critical", "chaos.litmuschaos.io/protected"]
+//	maxPods: 5
+//	maxNodes: 1
+//	maxPercentage: 50
+//	allowedWindows:
+//	- days: [Mon, Tue, Wed, Thu, Fri]
+//	  start: "09:00"
+//	  end: "17:00"
+//	  timezone: Europe/Berlin
+//	blockedWindows:
+//	- schedule: "0 0 20 12 *"
+//	  duration: 336h
+//	  timezone: Europe/Berlin
+//	approvalRequired: [node-drain, node-restart]
+type Policy struct {
+	DeniedNamespaces []string     `yaml:"deniedNamespaces"`
+	DeniedLabels     []string     `yaml:"deniedLabels"`
+	MaxPods          int          `yaml:"maxPods"`
+	MaxNodes         int          `yaml:"maxNodes"`
+	MaxPercentage    int          `yaml:"maxPercentage"`
+	AllowedWindows   []TimeWindow `yaml:"allowedWindows"`
+	BlockedWindows   []TimeWindow `yaml:"blockedWindows"`
+	ApprovalRequired []string     `yaml:"approvalRequired"`
+
+	deniedSelectors []labels.Selector
+}
+
+// current is the policy of the run, it is loaded once by Enforce and used by the target selection
+var current *Policy
+
+// Enforce loads the chaos policy and validates the run against it, before the experiment is started
+// the violation is recorded inside the chaosresult and the chaosengine, so that the run fails without injecting the chaos
+// the time windows are re-checked during the chaos and the chaos is aborted once the run is no longer allowed
+func Enforce(clients clients.ClientSets, experimentName string) error {
+	chaosDetails := types.ChaosDetails{}
+	types.InitialiseChaosVariables(&chaosDetails)
+
+	// the policy is required only if its configmap is provided explicitly
+	required := os.Getenv("CHAOS_POLICY_CONFIGMAP") != ""
+	policy, err := Load(clients, chaosDetails.ChaosNamespace, types.Getenv("CHAOS_POLICY_CONFIGMAP", "litmus-chaos-policy"), required)
+	if err != nil {
+		if cerrors.GetErrorType(err) == cerrors.ErrorTypePolicyViolation {
+			recordViolation(clients, &chaosDetails, err)
+		}
+		return stacktrace.Propagate(err, "could not load chaos policy")
+	}
+	if policy == nil {
+		return nil
+	}
+	current = policy
+
+	if err := policy.validateExperiment(clients, experimentName, chaosDetails.ChaosNamespace, time.Now()); err != nil {
+		recordViolation(clients, &chaosDetails, err)
+		return err
+	}
+	log.Info("[Policy]: The experiment is allowed by the chaos policy")
+
+	if len(policy.AllowedWindows) != 0 || len(policy.BlockedWindows) != 0 {
+		generatePolicyEvent(clients, &chaosDetails, "Chaos is allowed by the time windows of the chaos policy", "Normal")
+		go policy.watchWindows(clients, &chaosDetails)
+	}
+	return nil
+}
+
+// Load reads the chaos policy from the given configmap, it returns nil if the policy is not present
+// the required policy fails with the policy violation if the configmap can't be read by the service account,
+// otherwise the service accounts without the get access of configmaps are treated as having no policy
+func Load(clients clients.ClientSets, namespace, name string, required bool) (*Policy, error) {
+	cm, err := clients.KubeClient.CoreV1().ConfigMaps(namespace).Get(context.Background(), name, v1.GetOptions{})
+	if err != nil {
+		switch {
+		case k8serrors.IsNotFound(err):
+			return nil, nil
+		case k8serrors.IsForbidden(err):
+			if !required {
+				log.Warnf("[Policy]: Unable to read the %s chaos policy configmap, the run is not guarded by a chaos policy: %v", name, err)
+				return nil, nil
+			}
+			// the run is not allowed if the explicitly provided policy can't be read, otherwise the guardrails would be skipped silently
+			return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypePolicyViolation, Target: fmt.Sprintf("{configmap: %s, namespace: %s}", name, namespace), Reason: fmt.Sprintf("unable to read the chaos policy, the service account requires the get access of configmaps: %s", err.Error())}
+		}
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{configmap: %s, namespace: %s}", name, namespace), Reason: err.Error()}
+	}
+	return Parse(cm.Data[PolicyKey])
+}
+
+// Parse parses and validates the chaos policy
+func Parse(data string) (*Policy, error) {
+	policy := &Policy{}
+	if err := yaml.UnmarshalStrict([]byte(data), policy); err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("invalid chaos policy: %s", err.Error())}
+	}
+	for _, label := range policy.DeniedLabels {
+		selector, err := labels.Parse(label)
+		if err != nil {
+			return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("invalid denied label '%s' in chaos policy: %s", label, err.Error())}
+		}
+		policy.deniedSelectors = append(policy.deniedSelectors, selector)
+	}
+	for _, window := range append(append([]TimeWindow{}, policy.AllowedWindows...), policy.BlockedWindows...) {
+		if _, err := window.contains(time.Now()); err != nil {
+			return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("invalid time window in chaos policy: %s", err.Error())}
+		}
+	}
+	return policy, nil
+}
+
+// ValidatePods validates the target pods of the run against the chaos policy, if it is loaded
+func ValidatePods(pods []corev1.Pod) error {
+	if current == nil {
+		return nil
+	}
+	if current.MaxPods > 0 && len(pods) > current.MaxPods {
+		return violation("", fmt.Sprintf("%d target pods exceed the maximum of %d pods per run", len(pods), current.MaxPods))
+	}
+	for _, pod := range pods {
+		target := fmt.Sprintf("{podName: %s, namespace: %s}", pod.Name, pod.Namespace)
+		if current.isNamespaceDenied(pod.Namespace) {
+			return violation(target, fmt.Sprintf("namespace %s is denied", pod.Namespace))
+		}
+		if label := current.deniedLabel(pod.Labels); label != "" {
+			return violation(target, fmt.Sprintf("pods with label '%s' are denied", label))
+		}
+	}
+	return nil
+}
+
+// ValidateNodes validates the target nodes of the run against the chaos policy, if it is loaded
+func ValidateNodes(nodes []string, clients clients.ClientSets) error {
+	if current == nil {
+		return nil
+	}
+	if current.MaxNodes > 0 && len(nodes) > current.MaxNodes {
+		return violation("", fmt.Sprintf("%d target nodes exceed the maximum of %d nodes per run", len(nodes), current.MaxNodes))
+	}
+	if len(current.deniedSelectors) == 0 {
+		return nil
+	}
+	for _, name := range nodes {
+		node, err := clients.GetNode(name, 180, 2)
+		if err != nil {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{nodeName: %s}", name), Reason: err.Error()}
+		}
+		if label := current.deniedLabel(node.Labels); label != "" {
+			return violation(fmt.Sprintf("{nodeName: %s}", name), fmt.Sprintf("nodes with label '%s' are denied", label))
+		}
+	}
+	return nil
+}
+
+// validateExperiment validates the inputs of the run, which are known before the targets are selected
+func (p *Policy) validateExperiment(clients clients.ClientSets, experimentName, chaosNamespace string, now time.Time) error {
+	if err := p.checkWindows(now); err != nil {
+		return err
+	}
+
+	for _, target := range types.GetTargets(strings.TrimSpace(types.Getenv("TARGETS", ""))) {
+		if p.isNamespaceDenied(target.Namespace) {
+			return violation(fmt.Sprintf("{namespace: %s}", target.Namespace), fmt.Sprintf("namespace %s is denied", target.Namespace))
+		}
+	}
+
+	if p.MaxPercentage > 0 {
+		for _, env := range affectedPercentageENVs {
+			if percentage := getMaxPercentage(os.Getenv(env)); percentage > p.MaxPercentage {
+				return violation("", fmt.Sprintf("%s: %d exceeds the maximum of %d%% per run", env, percentage, p.MaxPercentage))
+			}
+		}
+	}
+
+	if contains(p.ApprovalRequired, experimentName) {
+		engineName := types.Getenv("CHAOSENGINE", "")
+		if engineName == "" {
+			return violation("", fmt.Sprintf("%s experiment requires an approval annotation on the chaosengine", experimentName))
+		}
+		engine, err := clients.GetChaosEngine(&types.ChaosDetails{EngineName: engineName, ChaosNamespace: chaosNamespace, Timeout: 180, Delay: 2})
+		if err != nil {
+			return stacktrace.Propagate(err, "could not get chaosengine")
+		}
+		if !contains(strings.Split(engine.Annotations[ApprovedExperimentsAnnotation], ","), experimentName) {
+			return violation(fmt.Sprintf("{chaosengine: %s, namespace: %s}", engineName, chaosNamespace), fmt.Sprintf("%s experiment requires the '%s' annotation on the chaosengine", experimentName, ApprovedExperimentsAnnotation))
+		}
+	}
+	return nil
+}
+
+func (p *Policy) isNamespaceDenied(namespace string) bool {
+	return namespace != "" && contains(p.DeniedNamespaces, namespace)
+}
+
+// deniedLabel returns the denied label which matches the given labels
+func (p *Policy) deniedLabel(set map[string]string) string {
+	for i, selector := range p.deniedSelectors {
+		if selector.Matches(labels.Set(set)) {
+			return p.DeniedLabels[i]
+		}
+	}
+	return ""
+}
+
+// getMaxPercentage returns the percentage of the affected targets, the upper bound is used for the range
+func getMaxPercentage(value string) int {
+	bounds := strings.Split(strings.TrimSpace(value), "-")
+	percentage, _ := strconv.Atoi(bounds[len(bounds)-1])
+	return percentage
+}
+
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if strings.TrimSpace(v) == value {
+			return true
+		}
+	}
+	return false
+}
+
+func violation(target, reason string) error {
+	return cerrors.Error{ErrorCode: cerrors.ErrorTypePolicyViolation, Target: target, Reason: "chaos policy violated: " + reason}
+}
+
+// recordViolation fails the chaosresult of the run with the policy violation and generates the chaosengine event
+func recordViolation(clients clients.ClientSets, chaosDetails *types.ChaosDetails, err error) {
+	resultDetails := types.ResultDetails{}
+	eventsDetails := types.EventDetails{}
+
+	types.SetResultAttributes(&resultDetails, *chaosDetails)
+	generatePolicyEvent(clients, chaosDetails, err.Error(), "Warning")
+
+	if resultErr := result.ChaosResult(chaosDetails, clients, &resultDetails, "SOT"); resultErr != nil {
+		log.Errorf("Unable to create the chaosresult, err: %v", resultErr)
+	}
+	result.RecordAfterFailure(chaosDetails, &resultDetails, err, clients, &eventsDetails)
+}
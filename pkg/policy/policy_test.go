@@ -0,0 +1,160 @@
+package policy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const testPolicy = `
+deniedNamespaces: [kube-system]
+deniedLabels: ["tier=critical"]
+maxPods: 2
+allowedWindows:
+- days: [Mon, Tue, Wed, Thu, Fri]
+  start: "22:00"
+  end: "06:00"
+  timezone: UTC
+`
+
+func TestParse(t *testing.T) {
+	_, err := Parse("deniedNamespace: [kube-system]")
+	assert.Error(t, err, "unknown fields should be rejected")
+
+	_, err = Parse("deniedLabels: ['tier in (']")
+	assert.Error(t, err)
+
+	_, err = Parse("allowedWindows: [{start: '25:00', end: '06:00'}]")
+	assert.Error(t, err)
+}
+
+func TestTimeWindow(t *testing.T) {
+	policy, err := Parse(testPolicy)
+	require.NoError(t, err)
+	window := policy.AllowedWindows[0]
+
+	tests := []struct {
+		now  string
+		want bool
+	}{
+		{now: "2026-10-19T23:00:00Z", want: true},  // Monday night
+		{now: "2026-10-20T05:59:00Z", want: true},  // after midnight of Monday's window
+		{now: "2026-10-20T06:00:00Z", want: false}, // window closed
+		{now: "2026-10-19T05:00:00Z", want: false}, // after midnight of Sunday's window
+	}
+	for _, tt := range tests {
+		now, _ := time.Parse(time.RFC3339, tt.now)
+		got, err := window.contains(now)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, got, tt.now)
+	}
+}
+
+func TestValidatePods(t *testing.T) {
+	policy, err := Parse(testPolicy)
+	require.NoError(t, err)
+	current = policy
+	defer func() { current = nil }()
+
+	newPod := func(namespace string, labels map[string]string) corev1.Pod {
+		return corev1.Pod{ObjectMeta: v1.ObjectMeta{Name: "app", Namespace: namespace, Labels: labels}}
+	}
+
+	assert.NoError(t, ValidatePods([]corev1.Pod{newPod("default", nil)}))
+
+	err = ValidatePods([]corev1.Pod{newPod("kube-system", nil)})
+	assert.Equal(t, cerrors.ErrorTypePolicyViolation, cerrors.GetErrorType(err))
+
+	err = ValidatePods([]corev1.Pod{newPod("default", map[string]string{"tier": "critical"})})
+	assert.Equal(t, cerrors.ErrorTypePolicyViolation, cerrors.GetErrorType(err))
+
+	err = ValidatePods([]corev1.Pod{newPod("default", nil), newPod("default", nil), newPod("default", nil)})
+	assert.Equal(t, cerrors.ErrorTypePolicyViolation, cerrors.GetErrorType(err))
+}
+
+func TestCheckWindows(t *testing.T) {
+	policy, err := Parse(`
+blockedWindows:
+- schedule: "0 0 20 12 *"
+  duration: 48h
+  timezone: Europe/Berlin
+`)
+	require.NoError(t, err)
+
+	tests := []struct {
+		now     string
+		blocked bool
+	}{
+		{now: "2026-12-19T22:59:00Z", blocked: false},
+		{now: "2026-12-19T23:00:00Z", blocked: true}, // midnight in Berlin
+		{now: "2026-12-21T22:59:00Z", blocked: true},
+		{now: "2026-12-21T23:00:00Z", blocked: false},
+	}
+	for _, tt := range tests {
+		now, _ := time.Parse(time.RFC3339, tt.now)
+		err := policy.checkWindows(now)
+		assert.Equal(t, tt.blocked, err != nil, tt.now)
+	}
+
+	_, err = Parse("blockedWindows: [{schedule: '0 0 20 12 *'}]")
+	assert.Error(t, err, "schedule without duration should be rejected")
+}
+
+func TestLoad(t *testing.T) {
+	tests := map[string]struct {
+		status   int
+		required bool
+		wantErr  bool
+	}{
+		"missing policy":            {status: http.StatusNotFound},
+		"missing required policy":   {status: http.StatusNotFound, required: true},
+		"forbidden policy":          {status: http.StatusForbidden},
+		"forbidden required policy": {status: http.StatusForbidden, required: true, wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// the api server rejects the configmap request with the given status
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				_ = json.NewEncoder(w).Encode(v1.Status{
+					TypeMeta: v1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+					Status:   v1.StatusFailure,
+					Code:     int32(tt.status),
+					Reason:   map[int]v1.StatusReason{http.StatusNotFound: v1.StatusReasonNotFound, http.StatusForbidden: v1.StatusReasonForbidden}[tt.status],
+				})
+			}))
+			defer server.Close()
+			kubeClient, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+			require.NoError(t, err)
+
+			policy, err := Load(clients.ClientSets{KubeClient: kubeClient}, "litmus", "litmus-chaos-policy", tt.required)
+			assert.Nil(t, policy)
+			if tt.wantErr {
+				assert.Equal(t, cerrors.ErrorTypePolicyViolation, cerrors.GetErrorType(err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAbortReason(t *testing.T) {
+	defer func() { abortReason = "" }()
+
+	p, err := Parse(testPolicy)
+	require.NoError(t, err)
+	setAbortReason(p.checkWindows(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "Chaos aborted by the chaos policy, chaos policy violated: chaos is not allowed at 2024-01-01T12:00:00Z, outside of the allowed windows", AbortReason())
+}
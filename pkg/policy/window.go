@@ -0,0 +1,202 @@
+package policy
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/events"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+)
+
+// windowCheckInterval is the interval at which the time windows are re-checked during the chaos
+var windowCheckInterval = 10 * time.Second
+
+// TimeWindow is the window of time in which the chaos is allowed or blocked
+// the window is either a cron schedule, which opens the window, along with its duration:
+//
+//	schedule: "0 22 * * 1-5"
+//	duration: 8h
+//
+// or the time of the day, the window spans midnight if the end is before the start:
+//
+//	days: [Mon, Tue, Wed, Thu, Fri]
+//	start: "22:00"
+//	end: "06:00"
+//
+// all the days are included if days are not provided, both are evaluated in the timezone (default: UTC)
+type TimeWindow struct {
+	Schedule string   `yaml:"schedule"`
+	Duration string   `yaml:"duration"`
+	Days     []string `yaml:"days"`
+	Start    string   `yaml:"start"`
+	End      string   `yaml:"end"`
+	Timezone string   `yaml:"timezone"`
+}
+
+// checkWindows returns the violation if the given time is outside of all the allowed windows or inside any blocked window
+func (p *Policy) checkWindows(now time.Time) error {
+	if len(p.AllowedWindows) != 0 {
+		allowed := false
+		for _, window := range p.AllowedWindows {
+			if allowed, _ = window.contains(now); allowed {
+				break
+			}
+		}
+		if !allowed {
+			return violation("", fmt.Sprintf("chaos is not allowed at %s, outside of the allowed windows", now.Format(time.RFC3339)))
+		}
+	}
+	for _, window := range p.BlockedWindows {
+		if blocked, _ := window.contains(now); blocked {
+			return violation("", fmt.Sprintf("chaos is not allowed at %s, inside the blocked window %s", now.Format(time.RFC3339), window))
+		}
+	}
+	return nil
+}
+
+// abortReason is the reason of the chaos aborted by the time windows, it is set by the window watcher and read by the abort goroutine
+var (
+	abortLock   sync.Mutex
+	abortReason string
+)
+
+// setAbortReason records the violation which aborted the chaos
+func setAbortReason(err error) {
+	reason := err.Error()
+	if violation, ok := err.(cerrors.Error); ok {
+		reason = violation.Reason
+	}
+	abortLock.Lock()
+	defer abortLock.Unlock()
+	abortReason = "Chaos aborted by the chaos policy, " + reason
+}
+
+// AbortReason returns the reason of the chaos aborted by the time windows of the chaos policy, it is empty if the chaos isn't aborted by the policy
+func AbortReason() string {
+	abortLock.Lock()
+	defer abortLock.Unlock()
+	return abortReason
+}
+
+// watchWindows re-checks the time windows during the chaos
+// once the allowed window closes or a blocked window opens, it deletes the helper pods of the run, so that the helpers revert
+// the chaos injected by them, and raises the abort signal, so that the rest of the chaos is reverted through the same path
+// as the abort of the chaosengine
+func (p *Policy) watchWindows(clients clients.ClientSets, chaosDetails *types.ChaosDetails) {
+	ticker := time.NewTicker(windowCheckInterval)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		err := p.checkWindows(now)
+		if err == nil {
+			continue
+		}
+		log.Errorf("[Policy]: Aborting the chaos, err: %v", err)
+		generatePolicyEvent(clients, chaosDetails, "Aborting the chaos, "+err.Error(), "Warning")
+		// the reason is recorded before the abort signal, so that the chaosresult explains the abort
+		setAbortReason(err)
+		if err := clients.DeleteHelperPods(chaosDetails); err != nil {
+			log.Errorf("[Policy]: Unable to delete the helper pods, err: %v", err)
+		}
+		if err := syscall.Kill(os.Getpid(), syscall.SIGTERM); err != nil {
+			log.Errorf("[Policy]: Unable to abort the chaos, err: %v", err)
+		}
+		return
+	}
+}
+
+// contains checks whether the given time lies inside the window
+func (w TimeWindow) contains(now time.Time) (bool, error) {
+	location := time.UTC
+	if w.Timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(w.Timezone); err != nil {
+			return false, fmt.Errorf("invalid timezone '%s'", w.Timezone)
+		}
+	}
+	now = now.In(location)
+
+	if w.Schedule != "" {
+		return w.containsSchedule(now)
+	}
+
+	start, err := parseClock(w.Start)
+	if err != nil {
+		return false, err
+	}
+	end, err := parseClock(w.End)
+	if err != nil {
+		return false, err
+	}
+
+	day := now
+	minutes := now.Hour()*60 + now.Minute()
+	inside := minutes >= start && minutes < end
+	if end <= start {
+		// the window spans midnight, the part after midnight belongs to the window of the previous day
+		inside = minutes >= start || minutes < end
+		if minutes < end {
+			day = now.AddDate(0, 0, -1)
+		}
+	}
+	if !inside || len(w.Days) == 0 {
+		return inside, nil
+	}
+	for _, d := range w.Days {
+		if strings.EqualFold(d, day.Weekday().String()[:3]) || strings.EqualFold(d, day.Weekday().String()) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// containsSchedule checks whether the window was opened by the schedule within the duration before the given time
+func (w TimeWindow) containsSchedule(now time.Time) (bool, error) {
+	schedule, err := cron.ParseStandard(w.Schedule)
+	if err != nil {
+		return false, fmt.Errorf("invalid schedule '%s', %s", w.Schedule, err.Error())
+	}
+	duration, err := time.ParseDuration(w.Duration)
+	if err != nil || duration <= 0 {
+		return false, fmt.Errorf("invalid duration '%s' of schedule '%s'", w.Duration, w.Schedule)
+	}
+	// the schedule is evaluated in the location of the given time
+	opened := schedule.Next(now.Add(-duration))
+	return !opened.After(now), nil
+}
+
+func (w TimeWindow) String() string {
+	if w.Schedule != "" {
+		return fmt.Sprintf("{schedule: %s, duration: %s, timezone: %s}", w.Schedule, w.Duration, w.Timezone)
+	}
+	return fmt.Sprintf("{days: %v, start: %s, end: %s, timezone: %s}", w.Days, w.Start, w.End, w.Timezone)
+}
+
+// parseClock returns the minutes of the day of the given HH:MM clock
+func parseClock(clock string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(clock))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time '%s', it should be in HH:MM format", clock)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// generatePolicyEvent records the decision of the chaos policy as chaosengine event
+func generatePolicyEvent(clients clients.ClientSets, chaosDetails *types.ChaosDetails, msg, eventType string) {
+	if chaosDetails.EngineName == "" {
+		return
+	}
+	eventsDetails := types.EventDetails{}
+	types.SetEngineEventAttributes(&eventsDetails, types.PolicyCheck, msg, eventType, chaosDetails)
+	if err := events.GenerateEvents(&eventsDetails, clients, chaosDetails, "ChaosEngine"); err != nil {
+		log.Errorf("failed to create %v event inside chaosengine", types.PolicyCheck)
+	}
+}
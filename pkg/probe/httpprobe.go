@@ -263,12 +263,12 @@ loop:
 			time.Sleep(probeTimeout.ProbePollingInterval)
 		}
 	}
-	// if experiment fails and stopOnfailure is provided as true then it will patch the chaosengine for abort
+	// if experiment fails and stopOnfailure is provided as true then the probe acts as guard and stops the chaos
 	// if experiment fails but stopOnfailure is provided as false then it will continue the execution
 	// and failed the experiment in the end
 	if isExperimentFailed && probe.RunProperties.StopOnFailure {
-		if err := stopChaosEngine(probe, clients, chaosresult, chaosDetails); err != nil {
-			log.Errorf("Unable to patch chaosengine to stop, err: %v", err)
+		if err := stopChaosByGuard(probe, clients, chaosresult, chaosDetails); err != nil {
+			log.Errorf("Unable to stop the chaos, err: %v", err)
 		}
 	}
 }
@@ -426,12 +426,12 @@ loop:
 			}
 		}
 	}
-	// if experiment fails and stopOnfailure is provided as true then it will patch the chaosengine for abort
+	// if experiment fails and stopOnfailure is provided as true then the probe acts as guard and stops the chaos
 	// if experiment fails but stopOnfailure is provided as false then it will continue the execution
 	// and failed the experiment in the end
 	if isExperimentFailed && probe.RunProperties.StopOnFailure {
-		if err := stopChaosEngine(probe, clients, chaosresult, chaosDetails); err != nil {
-			log.Errorf("unable to patch chaosengine to stop, err: %v", err)
+		if err := stopChaosByGuard(probe, clients, chaosresult, chaosDetails); err != nil {
+			log.Errorf("unable to stop the chaos, err: %v", err)
 		}
 	}
 }
@@ -5,7 +5,9 @@ import (
 	"context"
 	"fmt"
 	"html/template"
+	"os"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/kyokomi/emoji"
@@ -306,8 +308,11 @@ func parseCommand(templatedCommand string, resultDetails *types.ResultDetails) (
 	return out.String(), nil
 }
 
-// stopChaosEngine update the probe status and patch the chaosengine to stop state
-func stopChaosEngine(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) error {
+// stopChaosByGuard update the probe status and stops the in-flight chaos, as the guard probe has failed
+// the chaosengine is patched to the stop state and the helper pods of the run are deleted, so that the helpers revert the chaos injected by them
+// and the abort signal is raised, so that the rest of the chaos is reverted through the same path as the abort of the chaosengine
+// the run ends with the guarded verdict instead of the failed one
+func stopChaosByGuard(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) error {
 	// it will check for the error, It will detect the error if any error encountered in probe during chaos
 	if err = checkForErrorInContinuousProbe(chaosresult, probe.Name, chaosDetails.Timeout, chaosDetails.Delay); err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeProbeTimeout {
 		return err
@@ -315,17 +320,38 @@ func stopChaosEngine(probe v1alpha1.ProbeAttributes, clients clients.ClientSets,
 
 	// failing the probe, if the success condition doesn't met after the retry & timeout combinations
 	markedVerdictInEnd(err, chaosresult, probe, "PostChaos")
-	//patch chaosengine's state to stop
+
+	reason := "probe failed"
+	if probeDetails := getProbeByName(probe.Name, chaosresult.ProbeDetails); probeDetails != nil && probeDetails.Status.Description != "" {
+		reason = probeDetails.Status.Description
+	}
+	chaosDetails.SetGuardFailure(fmt.Sprintf("Chaos stopped by the %s guard probe to protect the application, %s", probe.Name, reason))
+	log.Errorf("[Probe]: %s, reverting the chaos", chaosDetails.GetGuardFailure())
+
+	// the abort signal is raised even if the chaosengine can't be stopped or the helpers can't be deleted, so that the run is still stopped
+	stopErr := stopChaosEngine(clients, chaosDetails)
+	if stopErr != nil {
+		log.Errorf("[Probe]: Unable to stop the chaosengine, err: %v", stopErr)
+	}
+	if err := clients.DeleteHelperPods(chaosDetails); err != nil {
+		log.Errorf("[Probe]: Unable to delete the helper pods to stop the chaos, err: %v", err)
+	}
+	if err := syscall.Kill(os.Getpid(), syscall.SIGTERM); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("failed to stop the chaos, %s", err.Error())}
+	}
+	return stopErr
+}
+
+// stopChaosEngine patches the chaosengine's state to stop
+func stopChaosEngine(clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 	engine, err := clients.LitmusClient.ChaosEngines(chaosDetails.ChaosNamespace).Get(context.Background(), chaosDetails.EngineName, v1.GetOptions{})
 	if err != nil {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("failed to get chaosengine, %s", err.Error())}
 	}
 	engine.Spec.EngineState = v1alpha1.EngineStateStop
-	_, err = clients.LitmusClient.ChaosEngines(chaosDetails.ChaosNamespace).Update(context.Background(), engine, v1.UpdateOptions{})
-	if err != nil {
+	if _, err = clients.LitmusClient.ChaosEngines(chaosDetails.ChaosNamespace).Update(context.Background(), engine, v1.UpdateOptions{}); err != nil {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("failed to patch the chaosengine to `stop` state, %v", err.Error())}
 	}
-
 	return nil
 }
 
@@ -3,6 +3,7 @@ package result
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"os/exec"
 	"strconv"
@@ -24,6 +25,22 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// ChaosSeedAnnotation is the annotation of the chaosresult which contains the seed of the run
+	// the run can be replayed with the same random decisions by providing it as CHAOS_SEED
+	ChaosSeedAnnotation = "litmuschaos.io/chaos-seed"
+	// SkippedTargetsAnnotation is the annotation of the chaosresult which contains the targets skipped by the safeguards
+	SkippedTargetsAnnotation = "litmuschaos.io/skipped-targets"
+	// DryRunPlanAnnotation is the annotation of the chaosresult which contains the plan of the dry run
+	DryRunPlanAnnotation = "litmuschaos.io/dry-run-plan"
+	// FailoversAnnotation is the annotation of the chaosresult which contains the database failovers triggered by the chaos
+	FailoversAnnotation = "litmuschaos.io/failovers"
+	// CommandOutputsAnnotation is the annotation of the chaosresult which contains the output of the commands run on the targets
+	CommandOutputsAnnotation = "litmuschaos.io/command-outputs"
+	// RecoveriesAnnotation is the annotation of the chaosresult which contains the time taken by the targets to recover by themselves
+	RecoveriesAnnotation = "litmuschaos.io/recoveries"
+)
+
 // ChaosResult Create and Update the chaos result
 func ChaosResult(chaosDetails *types.ChaosDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, state string) error {
 	experimentLabel := map[string]string{}
@@ -74,9 +91,10 @@ func InitializeChaosResult(chaosDetails *types.ChaosDetails, clients clients.Cli
 	_, _, probeStatus := GetProbeStatus(resultDetails)
 	chaosResult := &v1alpha1.ChaosResult{
 		ObjectMeta: v1.ObjectMeta{
-			Name:      resultDetails.Name,
-			Namespace: chaosDetails.ChaosNamespace,
-			Labels:    chaosResultLabel,
+			Name:        resultDetails.Name,
+			Namespace:   chaosDetails.ChaosNamespace,
+			Labels:      chaosResultLabel,
+			Annotations: map[string]string{ChaosSeedAnnotation: strconv.FormatInt(chaosDetails.Seed, 10)},
 		},
 		Spec: v1alpha1.ChaosResultSpec{
 			EngineName:     chaosDetails.EngineName,
@@ -160,18 +178,30 @@ func updateResultAttributes(clients clients.ClientSets, chaosDetails *types.Chao
 
 	// for existing chaos result resource it will patch the label
 	result.ObjectMeta.Labels = chaosResultLabel
+	setResultAnnotations(result, chaosDetails)
 	result.Status.History.Targets = chaosDetails.Targets
 	isAllProbePassed, experimentStopped, result.Status.ProbeStatuses = GetProbeStatus(resultDetails)
+	updateVerdict(result, resultDetails, isAllProbePassed, experimentStopped)
+	return result, nil
+}
+
+// updateVerdict updates the verdict, the probe success percentage and the run history of the chaosresult, once the run is over
+func updateVerdict(result *v1alpha1.ChaosResult, resultDetails *types.ResultDetails, isAllProbePassed, experimentStopped bool) {
 	result.Status.ExperimentStatus.Verdict = resultDetails.Verdict
 
 	switch strings.ToLower(string(resultDetails.Phase)) {
 	case "completed", "error", "stopped":
 		if !isAllProbePassed {
+			guarded := resultDetails.Verdict == types.ResultVerdictGuarded
 			result.Status.ExperimentStatus.Phase = v1alpha1.ResultPhaseCompletedWithProbeFailure
 			resultDetails.Verdict = v1alpha1.ResultVerdictFailed
 			if experimentStopped {
 				result.Status.ExperimentStatus.Phase = v1alpha1.ResultPhaseStopped
 				resultDetails.Verdict = v1alpha1.ResultVerdictStopped
+				// the run which is stopped by the guard probe keeps its own verdict
+				if guarded {
+					resultDetails.Verdict = types.ResultVerdictGuarded
+				}
 			}
 			result.Status.ExperimentStatus.Verdict = resultDetails.Verdict
 		}
@@ -189,7 +219,7 @@ func updateResultAttributes(clients clients.ClientSets, chaosDetails *types.Chao
 			} else {
 				result.Status.ExperimentStatus.ProbeSuccessPercentage = "0"
 			}
-		case "stopped":
+		case "stopped", "guarded":
 			result.Status.History.StoppedRuns++
 			probe.SetProbeVerdictAfterFailure(result)
 			if len(resultDetails.ProbeDetails) != 0 {
@@ -201,7 +231,6 @@ func updateResultAttributes(clients clients.ClientSets, chaosDetails *types.Chao
 	default:
 		result.Status.ExperimentStatus.ProbeSuccessPercentage = "Awaited"
 	}
-	return result, nil
 }
 
 // PatchChaosResult Update the chaos result
@@ -248,7 +277,13 @@ func RecordAfterFailure(chaosDetails *types.ChaosDetails, resultDetails *types.R
 	failStep, errorCode := cerrors.GetRootCauseAndErrorCode(err, string(chaosDetails.Phase))
 	phase := v1alpha1.ResultPhaseError
 	verdict := v1alpha1.ResultVerdictError
-	if probe.IsProbeFailed(failStep) {
+	switch {
+	case errorCode == cerrors.ErrorTypeDryRun:
+		// the dry run is stopped before the chaos injection, its plan is recorded inside the chaosresult
+		phase = v1alpha1.ResultPhaseCompleted
+		verdict = types.ResultVerdictDryRun
+		failStep, errorCode = "", ""
+	case probe.IsProbeFailed(failStep):
 		phase = v1alpha1.ResultPhaseCompleted
 		verdict = v1alpha1.ResultVerdictFailed
 	}
@@ -268,13 +303,40 @@ func RecordAfterFailure(chaosDetails *types.ChaosDetails, resultDetails *types.R
 
 	// add the summary event in chaos engine
 	if chaosDetails.EngineName != "" {
-		types.SetEngineEventAttributes(eventsDetails, types.Summary, msg, "Warning", chaosDetails)
+		types.SetEngineEventAttributes(eventsDetails, types.Summary, msg, eventType, chaosDetails)
 		if err := events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine"); err != nil {
 			log.Errorf("failed to create %v event inside chaosengine", types.Summary)
 		}
 	}
 }
 
+// setResultAnnotations records the details of the run, which are not part of the chaosresult status, as annotations
+func setResultAnnotations(result *v1alpha1.ChaosResult, chaosDetails *types.ChaosDetails) {
+	if result.Annotations == nil {
+		result.Annotations = map[string]string{}
+	}
+	result.Annotations[ChaosSeedAnnotation] = strconv.FormatInt(chaosDetails.Seed, 10)
+	setJSONAnnotation(result, SkippedTargetsAnnotation, chaosDetails.SkippedTargets, len(chaosDetails.SkippedTargets) != 0)
+	setJSONAnnotation(result, DryRunPlanAnnotation, chaosDetails.DryRunPlan, chaosDetails.DryRun)
+	setJSONAnnotation(result, FailoversAnnotation, chaosDetails.Failovers, len(chaosDetails.Failovers) != 0)
+	setJSONAnnotation(result, CommandOutputsAnnotation, chaosDetails.CommandOutputs, len(chaosDetails.CommandOutputs) != 0)
+	setJSONAnnotation(result, RecoveriesAnnotation, chaosDetails.Recoveries, len(chaosDetails.Recoveries) != 0)
+}
+
+// setJSONAnnotation sets the annotation to the given value in json format, the annotation is removed if it is not set
+func setJSONAnnotation(result *v1alpha1.ChaosResult, key string, value interface{}, set bool) {
+	if !set {
+		delete(result.Annotations, key)
+		return
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		log.Errorf("failed to marshal the %s annotation, err: %v", key, err)
+		return
+	}
+	result.Annotations[key] = string(data)
+}
+
 // updateHistory initialise the history for the older results
 func updateHistory(result *v1alpha1.ChaosResult) {
 	if result.Status.History == nil {
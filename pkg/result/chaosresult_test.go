@@ -0,0 +1,72 @@
+package result
+
+import (
+	"testing"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateVerdict(t *testing.T) {
+	passed := types.ProbeDetails{Name: "passed", Status: v1alpha1.ProbeStatus{Verdict: v1alpha1.ProbeVerdictPassed}}
+	failed := types.ProbeDetails{Name: "failed", Status: v1alpha1.ProbeStatus{Verdict: v1alpha1.ProbeVerdictFailed}}
+	guard := types.ProbeDetails{Name: "guard", Status: v1alpha1.ProbeStatus{Verdict: v1alpha1.ProbeVerdictFailed}, Stopped: true}
+
+	tests := map[string]struct {
+		verdict          v1alpha1.ResultVerdict
+		phase            v1alpha1.ResultPhase
+		probes           []*types.ProbeDetails
+		wantVerdict      v1alpha1.ResultVerdict
+		wantPhase        v1alpha1.ResultPhase
+		wantProbeSuccess string
+		wantStoppedRuns  int
+		wantFailedRuns   int
+	}{
+		"guarded run keeps its verdict": {
+			verdict:          types.ResultVerdictGuarded,
+			phase:            v1alpha1.ResultPhaseStopped,
+			probes:           []*types.ProbeDetails{&passed, &guard},
+			wantVerdict:      types.ResultVerdictGuarded,
+			wantPhase:        v1alpha1.ResultPhaseStopped,
+			wantProbeSuccess: "50",
+			wantStoppedRuns:  1,
+		},
+		"aborted run by a stop on failure probe": {
+			verdict:          v1alpha1.ResultVerdictStopped,
+			phase:            v1alpha1.ResultPhaseStopped,
+			probes:           []*types.ProbeDetails{&passed, &guard},
+			wantVerdict:      v1alpha1.ResultVerdictStopped,
+			wantPhase:        v1alpha1.ResultPhaseStopped,
+			wantProbeSuccess: "50",
+			wantStoppedRuns:  1,
+		},
+		"failed probe": {
+			verdict:          v1alpha1.ResultVerdictPassed,
+			phase:            v1alpha1.ResultPhaseCompleted,
+			probes:           []*types.ProbeDetails{&passed, &failed},
+			wantVerdict:      v1alpha1.ResultVerdictFailed,
+			wantPhase:        v1alpha1.ResultPhaseCompletedWithProbeFailure,
+			wantProbeSuccess: "50",
+			wantFailedRuns:   1,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resultDetails := &types.ResultDetails{Verdict: tt.verdict, Phase: tt.phase, ProbeDetails: tt.probes, PassedProbeCount: 1}
+			result := &v1alpha1.ChaosResult{}
+			result.Status.ExperimentStatus.Phase = tt.phase
+			result.Status.History = &v1alpha1.HistoryDetails{}
+
+			isAllProbePassed, experimentStopped, _ := GetProbeStatus(resultDetails)
+			updateVerdict(result, resultDetails, isAllProbePassed, experimentStopped)
+
+			assert.Equal(t, tt.wantVerdict, resultDetails.Verdict)
+			assert.Equal(t, tt.wantVerdict, result.Status.ExperimentStatus.Verdict)
+			assert.Equal(t, tt.wantPhase, result.Status.ExperimentStatus.Phase)
+			assert.Equal(t, tt.wantProbeSuccess, result.Status.ExperimentStatus.ProbeSuccessPercentage)
+			assert.Equal(t, tt.wantStoppedRuns, result.Status.History.StoppedRuns)
+			assert.Equal(t, tt.wantFailedRuns, result.Status.History.FailedRuns)
+		})
+	}
+}
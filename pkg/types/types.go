@@ -6,9 +6,11 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/utils/random"
 	"github.com/litmuschaos/litmus-go/pkg/utils/stringutils"
 
 	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
@@ -32,6 +34,8 @@ const (
 	Summary string = "Summary"
 	// ChaosInject this stage refer to the main chaos injection
 	ChaosInject string = "ChaosInject"
+	// PolicyCheck stage of experiment check for the chaos policy of the cluster before chaos injection
+	PolicyCheck string = "PolicyCheck"
 	// AwaitedVerdict marked the start of test
 	AwaitedVerdict string = "Awaited"
 	// PassVerdict marked the verdict as passed in the end of experiment
@@ -42,6 +46,10 @@ const (
 	AbortVerdict string = "Abort"
 	// ErrorVerdict marked the verdict as error in the end of experiment
 	ErrorVerdict string = "Error"
+	// GuardedVerdict marked the verdict as guarded when the chaos is stopped by a guard probe to protect the application
+	GuardedVerdict string = "Guarded"
+	// DryRunVerdict marked the verdict as dry run when the run is stopped before the chaos injection, after planning it
+	DryRunVerdict string = "DryRun"
 )
 
 type ExperimentPhase string
@@ -52,6 +60,12 @@ const (
 	ChaosInjectPhase ExperimentPhase = "ChaosInject"
 )
 
+// ResultVerdictGuarded is the verdict of the run which is stopped by a guard probe to protect the application
+const ResultVerdictGuarded = v1alpha1.ResultVerdict(GuardedVerdict)
+
+// ResultVerdictDryRun is the verdict of the dry run, which only plans the chaos without injecting it
+const ResultVerdictDryRun = v1alpha1.ResultVerdict(DryRunVerdict)
+
 // ResultDetails is for collecting all the chaos-result-related details
 type ResultDetails struct {
 	Name             string
@@ -132,6 +146,16 @@ type ChaosDetails struct {
 	Phase                ExperimentPhase
 	ProbeContext         ProbeContext
 	SideCar              []SideCar
+	TargetSelection      TargetSelection
+	Seed                 int64
+	PDBGuard             string
+	SkippedTargets       []SkippedTarget
+	guardFailure         string
+	DryRun               bool
+	DryRunPlan           DryRunPlan
+	Failovers            []Failover
+	CommandOutputs       []CommandOutput
+	Recoveries           []Recovery
 }
 
 type SideCar struct {
@@ -147,6 +171,8 @@ type ParentResource struct {
 	Name      string
 	Kind      string
 	Namespace string
+	// Revision is the revision of the parent, which is targeted, it is set if TARGET_REVISION is provided
+	Revision string
 }
 
 type ProbeContext struct {
@@ -154,12 +180,83 @@ type ProbeContext struct {
 	CancelFunc context.CancelFunc
 }
 
+// SkippedTarget contains the target which is skipped by the safeguards of the target selection and the reason
+type SkippedTarget struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	Reason    string `json:"reason"`
+}
+
+// Failover contains the failover of the database which is triggered by the chaos, and how long it took to recover
+type Failover struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	OldWriter string `json:"oldWriter,omitempty"`
+	NewWriter string `json:"newWriter,omitempty"`
+	StartedAt string `json:"startedAt"`
+	Duration  string `json:"duration"`
+}
+
+// Recovery contains the target which recovered by itself after the chaos, and how long it took to recover
+type Recovery struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	StartedAt string `json:"startedAt"`
+	Duration  string `json:"duration"`
+}
+
+// CommandOutput contains the output of the command, which is run on the target to inject the chaos
+type CommandOutput struct {
+	Target    string `json:"target"`
+	CommandID string `json:"commandID"`
+	Status    string `json:"status"`
+	Output    string `json:"output,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// DryRunPlan contains everything the run would inject the chaos with, it is recorded instead of injecting the chaos in the dry run
+type DryRunPlan struct {
+	Targets         []PlannedTarget   `json:"targets,omitempty"`
+	HelperPods      []PlannedHelper   `json:"helperPods,omitempty"`
+	FaultParameters map[string]string `json:"faultParameters,omitempty"`
+}
+
+// PlannedTarget contains the target which would be affected by the chaos
+type PlannedTarget struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	Node      string `json:"node,omitempty"`
+}
+
+// PlannedHelper contains the helper pod which would inject the chaos
+type PlannedHelper struct {
+	Name      string         `json:"name"`
+	Namespace string         `json:"namespace"`
+	Spec      corev1.PodSpec `json:"spec"`
+}
+
+// TargetSelection contains the strategy to select the target pods out of the candidate pods
+type TargetSelection struct {
+	Strategies       []string
+	TopologyKey      string
+	MaxPodsPerDomain int
+	// Revision restricts the target pods to a revision of their workloads, it is either canary, stable or the revision hash
+	Revision string
+	// RevisionLabel is the pod label, which contains the revision, the pod template hash is used if it is not provided
+	RevisionLabel string
+}
+
 // AppDetails contains all the application related envs
 type AppDetails struct {
 	Namespace string
 	Labels    []string
 	Kind      string
 	Names     []string
+	// APIVersion is set for the generic targets, provided as group/version/kind, e.g. serving.knative.dev/v1/Service
+	// the pods of the generic targets are derived from the owner chain of the pods
+	APIVersion string
 }
 
 func GetTargets(targets string) []AppDetails {
@@ -174,6 +271,10 @@ func GetTargets(targets string) []AppDetails {
 			Kind:      val[0],
 			Namespace: val[1],
 		}
+		// the generic target contains the group and version along with the kind, e.g. batch/v1/CronJob
+		if index := strings.LastIndex(val[0], "/"); index != -1 {
+			data.APIVersion, data.Kind = val[0][:index], val[0][index+1:]
+		}
 		if strings.Contains(val[2], "=") {
 			data.Labels = parse(val[2])
 		} else {
@@ -217,6 +318,38 @@ func InitialiseChaosVariables(chaosDetails *ChaosDetails) {
 	chaosDetails.Phase = PreChaosPhase
 	chaosDetails.ProbeContext.Ctx, chaosDetails.ProbeContext.CancelFunc = context.WithCancel(context.Background())
 	chaosDetails.Labels = map[string]string{}
+	chaosDetails.TargetSelection = getTargetSelection()
+	chaosDetails.Seed = getSeed()
+	chaosDetails.PDBGuard = strings.ToLower(Getenv("PDB_GUARD", "disabled"))
+	chaosDetails.SkippedTargets = []SkippedTarget{}
+	chaosDetails.DryRun, _ = strconv.ParseBool(Getenv("DRY_RUN", "false"))
+}
+
+// getSeed seeds the random source of the run with CHAOS_SEED
+// a new seed is generated if it is not provided, it can be reused to replay the run with the same random decisions
+func getSeed() int64 {
+	seed, err := strconv.ParseInt(Getenv("CHAOS_SEED", ""), 10, 64)
+	if err != nil {
+		seed = time.Now().UnixNano()
+	}
+	random.SetSeed(seed)
+	return seed
+}
+
+// getTargetSelection derive the target selection strategy from the envs
+func getTargetSelection() TargetSelection {
+	selection := TargetSelection{
+		TopologyKey: Getenv("TOPOLOGY_KEY", "topology.kubernetes.io/zone"),
+	}
+	for _, strategy := range strings.Split(Getenv("TARGET_SELECTION_STRATEGY", "random"), ",") {
+		if strategy = strings.ToLower(strings.TrimSpace(strategy)); strategy != "" {
+			selection.Strategies = append(selection.Strategies, strategy)
+		}
+	}
+	selection.MaxPodsPerDomain, _ = strconv.Atoi(Getenv("MAX_PODS_PER_TOPOLOGY", "1"))
+	selection.Revision = strings.TrimSpace(Getenv("TARGET_REVISION", ""))
+	selection.RevisionLabel = strings.TrimSpace(Getenv("REVISION_LABEL", ""))
+	return selection
 }
 
 // SetResultAttributes initialise all the chaos result ENV
@@ -240,7 +373,7 @@ func SetResultAttributes(resultDetails *ResultDetails, chaosDetails ChaosDetails
 func SetResultAfterCompletion(resultDetails *ResultDetails, verdict v1alpha1.ResultVerdict, phase v1alpha1.ResultPhase, failStep string, errorCode cerrors.ErrorType) {
 	resultDetails.Verdict = verdict
 	resultDetails.Phase = phase
-	if errorCode != cerrors.ErrorTypeHelperPodFailed && resultDetails.Phase == v1alpha1.ResultPhaseError {
+	if errorCode != cerrors.ErrorTypeHelperPodFailed && (resultDetails.Phase == v1alpha1.ResultPhaseError || verdict == ResultVerdictGuarded) {
 		resultDetails.ErrorOutput = &v1alpha1.ErrorOutput{
 			Reason:    failStep,
 			ErrorCode: string(errorCode),
@@ -272,7 +405,7 @@ func SetResultEventAttributes(eventsDetails *EventDetails, Reason, Message, Type
 // GetChaosResultVerdictEvent return the verdict and event type
 func GetChaosResultVerdictEvent(verdict v1alpha1.ResultVerdict) (string, string) {
 	switch verdict {
-	case v1alpha1.ResultVerdictPassed:
+	case v1alpha1.ResultVerdictPassed, ResultVerdictDryRun:
 		return string(verdict), "Normal"
 	default:
 		return string(verdict), "Warning"
@@ -415,3 +548,20 @@ func generateError(probeName, probeType, field string, err error) error {
 		Target:    fmt.Sprintf("{probeName: %s, type: %s}", probeName, probeType),
 	}
 }
+
+// guardLock protects the guard failure of the run, it is set by the probe goroutine and read by the abort goroutine
+var guardLock sync.Mutex
+
+// SetGuardFailure records the reason of the chaos stopped by the guard probe
+func (chaosDetails *ChaosDetails) SetGuardFailure(reason string) {
+	guardLock.Lock()
+	defer guardLock.Unlock()
+	chaosDetails.guardFailure = reason
+}
+
+// GetGuardFailure returns the reason of the chaos stopped by the guard probe, it is empty if the chaos is not stopped by a guard probe
+func (chaosDetails *ChaosDetails) GetGuardFailure() string {
+	guardLock.Lock()
+	defer guardLock.Unlock()
+	return chaosDetails.guardFailure
+}
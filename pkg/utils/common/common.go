@@ -3,7 +3,6 @@ package common
 import (
 	"bytes"
 	"fmt"
-	"math/rand"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -13,7 +12,9 @@ import (
 	"strings"
 	"syscall"
 	"time"
+	"unicode/utf8"
 
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/palantir/stacktrace"
 
@@ -21,8 +22,10 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/math"
+	"github.com/litmuschaos/litmus-go/pkg/policy"
 	"github.com/litmuschaos/litmus-go/pkg/result"
 	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/random"
 	apiv1 "k8s.io/api/core/v1"
 )
 
@@ -54,11 +57,10 @@ func RandomInterval(interval string) error {
 	default:
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: "could not parse CHAOS_INTERVAL env, invalid format"}
 	}
-	rand.Seed(time.Now().UnixNano())
 	if upperBound < 1 {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: "invalid CHAOS_INTERVAL env value, value below lower limit"}
 	}
-	waitTime := lowerBound + rand.Intn(upperBound-lowerBound)
+	waitTime := lowerBound + random.Intn(upperBound-lowerBound)
 	log.Infof("[Wait]: Wait for the random chaos interval %vs", waitTime)
 	WaitForDuration(waitTime)
 	return nil
@@ -73,7 +75,21 @@ func AbortWatcher(expname string, clients clients.ClientSets, resultDetails *typ
 
 // AbortWatcherWithoutExit continuously watch for the abort signals
 func AbortWatcherWithoutExit(expname string, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails, eventsDetails *types.EventDetails) {
+	waitForAbortSignal()
+	recordAbort(expname, clients, resultDetails, chaosDetails, eventsDetails)
+}
+
+// AbortWatcherWithRevert continuously watch for the abort signals
+// it reverts the chaos, before updating the chaosresult, for the experiments which don't revert the chaos inside the helper pods on their own
+func AbortWatcherWithRevert(expname string, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails, eventsDetails *types.EventDetails, revert func()) {
+	waitForAbortSignal()
+	revert()
+	recordAbort(expname, clients, resultDetails, chaosDetails, eventsDetails)
+	os.Exit(1)
+}
 
+// waitForAbortSignal waits until the abort signal is received
+func waitForAbortSignal() {
 	// signChan channel is used to transmit signal notifications.
 	signChan := make(chan os.Signal, 1)
 	// Catch and relay certain signal(s) to signChan channel.
@@ -83,16 +99,19 @@ func AbortWatcherWithoutExit(expname string, clients clients.ClientSets, resultD
 	<-signChan
 
 	log.Info("[Chaos]: Chaos Experiment Abortion started because of terminated signal received")
+}
+
+// recordAbort updates the chaosresult with the stopped verdict and generates the abort events
+func recordAbort(expname string, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails, eventsDetails *types.EventDetails) {
 	// updating the chaosresult after stopped
-	failStep := "Chaos injection stopped!"
-	types.SetResultAfterCompletion(resultDetails, "Stopped", "Stopped", failStep, cerrors.ErrorTypeExperimentAborted)
+	failStep, verdict, errorCode, msg, eventReason := abortVerdict(expname, chaosDetails)
+	types.SetResultAfterCompletion(resultDetails, verdict, v1alpha1.ResultPhaseStopped, failStep, errorCode)
 	if err := result.ChaosResult(chaosDetails, clients, resultDetails, "EOT"); err != nil {
 		log.Errorf("[ABORT]: Failed to update result, err: %v", err)
 	}
 	log.Info("[ABORT]: Updated chaosresult post stop")
 
 	// generating summary event in chaosengine
-	msg := expname + " experiment has been aborted"
 	types.SetEngineEventAttributes(eventsDetails, types.Summary, msg, "Warning", chaosDetails)
 	err := events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
 	if err != nil {
@@ -100,23 +119,35 @@ func AbortWatcherWithoutExit(expname string, clients clients.ClientSets, resultD
 	}
 
 	// generating summary event in chaosresult
-	types.SetResultEventAttributes(eventsDetails, types.AbortVerdict, msg, "Warning", resultDetails)
+	types.SetResultEventAttributes(eventsDetails, eventReason, msg, "Warning", resultDetails)
 	err = events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosResult")
 	if err != nil {
 		log.Errorf("[ABORT]: Failed to create chaosresult abort event, err: %v", err)
 	}
 }
 
+// abortVerdict returns the fail step, verdict, error code, summary message and event reason of the aborted run
+// the chaos stopped by the guard probe is marked as guarded to differentiate it from the failed experiment
+// and the chaos aborted by the time windows of the chaos policy records the policy violation
+func abortVerdict(expname string, chaosDetails *types.ChaosDetails) (string, v1alpha1.ResultVerdict, cerrors.ErrorType, string, string) {
+	if guardFailure := chaosDetails.GetGuardFailure(); guardFailure != "" {
+		return guardFailure, types.ResultVerdictGuarded, cerrors.FailureTypeGuardProbe, expname + " experiment has been stopped by the guard probe", types.GuardedVerdict
+	}
+	if policyAbort := policy.AbortReason(); policyAbort != "" {
+		return policyAbort, v1alpha1.ResultVerdictStopped, cerrors.ErrorTypePolicyViolation, expname + " experiment has been aborted by the chaos policy", types.AbortVerdict
+	}
+	return "Chaos injection stopped!", v1alpha1.ResultVerdictStopped, cerrors.ErrorTypeExperimentAborted, expname + " experiment has been aborted", types.AbortVerdict
+}
+
 // FilterBasedOnPercentage return the slice of list based on the the provided percentage
 func FilterBasedOnPercentage(percentage int, list []string) []string {
 
 	var finalList []string
 	newInstanceListLength := math.Maximum(1, math.Adjustment(percentage, len(list)))
-	rand.Seed(time.Now().UnixNano())
 
 	// it will generate the random instanceList
 	// it starts from the random index and choose requirement no of volumeID next to that index in a circular way.
-	index := rand.Intn(len(list))
+	index := random.Intn(len(list))
 	for i := 0; i < newInstanceListLength; i++ {
 		finalList = append(finalList, list[index])
 		index = (index + 1) % len(list)
@@ -187,9 +218,8 @@ func GetStatusMessage(defaultCheck bool, defaultMsg, probeStatus string) string
 // GetRandomSequence will gives a random value for sequence
 func GetRandomSequence(sequence string) string {
 	if strings.ToLower(sequence) == "random" {
-		rand.Seed(time.Now().UnixNano())
 		seq := []string{"serial", "parallel"}
-		randomIndex := rand.Intn(len(seq))
+		randomIndex := random.Intn(len(seq))
 		return seq[randomIndex]
 	}
 	return sequence
@@ -215,8 +245,7 @@ func ValidateRange(a string) string {
 
 // getRandomValue gives a random value between two integers
 func getRandomValue(a, b int) int {
-	rand.Seed(time.Now().Unix())
-	return (a + rand.Intn(b-a+1))
+	return (a + random.Intn(b-a+1))
 }
 
 // SubStringExistsInSlice checks the existence of sub string in slice
@@ -345,3 +374,63 @@ func GetValuesFromChaosEngine(chaosDetails *types.ChaosDetails, clients clients.
 
 	return nil
 }
+
+// RecordFailover records the failover or the reboot of the database, which started at the given time and recovered now, in chaosdetails struct
+// it is added to the chaosresult as annotation, so that the recovery time of the clients can be compared with the failover duration
+func RecordFailover(kind, name, oldWriter, newWriter string, startedAt time.Time, chaosDetails *types.ChaosDetails) {
+	failover := types.Failover{
+		Kind:      kind,
+		Name:      name,
+		OldWriter: oldWriter,
+		NewWriter: newWriter,
+		StartedAt: startedAt.UTC().Format(time.RFC3339),
+		Duration:  time.Since(startedAt).Round(time.Second).String(),
+	}
+	chaosDetails.Failovers = append(chaosDetails.Failovers, failover)
+	if newWriter == "" {
+		log.Infof("[Info]: The %v %v recovered in %v", kind, name, failover.Duration)
+		return
+	}
+	log.Infof("[Info]: The %v %v failed over from %v to %v in %v", kind, name, oldWriter, newWriter, failover.Duration)
+}
+
+// RecordRecovery records the recovery of the target, which started at the given time and completed now, in chaosdetails struct
+// it is added to the chaosresult as annotation
+func RecordRecovery(kind, name string, startedAt time.Time, chaosDetails *types.ChaosDetails) {
+	recovery := types.Recovery{
+		Kind:      kind,
+		Name:      name,
+		StartedAt: startedAt.UTC().Format(time.RFC3339),
+		Duration:  time.Since(startedAt).Round(time.Second).String(),
+	}
+	chaosDetails.Recoveries = append(chaosDetails.Recoveries, recovery)
+	log.Infof("[Info]: The %v %v recovered in %v", kind, name, recovery.Duration)
+}
+
+// maxCommandOutputLength is the maximum length of the recorded output, which keeps the chaosresult annotations within their size limit
+const maxCommandOutputLength = 2048
+
+// RecordCommandOutput records the output of the command run on the target in chaosdetails struct
+// only the end of the long outputs is kept, it is added to the chaosresult as annotation
+func RecordCommandOutput(target, commandID, status, output, errOutput string, chaosDetails *types.ChaosDetails) {
+	chaosDetails.CommandOutputs = append(chaosDetails.CommandOutputs, types.CommandOutput{
+		Target:    target,
+		CommandID: commandID,
+		Status:    status,
+		Output:    truncateOutput(output),
+		Error:     truncateOutput(errOutput),
+	})
+}
+
+// truncateOutput keeps the last maxCommandOutputLength bytes of the output
+func truncateOutput(output string) string {
+	if len(output) <= maxCommandOutputLength {
+		return output
+	}
+	start := len(output) - maxCommandOutputLength
+	// the output is cut at the start of a character
+	for start < len(output) && !utf8.RuneStart(output[start]) {
+		start++
+	}
+	return "..." + output[start:]
+}
@@ -0,0 +1,29 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAbortVerdict(t *testing.T) {
+	chaosDetails := &types.ChaosDetails{}
+
+	failStep, verdict, errorCode, msg, eventReason := abortVerdict("pod-delete", chaosDetails)
+	assert.Equal(t, "Chaos injection stopped!", failStep)
+	assert.Equal(t, v1alpha1.ResultVerdictStopped, verdict)
+	assert.Equal(t, cerrors.ErrorTypeExperimentAborted, errorCode)
+	assert.Equal(t, "pod-delete experiment has been aborted", msg)
+	assert.Equal(t, types.AbortVerdict, eventReason)
+
+	chaosDetails.SetGuardFailure("Chaos stopped by the health guard probe to protect the application, probe failed")
+	failStep, verdict, errorCode, msg, eventReason = abortVerdict("pod-delete", chaosDetails)
+	assert.Equal(t, "Chaos stopped by the health guard probe to protect the application, probe failed", failStep)
+	assert.Equal(t, types.ResultVerdictGuarded, verdict)
+	assert.Equal(t, cerrors.FailureTypeGuardProbe, errorCode)
+	assert.Equal(t, "pod-delete experiment has been stopped by the guard probe", msg)
+	assert.Equal(t, types.GuardedVerdict, eventReason)
+}
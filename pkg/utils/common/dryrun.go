@@ -0,0 +1,145 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	core_v1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// sensitiveParameter matches the fault parameters whose values are not recorded in the dry run plan
+var sensitiveParameter = regexp.MustCompile(`(?i)pass|secret|token|credential`)
+
+// CreateHelperPod creates the helper pod of the experiment
+// in the dry run, the helper pod is only validated by the server-side dry run, which checks the RBAC and the admission of the pod,
+// and it is recorded in the dry run plan instead of being created
+func CreateHelperPod(namespace string, helperPod *core_v1.Pod, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	if !chaosDetails.DryRun {
+		return clients.CreatePod(namespace, helperPod)
+	}
+
+	if _, err := clients.KubeClient.CoreV1().Pods(namespace).Create(context.Background(), helperPod, v1.CreateOptions{DryRun: []string{v1.DryRunAll}}); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeHelper, Target: fmt.Sprintf("{podName: %s, namespace: %s}", helperPod.Name, namespace), Reason: fmt.Sprintf("helper pod is rejected by the dry run: %s", err.Error())}
+	}
+	log.Infof("[DryRun]: The %s helper pod is validated, it is not created in the dry run", helperPod.Name)
+
+	plan := &chaosDetails.DryRunPlan
+	plan.HelperPods = append(plan.HelperPods, types.PlannedHelper{
+		Name:      helperPod.Name,
+		Namespace: namespace,
+		Spec:      helperPod.Spec,
+	})
+	// the helper pods receive the fault parameters through their envs
+	if len(helperPod.Spec.Containers) != 0 {
+		if plan.FaultParameters == nil {
+			plan.FaultParameters = map[string]string{}
+		}
+		for _, env := range helperPod.Spec.Containers[0].Env {
+			if env.ValueFrom == nil {
+				plan.FaultParameters[env.Name] = redact(env.Name, env.Value)
+			}
+		}
+	}
+	return nil
+}
+
+// PlanTargets records the targets of the given kind in the dry run plan
+func PlanTargets(kind, namespace string, names []string, chaosDetails *types.ChaosDetails) {
+	if !chaosDetails.DryRun {
+		return
+	}
+	for _, name := range names {
+		addPlannedTarget(types.PlannedTarget{Kind: kind, Name: name, Namespace: namespace}, chaosDetails)
+	}
+}
+
+// planPods records the target pods in the dry run plan
+func planPods(pods []core_v1.Pod, chaosDetails *types.ChaosDetails) {
+	if !chaosDetails.DryRun {
+		return
+	}
+	for _, pod := range pods {
+		addPlannedTarget(types.PlannedTarget{Kind: "pod", Name: pod.Name, Namespace: pod.Namespace, Node: pod.Spec.NodeName}, chaosDetails)
+	}
+}
+
+func addPlannedTarget(target types.PlannedTarget, chaosDetails *types.ChaosDetails) {
+	for _, t := range chaosDetails.DryRunPlan.Targets {
+		if t.Kind == target.Kind && t.Name == target.Name && t.Namespace == target.Namespace {
+			return
+		}
+	}
+	chaosDetails.DryRunPlan.Targets = append(chaosDetails.DryRunPlan.Targets, target)
+}
+
+// StopForDryRun stops the dry run right before the chaos injection
+// the fault parameters are derived from the given experiment details and recorded in the dry run plan,
+// the returned error unwinds the experiment, which records the plan inside the chaosresult with the dry run verdict
+func StopForDryRun(experimentsDetails interface{}, chaosDetails *types.ChaosDetails) error {
+	if experimentsDetails != nil {
+		chaosDetails.DryRunPlan.FaultParameters = GetFaultParameters(experimentsDetails)
+	}
+	return stopDryRun(chaosDetails)
+}
+
+func stopDryRun(chaosDetails *types.ChaosDetails) error {
+	plan, _ := json.Marshal(chaosDetails.DryRunPlan)
+	log.Infof("[DryRun]: Stopping the run before the chaos injection, plan: %s", string(plan))
+	return cerrors.Error{ErrorCode: cerrors.ErrorTypeDryRun, Reason: "dry run is completed, the chaos is not injected"}
+}
+
+// GetFaultParameters returns the scalar fields of the experiment details, which are set, as fault parameters
+// the nested details of the experiment are flattened and the values of the sensitive fields, like passwords and secrets, are redacted
+func GetFaultParameters(experimentsDetails interface{}) map[string]string {
+	parameters := map[string]string{}
+	addFaultParameters(reflect.ValueOf(experimentsDetails), "", parameters)
+	return parameters
+}
+
+func addFaultParameters(value reflect.Value, prefix string, parameters map[string]string) {
+	value = reflect.Indirect(value)
+	if value.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < value.NumField(); i++ {
+		field, fieldType := reflect.Indirect(value.Field(i)), value.Type().Field(i)
+		if !fieldType.IsExported() || !field.IsValid() || field.IsZero() {
+			continue
+		}
+		name := prefix + fieldType.Name
+		switch field.Kind() {
+		case reflect.String, reflect.Bool, reflect.Int, reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64:
+			parameters[name] = redact(name, fmt.Sprint(field.Interface()))
+		case reflect.Slice:
+			if field.Type().Elem().Kind() != reflect.String {
+				continue
+			}
+			var values []string
+			for j := 0; j < field.Len(); j++ {
+				values = append(values, field.Index(j).String())
+			}
+			parameters[name] = redact(name, strings.Join(values, ","))
+		case reflect.Struct:
+			// only the nested details of the experiments are flattened, the embedded kubernetes objects are skipped
+			if strings.HasPrefix(field.Type().PkgPath(), "github.com/litmuschaos/litmus-go/") {
+				addFaultParameters(field, name+".", parameters)
+			}
+		}
+	}
+}
+
+func redact(name, value string) string {
+	if sensitiveParameter.MatchString(name) {
+		return "<redacted>"
+	}
+	return value
+}
@@ -0,0 +1,63 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+)
+
+type chaosLibDetails struct {
+	Sequence string
+}
+
+type faultDetails struct {
+	ExperimentName  string
+	ChaosDuration   int
+	Force           bool
+	TargetIDList    []string
+	Password        string
+	ChaosLib        *chaosLibDetails
+	TargetPodList   core_v1.PodList
+	ChaosInterval   string
+	unexportedField string
+}
+
+func TestGetFaultParameters(t *testing.T) {
+	details := &faultDetails{
+		ExperimentName:  "pod-delete",
+		ChaosDuration:   30,
+		Force:           true,
+		TargetIDList:    []string{"i-1", "i-2"},
+		Password:        "secret",
+		ChaosLib:        &chaosLibDetails{Sequence: "parallel"},
+		TargetPodList:   core_v1.PodList{Items: []core_v1.Pod{{}}},
+		unexportedField: "ignored",
+	}
+
+	assert.Equal(t, map[string]string{
+		"ExperimentName":    "pod-delete",
+		"ChaosDuration":     "30",
+		"Force":             "true",
+		"TargetIDList":      "i-1,i-2",
+		"Password":          "<redacted>",
+		"ChaosLib.Sequence": "parallel",
+	}, GetFaultParameters(details))
+}
+
+func TestStopForDryRun(t *testing.T) {
+	chaosDetails := &types.ChaosDetails{DryRun: true}
+	PlanTargets("EC2", "", []string{"i-1", "i-2", "i-1"}, chaosDetails)
+
+	err := StopForDryRun(&faultDetails{ExperimentName: "ec2-terminate-by-id"}, chaosDetails)
+	assert.Equal(t, cerrors.ErrorTypeDryRun, cerrors.GetErrorType(err))
+	assert.Len(t, chaosDetails.DryRunPlan.Targets, 2)
+	assert.Equal(t, "ec2-terminate-by-id", chaosDetails.DryRunPlan.FaultParameters["ExperimentName"])
+
+	// the targets are only planned in the dry run
+	chaosDetails = &types.ChaosDetails{}
+	PlanTargets("EC2", "", []string{"i-1"}, chaosDetails)
+	assert.Empty(t, chaosDetails.DryRunPlan.Targets)
+}
@@ -3,10 +3,8 @@ package common
 import (
 	"context"
 	"fmt"
-	"math/rand"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/palantir/stacktrace"
 	apiv1 "k8s.io/api/core/v1"
@@ -15,22 +13,31 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/math"
+	"github.com/litmuschaos/litmus-go/pkg/policy"
 	"github.com/litmuschaos/litmus-go/pkg/probe"
 	"github.com/litmuschaos/litmus-go/pkg/status"
 	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/random"
 )
 
 var err error
 
 // GetNodeList check for the availability of the application node for the chaos execution
 // if the application node is not defined it will derive the random target node list using node affected percentage
-func GetNodeList(nodeNames, nodeLabel string, nodeAffPerc int, clients clients.ClientSets) ([]string, error) {
+func GetNodeList(nodeNames, nodeLabel string, nodeAffPerc int, clients clients.ClientSets, chaosDetails *types.ChaosDetails) ([]string, error) {
 
 	var nodeList []string
 	var nodes *apiv1.NodeList
 
 	if nodeNames != "" {
 		targetNodesList := strings.Split(nodeNames, ",")
+		if targetNodesList, err = filterNodesByPDB(targetNodesList, len(targetNodesList), clients, chaosDetails); err != nil {
+			return nil, stacktrace.Propagate(err, "could not filter nodes by poddisruptionbudgets")
+		}
+		if err := policy.ValidateNodes(targetNodesList, clients); err != nil {
+			return nil, err
+		}
+		PlanTargets("node", "", targetNodesList, chaosDetails)
 		return targetNodesList, nil
 	}
 
@@ -51,20 +58,30 @@ func GetNodeList(nodeNames, nodeLabel string, nodeAffPerc int, clients clients.C
 
 	// it will generate the random nodelist
 	// it starts from the random index and choose requirement no of pods next to that index in a circular way.
-	rand.Seed(time.Now().UnixNano())
-	index := rand.Intn(len(nodes.Items))
+	index := random.Intn(len(nodes.Items))
 	for i := 0; i < newNodeListLength; i++ {
 		nodeList = append(nodeList, nodes.Items[index].Name)
 		index = (index + 1) % len(nodes.Items)
 	}
 
-	log.Infof("[Chaos]:Number of nodes targeted: %v", strconv.Itoa(newNodeListLength))
+	if nodeList, err = filterNodesByPDB(nodeList, newNodeListLength, clients, chaosDetails); err != nil {
+		return nil, stacktrace.Propagate(err, "could not filter nodes by poddisruptionbudgets")
+	}
+	if err := policy.ValidateNodes(nodeList, clients); err != nil {
+		return nil, err
+	}
+
+	log.Infof("[Chaos]:Number of nodes targeted: %v", strconv.Itoa(len(nodeList)))
+	PlanTargets("node", "", nodeList, chaosDetails)
 
 	return nodeList, nil
 }
 
 // GetNodeName will select a random replica of application pod and return the node name of that application pod
-func GetNodeName(namespace, labels, nodeLabel string, clients clients.ClientSets) (string, error) {
+// if the PodDisruptionBudget guard skips the selected node, the nodes of the next replicas are tried in a circular way
+func GetNodeName(namespace, labels, nodeLabel string, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (string, error) {
+
+	var candidates []string
 
 	switch nodeLabel {
 	case "":
@@ -75,18 +92,36 @@ func GetNodeName(namespace, labels, nodeLabel string, clients clients.ClientSets
 			return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{podLabel: %s, namespace: %s}", labels, namespace), Reason: "no pod found with matching labels"}
 		}
 
-		rand.Seed(time.Now().Unix())
-		randomIndex := rand.Intn(len(podList.Items))
-		return podList.Items[randomIndex].Spec.NodeName, nil
+		randomIndex := random.Intn(len(podList.Items))
+		for i := range podList.Items {
+			nodeName := podList.Items[(randomIndex+i)%len(podList.Items)].Spec.NodeName
+			if nodeName != "" && !Contains(nodeName, candidates) {
+				candidates = append(candidates, nodeName)
+			}
+		}
+		if len(candidates) == 0 {
+			return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{podLabel: %s, namespace: %s}", labels, namespace), Reason: "no scheduled pod found with matching labels"}
+		}
 	default:
 		nodeList, err := getNodesByLabels(nodeLabel, clients)
 		if err != nil {
 			return "", stacktrace.Propagate(err, "could not get nodes by labels")
 		}
-		rand.Seed(time.Now().Unix())
-		randomIndex := rand.Intn(len(nodeList.Items))
-		return nodeList.Items[randomIndex].Name, nil
+		randomIndex := random.Intn(len(nodeList.Items))
+		for i := range nodeList.Items {
+			candidates = append(candidates, nodeList.Items[(randomIndex+i)%len(nodeList.Items)].Name)
+		}
+	}
+
+	nodes, err := filterNodesByPDB(candidates, 1, clients, chaosDetails)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "could not filter nodes by poddisruptionbudgets")
+	}
+	if err := policy.ValidateNodes(nodes[:1], clients); err != nil {
+		return "", err
 	}
+	PlanTargets("node", "", nodes[:1], chaosDetails)
+	return nodes[0], nil
 }
 
 func getAllNodes(clients clients.ClientSets) (*apiv1.NodeList, error) {
@@ -110,6 +145,11 @@ func getNodesByLabels(nodeLabel string, clients clients.ClientSets) (*apiv1.Node
 }
 
 func checkHelperStatus(appLabel string, chaosDetails *types.ChaosDetails, clients clients.ClientSets) error {
+	// the helper pods are only planned in the dry run, so the run is stopped before they are awaited
+	if chaosDetails.DryRun {
+		return stopDryRun(chaosDetails)
+	}
+
 	// Checking the status of the helper pods
 	// if the pod doesn't transition to the 'running' state within a specified timeout period, consider the experiment unsuccessful
 	log.Info("[Status]: Checking the status of the helper pods")
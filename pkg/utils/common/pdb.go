@@ -0,0 +1,200 @@
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	core_v1 "k8s.io/api/core/v1"
+	policy_v1 "k8s.io/api/policy/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// PodDisruptionBudget guard modes
+const (
+	// PDBGuardDisabled doesn't check the PodDisruptionBudgets of the targets
+	PDBGuardDisabled = "disabled"
+	// PDBGuardSkip skips the targets whose disruption would exceed the disruptionsAllowed of a PodDisruptionBudget
+	PDBGuardSkip = "skip"
+	// PDBGuardReject fails the target selection if the disruption of any target would exceed the disruptionsAllowed of a PodDisruptionBudget
+	PDBGuardReject = "reject"
+)
+
+// pdbGuard tracks the remaining disruptions of the PodDisruptionBudgets while the targets are being selected
+// the budget is shared by all the targets of the run, so that the selected targets together don't exceed it
+type pdbGuard struct {
+	clients      clients.ClientSets
+	chaosDetails *types.ChaosDetails
+	pdbs         map[string][]policy_v1.PodDisruptionBudget
+	remaining    map[string]int32
+}
+
+// newPDBGuard returns the PodDisruptionBudget guard, it returns nil if the guard is disabled
+func newPDBGuard(clients clients.ClientSets, chaosDetails *types.ChaosDetails) (*pdbGuard, error) {
+	switch chaosDetails.PDBGuard {
+	case "", PDBGuardDisabled:
+		return nil, nil
+	case PDBGuardSkip, PDBGuardReject:
+		return &pdbGuard{
+			clients:      clients,
+			chaosDetails: chaosDetails,
+			pdbs:         map[string][]policy_v1.PodDisruptionBudget{},
+			remaining:    map[string]int32{},
+		}, nil
+	}
+	return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: fmt.Sprintf("unsupported PDB_GUARD: '%s', supported values are: %s,%s,%s", chaosDetails.PDBGuard, PDBGuardDisabled, PDBGuardSkip, PDBGuardReject)}
+}
+
+// allow checks whether the target can be disrupted without exceeding the PodDisruptionBudgets of the given pods
+// the disruptions of the allowed target are consumed from the budgets, the denied target is either skipped or rejected
+func (g *pdbGuard) allow(kind, name, namespace string, pods []core_v1.Pod) (bool, error) {
+	reason, err := g.admit(pods)
+	if err != nil {
+		return false, err
+	}
+	if reason == "" {
+		return true, nil
+	}
+
+	target := fmt.Sprintf("{%sName: %s, namespace: %s}", kind, name, namespace)
+	if namespace == "" {
+		target = fmt.Sprintf("{%sName: %s}", kind, name)
+	}
+	if g.chaosDetails.PDBGuard == PDBGuardReject {
+		return false, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: target, Reason: reason}
+	}
+	log.Warnf("[Skip]: Skipping the target %s, %s", target, reason)
+	g.chaosDetails.SkippedTargets = append(g.chaosDetails.SkippedTargets, types.SkippedTarget{
+		Kind:      kind,
+		Name:      name,
+		Namespace: namespace,
+		Reason:    reason,
+	})
+	return false, nil
+}
+
+// admit consumes the disruptions of the given pods from the budgets of the matching PodDisruptionBudgets
+// it returns the reason, without consuming anything, if any budget would be exceeded
+func (g *pdbGuard) admit(pods []core_v1.Pod) (string, error) {
+	disruptions := map[string]int32{}
+	var order []string
+	for _, pod := range pods {
+		if pod.Status.Phase == core_v1.PodSucceeded || pod.Status.Phase == core_v1.PodFailed {
+			continue
+		}
+		pdbs, err := g.getPDBs(pod.Namespace)
+		if err != nil {
+			return "", err
+		}
+		for _, pdb := range pdbs {
+			selector, err := v1.LabelSelectorAsSelector(pdb.Spec.Selector)
+			if err != nil || !selector.Matches(labels.Set(pod.Labels)) {
+				continue
+			}
+			key := pdb.Namespace + "/" + pdb.Name
+			if _, ok := disruptions[key]; !ok {
+				order = append(order, key)
+			}
+			disruptions[key]++
+		}
+	}
+
+	for _, key := range order {
+		if disruptions[key] > g.remaining[key] {
+			return fmt.Sprintf("disruption of %d pod(s) exceeds the remaining disruptionsAllowed (%d) of PodDisruptionBudget %s", disruptions[key], g.remaining[key], key), nil
+		}
+	}
+	for key, count := range disruptions {
+		g.remaining[key] -= count
+	}
+	return "", nil
+}
+
+// getPDBs returns the PodDisruptionBudgets of the given namespace
+func (g *pdbGuard) getPDBs(namespace string) ([]policy_v1.PodDisruptionBudget, error) {
+	if pdbs, ok := g.pdbs[namespace]; ok {
+		return pdbs, nil
+	}
+	pdbList, err := g.clients.KubeClient.PolicyV1().PodDisruptionBudgets(namespace).List(context.Background(), v1.ListOptions{})
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{namespace: %s}", namespace), Reason: fmt.Sprintf("failed to list poddisruptionbudgets: %s", err.Error())}
+	}
+	for _, pdb := range pdbList.Items {
+		g.remaining[pdb.Namespace+"/"+pdb.Name] = pdb.Status.DisruptionsAllowed
+	}
+	g.pdbs[namespace] = pdbList.Items
+	return pdbList.Items, nil
+}
+
+// getPodsOnNode returns the pods scheduled on the given node, these are disrupted along with the node
+func (g *pdbGuard) getPodsOnNode(nodeName string) ([]core_v1.Pod, error) {
+	pods, err := g.clients.KubeClient.CoreV1().Pods("").List(context.Background(), v1.ListOptions{FieldSelector: "spec.nodeName=" + nodeName})
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{nodeName: %s}", nodeName), Reason: fmt.Sprintf("failed to list pods: %s", err.Error())}
+	}
+	return pods.Items, nil
+}
+
+// filterPodsByPDB removes the target pods whose disruption would exceed their PodDisruptionBudgets
+func filterPodsByPDB(pods core_v1.PodList, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (core_v1.PodList, error) {
+	guard, err := newPDBGuard(clients, chaosDetails)
+	if err != nil || guard == nil {
+		return pods, err
+	}
+
+	var filteredPods core_v1.PodList
+	for _, pod := range pods.Items {
+		allowed, err := guard.allow("pod", pod.Name, pod.Namespace, []core_v1.Pod{pod})
+		if err != nil {
+			return core_v1.PodList{}, err
+		}
+		if allowed {
+			filteredPods.Items = append(filteredPods.Items, pod)
+		}
+	}
+	if len(filteredPods.Items) == 0 {
+		return filteredPods, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: GetAppDetailsForLogging(chaosDetails.AppDetail), Reason: "all the target pods are skipped by the PodDisruptionBudget guard"}
+	}
+	return filteredPods, nil
+}
+
+// filterNodesByPDB removes the target nodes, whose disruption would exceed the PodDisruptionBudgets of the pods scheduled on them
+// it returns at most limit nodes, the rest of the nodes are only checked if the preceding nodes are skipped
+func filterNodesByPDB(nodes []string, limit int, clients clients.ClientSets, chaosDetails *types.ChaosDetails) ([]string, error) {
+	guard, err := newPDBGuard(clients, chaosDetails)
+	if err != nil {
+		return nil, err
+	}
+	if guard == nil {
+		if len(nodes) > limit {
+			return nodes[:limit], nil
+		}
+		return nodes, nil
+	}
+
+	var filteredNodes []string
+	for _, node := range nodes {
+		if len(filteredNodes) == limit {
+			break
+		}
+		pods, err := guard.getPodsOnNode(node)
+		if err != nil {
+			return nil, err
+		}
+		allowed, err := guard.allow("node", node, "", pods)
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			filteredNodes = append(filteredNodes, node)
+		}
+	}
+	if len(filteredNodes) == 0 {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{nodes: %v}", nodes), Reason: "all the target nodes are skipped by the PodDisruptionBudget guard"}
+	}
+	return filteredNodes, nil
+}
@@ -0,0 +1,58 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+	policy_v1 "k8s.io/api/policy/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_pdbGuard_allow(t *testing.T) {
+	newPod := func(name string) core_v1.Pod {
+		return core_v1.Pod{ObjectMeta: v1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "nginx"}}}
+	}
+	newGuard := func(mode string) *pdbGuard {
+		chaosDetails := &types.ChaosDetails{PDBGuard: mode}
+		guard, _ := newPDBGuard(clients.ClientSets{}, chaosDetails)
+		// the budgets of the namespace are pre-populated, so that the guard doesn't list them
+		guard.pdbs["default"] = []policy_v1.PodDisruptionBudget{{
+			ObjectMeta: v1.ObjectMeta{Name: "nginx-pdb", Namespace: "default"},
+			Spec:       policy_v1.PodDisruptionBudgetSpec{Selector: &v1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}}},
+		}}
+		guard.remaining["default/nginx-pdb"] = 1
+		return guard
+	}
+
+	t.Run("skip records the targets exceeding the budget", func(t *testing.T) {
+		guard := newGuard(PDBGuardSkip)
+		allowed, err := guard.allow("pod", "nginx-1", "default", []core_v1.Pod{newPod("nginx-1")})
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+
+		allowed, err = guard.allow("pod", "nginx-2", "default", []core_v1.Pod{newPod("nginx-2")})
+		assert.NoError(t, err)
+		assert.False(t, allowed)
+		assert.Len(t, guard.chaosDetails.SkippedTargets, 1)
+		assert.Equal(t, "nginx-2", guard.chaosDetails.SkippedTargets[0].Name)
+	})
+
+	t.Run("reject fails the targets exceeding the budget", func(t *testing.T) {
+		guard := newGuard(PDBGuardReject)
+		_, err := guard.allow("node", "node-1", "", []core_v1.Pod{newPod("nginx-1"), newPod("nginx-2")})
+		assert.Error(t, err)
+		assert.Empty(t, guard.chaosDetails.SkippedTargets)
+	})
+
+	t.Run("pods without matching budget are allowed", func(t *testing.T) {
+		guard := newGuard(PDBGuardReject)
+		pod := newPod("redis-1")
+		pod.Labels = map[string]string{"app": "redis"}
+		allowed, err := guard.allow("pod", "redis-1", "default", []core_v1.Pod{pod, pod})
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+	})
+}
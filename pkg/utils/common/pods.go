@@ -3,7 +3,6 @@ package common
 import (
 	"context"
 	"fmt"
-	"math/rand"
 	"os"
 	"os/exec"
 	"strconv"
@@ -18,6 +17,7 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/math"
+	"github.com/litmuschaos/litmus-go/pkg/policy"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	"github.com/litmuschaos/litmus-go/pkg/workloads"
@@ -158,6 +158,15 @@ func VerifyExistanceOfPods(namespace, pods string, clients clients.ClientSets) (
 // GetPodList check for the availability of the target pod for the chaos execution
 // if the target pod is not defined it will derive the random target pod list using pod affected percentage
 func GetPodList(targetPods string, podAffPerc int, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (core_v1.PodList, error) {
+	pods, err := getPodList(targetPods, podAffPerc, clients, chaosDetails)
+	if err != nil {
+		return core_v1.PodList{}, err
+	}
+	planPods(pods.Items, chaosDetails)
+	return pods, nil
+}
+
+func getPodList(targetPods string, podAffPerc int, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (core_v1.PodList, error) {
 	finalPods := core_v1.PodList{}
 	var namespace string
 	if chaosDetails.AppDetail != nil {
@@ -179,6 +188,12 @@ func GetPodList(targetPods string, podAffPerc int, clients clients.ClientSets, c
 		if err != nil {
 			return core_v1.PodList{}, stacktrace.Propagate(err, "could not get target pods when TARGET_PODS env set")
 		}
+		// the given pods are filtered by the revision as well, all of them are targeted if they belong to the revision
+		podList, revisions, err := filterPodsByRevision(podList, clients, chaosDetails)
+		if err != nil {
+			return core_v1.PodList{}, stacktrace.Propagate(err, "could not filter pods by revision")
+		}
+		setTargetRevisions(podList, revisions, chaosDetails)
 		finalPods.Items = append(finalPods.Items, podList.Items...)
 	default:
 		podList, err := GetTargetPodsWhenTargetPodsENVNotSet(podAffPerc, clients, chaosDetails)
@@ -284,7 +299,7 @@ func GetTargetPodsWhenTargetPodsENVNotSet(podAffPerc int, clients clients.Client
 		if err != nil {
 			return finalPods, stacktrace.Propagate(err, "could not filter non chaos pods")
 		}
-		return filterPodsByPercentage(pods, podAffPerc), nil
+		return filterPodsByPercentage(pods, podAffPerc, clients, chaosDetails)
 	}
 
 	for _, target := range chaosDetails.AppDetail {
@@ -328,38 +343,38 @@ func GetTargetPodsWhenTargetPodsENVNotSet(podAffPerc int, clients clients.Client
 	if podKind {
 		return finalPods, nil
 	}
-	return filterPodsByPercentage(finalPods, podAffPerc), nil
+	return filterPodsByPercentage(finalPods, podAffPerc, clients, chaosDetails)
 }
 
 func filterPodsByOwnerKind(pods []core_v1.Pod, target types.AppDetails, clients clients.ClientSets) ([]core_v1.Pod, error) {
 	var filteredPods []core_v1.Pod
 	for _, pod := range pods {
-		parentType, _, err := workloads.GetPodOwnerTypeAndName(&pod, clients.DynamicClient)
+		owned, err := workloads.IsPodOwnedBy(target, "", &pod, clients.DynamicClient)
 		if err != nil {
 			return nil, err
 		}
-		if target.Kind == parentType {
+		if owned {
 			filteredPods = append(filteredPods, pod)
 		}
 	}
 	return filteredPods, nil
 }
 
-func filterPodsByPercentage(finalPods core_v1.PodList, podAffPerc int) core_v1.PodList {
+func filterPodsByPercentage(finalPods core_v1.PodList, podAffPerc int, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (core_v1.PodList, error) {
 	finalPods = removeDuplicatePods(finalPods)
 
-	newPodListLength := math.Maximum(1, math.Adjustment(math.Minimum(podAffPerc, 100), len(finalPods.Items)))
-	rand.Seed(time.Now().UnixNano())
+	finalPods, revisions, err := filterPodsByRevision(finalPods, clients, chaosDetails)
+	if err != nil {
+		return core_v1.PodList{}, stacktrace.Propagate(err, "could not filter pods by revision")
+	}
 
-	var realPods core_v1.PodList
-	// it will generate the random podlist
-	// it starts from the random index and choose requirement no of pods next to that index in a circular way.
-	index := rand.Intn(len(finalPods.Items))
-	for i := 0; i < newPodListLength; i++ {
-		realPods.Items = append(realPods.Items, finalPods.Items[index])
-		index = (index + 1) % len(finalPods.Items)
+	newPodListLength := math.Maximum(1, math.Adjustment(math.Minimum(podAffPerc, 100), len(finalPods.Items)))
+	selectedPods, err := selectTargetPods(finalPods, newPodListLength, clients, chaosDetails)
+	if err != nil {
+		return core_v1.PodList{}, err
 	}
-	return realPods
+	setTargetRevisions(selectedPods, revisions, chaosDetails)
+	return selectedPods, nil
 }
 
 // DeleteHelperPodBasedOnJobCleanupPolicy deletes specific helper pod based on jobCleanupPolicy
@@ -539,11 +554,11 @@ func GetPodListFromSpecifiedNodes(podAffPerc int, nodeLabel string, clients clie
 		return core_v1.PodList{}, err
 	}
 
-	return getTargetPodsWhenNodeFilterSet(podAffPerc, pods, nodeNames)
+	return getTargetPodsWhenNodeFilterSet(podAffPerc, pods, nodeNames, clients, chaosDetails)
 }
 
 // getTargetPodsWhenNodeFilterSet will give the target pod when the node filter is setup
-func getTargetPodsWhenNodeFilterSet(podAffPerc int, pods core_v1.PodList, nodes []string) (core_v1.PodList, error) {
+func getTargetPodsWhenNodeFilterSet(podAffPerc int, pods core_v1.PodList, nodes []string, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (core_v1.PodList, error) {
 	nodeFilteredPods := core_v1.PodList{}
 
 	// add filter for pods which are on derived node list
@@ -561,7 +576,7 @@ func getTargetPodsWhenNodeFilterSet(podAffPerc int, pods core_v1.PodList, nodes
 		return nodeFilteredPods, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{nodes: %v}", nodes), Reason: "no pod found on specified node(s)"}
 	}
 
-	return filterPodsByPercentage(nodeFilteredPods, podAffPerc), nil
+	return filterPodsByPercentage(nodeFilteredPods, podAffPerc, clients, chaosDetails)
 }
 
 func GetTargetPods(nodeLabel, targetPods, podsAffectedPerc string, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (core_v1.PodList, error) {
@@ -579,18 +594,27 @@ func GetTargetPods(nodeLabel, targetPods, podsAffectedPerc string, clients clien
 		if targetPods != "" && nodeLabel != "" {
 			log.Infof("TARGET_PODS env is provided, overriding the NODE_LABEL input")
 		}
-		pods, err = GetPodList(targetPods, podAffectedPerc, clients, chaosDetails)
+		pods, err = getPodList(targetPods, podAffectedPerc, clients, chaosDetails)
 		if err != nil {
 			return core_v1.PodList{}, err
 		}
 	}
 
+	if pods, err = filterPodsByPDB(pods, clients, chaosDetails); err != nil {
+		return core_v1.PodList{}, stacktrace.Propagate(err, "could not filter pods by poddisruptionbudgets")
+	}
+
+	if err := policy.ValidatePods(pods.Items); err != nil {
+		return core_v1.PodList{}, err
+	}
+
 	podNames := []string{}
 	for _, pod := range pods.Items {
 		podNames = append(podNames, pod.Name)
 	}
 	log.Infof("[Chaos]:Number of pods targeted: %v", len(pods.Items))
 	log.Infof("Target pods list for chaos, %v", podNames)
+	planPods(pods.Items, chaosDetails)
 
 	return pods, nil
 }
@@ -673,6 +697,9 @@ func ParseTargets(source string) (*TargetsDetails, error) {
 func GetAppDetailsForLogging(appDetails []types.AppDetails) string {
 	var result []string
 	for _, k := range appDetails {
+		if k.APIVersion != "" {
+			k.Kind = k.APIVersion + "/" + k.Kind
+		}
 		if k.Labels != nil {
 			result = append(result, fmt.Sprintf("{namespace: %s, kind: %s, labels: %s}", k.Namespace, k.Kind, k.Labels))
 			continue
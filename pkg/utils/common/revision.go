@@ -0,0 +1,129 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/workloads"
+	core_v1 "k8s.io/api/core/v1"
+)
+
+// target revisions
+const (
+	// RevisionCanary targets the pods of the revision, which is being rolled out
+	RevisionCanary = "canary"
+	// RevisionStable targets the pods of the stable revisions
+	RevisionStable = "stable"
+)
+
+// filterPodsByRevision keeps the pods of the TARGET_REVISION revision of their workloads
+// it returns the filtered pods along with their revisions, keyed by podKey
+func filterPodsByRevision(pods core_v1.PodList, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (core_v1.PodList, map[string]workloads.Revision, error) {
+	target := chaosDetails.TargetSelection.Revision
+	if target == "" {
+		return pods, nil, nil
+	}
+
+	revisions := make([]workloads.Revision, len(pods.Items))
+	for i := range pods.Items {
+		var err error
+		if revisions[i], err = workloads.GetPodRevision(&pods.Items[i], chaosDetails.TargetSelection.RevisionLabel, clients.DynamicClient); err != nil {
+			return core_v1.PodList{}, nil, err
+		}
+	}
+	selected, err := selectRevisions(revisions, target)
+	if err != nil {
+		return core_v1.PodList{}, nil, err
+	}
+
+	var filteredPods core_v1.PodList
+	filteredRevisions := map[string]workloads.Revision{}
+	for i, pod := range pods.Items {
+		if selected[i] {
+			filteredPods.Items = append(filteredPods.Items, pod)
+			filteredRevisions[podKey(pod)] = revisions[i]
+		}
+	}
+	if len(filteredPods.Items) == 0 {
+		return core_v1.PodList{}, nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: GetAppDetailsForLogging(chaosDetails.AppDetail), Reason: fmt.Sprintf("no target pods found in the '%s' revision", target)}
+	}
+	log.Infof("[Info]: %d out of %d pods belong to the '%s' revision", len(filteredPods.Items), len(pods.Items), target)
+	return filteredPods, filteredRevisions, nil
+}
+
+// selectRevisions checks whether the given revisions match the target revision
+// the canary is the latest revision of the workload, while the rest of its revisions are stable, the single revision of a
+// workload is always stable. the stable revision of the argo rollouts is derived from the status of the rollout instead
+func selectRevisions(revisions []workloads.Revision, target string) ([]bool, error) {
+	selected := make([]bool, len(revisions))
+	target = strings.TrimSpace(target)
+	if !strings.EqualFold(target, RevisionCanary) && !strings.EqualFold(target, RevisionStable) {
+		for i, revision := range revisions {
+			selected[i] = revision.Hash != "" && revision.Hash == target
+		}
+		return selected, nil
+	}
+
+	latest, hashes := map[string]int{}, map[string]map[string]bool{}
+	for _, revision := range revisions {
+		if revision.Hash == "" {
+			continue
+		}
+		key := workloadKey(revision.Workload)
+		if hashes[key] == nil {
+			hashes[key] = map[string]bool{}
+		}
+		hashes[key][revision.Hash] = true
+		latest[key] = max(latest[key], revision.Number)
+	}
+
+	for i, revision := range revisions {
+		if revision.Hash == "" {
+			continue
+		}
+		key := workloadKey(revision.Workload)
+		stable := true
+		switch {
+		case revision.StableHash != "":
+			stable = revision.Hash == revision.StableHash
+		case len(hashes[key]) > 1:
+			if latest[key] == 0 {
+				return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{%sName: %s, namespace: %s}", revision.Workload.Kind, revision.Workload.Name, revision.Workload.Namespace), Reason: "the revisions of the workload can't be ordered, provide the revision in TARGET_REVISION instead of canary or stable"}
+			}
+			stable = revision.Number != latest[key]
+		}
+		selected[i] = stable == strings.EqualFold(target, RevisionStable)
+	}
+	return selected, nil
+}
+
+// setTargetRevisions records the revisions of the selected pods in the parent resources and the targets of the chaosresult
+func setTargetRevisions(pods core_v1.PodList, revisions map[string]workloads.Revision, chaosDetails *types.ChaosDetails) {
+	for _, pod := range pods.Items {
+		revision, ok := revisions[podKey(pod)]
+		if !ok {
+			continue
+		}
+		setParentRevision(revision.Workload, revision.Hash, chaosDetails)
+		SetTargets(revision.Workload.Name+"@"+revision.Hash, "targeted", "revision", chaosDetails)
+	}
+}
+
+// setParentRevision sets the targeted revision of the parent in chaosdetails struct
+func setParentRevision(parent workloads.Workload, revision string, chaosDetails *types.ChaosDetails) {
+	for i := range chaosDetails.ParentsResources {
+		if chaosDetails.ParentsResources[i].Name == parent.Name && chaosDetails.ParentsResources[i].Namespace == parent.Namespace {
+			chaosDetails.ParentsResources[i].Revision = revision
+			return
+		}
+	}
+	chaosDetails.ParentsResources = append(chaosDetails.ParentsResources, types.ParentResource{Name: parent.Name, Kind: parent.Kind, Namespace: parent.Namespace, Revision: revision})
+}
+
+func workloadKey(workload workloads.Workload) string {
+	return fmt.Sprintf("%s/%s/%s", workload.Kind, workload.Namespace, workload.Name)
+}
@@ -0,0 +1,89 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/workloads"
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+)
+
+func Test_selectRevisions(t *testing.T) {
+	deployment := workloads.Workload{Name: "nginx", Kind: "deployment", Namespace: "default"}
+	rollout := workloads.Workload{Name: "api", Kind: "rollout", Namespace: "default"}
+	single := workloads.Workload{Name: "db", Kind: "deployment", Namespace: "default"}
+
+	revisions := []workloads.Revision{
+		{Workload: deployment, Hash: "6d4cf56db6", Number: 3},
+		{Workload: deployment, Hash: "7c9f8b5d4", Number: 4},
+		{Workload: rollout, Hash: "5b8c7d", Number: 7, StableHash: "5b8c7d"},
+		{Workload: rollout, Hash: "9f7e6a", Number: 6, StableHash: "5b8c7d"},
+		{Workload: single, Hash: "84f5c9", Number: 2},
+		{},
+	}
+
+	tests := []struct {
+		name     string
+		target   string
+		expected []bool
+	}{
+		{
+			name:     "canary is the latest revision, or the revision which is not stable in the rollout",
+			target:   "canary",
+			expected: []bool{false, true, false, true, false, false},
+		},
+		{
+			name:     "stable is the rest of the revisions",
+			target:   "Stable",
+			expected: []bool{true, false, true, false, true, false},
+		},
+		{
+			name:     "revision hash",
+			target:   "7c9f8b5d4",
+			expected: []bool{false, true, false, false, false, false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := selectRevisions(revisions, tt.target)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, selected)
+		})
+	}
+
+	t.Run("replicasets without workload are separate workloads", func(t *testing.T) {
+		selected, err := selectRevisions([]workloads.Revision{
+			{Workload: workloads.Workload{Name: "cache-6b5f7", Kind: "replicaset", Namespace: "default"}, Hash: "6b5f7"},
+			{Workload: workloads.Workload{Name: "queue-8d9c4", Kind: "replicaset", Namespace: "default"}, Hash: "8d9c4"},
+		}, RevisionStable)
+		assert.NoError(t, err)
+		assert.Equal(t, []bool{true, true}, selected)
+	})
+
+	t.Run("unordered revisions can't be split into canary and stable", func(t *testing.T) {
+		_, err := selectRevisions([]workloads.Revision{
+			{Workload: deployment, Hash: "v1"},
+			{Workload: deployment, Hash: "v2"},
+		}, RevisionCanary)
+		assert.Error(t, err)
+	})
+}
+
+func Test_setTargetRevisions(t *testing.T) {
+	pods, _, _ := newSelectionCandidates("zone-a")
+	revision := workloads.Revision{Workload: workloads.Workload{Name: "nginx", Kind: "deployment", Namespace: "default"}, Hash: "7c9f8b5d4"}
+	revisions := map[string]workloads.Revision{}
+	for _, pod := range pods {
+		revisions[podKey(pod)] = revision
+	}
+	chaosDetails := &types.ChaosDetails{}
+
+	setTargetRevisions(core_v1.PodList{Items: pods}, revisions, chaosDetails)
+
+	assert.Equal(t, []types.ParentResource{{Name: "nginx", Kind: "deployment", Namespace: "default", Revision: "7c9f8b5d4"}}, chaosDetails.ParentsResources)
+	if assert.Len(t, chaosDetails.Targets, 1) {
+		assert.Equal(t, "nginx@7c9f8b5d4", chaosDetails.Targets[0].Name)
+		assert.Equal(t, "revision", chaosDetails.Targets[0].Kind)
+	}
+}
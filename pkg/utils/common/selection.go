@@ -0,0 +1,228 @@
+package common
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/random"
+	"github.com/litmuschaos/litmus-go/pkg/workloads"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	core_v1 "k8s.io/api/core/v1"
+)
+
+// target selection strategies
+const (
+	// SelectionRandom picks the required number of pods at random
+	SelectionRandom = "random"
+	// SelectionSpread picks at most MAX_PODS_PER_TOPOLOGY pods from every topology domain
+	SelectionSpread = "spread"
+	// SelectionConcentrate picks all the pods of a single topology domain
+	SelectionConcentrate = "concentrate"
+	// SelectionExcludeLastReplica never picks all the replicas of a workload, it can be combined with the other strategies
+	SelectionExcludeLastReplica = "exclude-last-replica"
+)
+
+// selectTargetPods selects count pods out of the candidate pods based on the target selection strategy
+// the selection is drawn from the seeded random source, so a failed run can be replayed with the same targets using its CHAOS_SEED
+func selectTargetPods(pods core_v1.PodList, count int, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (core_v1.PodList, error) {
+	selection := chaosDetails.TargetSelection
+
+	strategy, excludeLastReplica, err := parseSelectionStrategy(selection.Strategies)
+	if err != nil {
+		return core_v1.PodList{}, err
+	}
+
+	candidates := sortPods(pods.Items)
+
+	var domains, owners map[string]string
+	if strategy == SelectionSpread || strategy == SelectionConcentrate {
+		if selection.MaxPodsPerDomain < 1 {
+			return core_v1.PodList{}, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: fmt.Sprintf("invalid MAX_PODS_PER_TOPOLOGY: %d, it should be greater than zero", selection.MaxPodsPerDomain)}
+		}
+		if domains, err = getTopologyDomains(candidates, selection.TopologyKey, clients, chaosDetails); err != nil {
+			return core_v1.PodList{}, stacktrace.Propagate(err, "could not get topology domains of the pods")
+		}
+	}
+	if excludeLastReplica {
+		if owners, err = getPodOwners(candidates, clients); err != nil {
+			return core_v1.PodList{}, stacktrace.Propagate(err, "could not get owners of the pods")
+		}
+	}
+
+	log.InfoWithValues("[Info]: Target selection details:", logrus.Fields{
+		"Strategy":           strings.Join(selection.Strategies, ","),
+		"TopologyKey":        selection.TopologyKey,
+		"MaxPodsPerTopology": selection.MaxPodsPerDomain,
+		"Seed":               chaosDetails.Seed,
+	})
+
+	selected := selectPods(candidates, count, strategy, selection.MaxPodsPerDomain, domains, owners, random.Rand())
+	if len(selected) == 0 {
+		return core_v1.PodList{}, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: GetAppDetailsForLogging(chaosDetails.AppDetail), Reason: fmt.Sprintf("no target pods left after applying the '%s' selection strategy", strings.Join(selection.Strategies, ","))}
+	}
+	return core_v1.PodList{Items: selected}, nil
+}
+
+// parseSelectionStrategy validates the selection strategies
+// it returns the primary strategy and whether the last replica of the workloads should be excluded
+func parseSelectionStrategy(strategies []string) (string, bool, error) {
+	strategy, excludeLastReplica := "", false
+	for _, s := range strategies {
+		switch s {
+		case SelectionExcludeLastReplica:
+			excludeLastReplica = true
+		case SelectionRandom, SelectionSpread, SelectionConcentrate:
+			if strategy != "" && strategy != s {
+				return "", false, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: fmt.Sprintf("'%s' and '%s' selection strategies can't be combined", strategy, s)}
+			}
+			strategy = s
+		default:
+			return "", false, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: fmt.Sprintf("unsupported TARGET_SELECTION_STRATEGY: '%s', supported strategies are: %s,%s,%s,%s", s, SelectionRandom, SelectionSpread, SelectionConcentrate, SelectionExcludeLastReplica)}
+		}
+	}
+	if strategy == "" {
+		strategy = SelectionRandom
+	}
+	return strategy, excludeLastReplica, nil
+}
+
+// selectPods selects the pods out of the sorted candidate pods, all the randomness is derived from the given generator
+// domains and owners contain the topology domain and the owner workload of the pods, keyed by podKey
+func selectPods(candidates []core_v1.Pod, count int, strategy string, maxPerDomain int, domains, owners map[string]string, rng *rand.Rand) []core_v1.Pod {
+	pods := append([]core_v1.Pod{}, candidates...)
+	rng.Shuffle(len(pods), func(i, j int) { pods[i], pods[j] = pods[j], pods[i] })
+
+	// the number of candidate pods of every owner, one of them is always left out if exclude-last-replica is set
+	replicas := map[string]int{}
+	for _, pod := range pods {
+		if owner := owners[podKey(pod)]; owner != "" {
+			replicas[owner]++
+		}
+	}
+	selectedReplicas := map[string]int{}
+	canSelect := func(pod core_v1.Pod) bool {
+		owner := owners[podKey(pod)]
+		return owner == "" || selectedReplicas[owner]+1 < replicas[owner]
+	}
+
+	var selected []core_v1.Pod
+	add := func(pod core_v1.Pod) {
+		selected = append(selected, pod)
+		if owner := owners[podKey(pod)]; owner != "" {
+			selectedReplicas[owner]++
+		}
+	}
+
+	switch strategy {
+	case SelectionSpread:
+		// group the shuffled pods by domain and pick them round-robin, so that the targets are spread evenly
+		var order []string
+		grouped := map[string][]core_v1.Pod{}
+		for _, pod := range pods {
+			domain := domains[podKey(pod)]
+			if _, ok := grouped[domain]; !ok {
+				order = append(order, domain)
+			}
+			grouped[domain] = append(grouped[domain], pod)
+		}
+		picked := map[string]int{}
+		for progress := true; progress && len(selected) < count; {
+			progress = false
+			for _, domain := range order {
+				if len(selected) == count {
+					break
+				}
+				for len(grouped[domain]) != 0 && picked[domain] < maxPerDomain {
+					pod := grouped[domain][0]
+					grouped[domain] = grouped[domain][1:]
+					if canSelect(pod) {
+						add(pod)
+						picked[domain]++
+						progress = true
+						break
+					}
+				}
+			}
+		}
+	case SelectionConcentrate:
+		// all the pods of the domain of the first shuffled pod are selected
+		if len(pods) == 0 {
+			return nil
+		}
+		domain := domains[podKey(pods[0])]
+		for _, pod := range pods {
+			if domains[podKey(pod)] == domain && canSelect(pod) {
+				add(pod)
+			}
+		}
+	default:
+		for _, pod := range pods {
+			if len(selected) == count {
+				break
+			}
+			if canSelect(pod) {
+				add(pod)
+			}
+		}
+	}
+	return selected
+}
+
+// getTopologyDomains returns the value of the topology key on the node of every pod
+// the pods which are not scheduled, or whose node doesn't have the topology key, are grouped into an empty domain
+func getTopologyDomains(pods []core_v1.Pod, topologyKey string, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (map[string]string, error) {
+	domains := map[string]string{}
+	nodeDomains := map[string]string{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		domain, ok := nodeDomains[pod.Spec.NodeName]
+		if !ok {
+			node, err := clients.GetNode(pod.Spec.NodeName, chaosDetails.Timeout, chaosDetails.Delay)
+			if err != nil {
+				return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{nodeName: %s}", pod.Spec.NodeName), Reason: err.Error()}
+			}
+			domain = node.Labels[topologyKey]
+			nodeDomains[pod.Spec.NodeName] = domain
+		}
+		domains[podKey(pod)] = domain
+	}
+	return domains, nil
+}
+
+// getPodOwners returns the owner workload of every pod, the pods without any owner are not part of any workload
+func getPodOwners(pods []core_v1.Pod, clients clients.ClientSets) (map[string]string, error) {
+	owners := map[string]string{}
+	for i := range pods {
+		if len(pods[i].OwnerReferences) == 0 {
+			continue
+		}
+		kind, name, err := workloads.GetPodOwnerTypeAndName(&pods[i], clients.DynamicClient)
+		if err != nil {
+			return nil, err
+		}
+		owners[podKey(pods[i])] = fmt.Sprintf("%s/%s/%s", kind, pods[i].Namespace, name)
+	}
+	return owners, nil
+}
+
+// sortPods returns a copy of the pods sorted by namespace and name, so that the selection doesn't depend on the listing order
+func sortPods(pods []core_v1.Pod) []core_v1.Pod {
+	sorted := append([]core_v1.Pod{}, pods...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return podKey(sorted[i]) < podKey(sorted[j])
+	})
+	return sorted
+}
+
+func podKey(pod core_v1.Pod) string {
+	return pod.Namespace + "/" + pod.Name
+}
@@ -0,0 +1,85 @@
+package common
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// newSelectionCandidates creates 3 replicas of the same workload in every zone
+func newSelectionCandidates(zones ...string) ([]core_v1.Pod, map[string]string, map[string]string) {
+	var pods []core_v1.Pod
+	domains, owners := map[string]string{}, map[string]string{}
+	for _, zone := range zones {
+		for i := 0; i < 3; i++ {
+			pod := core_v1.Pod{ObjectMeta: v1.ObjectMeta{Name: fmt.Sprintf("nginx-%s-%d", zone, i), Namespace: "default"}}
+			domains[podKey(pod)] = zone
+			owners[podKey(pod)] = "deployment/default/nginx"
+			pods = append(pods, pod)
+		}
+	}
+	return pods, domains, owners
+}
+
+func podNames(pods []core_v1.Pod) []string {
+	var names []string
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	return names
+}
+
+func Test_selectPods(t *testing.T) {
+	pods, domains, owners := newSelectionCandidates("zone-a", "zone-b", "zone-c")
+
+	t.Run("same seed selects the same pods", func(t *testing.T) {
+		first := selectPods(sortPods(pods), 4, SelectionRandom, 1, nil, nil, rand.New(rand.NewSource(42)))
+		reversed := append([]core_v1.Pod{}, pods...)
+		for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+			reversed[i], reversed[j] = reversed[j], reversed[i]
+		}
+		second := selectPods(sortPods(reversed), 4, SelectionRandom, 1, nil, nil, rand.New(rand.NewSource(42)))
+		assert.Len(t, first, 4)
+		assert.Equal(t, podNames(first), podNames(second))
+	})
+
+	t.Run("spread picks at most max pods per domain", func(t *testing.T) {
+		selected := selectPods(sortPods(pods), 9, SelectionSpread, 2, domains, nil, rand.New(rand.NewSource(7)))
+		assert.Len(t, selected, 6)
+		perDomain := map[string]int{}
+		for _, pod := range selected {
+			perDomain[domains[podKey(pod)]]++
+		}
+		assert.Equal(t, map[string]int{"zone-a": 2, "zone-b": 2, "zone-c": 2}, perDomain)
+	})
+
+	t.Run("concentrate picks all pods of one domain", func(t *testing.T) {
+		selected := selectPods(sortPods(pods), 1, SelectionConcentrate, 1, domains, nil, rand.New(rand.NewSource(7)))
+		assert.Len(t, selected, 3)
+		for _, pod := range selected {
+			assert.Equal(t, domains[podKey(selected[0])], domains[podKey(pod)])
+		}
+	})
+
+	t.Run("exclude-last-replica leaves one replica of the workload", func(t *testing.T) {
+		selected := selectPods(sortPods(pods), 9, SelectionRandom, 1, nil, owners, rand.New(rand.NewSource(7)))
+		assert.Len(t, selected, 8)
+	})
+}
+
+func Test_parseSelectionStrategy(t *testing.T) {
+	strategy, excludeLastReplica, err := parseSelectionStrategy([]string{SelectionSpread, SelectionExcludeLastReplica})
+	assert.NoError(t, err)
+	assert.Equal(t, SelectionSpread, strategy)
+	assert.True(t, excludeLastReplica)
+
+	_, _, err = parseSelectionStrategy([]string{SelectionSpread, SelectionConcentrate})
+	assert.Error(t, err)
+
+	_, _, err = parseSelectionStrategy([]string{"round-robin"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,65 @@
+package random
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// lockedSource is a seeded random source, which is safe for the concurrent use
+type lockedSource struct {
+	mu   sync.Mutex
+	src  rand.Source64
+	seed int64
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Uint64()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+	s.seed = seed
+}
+
+var (
+	source = newLockedSource(time.Now().UnixNano())
+	rnd    = rand.New(source)
+)
+
+func newLockedSource(seed int64) *lockedSource {
+	return &lockedSource{src: rand.NewSource(seed).(rand.Source64), seed: seed}
+}
+
+// SetSeed seeds the random source of the experiment
+// all the random decisions of the run are derived from this source, so a run can be replayed with the same seed
+func SetSeed(seed int64) {
+	source.Seed(seed)
+}
+
+// Seed returns the seed of the random source
+func Seed() int64 {
+	source.mu.Lock()
+	defer source.mu.Unlock()
+	return source.seed
+}
+
+// Rand returns the seeded random generator
+func Rand() *rand.Rand {
+	return rnd
+}
+
+// Intn returns a random number in the range [0,n) from the seeded source
+func Intn(n int) int {
+	return rnd.Intn(n)
+}
@@ -0,0 +1,23 @@
+package random
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetSeedReplaysSequence(t *testing.T) {
+	draw := func() []int {
+		var values []int
+		for i := 0; i < 10; i++ {
+			values = append(values, Intn(1000))
+		}
+		return values
+	}
+
+	SetSeed(42)
+	first := draw()
+	SetSeed(42)
+	assert.Equal(t, first, draw())
+	assert.Equal(t, int64(42), Seed())
+}
@@ -26,6 +26,10 @@ func GetENV(experimentDetails *experimentTypes.ExperimentDetails) {
 	experimentDetails.Sequence = types.Getenv("SEQUENCE", "parallel")
 	experimentDetails.VMIds = strings.TrimSpace(types.Getenv("APP_VM_MOIDS", ""))
 	experimentDetails.VMTag = strings.TrimSpace(types.Getenv("APP_VM_TAG", ""))
+	experimentDetails.VMFolder = strings.TrimSpace(types.Getenv("APP_VM_FOLDER", ""))
+	experimentDetails.VMResourcePool = strings.TrimSpace(types.Getenv("APP_VM_RESOURCE_POOL", ""))
+	experimentDetails.VMNames = strings.ReplaceAll(types.Getenv("APP_VM_NAMES", ""), " ", "")
+	experimentDetails.Datacenter = strings.TrimSpace(types.Getenv("DATACENTER", ""))
 	experimentDetails.VcenterServer = types.Getenv("VCENTERSERVER", "")
 	experimentDetails.VcenterUser = types.Getenv("VCENTERUSER", "")
 	experimentDetails.VcenterPass = types.Getenv("VCENTERPASS", "")
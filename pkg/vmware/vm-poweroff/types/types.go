@@ -23,6 +23,10 @@ type ExperimentDetails struct {
 	Sequence       string
 	VMIds          string
 	VMTag          string
+	VMFolder       string
+	VMResourcePool string
+	VMNames        string
+	Datacenter     string
 	VcenterServer  string
 	VcenterUser    string
 	VcenterPass    string
@@ -0,0 +1,111 @@
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	kcorev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// PodTemplateHashLabel is the label of the deployment pods, which contains the hash of their replicaset
+	PodTemplateHashLabel = "pod-template-hash"
+	// RolloutsPodTemplateHashLabel is the label of the argo rollouts pods, which contains the hash of their replicaset
+	RolloutsPodTemplateHashLabel = "rollouts-pod-template-hash"
+	// deploymentRevisionAnnotation contains the revision number of the replicasets of the deployments and the argo rollouts
+	deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+)
+
+var gvrrollout = schema.GroupVersionResource{
+	Group:    "argoproj.io",
+	Version:  "v1alpha1",
+	Resource: "rollouts",
+}
+
+// Revision is the revision of the workload, which owns the pod
+type Revision struct {
+	Workload Workload
+	// Hash identifies the revision, it is the pod template hash or the value of the revision label
+	Hash string
+	// Number is the revision number of the replicaset, it orders the revisions of the workload
+	Number int
+	// StableHash is the hash of the stable revision, it is only known for the argo rollouts
+	StableHash string
+}
+
+// GetPodRevision returns the revision of the workload, which owns the pod
+// the revision is derived from the given revision label if it is provided, otherwise from the pod template hash of the
+// argo rollouts or the deployments, the empty revision is returned for the pods which don't belong to any revision
+func GetPodRevision(pod *kcorev1.Pod, revisionLabel string, dynamicClient dynamic.Interface) (Revision, error) {
+	if revisionLabel != "" {
+		if pod.Labels[revisionLabel] == "" {
+			return Revision{}, nil
+		}
+		kind, name, err := GetPodOwnerTypeAndName(pod, dynamicClient)
+		if err != nil {
+			return Revision{}, err
+		}
+		if name == "" {
+			kind, name = standaloneOwner(pod)
+		}
+		return Revision{Workload: Workload{Name: name, Kind: kind, Namespace: pod.Namespace}, Hash: pod.Labels[revisionLabel]}, nil
+	}
+
+	hash := pod.Labels[RolloutsPodTemplateHashLabel]
+	if hash == "" {
+		hash = pod.Labels[PodTemplateHashLabel]
+	}
+	if hash == "" {
+		return Revision{}, nil
+	}
+
+	for _, owner := range pod.GetOwnerReferences() {
+		if owner.Kind != "ReplicaSet" {
+			continue
+		}
+		rs, kind, name, err := getParentOf(owner.Name, pod.Namespace, gvrrs, dynamicClient)
+		if err != nil {
+			return Revision{}, err
+		}
+		if kind == "" {
+			kind, name = standaloneOwner(pod)
+		}
+		revision := Revision{Workload: Workload{Name: name, Kind: kind, Namespace: pod.Namespace}, Hash: hash}
+		revision.Number, _ = strconv.Atoi(rs.GetAnnotations()[deploymentRevisionAnnotation])
+		if kind == "rollout" {
+			if revision.StableHash, err = getStableHash(name, pod.Namespace, dynamicClient); err != nil {
+				return Revision{}, err
+			}
+		}
+		return revision, nil
+	}
+	return Revision{}, nil
+}
+
+// standaloneOwner returns the kind and name of the replicaset of the pod, which isn't owned by any workload
+// the replicaset is the workload of such pods, so that the revisions of the different replicasets aren't compared against each other
+// the pod is its own workload, if it isn't owned by any replicaset
+func standaloneOwner(pod *kcorev1.Pod) (string, string) {
+	for _, owner := range pod.GetOwnerReferences() {
+		if owner.Kind == "ReplicaSet" {
+			return "replicaset", owner.Name
+		}
+	}
+	return "pod", pod.Name
+}
+
+// getStableHash returns the pod template hash of the stable revision of the argo rollout
+func getStableHash(name, namespace string, dynamicClient dynamic.Interface) (string, error) {
+	rollout, err := dynamicClient.Resource(gvrrollout).Namespace(namespace).Get(context.Background(), name, v1.GetOptions{})
+	if err != nil {
+		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{namespace: %s, kind: rollout, name: %s}", namespace, name), Reason: err.Error()}
+	}
+	stableHash, _, _ := unstructured.NestedString(rollout.Object, "status", "stableRS")
+	return stableHash, nil
+}
@@ -8,21 +8,29 @@ import (
 
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/palantir/stacktrace"
 
 	kcorev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 )
 
 type Workload struct {
-	Name      string `json:"name"`
-	Kind      string `json:"kind"`
-	Namespace string `json:"namespace"`
+	Name       string `json:"name"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace"`
+	APIVersion string `json:"apiVersion,omitempty"`
 }
 
+// maxOwnerDepth is the maximum depth of the owner chain, which is resolved for a pod
+const maxOwnerDepth = 10
+
 var (
 	gvrrc = schema.GroupVersionResource{
 		Group:    "",
@@ -51,14 +59,11 @@ func getPodsFromWorkload(target types.AppDetails, allPods *kcorev1.PodList, dyna
 	for _, wld := range target.Names {
 		found := false
 		for _, r := range allPods.Items {
-			ownerType, ownerName, err := GetPodOwnerTypeAndName(&r, dynamicClient)
+			owned, err := IsPodOwnedBy(target, wld, &r, dynamicClient)
 			if err != nil {
 				return pods, err
 			}
-			if ownerName == "" || ownerType == "" {
-				continue
-			}
-			if target.Kind == ownerType && wld == ownerName {
+			if owned {
 				found = true
 				pods.Items = append(pods.Items, r)
 			}
@@ -70,6 +75,73 @@ func getPodsFromWorkload(target types.AppDetails, allPods *kcorev1.PodList, dyna
 	return pods, nil
 }
 
+// IsPodOwnedBy checks whether the pod is owned by the workload of the target kind with the given name, the empty name matches any workload of the kind
+// the generic targets, provided with their apiVersion, are matched against all the owners in the owner chain of the pod
+func IsPodOwnedBy(target types.AppDetails, name string, pod *kcorev1.Pod, dynamicClient dynamic.Interface) (bool, error) {
+	if target.APIVersion == "" {
+		ownerType, ownerName, err := GetPodOwnerTypeAndName(pod, dynamicClient)
+		if err != nil || ownerName == "" || ownerType == "" {
+			return false, err
+		}
+		return target.Kind == ownerType && (name == "" || name == ownerName), nil
+	}
+
+	targetGV, err := schema.ParseGroupVersion(target.APIVersion)
+	if err != nil {
+		return false, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{namespace: %s, kind: %s/%s}", target.Namespace, target.APIVersion, target.Kind), Reason: fmt.Sprintf("invalid apiVersion: %s", err.Error())}
+	}
+	owners, err := GetPodOwnerChain(pod, dynamicClient)
+	if err != nil {
+		return false, err
+	}
+	for _, owner := range owners {
+		ownerGV, err := schema.ParseGroupVersion(owner.APIVersion)
+		if err != nil {
+			continue
+		}
+		// the version is not compared, as the owner references may refer to any served version of the workload
+		if ownerGV.Group == targetGV.Group && strings.EqualFold(owner.Kind, target.Kind) && (name == "" || name == owner.Name) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetPodOwnerChain returns the owners of the pod, starting from its immediate owner till the top-level owner
+// the owners are resolved through the dynamic client, so that the workloads of any kind and depth, like the cronjobs of the jobs,
+// the knative services of the deployments or the custom resources of the operators, are part of the chain
+func GetPodOwnerChain(pod *kcorev1.Pod, dynamicClient dynamic.Interface) ([]Workload, error) {
+	var owners []Workload
+	owner := getControllerOf(pod.GetOwnerReferences())
+	for owner != nil && len(owners) < maxOwnerDepth {
+		owners = append(owners, Workload{Name: owner.Name, Kind: owner.Kind, Namespace: pod.Namespace, APIVersion: owner.APIVersion})
+
+		gvr, _ := meta.UnsafeGuessKindToResource(schema.FromAPIVersionAndKind(owner.APIVersion, owner.Kind))
+		res, err := dynamicClient.Resource(gvr).Namespace(pod.Namespace).Get(context.Background(), owner.Name, v1.GetOptions{})
+		if err != nil {
+			// the chain ends at the owner, which is already deleted or which can't be read by the experiment
+			if k8serrors.IsNotFound(err) || k8serrors.IsForbidden(err) {
+				log.Warnf("[Info]: Unable to resolve the owners of %s %s, err: %v", owner.Kind, owner.Name, err)
+				break
+			}
+			return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{namespace: %s, kind: %s, name: %s}", pod.Namespace, owner.Kind, owner.Name), Reason: err.Error()}
+		}
+		owner = getControllerOf(res.GetOwnerReferences())
+	}
+	return owners, nil
+}
+
+// getControllerOf returns the controller of the object, the first owner is used if none of the owners is marked as controller
+func getControllerOf(owners []v1.OwnerReference) *v1.OwnerReference {
+	if len(owners) == 0 {
+		return nil
+	}
+	if controller := v1.GetControllerOfNoCopy(&v1.ObjectMeta{OwnerReferences: owners}); controller != nil {
+		return controller
+	}
+	return &owners[0]
+}
+
 func GetPodOwnerTypeAndName(pod *kcorev1.Pod, dynamicClient dynamic.Interface) (parentType, parentName string, err error) {
 	for _, owner := range pod.GetOwnerReferences() {
 		parentName = owner.Name
@@ -90,16 +162,22 @@ func GetPodOwnerTypeAndName(pod *kcorev1.Pod, dynamicClient dynamic.Interface) (
 }
 
 func getParent(name, namespace string, gvr schema.GroupVersionResource, dynamicClient dynamic.Interface) (string, string, error) {
+	_, kind, parentName, err := getParentOf(name, namespace, gvr, dynamicClient)
+	return kind, parentName, err
+}
+
+// getParentOf returns the given resource along with the kind and name of its parent workload
+func getParentOf(name, namespace string, gvr schema.GroupVersionResource, dynamicClient dynamic.Interface) (*unstructured.Unstructured, string, string, error) {
 	res, err := dynamicClient.Resource(gvr).Namespace(namespace).Get(context.Background(), name, v1.GetOptions{})
 	if err != nil {
-		return "", "", cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{namespace: %s, kind: %s, name: %s}", namespace, gvr.Resource, name), Reason: err.Error()}
+		return nil, "", "", cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{namespace: %s, kind: %s, name: %s}", namespace, gvr.Resource, name), Reason: err.Error()}
 	}
 
 	for _, v := range res.GetOwnerReferences() {
 		kind := strings.ToLower(v.Kind)
 		if kind == "deployment" || kind == "rollout" || kind == "deploymentconfig" {
-			return kind, v.Name, nil
+			return res, kind, v.Name, nil
 		}
 	}
-	return "", "", nil
+	return res, "", "", nil
 }
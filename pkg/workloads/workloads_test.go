@@ -296,3 +296,246 @@ func Test_GetPodOwnerTypeAndName(t *testing.T) {
 		})
 	}
 }
+
+func newOwnedObject(apiVersion, kind, name string, owners ...metav1.OwnerReference) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetName(name)
+	obj.SetNamespace("default")
+	obj.SetOwnerReferences(owners)
+	return obj
+}
+
+func newOwnerChainClient() dynamic.Interface {
+	controller := true
+	return dfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Group: "batch", Version: "v1", Resource: "jobs"}:                         "JobList",
+		{Group: "batch", Version: "v1", Resource: "cronjobs"}:                     "CronJobList",
+		{Group: "apps", Version: "v1", Resource: "replicasets"}:                   "ReplicaSetList",
+		{Group: "apps", Version: "v1", Resource: "deployments"}:                   "DeploymentList",
+		{Group: "serving.knative.dev", Version: "v1", Resource: "revisions"}:      "RevisionList",
+		{Group: "serving.knative.dev", Version: "v1", Resource: "configurations"}: "ConfigurationList",
+		{Group: "serving.knative.dev", Version: "v1", Resource: "services"}:       "ServiceList",
+	},
+		newOwnedObject("batch/v1", "Job", "nightly-28000000", metav1.OwnerReference{APIVersion: "batch/v1", Kind: "CronJob", Name: "nightly", Controller: &controller}),
+		newOwnedObject("batch/v1", "CronJob", "nightly"),
+		newOwnedObject("apps/v1", "ReplicaSet", "hello-00001-deployment-5d4f8", metav1.OwnerReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "hello-00001-deployment", Controller: &controller}),
+		newOwnedObject("apps/v1", "Deployment", "hello-00001-deployment", metav1.OwnerReference{APIVersion: "serving.knative.dev/v1", Kind: "Revision", Name: "hello-00001", Controller: &controller}),
+		newOwnedObject("serving.knative.dev/v1", "Revision", "hello-00001",
+			metav1.OwnerReference{APIVersion: "serving.knative.dev/v1", Kind: "Route", Name: "not-the-controller"},
+			metav1.OwnerReference{APIVersion: "serving.knative.dev/v1", Kind: "Configuration", Name: "hello", Controller: &controller}),
+		newOwnedObject("serving.knative.dev/v1", "Configuration", "hello", metav1.OwnerReference{APIVersion: "serving.knative.dev/v1", Kind: "Service", Name: "hello", Controller: &controller}),
+		newOwnedObject("serving.knative.dev/v1", "Service", "hello"),
+	)
+}
+
+func Test_GetPodOwnerChain(t *testing.T) {
+	fakeDynamic := newOwnerChainClient()
+
+	tests := []struct {
+		name           string
+		owner          metav1.OwnerReference
+		expectedOwners []string
+	}{
+		{
+			name:           "job spawned by cronjob",
+			owner:          metav1.OwnerReference{APIVersion: "batch/v1", Kind: "Job", Name: "nightly-28000000"},
+			expectedOwners: []string{"Job/nightly-28000000", "CronJob/nightly"},
+		},
+		{
+			name:  "knative service",
+			owner: metav1.OwnerReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "hello-00001-deployment-5d4f8"},
+			expectedOwners: []string{
+				"ReplicaSet/hello-00001-deployment-5d4f8",
+				"Deployment/hello-00001-deployment",
+				"Revision/hello-00001",
+				"Configuration/hello",
+				"Service/hello",
+			},
+		},
+		{
+			name:           "deleted owner ends the chain",
+			owner:          metav1.OwnerReference{APIVersion: "example.com/v1alpha1", Kind: "Database", Name: "deleted"},
+			expectedOwners: []string{"Database/deleted"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "pod",
+					Namespace:       "default",
+					OwnerReferences: []metav1.OwnerReference{tt.owner},
+				},
+			}
+			owners, err := GetPodOwnerChain(pod, fakeDynamic)
+			assert.NoError(t, err)
+			var got []string
+			for _, owner := range owners {
+				got = append(got, owner.Kind+"/"+owner.Name)
+			}
+			assert.Equal(t, tt.expectedOwners, got)
+		})
+	}
+}
+
+func Test_getPodsFromWorkload_genericTarget(t *testing.T) {
+	fakeDynamic := newOwnerChainClient()
+	allPods := &corev1.PodList{
+		Items: []corev1.Pod{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "nightly-28000000-x7k2p",
+					Namespace:       "default",
+					OwnerReferences: []metav1.OwnerReference{{APIVersion: "batch/v1", Kind: "Job", Name: "nightly-28000000"}},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "hello-00001-deployment-5d4f8-abcde",
+					Namespace:       "default",
+					OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "hello-00001-deployment-5d4f8"}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		targets     string
+		expectedPod string
+		expectErr   bool
+	}{
+		{
+			name:        "cronjob by name",
+			targets:     "batch/v1/CronJob:default:[nightly]",
+			expectedPod: "nightly-28000000-x7k2p",
+		},
+		{
+			name:        "knative service by name",
+			targets:     "serving.knative.dev/v1/Service:default:[hello]",
+			expectedPod: "hello-00001-deployment-5d4f8-abcde",
+		},
+		{
+			name:        "intermediate owner by name",
+			targets:     "serving.knative.dev/v1/Revision:default:[hello-00001]",
+			expectedPod: "hello-00001-deployment-5d4f8-abcde",
+		},
+		{
+			name:      "kind of a different group",
+			targets:   "v1/Service:default:[hello]",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := types.GetTargets(tt.targets)[0]
+			got, err := getPodsFromWorkload(target, allPods, fakeDynamic)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if assert.Len(t, got.Items, 1) {
+				assert.Equal(t, tt.expectedPod, got.Items[0].Name)
+			}
+		})
+	}
+}
+
+func Test_GetPodRevision(t *testing.T) {
+	rollout := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{"stableRS": "5b8c7d"},
+	}}
+	rollout.SetAPIVersion("argoproj.io/v1alpha1")
+	rollout.SetKind("Rollout")
+	rollout.SetName("api")
+	rollout.SetNamespace("default")
+
+	deploymentRS := newOwnedObject("apps/v1", "ReplicaSet", "nginx-7c9f8b5d4", metav1.OwnerReference{Kind: "Deployment", Name: "nginx"})
+	deploymentRS.SetAnnotations(map[string]string{"deployment.kubernetes.io/revision": "4"})
+	rolloutRS := newOwnedObject("apps/v1", "ReplicaSet", "api-9f7e6a", metav1.OwnerReference{Kind: "Rollout", Name: "api"})
+	rolloutRS.SetAnnotations(map[string]string{"deployment.kubernetes.io/revision": "8"})
+
+	fakeDynamic := dfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Group: "apps", Version: "v1", Resource: "replicasets"}:           "ReplicaSetList",
+		{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts"}: "RolloutList",
+	}, deploymentRS, rolloutRS, rollout, newOwnedObject("apps/v1", "ReplicaSet", "cache-6b5f7"))
+
+	newPod := func(labels map[string]string, owner string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:            "pod",
+			Namespace:       "default",
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: owner}},
+		}}
+	}
+
+	tests := []struct {
+		name          string
+		pod           *corev1.Pod
+		revisionLabel string
+		expected      Revision
+	}{
+		{
+			name: "deployment",
+			pod:  newPod(map[string]string{PodTemplateHashLabel: "7c9f8b5d4"}, "nginx-7c9f8b5d4"),
+			expected: Revision{
+				Workload: Workload{Name: "nginx", Kind: "deployment", Namespace: "default"},
+				Hash:     "7c9f8b5d4",
+				Number:   4,
+			},
+		},
+		{
+			name: "argo rollout",
+			pod:  newPod(map[string]string{RolloutsPodTemplateHashLabel: "9f7e6a"}, "api-9f7e6a"),
+			expected: Revision{
+				Workload:   Workload{Name: "api", Kind: "rollout", Namespace: "default"},
+				Hash:       "9f7e6a",
+				Number:     8,
+				StableHash: "5b8c7d",
+			},
+		},
+		{
+			name:          "revision label",
+			pod:           newPod(map[string]string{PodTemplateHashLabel: "7c9f8b5d4", "version": "v2"}, "nginx-7c9f8b5d4"),
+			revisionLabel: "version",
+			expected: Revision{
+				Workload: Workload{Name: "nginx", Kind: "deployment", Namespace: "default"},
+				Hash:     "v2",
+			},
+		},
+		{
+			name: "replicaset without workload",
+			pod:  newPod(map[string]string{PodTemplateHashLabel: "6b5f7"}, "cache-6b5f7"),
+			expected: Revision{
+				Workload: Workload{Name: "cache-6b5f7", Kind: "replicaset", Namespace: "default"},
+				Hash:     "6b5f7",
+			},
+		},
+		{
+			name:          "revision label of the replicaset without workload",
+			pod:           newPod(map[string]string{PodTemplateHashLabel: "6b5f7", "version": "v1"}, "cache-6b5f7"),
+			revisionLabel: "version",
+			expected: Revision{
+				Workload: Workload{Name: "cache-6b5f7", Kind: "replicaset", Namespace: "default"},
+				Hash:     "v1",
+			},
+		},
+		{
+			name:     "pod without revision",
+			pod:      newPod(nil, "nginx-7c9f8b5d4"),
+			expected: Revision{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revision, err := GetPodRevision(tt.pod, tt.revisionLabel, fakeDynamic)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, revision)
+		})
+	}
+}